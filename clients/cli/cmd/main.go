@@ -7,6 +7,10 @@ import (
 	"os"
 	"time"
 
+	// Location timezones come from the server's registry; embedding the
+	// database keeps them loadable on hosts without zoneinfo (Windows).
+	_ "time/tzdata"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/nickfang/personal-dashboard/clients/cli/internal/client"
@@ -342,6 +342,25 @@ func TestResponseMerge_LWW_EqualTimestampSkips(t *testing.T) {
 	}
 }
 
+func TestResponseMerge_LocationsReplaceAndSurviveSingleLocationFetch(t *testing.T) {
+	dst := &Response{Locations: map[string]Location{
+		"house-nick": {ID: "house-nick", Timezone: "America/Chicago"},
+	}}
+	dst.Merge(&Response{Locations: map[string]Location{
+		"house-nick": {ID: "house-nick", Timezone: "America/Denver"},
+	}})
+	if got := dst.Locations["house-nick"].Timezone; got != "America/Denver" {
+		t.Errorf("Timezone = %q, want the newer registry entry", got)
+	}
+
+	// A single-location response has no locations map; the registry entry
+	// from the last full fetch must stay.
+	dst.Merge(&Response{Weather: map[string]Weather{"house-nick": {LastUpdated: "2026-04-11T14:30:05Z"}}})
+	if _, ok := dst.Locations["house-nick"]; !ok {
+		t.Error("single-location merge dropped the registry entry")
+	}
+}
+
 func TestResponseMerge_NilGuards(t *testing.T) {
 	// nil receiver is a no-op (would crash otherwise).
 	var nilDst *Response
@@ -9,6 +9,10 @@ type Response struct {
 	Pollen   map[string]Pollen   `json:"pollen"`
 	Forecast map[string]Forecast `json:"forecast"`
 	Alerts   map[string][]Alert  `json:"alerts"`
+
+	// Locations is the registry entry for each location ID. Only the full
+	// dashboard response carries it.
+	Locations map[string]Location `json:"locations"`
 }
 
 // Merge folds the entries from src into r using last-write-wins semantics:
@@ -69,6 +73,14 @@ func (r *Response) Merge(src *Response) {
 			r.Alerts[k] = src.Alerts[k]
 		}
 	}
+	// Registry entries carry no timestamp; the full response that brings
+	// them is the latest word, so they replace what was there.
+	if r.Locations == nil {
+		r.Locations = make(map[string]Location, len(src.Locations))
+	}
+	for k, v := range src.Locations {
+		r.Locations[k] = v
+	}
 	// Alerts for locations without a forecast entry can't be ordered by
 	// IssuedAt, so they only fill gaps.
 	for k, v := range src.Alerts {
@@ -84,6 +96,14 @@ func (r *Response) Merge(src *Response) {
 	}
 }
 
+// Location is one entry of the dashboard-api location registry, reduced to
+// the fields the TUI renders.
+type Location struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Timezone string `json:"timezone"` // IANA zone, e.g. America/Chicago
+}
+
 // Weather matches the protojson output of the dashboard-api weather payload.
 type Weather struct {
 	LocationID           string  `json:"locationId"`
@@ -9,9 +9,10 @@ import (
 	"github.com/nickfang/personal-dashboard/clients/cli/internal/client"
 )
 
-// formatTimestamp parses an RFC3339 string and returns "MM.DD HH:MM:SS".
-// On parse error, returns the raw input (possibly truncated).
-func formatTimestamp(s string) string {
+// formatTimestamp parses an RFC3339 string and returns "MM.DD HH:MM:SS" in
+// zone, the location's own. On parse error, returns the raw input (possibly
+// truncated).
+func formatTimestamp(s string, zone *time.Location) string {
 	if s == "" {
 		return ""
 	}
@@ -22,7 +23,7 @@ func formatTimestamp(s string) string {
 		}
 		return s
 	}
-	return t.Local().Format("01.02 15:04:05")
+	return t.In(zone).Format("01.02 15:04:05")
 }
 
 // sectionBar renders a horizontal bar like "── WEATHER ────── 04.11 14:30:05 ──"
@@ -51,23 +52,24 @@ func sectionBar(title, timestamp string, innerWidth int) string {
 
 // renderLocation renders a single location's full block (border + sections),
 // with an alert banner at the top when the location has active alerts.
-func renderLocation(id string, w *client.Weather, p *client.Pressure, pol *client.Pollen, f *client.Forecast, alerts []client.Alert, innerWidth int) string {
+// Section timestamps render in zone.
+func renderLocation(id string, zone *time.Location, w *client.Weather, p *client.Pressure, pol *client.Pollen, f *client.Forecast, alerts []client.Alert, innerWidth int) string {
 	if innerWidth < 30 {
 		innerWidth = 30
 	}
 
 	var weatherTs, pressureTs, pollenTs, forecastTs string
 	if w != nil {
-		weatherTs = formatTimestamp(w.LastUpdated)
+		weatherTs = formatTimestamp(w.LastUpdated, zone)
 	}
 	if p != nil {
-		pressureTs = formatTimestamp(p.LastUpdated)
+		pressureTs = formatTimestamp(p.LastUpdated, zone)
 	}
 	if pol != nil {
-		pollenTs = formatTimestamp(pol.CollectedAt)
+		pollenTs = formatTimestamp(pol.CollectedAt, zone)
 	}
 	if f != nil {
-		forecastTs = formatTimestamp(f.IssuedAt)
+		forecastTs = formatTimestamp(f.IssuedAt, zone)
 	}
 
 	// Title bar across the top with location ID.
@@ -266,12 +266,12 @@ func (m Model) View() string {
 				idx = len(ids) - 1
 			}
 			id := ids[idx]
-			b.WriteString(renderLocation(id, weatherFor(m.data, id), pressureFor(m.data, id), pollenFor(m.data, id), forecastFor(m.data, id), alertsFor(m.data, id), innerWidth))
+			b.WriteString(renderLocation(id, zoneFor(m.data, id), weatherFor(m.data, id), pressureFor(m.data, id), pollenFor(m.data, id), forecastFor(m.data, id), alertsFor(m.data, id), innerWidth))
 			b.WriteString("\n\n")
 		}
 	default:
 		for _, id := range ids {
-			b.WriteString(renderLocation(id, weatherFor(m.data, id), pressureFor(m.data, id), pollenFor(m.data, id), forecastFor(m.data, id), alertsFor(m.data, id), innerWidth))
+			b.WriteString(renderLocation(id, zoneFor(m.data, id), weatherFor(m.data, id), pressureFor(m.data, id), pollenFor(m.data, id), forecastFor(m.data, id), alertsFor(m.data, id), innerWidth))
 			b.WriteString("\n\n")
 		}
 	}
@@ -339,6 +339,23 @@ func forecastFor(r *client.Response, id string) *client.Forecast {
 	return nil
 }
 
+// zoneFor returns the timezone the location's timestamps render in: its
+// registry zone, or the terminal's own when the server sent none.
+func zoneFor(r *client.Response, id string) *time.Location {
+	if r == nil {
+		return time.Local
+	}
+	l, ok := r.Locations[id]
+	if !ok || l.Timezone == "" {
+		return time.Local
+	}
+	zone, err := time.LoadLocation(l.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return zone
+}
+
 // alertsFor returns the alerts for id, or nil if absent.
 func alertsFor(r *client.Response, id string) []client.Alert {
 	if r == nil {
@@ -234,7 +234,7 @@ func TestRenderLocation(t *testing.T) {
 	weatherTs := parseLocal(t, "2026-04-11T14:30:05Z").Format("01.02 15:04:05")
 	pollenTs := parseLocal(t, "2026-04-11T06:00:00Z").Format("01.02 15:04:05")
 
-	got := renderLocation("house-nick",
+	got := renderLocation("house-nick", time.Local,
 		&client.Weather{TempF: 85.2, TempFeelF: 89.1, HumidityPercent: 62, PrecipitationPercent: 10, LastUpdated: "2026-04-11T14:30:05Z", PressureMb: 1013.25},
 		&client.Pressure{Trend: "rising", Delta1h: 0.3, LastUpdated: "2026-04-11T14:30:05Z"},
 		&client.Pollen{Plants: []client.PollenPlant{{DisplayName: "Oak", Index: 3, Category: "Moderate", InSeason: true}}, CollectedAt: "2026-04-11T06:00:00Z"},
@@ -256,17 +256,43 @@ func TestFormatTimestamp(t *testing.T) {
 	}
 	want := parsed.Local().Format("01.02 15:04:05")
 
-	if got := formatTimestamp(input); got != want {
+	if got := formatTimestamp(input, time.Local); got != want {
 		t.Errorf("got %q, want %q", got, want)
 	}
-	if got := formatTimestamp(""); got != "" {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatTimestamp(input, tokyo); got != "04.11 23:30:05" {
+		t.Errorf("Asia/Tokyo: got %q, want 04.11 23:30:05", got)
+	}
+	if got := formatTimestamp("", time.Local); got != "" {
 		t.Errorf("empty should return empty, got %q", got)
 	}
-	if got := formatTimestamp("not-a-time"); got == "" {
+	if got := formatTimestamp("not-a-time", time.Local); got == "" {
 		t.Errorf("bad parse should return raw, got empty")
 	}
 }
 
+func TestZoneFor(t *testing.T) {
+	r := &client.Response{Locations: map[string]client.Location{
+		"cabin":     {ID: "cabin", Timezone: "America/Denver"},
+		"house-old": {ID: "house-old"},
+		"typo":      {ID: "typo", Timezone: "America/Austin"},
+	}}
+	if got := zoneFor(r, "cabin").String(); got != "America/Denver" {
+		t.Errorf("cabin zone = %q, want America/Denver", got)
+	}
+	for _, id := range []string{"house-old", "typo", "missing"} {
+		if got := zoneFor(r, id); got != time.Local {
+			t.Errorf("%s zone = %v, want the terminal's local zone", id, got)
+		}
+	}
+	if got := zoneFor(nil, "cabin"); got != time.Local {
+		t.Errorf("nil response zone = %v, want local", got)
+	}
+}
+
 func TestModelUpdateQuit(t *testing.T) {
 	m := NewModel(nil, time.Minute, "")
 	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
@@ -3,6 +3,21 @@ resource "google_service_account" "sa" {
   display_name = var.sa_display_name
 }
 
+# Reads the location registry (weather-log/locations) for display names and
+# writes it through the /v1/locations routes.
+resource "google_project_iam_member" "firestore_user" {
+  project = var.project_id
+  role    = "roles/datastore.user"
+  member  = "serviceAccount:${google_service_account.sa.email}"
+}
+
+resource "google_secret_manager_secret_iam_member" "secret_access" {
+  for_each  = toset(var.secret_refs)
+  secret_id = each.value
+  role      = "roles/secretmanager.secretAccessor"
+  member    = "serviceAccount:${google_service_account.sa.email}"
+}
+
 resource "null_resource" "bootstrap" {
   provisioner "local-exec" {
     command = <<-EOT
@@ -36,6 +51,19 @@ resource "google_cloud_run_v2_service" "service" {
           value = env.value
         }
       }
+
+      dynamic "env" {
+        for_each = var.secret_env_vars
+        content {
+          name = env.key
+          value_source {
+            secret_key_ref {
+              secret  = env.value.secret_id
+              version = env.value.version
+            }
+          }
+        }
+      }
     }
   }
 
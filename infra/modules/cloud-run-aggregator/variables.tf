@@ -23,6 +23,19 @@ variable "env_vars" {
   default = {}
 }
 
+variable "secret_env_vars" {
+  type = map(object({
+    secret_id = string
+    version   = string
+  }))
+  default = {}
+}
+
+variable "secret_refs" {
+  type    = list(string)
+  default = []
+}
+
 variable "artifact_registry_url" {
   type = string
 }
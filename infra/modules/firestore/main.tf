@@ -6,3 +6,19 @@ resource "google_firestore_database" "database" {
   location_id = var.region
   type        = "FIRESTORE_NATIVE"
 }
+
+resource "google_firestore_index" "index" {
+  for_each = var.composite_indexes
+
+  project    = var.project_id
+  database   = google_firestore_database.database[each.value.database].name
+  collection = each.value.collection
+
+  dynamic "fields" {
+    for_each = each.value.fields
+    content {
+      field_path = fields.value.field_path
+      order      = fields.value.order
+    }
+  }
+}
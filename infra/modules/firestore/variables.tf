@@ -12,3 +12,16 @@ variable "database_ids" {
   description = "List of Firestore database names to create"
   type        = list(string)
 }
+
+variable "composite_indexes" {
+  description = "Composite indexes keyed by a descriptive name. Each database must be in database_ids."
+  type = map(object({
+    database   = string
+    collection = string
+    fields = list(object({
+      field_path = string
+      order      = string
+    }))
+  }))
+  default = {}
+}
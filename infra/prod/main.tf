@@ -41,13 +41,137 @@ module "firestore" {
   region       = var.region
   database_ids = ["weather-log", "pollen-log"]
 
+  composite_indexes = {
+    # weather-provider QueryRawWeather and forecast-verifier: location filter, timestamp range and order
+    weather_raw_location_timestamp = {
+      database   = "weather-log"
+      collection = "weather_raw"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "timestamp", order = "ASCENDING" },
+      ]
+    }
+    # weather-provider cmd/export and forecast-verifier: forecast_raw and pollen_raw range scans
+    forecast_raw_location_issued_at = {
+      database   = "weather-log"
+      collection = "forecast_raw"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "issued_at", order = "ASCENDING" },
+      ]
+    }
+    pollen_raw_location_collected_at = {
+      database   = "pollen-log"
+      collection = "pollen_raw"
+      fields = [
+        { field_path = "location_id", order = "ASCENDING" },
+        { field_path = "collected_at", order = "ASCENDING" },
+      ]
+    }
+    # notifier cmd/history: one location's observation records over a range
+    notifier_observations_location_evaluated_at = {
+      database   = "weather-log"
+      collection = "notifier_observations"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "evaluated_at", order = "ASCENDING" },
+      ]
+    }
+    # weather-provider ListAlertHistory: location and/or rule filters, newest window_start first.
+    # Declared in both databases: each collector archives into its own.
+    alert_history_location_window_start = {
+      database   = "weather-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "window_start", order = "DESCENDING" },
+      ]
+    }
+    alert_history_rule_window_start = {
+      database   = "weather-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "rule_id", order = "ASCENDING" },
+        { field_path = "window_start", order = "DESCENDING" },
+      ]
+    }
+    alert_history_location_rule_window_start = {
+      database   = "weather-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "rule_id", order = "ASCENDING" },
+        { field_path = "window_start", order = "DESCENDING" },
+      ]
+    }
+    pollen_alert_history_location_window_start = {
+      database   = "pollen-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "window_start", order = "DESCENDING" },
+      ]
+    }
+    pollen_alert_history_rule_window_start = {
+      database   = "pollen-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "rule_id", order = "ASCENDING" },
+        { field_path = "window_start", order = "DESCENDING" },
+      ]
+    }
+    pollen_alert_history_location_rule_window_start = {
+      database   = "pollen-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "rule_id", order = "ASCENDING" },
+        { field_path = "window_start", order = "DESCENDING" },
+      ]
+    }
+    # weather-provider cmd/export alerts dataset: one location's archive by issued_at
+    alert_history_location_issued_at = {
+      database   = "weather-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "issued_at", order = "ASCENDING" },
+      ]
+    }
+    pollen_alert_history_location_issued_at = {
+      database   = "pollen-log"
+      collection = "alert_history"
+      fields = [
+        { field_path = "location", order = "ASCENDING" },
+        { field_path = "issued_at", order = "ASCENDING" },
+      ]
+    }
+    # weather-provider ListAlertEvents: one alert's events, oldest first, in both databases
+    alert_events_alert_at = {
+      database   = "weather-log"
+      collection = "alert_events"
+      fields = [
+        { field_path = "alert_id", order = "ASCENDING" },
+        { field_path = "at", order = "ASCENDING" },
+      ]
+    }
+    pollen_alert_events_alert_at = {
+      database   = "pollen-log"
+      collection = "alert_events"
+      fields = [
+        { field_path = "alert_id", order = "ASCENDING" },
+        { field_path = "at", order = "ASCENDING" },
+      ]
+    }
+  }
+
   depends_on = [module.foundation]
 }
 
 module "secrets" {
   source     = "../modules/secrets"
   project_id = var.project_id
-  secret_ids = ["google-maps-api-key", "notify-smtp-password"]
+  secret_ids = ["google-maps-api-key", "notify-smtp-password", "dashboard-admin-token"]
 
   depends_on = [module.foundation]
 }
@@ -93,7 +217,11 @@ module "pollen_collector" {
   services_path         = local.services_path
 
   env_vars = {
-    GCP_PROJECT_ID = var.project_id
+    GCP_PROJECT_ID      = var.project_id
+    POLLEN_HIGH_INDEX   = "4"
+    POLLEN_SEVERE_INDEX = "5"
+    POLLEN_JUMP_LEVELS  = "2"
+    POLLEN_WINDOW_HOURS = "24"
   }
 
   secret_env_vars = {
@@ -101,9 +229,13 @@ module "pollen_collector" {
       secret_id = "google-maps-api-key"
       version   = "latest"
     }
+    NOTIFY_SMTP_PASSWORD = {
+      secret_id = "notify-smtp-password"
+      version   = "latest"
+    }
   }
 
-  secret_refs = ["google-maps-api-key"]
+  secret_refs = ["google-maps-api-key", "notify-smtp-password"]
 
   depends_on = [module.foundation, module.secrets]
 }
@@ -122,9 +254,7 @@ module "forecast_collector" {
   env_vars = {
     GCP_PROJECT_ID         = var.project_id
     FORECAST_HORIZON_HOURS = "72"
-    PRESSURE_DROP_MB       = "5"
-    PRESSURE_SEVERE_MB     = "10"
-    PRESSURE_WINDOW_HOURS  = "3"
+    DETECTION_RULES_FILE   = "rules.json"
   }
 
   secret_env_vars = {
@@ -132,13 +262,9 @@ module "forecast_collector" {
       secret_id = "google-maps-api-key"
       version   = "latest"
     }
-    NOTIFY_SMTP_PASSWORD = {
-      secret_id = "notify-smtp-password"
-      version   = "latest"
-    }
   }
 
-  secret_refs = ["google-maps-api-key", "notify-smtp-password"]
+  secret_refs = ["google-maps-api-key"]
 
   depends_on = [module.foundation, module.secrets]
 }
@@ -155,7 +281,61 @@ module "notifier" {
   services_path         = local.services_path
 
   env_vars = {
-    GCP_PROJECT_ID = var.project_id
+    GCP_PROJECT_ID              = var.project_id
+    NOTIFY_MAX_DIVERGENCE_MB    = "3"
+    NOTIFY_MAX_FORECAST_AGE_MIN = "540"
+  }
+
+  secret_env_vars = {
+    NOTIFY_SMTP_PASSWORD = {
+      secret_id = "notify-smtp-password"
+      version   = "latest"
+    }
+  }
+
+  secret_refs = ["notify-smtp-password"]
+
+  depends_on = [module.foundation, module.secrets]
+}
+
+module "forecast_verifier" {
+  source                = "../modules/cloud-run-job"
+  project_id            = var.project_id
+  region                = var.region
+  name                  = "forecast-verifier"
+  sa_display_name       = "Service Account for Forecast Verifier Job"
+  schedule              = "30 0 * * *"
+  scheduler_description = "Triggers the forecast verifier job daily"
+  artifact_registry_url = module.foundation.artifact_registry_url
+  services_path         = local.services_path
+
+  env_vars = {
+    GCP_PROJECT_ID        = var.project_id
+    VERIFY_WINDOW_DAYS    = "30"
+    VERIFY_MAX_LEAD_HOURS = "72"
+  }
+
+  depends_on = [module.foundation]
+}
+
+module "archive_retention" {
+  source                = "../modules/cloud-run-job"
+  project_id            = var.project_id
+  region                = var.region
+  name                  = "archive-retention"
+  sa_display_name       = "Service Account for Archive Retention Job"
+  schedule              = "0 3 * * *"
+  scheduler_description = "Triggers the archive retention job daily"
+  artifact_registry_url = module.foundation.artifact_registry_url
+  services_path         = local.services_path
+
+  env_vars = {
+    GCP_PROJECT_ID        = var.project_id
+    RETAIN_WEATHER_DAYS   = "90"
+    RETAIN_FORECAST_DAYS  = "60"
+    RETAIN_POLLEN_DAYS    = "365"
+    RETAIN_FORECAST_LEADS = "6,12,24,48,72"
+    RETENTION_DRY_RUN     = "true"
   }
 
   depends_on = [module.foundation]
@@ -210,6 +390,7 @@ module "dashboard_api" {
   services_path         = local.services_path
 
   env_vars = {
+    GCP_PROJECT_ID        = var.project_id
     WEATHER_PROVIDER_ADDR = "${trimprefix(module.weather_provider.service_uri, "https://")}:443"
     POLLEN_PROVIDER_ADDR  = "${trimprefix(module.pollen_provider.service_uri, "https://")}:443"
   }
@@ -225,7 +406,18 @@ module "dashboard_api" {
     },
   ]
 
-  depends_on = [module.foundation]
+  # Bearer token for the /v1/locations write routes. Until a real value
+  # replaces the placeholder version, writes are refused with 401.
+  secret_env_vars = {
+    LOCATIONS_ADMIN_TOKEN = {
+      secret_id = "dashboard-admin-token"
+      version   = "latest"
+    }
+  }
+
+  secret_refs = ["dashboard-admin-token"]
+
+  depends_on = [module.foundation, module.secrets]
 }
 
 # --- CI/CD Identity ---
@@ -244,6 +436,8 @@ module "github_oidc" {
     module.pollen_collector.service_account_id,
     module.forecast_collector.service_account_id,
     module.notifier.service_account_id,
+    module.forecast_verifier.service_account_id,
+    module.archive_retention.service_account_id,
     module.weather_provider.service_account_id,
     module.pollen_provider.service_account_id,
     module.dashboard_api.service_account_id,
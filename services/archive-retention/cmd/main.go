@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/nickfang/personal-dashboard/services/archive-retention/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/archive-retention/internal/service"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+func main() {
+	shared.InitLogging()
+
+	if err := godotenv.Load(); err != nil {
+		slog.Debug("No .env file found, using system environment variables", "error", err)
+	}
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
+	projectID := os.Getenv("GCP_PROJECT_ID")
+	if projectID == "" && !useSQLite {
+		slog.Error("Missing required env vars", "vars", "GCP_PROJECT_ID")
+		os.Exit(1)
+	}
+	cfg := loadConfig()
+	if err := cfg.Validate(); err != nil {
+		slog.Error("Invalid retention config", "error", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	locations, err := loadAllLocations(ctx, projectID)
+	if err != nil {
+		slog.Error("Failed to load locations", "error", err)
+		os.Exit(1)
+	}
+
+	store, err := repository.OpenStore(ctx, projectID)
+	if err != nil {
+		slog.Error("Failed to create store", "error", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	svc := service.NewRetentionService(store, cfg, locations)
+	if _, err := svc.Run(ctx, time.Now()); err != nil {
+		slog.Error("Retention failed", "error", err)
+		store.Close()
+		os.Exit(1)
+	}
+}
+
+func loadConfig() service.Config {
+	cfg := service.DefaultConfig()
+	cfg.WeatherDays = envInt("RETAIN_WEATHER_DAYS", cfg.WeatherDays)
+	cfg.ForecastDays = envInt("RETAIN_FORECAST_DAYS", cfg.ForecastDays)
+	cfg.PollenDays = envInt("RETAIN_POLLEN_DAYS", cfg.PollenDays)
+	cfg.ForecastLeads = envInts("RETAIN_FORECAST_LEADS", cfg.ForecastLeads)
+	cfg.DryRun = os.Getenv("RETENTION_DRY_RUN") == "true"
+	return cfg
+}
+
+// loadAllLocations reads every registered location, disabled ones included:
+// their archives age out too, and need their timezone to be grouped by day.
+func loadAllLocations(ctx context.Context, projectID string) ([]shared.Location, error) {
+	src, err := registry.Open(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("opening location registry: %w", err)
+	}
+	defer src.Close()
+	return src.Locations(ctx)
+}
+
+// envInt reads an integer env var, falling back to a default when unset or invalid.
+func envInt(name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+		return fallback
+	}
+	return v
+}
+
+// envInts reads a comma-separated list of integers, falling back to a
+// default when unset or when any entry is invalid.
+func envInts(name string, fallback []int) []int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	var out []int
+	for _, field := range strings.Split(raw, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || v < 0 {
+			slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+			return fallback
+		}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nickfang/personal-dashboard/services/archive-retention/internal/service"
+)
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	for _, name := range []string{"RETAIN_WEATHER_DAYS", "RETAIN_FORECAST_DAYS", "RETAIN_POLLEN_DAYS", "RETAIN_FORECAST_LEADS", "RETENTION_DRY_RUN"} {
+		t.Setenv(name, "")
+	}
+	cfg := loadConfig()
+	want := service.DefaultConfig()
+	if cfg.WeatherDays != want.WeatherDays || !slices.Equal(cfg.ForecastLeads, want.ForecastLeads) || cfg.DryRun {
+		t.Errorf("loadConfig() = %+v, want the defaults", cfg)
+	}
+}
+
+func TestLoadConfig_FromEnv(t *testing.T) {
+	t.Setenv("RETAIN_WEATHER_DAYS", "120")
+	t.Setenv("RETAIN_FORECAST_LEADS", "3, 24")
+	t.Setenv("RETENTION_DRY_RUN", "true")
+	cfg := loadConfig()
+	if cfg.WeatherDays != 120 || !slices.Equal(cfg.ForecastLeads, []int{3, 24}) || !cfg.DryRun {
+		t.Errorf("loadConfig() = %+v", cfg)
+	}
+}
+
+func TestEnvInts_InvalidFallsBack(t *testing.T) {
+	t.Setenv("RETAIN_FORECAST_LEADS", "6,twelve")
+	if got := envInts("RETAIN_FORECAST_LEADS", []int{6}); !slices.Equal(got, []int{6}) {
+		t.Errorf("envInts = %v, want the fallback", got)
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteStore rolls up the same archives as FirestoreStore, with the weather
+// and pollen collections side by side in the one docstore file.
+type SQLiteStore struct {
+	db *docstore.DB
+}
+
+func NewSQLiteStore(db *docstore.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// OpenStore returns the SQLite store when docstore selects it, and otherwise
+// connects to both weather-log and pollen-log, whose raw archives it rolls
+// up.
+func OpenStore(ctx context.Context, projectID string) (StoreCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteStore(db), nil
+	}
+	fs, err := NewFirestoreStore(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) ScanObservations(ctx context.Context, before time.Time, fn func(id string, p Observation) error) error {
+	return scanSQLite(ctx, s.db, shared.WeatherRawCollection, "timestamp", before, fn)
+}
+
+func (s *SQLiteStore) ScanForecastRuns(ctx context.Context, before time.Time, fn func(id string, r ForecastRun) error) error {
+	return scanSQLite(ctx, s.db, shared.ForecastRawCollection, "issued_at", before, fn)
+}
+
+func (s *SQLiteStore) ScanPollenSnapshots(ctx context.Context, before time.Time, fn func(id string, snap PollenSnapshot) error) error {
+	return scanSQLite(ctx, s.db, shared.PollenRawCollection, "collected_at", before, fn)
+}
+
+func (s *SQLiteStore) RollUpObservations(ctx context.Context, r WeatherRollup, originals []string) error {
+	return rollUpSQLite(ctx, s.db, shared.WeatherRollupCollection, shared.WeatherRawCollection, RollupID(r.Location, r.Date), r, originals)
+}
+
+func (s *SQLiteStore) RollUpPollen(ctx context.Context, r PollenRollup, originals []string) error {
+	return rollUpSQLite(ctx, s.db, shared.PollenRollupCollection, shared.PollenRawCollection, RollupID(r.LocationID, r.Date), r, originals)
+}
+
+func (s *SQLiteStore) RollUpForecastRun(ctx context.Context, id string, r ForecastRun) error {
+	err := s.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		if err := tx.Set(shared.ForecastRollupCollection, id, r); err != nil {
+			return err
+		}
+		return tx.DeleteExisting(shared.ForecastRawCollection, id)
+	})
+	if err != nil {
+		return fmt.Errorf("rolling up forecast run %s: %w", id, err)
+	}
+	return nil
+}
+
+// scanSQLite reads the whole range at once: the local file holds far less
+// than a deployed archive, and the docstore has no ordered cursor to page by.
+func scanSQLite[T any, PT interface {
+	*T
+	schema.Document
+}](ctx context.Context, db *docstore.DB, collection, field string, before time.Time, fn func(id string, item T) error) error {
+	docs, err := db.Documents(ctx, docstore.Query{
+		Collection: collection,
+		Where:      []docstore.Filter{{Field: field, Op: "<", Value: before}},
+		OrderBy:    field,
+	})
+	if err != nil {
+		return fmt.Errorf("scanning %s: %w", collection, err)
+	}
+	for _, doc := range docs {
+		var item T
+		if err := doc.DataTo(&item); err != nil {
+			return err
+		}
+		schema.Read(doc.ID, PT(&item))
+		if err := fn(doc.ID, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rollUpSQLite[T any, PT interface {
+	*T
+	schema.Document
+	Merge(T)
+}](ctx context.Context, db *docstore.DB, rollups, raw, id string, r T, originals []string) error {
+	err := db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		merged := r
+		var stored T
+		err := tx.Get(rollups, id, &stored)
+		switch {
+		case errors.Is(err, docstore.ErrNotFound):
+		case err != nil:
+			return err
+		default:
+			if err := schema.Rewrite(id, PT(&stored)); err != nil {
+				return err
+			}
+			PT(&stored).Merge(r)
+			merged = stored
+		}
+		if err := tx.Set(rollups, id, merged); err != nil {
+			return err
+		}
+		for _, orig := range originals {
+			if err := tx.DeleteExisting(raw, orig); err != nil {
+				return fmt.Errorf("deleting %s/%s: %w", raw, orig, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("rolling up %s/%s: %w", rollups, id, err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+func openTestStore(t *testing.T) (*SQLiteStore, *docstore.DB) {
+	t.Helper()
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "retention.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	store := NewSQLiteStore(db)
+	t.Cleanup(func() { store.Close() })
+	return store, db
+}
+
+func TestSQLiteStore_RollUpObservations(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 5, 1, 5, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		p := Observation{SchemaVersion: schema.Version, Location: "house-nick", Timestamp: base.Add(time.Duration(i) * time.Hour), PressureMb: 1010 + float64(i)}
+		if err := db.Set(ctx, shared.WeatherRawCollection, fmt.Sprintf("obs-%d", i), p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// One observation past the cutoff is not scanned.
+	if err := db.Set(ctx, shared.WeatherRawCollection, "obs-late", Observation{Location: "house-nick", Timestamp: base.AddDate(0, 0, 2)}); err != nil {
+		t.Fatal(err)
+	}
+
+	var scanned []Observation
+	var ids []string
+	err := store.ScanObservations(ctx, base.AddDate(0, 0, 1), func(id string, p Observation) error {
+		scanned = append(scanned, p)
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scanned) != 4 || ids[0] != "obs-0" || !scanned[3].Timestamp.Equal(base.Add(3*time.Hour)) {
+		t.Fatalf("scanned %v, want obs-0..obs-3 oldest first", ids)
+	}
+
+	// Two parts of one day, committed separately, merge into one rollup.
+	for _, part := range [][]int{{0, 1}, {2, 3}} {
+		r := WeatherRollup{SchemaVersion: schema.Version, Location: "house-nick", Date: "2026-05-01", Start: base}
+		var originals []string
+		for _, i := range part {
+			r.Add(scanned[i])
+			originals = append(originals, ids[i])
+		}
+		if err := store.RollUpObservations(ctx, r, originals); err != nil {
+			t.Fatalf("RollUpObservations: %v", err)
+		}
+	}
+
+	var rollup WeatherRollup
+	if err := db.Get(ctx, shared.WeatherRollupCollection, "house-nick_2026-05-01", &rollup); err != nil {
+		t.Fatal(err)
+	}
+	if rollup.Observations != 4 || rollup.PressureMb.Min != 1010 || rollup.PressureMb.Max != 1013 || rollup.PressureMb.Mean != 1011.5 {
+		t.Errorf("rollup = %d observations, pressure %+v", rollup.Observations, rollup.PressureMb)
+	}
+	if !rollup.First.Equal(base) || !rollup.Last.Equal(base.Add(3*time.Hour)) {
+		t.Errorf("rollup spans %v..%v", rollup.First, rollup.Last)
+	}
+	left, err := db.Documents(ctx, docstore.Query{Collection: shared.WeatherRawCollection})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 1 || left[0].ID != "obs-late" {
+		t.Errorf("%d raw documents left, want only obs-late", len(left))
+	}
+}
+
+func TestSQLiteStore_RollUpIsAllOrNothing(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := db.Set(ctx, shared.PollenRawCollection, "p1", PollenSnapshot{LocationID: "house-nick", CollectedAt: at}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := PollenRollup{SchemaVersion: schema.Version, LocationID: "house-nick", Date: "2026-05-01"}
+	r.Add(PollenSnapshot{CollectedAt: at})
+	err := store.RollUpPollen(ctx, r, []string{"p1", "p-already-gone"})
+	if !errors.Is(err, docstore.ErrNotFound) {
+		t.Fatalf("RollUpPollen = %v, want ErrNotFound for the missing original", err)
+	}
+	if err := db.Get(ctx, shared.PollenRollupCollection, "house-nick_2026-05-01", &PollenRollup{}); !errors.Is(err, docstore.ErrNotFound) {
+		t.Errorf("rollup written despite the failed delete: %v", err)
+	}
+	if err := db.Get(ctx, shared.PollenRawCollection, "p1", &PollenSnapshot{}); err != nil {
+		t.Errorf("p1 deleted despite the failed roll-up: %v", err)
+	}
+}
+
+func TestSQLiteStore_RollUpForecastRun(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	issued := time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)
+	run := ForecastRun{SchemaVersion: schema.Version, Location: "house-nick", IssuedAt: issued, Points: []ForecastPoint{{ValidTime: issued.Add(time.Hour)}, {ValidTime: issued.Add(6 * time.Hour)}}}
+	if err := db.Set(ctx, shared.ForecastRawCollection, "run1", run); err != nil {
+		t.Fatal(err)
+	}
+
+	trimmed := run
+	trimmed.Points = run.Points[1:]
+	if err := store.RollUpForecastRun(ctx, "run1", trimmed); err != nil {
+		t.Fatal(err)
+	}
+	var stored ForecastRun
+	if err := db.Get(ctx, shared.ForecastRollupCollection, "run1", &stored); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Points) != 1 || !stored.IssuedAt.Equal(issued) {
+		t.Errorf("forecast_rollup/run1 = %+v, want the 6h point only", stored)
+	}
+	if err := db.Get(ctx, shared.ForecastRawCollection, "run1", &ForecastRun{}); !errors.Is(err, docstore.ErrNotFound) {
+		t.Errorf("original still in forecast_raw: %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Store reads the three raw archives oldest first and replaces their aged
+// documents with rollups. Each RollUp call writes the rollup and deletes
+// the originals it covers in one transaction, so a document is never both
+// deleted and missing from its rollup, nor counted in it twice: a day rollup
+// already stored is merged into rather than replaced, and the transaction
+// fails if an original has already been deleted.
+type Store interface {
+	ScanObservations(ctx context.Context, before time.Time, fn func(id string, p Observation) error) error
+	ScanForecastRuns(ctx context.Context, before time.Time, fn func(id string, r ForecastRun) error) error
+	ScanPollenSnapshots(ctx context.Context, before time.Time, fn func(id string, snap PollenSnapshot) error) error
+
+	RollUpObservations(ctx context.Context, r WeatherRollup, originals []string) error
+	RollUpPollen(ctx context.Context, r PollenRollup, originals []string) error
+	// RollUpForecastRun stores the trimmed run in forecast_rollup under the
+	// original's ID and deletes the original.
+	RollUpForecastRun(ctx context.Context, id string, r ForecastRun) error
+}
+
+// StoreCloser is a Store that holds a connection.
+type StoreCloser interface {
+	Store
+	Close() error
+}
+
+// scanPageSize bounds each read, so no single query has to outlive a long
+// first run over months of archive.
+const scanPageSize = 500
+
+type FirestoreStore struct {
+	weather *firestore.Client
+	pollen  *firestore.Client
+}
+
+// NewFirestoreStore connects to both databases: weather-log holds the
+// weather and forecast archives, pollen-log the pollen one.
+func NewFirestoreStore(ctx context.Context, projectID string) (*FirestoreStore, error) {
+	weather, err := firestore.NewClientWithDatabase(ctx, projectID, shared.WeatherDatabaseID)
+	if err != nil {
+		return nil, err
+	}
+	pollen, err := firestore.NewClientWithDatabase(ctx, projectID, shared.PollenDatabaseID)
+	if err != nil {
+		weather.Close()
+		return nil, err
+	}
+	return &FirestoreStore{weather: weather, pollen: pollen}, nil
+}
+
+func (s *FirestoreStore) Close() error {
+	return errors.Join(s.weather.Close(), s.pollen.Close())
+}
+
+func (s *FirestoreStore) ScanObservations(ctx context.Context, before time.Time, fn func(id string, p Observation) error) error {
+	return scan(ctx, s.weather.Collection(shared.WeatherRawCollection), "timestamp", before, fn)
+}
+
+func (s *FirestoreStore) ScanForecastRuns(ctx context.Context, before time.Time, fn func(id string, r ForecastRun) error) error {
+	return scan(ctx, s.weather.Collection(shared.ForecastRawCollection), "issued_at", before, fn)
+}
+
+func (s *FirestoreStore) ScanPollenSnapshots(ctx context.Context, before time.Time, fn func(id string, snap PollenSnapshot) error) error {
+	return scan(ctx, s.pollen.Collection(shared.PollenRawCollection), "collected_at", before, fn)
+}
+
+func (s *FirestoreStore) RollUpObservations(ctx context.Context, r WeatherRollup, originals []string) error {
+	return rollUp(ctx, s.weather, shared.WeatherRollupCollection, shared.WeatherRawCollection, RollupID(r.Location, r.Date), r, originals)
+}
+
+func (s *FirestoreStore) RollUpPollen(ctx context.Context, r PollenRollup, originals []string) error {
+	return rollUp(ctx, s.pollen, shared.PollenRollupCollection, shared.PollenRawCollection, RollupID(r.LocationID, r.Date), r, originals)
+}
+
+func (s *FirestoreStore) RollUpForecastRun(ctx context.Context, id string, r ForecastRun) error {
+	rollup := s.weather.Collection(shared.ForecastRollupCollection).Doc(id)
+	original := s.weather.Collection(shared.ForecastRawCollection).Doc(id)
+	err := s.weather.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Set(rollup, r); err != nil {
+			return err
+		}
+		return tx.Delete(original, firestore.Exists)
+	})
+	if err != nil {
+		return fmt.Errorf("rolling up forecast run %s: %w", id, err)
+	}
+	return nil
+}
+
+// scan pages through the documents with field before a time, oldest first.
+// It relies on Firestore's automatic single-field index on field.
+func scan[T any, PT interface {
+	*T
+	schema.Document
+}](ctx context.Context, coll *firestore.CollectionRef, field string, before time.Time, fn func(id string, item T) error) error {
+	query := coll.Where(field, "<", before).OrderBy(field, firestore.Asc).Limit(scanPageSize)
+	var last *firestore.DocumentSnapshot
+	for {
+		page := query
+		if last != nil {
+			page = query.StartAfter(last)
+		}
+		snaps, err := page.Documents(ctx).GetAll()
+		if err != nil {
+			return fmt.Errorf("scanning %s: %w", coll.ID, err)
+		}
+		for _, snap := range snaps {
+			var item T
+			if err := snap.DataTo(&item); err != nil {
+				return fmt.Errorf("decoding %s: %w", snap.Ref.Path, err)
+			}
+			schema.Read(snap.Ref.ID, PT(&item))
+			if err := fn(snap.Ref.ID, item); err != nil {
+				return err
+			}
+		}
+		if len(snaps) < scanPageSize {
+			return nil
+		}
+		last = snaps[len(snaps)-1]
+	}
+}
+
+// rollUp merges r into the rollup stored at id, or creates it, and deletes
+// the originals, in one transaction.
+func rollUp[T any, PT interface {
+	*T
+	schema.Document
+	Merge(T)
+}](ctx context.Context, client *firestore.Client, rollups, raw, id string, r T, originals []string) error {
+	ref := client.Collection(rollups).Doc(id)
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		merged := r
+		snap, err := tx.Get(ref)
+		switch {
+		case status.Code(err) == codes.NotFound:
+		case err != nil:
+			return err
+		default:
+			var stored T
+			if err := snap.DataTo(&stored); err != nil {
+				return fmt.Errorf("decoding %s: %w", ref.Path, err)
+			}
+			if err := schema.Rewrite(id, PT(&stored)); err != nil {
+				return err
+			}
+			PT(&stored).Merge(r)
+			merged = stored
+		}
+		if err := tx.Set(ref, merged); err != nil {
+			return err
+		}
+		for _, orig := range originals {
+			if err := tx.Delete(client.Collection(raw).Doc(orig), firestore.Exists); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("rolling up %s/%s: %w", rollups, id, err)
+	}
+	return nil
+}
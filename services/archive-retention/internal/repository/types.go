@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/nickfang/personal-dashboard/services/shared/schema"
+
+// The archives this job prunes, and the rollups it replaces them with, are
+// defined once in shared/schema. A forecast_rollup document is a ForecastRun
+// with only the kept lead times, stored under the original run's ID.
+type (
+	Observation    = schema.WeatherPoint
+	ForecastPoint  = schema.ForecastPoint
+	ForecastRun    = schema.ForecastRun
+	PollenSnapshot = schema.PollenSnapshot
+	WeatherRollup  = schema.WeatherRollup
+	PollenRollup   = schema.PollenRollup
+)
+
+// RollupID is the document ID of one location's rollup for one day, e.g.
+// weather_rollup/house-nick_2026-06-12.
+func RollupID(location, date string) string {
+	return location + "_" + date
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/archive-retention/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// MinRetainDays is the shortest window Validate accepts. forecast-verifier
+// scores the last 30 days of forecast_raw against weather_raw, so neither may
+// be pruned inside that.
+const MinRetainDays = 31
+
+// maxOriginalsPerCommit keeps each rollup transaction well inside
+// Firestore's limit of 500 writes. A day with more originals is committed in
+// parts, each merged into the same rollup.
+const maxOriginalsPerCommit = 400
+
+// Config is how long each archive keeps full resolution.
+type Config struct {
+	WeatherDays   int   // RETAIN_WEATHER_DAYS
+	ForecastDays  int   // RETAIN_FORECAST_DAYS
+	PollenDays    int   // RETAIN_POLLEN_DAYS
+	ForecastLeads []int // RETAIN_FORECAST_LEADS: lead hours forecast_rollup keeps
+	DryRun        bool  // RETENTION_DRY_RUN: report without writing or deleting
+}
+
+func DefaultConfig() Config {
+	return Config{
+		WeatherDays:   90,
+		ForecastDays:  60,
+		PollenDays:    365,
+		ForecastLeads: []int{6, 12, 24, 48, 72},
+	}
+}
+
+func (c Config) Validate() error {
+	for name, days := range map[string]int{"weather": c.WeatherDays, "forecast": c.ForecastDays, "pollen": c.PollenDays} {
+		if days < MinRetainDays {
+			return fmt.Errorf("%s retention of %d days is under the %d-day minimum", name, days, MinRetainDays)
+		}
+	}
+	if len(c.ForecastLeads) == 0 {
+		return fmt.Errorf("no forecast lead times to keep")
+	}
+	for _, h := range c.ForecastLeads {
+		if h < 0 {
+			return fmt.Errorf("forecast lead time %dh is negative", h)
+		}
+	}
+	return nil
+}
+
+// Report is what one archive's pass did, or in a dry run would have done.
+type Report struct {
+	Collection string
+	Cutoff     time.Time // originals before this are rolled up
+	DryRun     bool
+	Scanned    int // originals older than Cutoff
+	Deleted    int // rolled up and deleted
+	Rollups    int // rollup documents written or merged into
+	// Pending counts originals before Cutoff left in place because the rest
+	// of their day is not: a day is only rolled up once all of it has aged out.
+	Pending    int
+	PointsKept int            // forecast points carried into forecast_rollup
+	Oldest     time.Time      // earliest deleted original
+	Newest     time.Time      // latest deleted original
+	ByLocation map[string]int // deleted originals per location
+}
+
+func (r *Report) deleted(location string, first, last time.Time, n int) {
+	r.Deleted += n
+	r.ByLocation[location] += n
+	if r.Oldest.IsZero() || first.Before(r.Oldest) {
+		r.Oldest = first
+	}
+	if last.After(r.Newest) {
+		r.Newest = last
+	}
+}
+
+// RetentionService replaces aged raw archive documents with rollups.
+type RetentionService struct {
+	store repository.Store
+	cfg   Config
+	zones map[string]*time.Location
+}
+
+// NewRetentionService takes every registered location, enabled or not, for
+// its timezone: observations are grouped by calendar day at the location.
+// Documents from a location no longer registered fall back to UTC days.
+func NewRetentionService(store repository.Store, cfg Config, locations []shared.Location) *RetentionService {
+	zones := make(map[string]*time.Location, len(locations))
+	for _, l := range locations {
+		zones[l.ID] = l.TimeZone()
+	}
+	return &RetentionService{store: store, cfg: cfg, zones: zones}
+}
+
+// Run prunes each archive in turn. A failure in one does not stop the
+// others; everything rolled up before it stays committed, and the next run
+// picks up the rest.
+func (s *RetentionService) Run(ctx context.Context, now time.Time) ([]Report, error) {
+	passes := []func(context.Context, time.Time) (Report, error){s.Observations, s.ForecastRuns, s.Pollen}
+	var reports []Report
+	var errs []error
+	for _, pass := range passes {
+		report, err := pass(ctx, now)
+		logReport(report)
+		reports = append(reports, report)
+		if err != nil {
+			slog.Error("Retention pass failed", "collection", report.Collection, "error", err)
+			errs = append(errs, err)
+		}
+	}
+	return reports, errors.Join(errs...)
+}
+
+func (s *RetentionService) newReport(collection string, now time.Time, days int) Report {
+	return Report{
+		Collection: collection,
+		Cutoff:     now.AddDate(0, 0, -days),
+		DryRun:     s.cfg.DryRun,
+		ByLocation: map[string]int{},
+	}
+}
+
+// Observations rolls weather_raw up into daily weather_rollup documents.
+func (s *RetentionService) Observations(ctx context.Context, now time.Time) (Report, error) {
+	report := s.newReport(shared.WeatherRawCollection, now, s.cfg.WeatherDays)
+	days := newDayBuckets(s, &report, func(d *day[repository.WeatherRollup]) error {
+		r := d.rollup
+		r.SchemaVersion, r.Location, r.Date, r.Start = schema.Version, d.location, d.date, d.start
+		if s.cfg.DryRun {
+			return nil
+		}
+		return s.store.RollUpObservations(ctx, r, d.ids)
+	})
+	err := s.store.ScanObservations(ctx, report.Cutoff, func(id string, p repository.Observation) error {
+		return days.add(id, p.Location, p.Timestamp, func(r *repository.WeatherRollup) { r.Add(p) })
+	})
+	if err == nil {
+		err = days.flushAll()
+	}
+	return report, err
+}
+
+// Pollen rolls pollen_raw up into daily pollen_rollup documents.
+func (s *RetentionService) Pollen(ctx context.Context, now time.Time) (Report, error) {
+	report := s.newReport(shared.PollenRawCollection, now, s.cfg.PollenDays)
+	days := newDayBuckets(s, &report, func(d *day[repository.PollenRollup]) error {
+		r := d.rollup
+		r.SchemaVersion, r.LocationID, r.Date, r.Start = schema.Version, d.location, d.date, d.start
+		if s.cfg.DryRun {
+			return nil
+		}
+		return s.store.RollUpPollen(ctx, r, d.ids)
+	})
+	err := s.store.ScanPollenSnapshots(ctx, report.Cutoff, func(id string, snap repository.PollenSnapshot) error {
+		return days.add(id, snap.LocationID, snap.CollectedAt, func(r *repository.PollenRollup) { r.Add(snap) })
+	})
+	if err == nil {
+		err = days.flushAll()
+	}
+	return report, err
+}
+
+// ForecastRuns moves each aged forecast_raw run to forecast_rollup with only
+// the configured lead times.
+func (s *RetentionService) ForecastRuns(ctx context.Context, now time.Time) (Report, error) {
+	report := s.newReport(shared.ForecastRawCollection, now, s.cfg.ForecastDays)
+	err := s.store.ScanForecastRuns(ctx, report.Cutoff, func(id string, run repository.ForecastRun) error {
+		report.Scanned++
+		trimmed := run
+		trimmed.SchemaVersion = schema.Version
+		trimmed.Points = KeepLeads(run, s.cfg.ForecastLeads)
+		if !s.cfg.DryRun {
+			if err := s.store.RollUpForecastRun(ctx, id, trimmed); err != nil {
+				return err
+			}
+		}
+		report.Rollups++
+		report.PointsKept += len(trimmed.Points)
+		report.deleted(run.Location, run.IssuedAt, run.IssuedAt, 1)
+		return nil
+	})
+	return report, err
+}
+
+// KeepLeads returns the points of run whose lead time, rounded to the hour,
+// is one of leads.
+func KeepLeads(run repository.ForecastRun, leads []int) []repository.ForecastPoint {
+	var kept []repository.ForecastPoint
+	for _, p := range run.Points {
+		lead := int(math.Round(p.ValidTime.Sub(run.IssuedAt).Hours()))
+		if slices.Contains(leads, lead) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// day is one location's originals for one local calendar day.
+type day[R any] struct {
+	location, date string
+	start, end     time.Time
+	rollup         R
+	ids            []string
+	first, last    time.Time // span of ids, for the report
+}
+
+// dayBuckets groups a scan's originals, which arrive oldest first, by
+// location and local day. A day is flushed once the scan has moved past its
+// end, so it is complete; one that ends after the cutoff is never opened,
+// and its originals wait for a later run.
+type dayBuckets[R any] struct {
+	svc    *RetentionService
+	report *Report
+	flush  func(*day[R]) error
+	open   map[string]*day[R]
+}
+
+func newDayBuckets[R any](svc *RetentionService, report *Report, flush func(*day[R]) error) *dayBuckets[R] {
+	return &dayBuckets[R]{svc: svc, report: report, flush: flush, open: map[string]*day[R]{}}
+}
+
+func (b *dayBuckets[R]) add(id, location string, at time.Time, fold func(*R)) error {
+	b.report.Scanned++
+	zone := b.svc.zones[location]
+	if zone == nil {
+		zone = time.UTC
+	}
+	local := at.In(zone)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, zone)
+	end := start.AddDate(0, 0, 1)
+	if end.After(b.report.Cutoff) {
+		b.report.Pending++
+		return nil
+	}
+
+	key := repository.RollupID(location, start.Format(time.DateOnly))
+	d, ok := b.open[key]
+	if !ok {
+		d = &day[R]{location: location, date: start.Format(time.DateOnly), start: start.UTC(), end: end}
+		b.open[key] = d
+		b.report.Rollups++
+	}
+	fold(&d.rollup)
+	if len(d.ids) == 0 {
+		d.first = at
+	}
+	d.ids = append(d.ids, id)
+	d.last = at
+	if len(d.ids) >= maxOriginalsPerCommit {
+		if err := b.commit(d); err != nil {
+			return err
+		}
+	}
+
+	for key, d := range b.open {
+		if !d.end.After(at) {
+			if err := b.commit(d); err != nil {
+				return err
+			}
+			delete(b.open, key)
+		}
+	}
+	return nil
+}
+
+// flushAll commits every open day. Each ends at or before the cutoff, and
+// the scan stopped there, so none can gain more originals.
+func (b *dayBuckets[R]) flushAll() error {
+	for key, d := range b.open {
+		if err := b.commit(d); err != nil {
+			return err
+		}
+		delete(b.open, key)
+	}
+	return nil
+}
+
+// commit flushes what the day has gathered so far and starts it afresh;
+// the store merges each part into the same rollup.
+func (b *dayBuckets[R]) commit(d *day[R]) error {
+	if len(d.ids) == 0 {
+		return nil
+	}
+	if err := b.flush(d); err != nil {
+		return err
+	}
+	b.report.deleted(d.location, d.first, d.last, len(d.ids))
+	var zero R
+	d.rollup, d.ids = zero, nil
+	return nil
+}
+
+func logReport(r Report) {
+	msg := "Retention complete"
+	if r.DryRun {
+		msg = "Retention dry run: nothing written or deleted"
+	}
+	attrs := []any{
+		"collection", r.Collection,
+		"cutoff", r.Cutoff,
+		"scanned", r.Scanned,
+		"deleted", r.Deleted,
+		"rollups", r.Rollups,
+		"pending", r.Pending,
+	}
+	if r.Collection == shared.ForecastRawCollection {
+		attrs = append(attrs, "points_kept", r.PointsKept)
+	}
+	if r.Deleted > 0 {
+		attrs = append(attrs, "oldest", r.Oldest, "newest", r.Newest, "by_location", r.ByLocation)
+	}
+	slog.Info(msg, attrs...)
+}
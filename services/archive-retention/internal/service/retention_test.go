@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/archive-retention/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/archive-retention/internal/testutil"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+var (
+	// now puts every cutoff at 12:00 UTC, seven hours into a Chicago day.
+	now       = time.Date(2026, 6, 20, 12, 0, 0, 0, time.UTC)
+	locations = []shared.Location{{ID: "house-nick", Timezone: "America/Chicago"}}
+	testCfg   = Config{WeatherDays: 31, ForecastDays: 31, PollenDays: 31, ForecastLeads: []int{6, 24}}
+)
+
+// hourly returns one observation an hour for location over [from, to).
+func hourly(location string, from, to time.Time) []testutil.Doc[repository.Observation] {
+	var docs []testutil.Doc[repository.Observation]
+	for at := from; at.Before(to); at = at.Add(time.Hour) {
+		docs = append(docs, testutil.Doc[repository.Observation]{
+			ID:   fmt.Sprintf("%s-%s", location, at.Format("010215")),
+			Data: repository.Observation{Location: location, Timestamp: at, PressureMb: 1000 + float64(at.Hour())},
+		})
+	}
+	return docs
+}
+
+func TestObservations_RollsUpWholeLocalDays(t *testing.T) {
+	// 2026-05-18 00:00 UTC is 19:00 on the 17th in Chicago; the cutoff is
+	// 2026-05-20 12:00 UTC, partway through the 20th there.
+	store := &testutil.MockStore{Observations: hourly("house-nick", time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 21, 0, 0, 0, 0, time.UTC))}
+	svc := NewRetentionService(store, testCfg, locations)
+
+	report, err := svc.Observations(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Scanned != 60 || report.Deleted != 53 || report.Pending != 7 || report.Rollups != 3 {
+		t.Errorf("report = %+v, want 60 scanned: 53 over three days, 7 pending", report)
+	}
+	if len(store.WeatherRollups) != 3 {
+		t.Fatalf("committed %d rollups, want 3", len(store.WeatherRollups))
+	}
+	wantDays := map[string]int{"2026-05-17": 5, "2026-05-18": 24, "2026-05-19": 24}
+	for _, c := range store.WeatherRollups {
+		r := c.Rollup
+		if n, ok := wantDays[r.Date]; !ok || len(c.Originals) != n || r.Observations != n || r.PressureMb.Count != n {
+			t.Errorf("rollup %s has %d originals, %d observations; want %d", r.Date, len(c.Originals), r.Observations, wantDays[r.Date])
+		}
+		if r.Location != "house-nick" || r.SchemaVersion != schema.Version {
+			t.Errorf("rollup %s identity = %q v%d", r.Date, r.Location, r.SchemaVersion)
+		}
+		if r.Start.Hour() != 5 {
+			t.Errorf("rollup %s starts %v, want Chicago midnight (05:00 UTC)", r.Date, r.Start)
+		}
+	}
+	if report.ByLocation["house-nick"] != 53 || !report.Oldest.Equal(store.Observations[0].Data.Timestamp) {
+		t.Errorf("report span = %v..%v by %v", report.Oldest, report.Newest, report.ByLocation)
+	}
+}
+
+func TestObservations_UnregisteredLocationUsesUTCDays(t *testing.T) {
+	store := &testutil.MockStore{Observations: hourly("house-gone", time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC))}
+	svc := NewRetentionService(store, testCfg, locations)
+
+	if _, err := svc.Observations(context.Background(), now); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.WeatherRollups) != 2 || store.WeatherRollups[0].Rollup.Date != "2026-05-18" || store.WeatherRollups[0].Rollup.Observations != 24 {
+		t.Errorf("rollups = %+v, want two whole UTC days", store.WeatherRollups)
+	}
+}
+
+func TestObservations_DryRunCommitsNothing(t *testing.T) {
+	store := &testutil.MockStore{Observations: hourly("house-nick", time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 21, 0, 0, 0, 0, time.UTC))}
+	cfg := testCfg
+	cfg.DryRun = true
+	svc := NewRetentionService(store, cfg, locations)
+
+	report, err := svc.Observations(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.WeatherRollups) != 0 {
+		t.Errorf("dry run committed %d rollups", len(store.WeatherRollups))
+	}
+	if !report.DryRun || report.Deleted != 53 || report.Rollups != 3 {
+		t.Errorf("dry-run report = %+v, want what a real run would do", report)
+	}
+}
+
+func TestObservations_LargeDayCommitsInParts(t *testing.T) {
+	start := time.Date(2026, 5, 1, 5, 0, 0, 0, time.UTC) // Chicago midnight
+	var docs []testutil.Doc[repository.Observation]
+	for i := range 450 {
+		docs = append(docs, testutil.Doc[repository.Observation]{
+			ID:   fmt.Sprintf("obs-%03d", i),
+			Data: repository.Observation{Location: "house-nick", Timestamp: start.Add(time.Duration(i) * time.Minute)},
+		})
+	}
+	store := &testutil.MockStore{Observations: docs}
+	svc := NewRetentionService(store, testCfg, locations)
+
+	report, err := svc.Observations(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.WeatherRollups) != 2 || len(store.WeatherRollups[0].Originals) != maxOriginalsPerCommit {
+		t.Fatalf("committed %d parts, want %d originals then the rest", len(store.WeatherRollups), maxOriginalsPerCommit)
+	}
+	first, second := store.WeatherRollups[0].Rollup, store.WeatherRollups[1].Rollup
+	if first.Date != second.Date || first.Observations+second.Observations != 450 {
+		t.Errorf("parts = %s/%d and %s/%d, want one day split 400+50", first.Date, first.Observations, second.Date, second.Observations)
+	}
+	if report.Rollups != 1 || report.Deleted != 450 {
+		t.Errorf("report = %+v, want one rollup of 450", report)
+	}
+}
+
+func TestForecastRuns_KeepsConfiguredLeads(t *testing.T) {
+	issued := time.Date(2026, 5, 1, 6, 0, 12, 0, time.UTC)
+	run := repository.ForecastRun{Location: "house-nick", IssuedAt: issued}
+	for h := 1; h <= 72; h++ {
+		run.Points = append(run.Points, repository.ForecastPoint{ValidTime: issued.Truncate(time.Hour).Add(time.Duration(h) * time.Hour)})
+	}
+	recent := repository.ForecastRun{Location: "house-nick", IssuedAt: now.AddDate(0, 0, -1)}
+	store := &testutil.MockStore{Runs: []testutil.Doc[repository.ForecastRun]{{ID: "run1", Data: run}, {ID: "run2", Data: recent}}}
+	svc := NewRetentionService(store, testCfg, locations)
+
+	report, err := svc.ForecastRuns(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.ForecastRollups) != 1 || store.ForecastRollups[0].Originals[0] != "run1" {
+		t.Fatalf("rollups = %+v, want run1 only", store.ForecastRollups)
+	}
+	kept := store.ForecastRollups[0].Rollup
+	if len(kept.Points) != 2 || !kept.Points[0].ValidTime.Equal(issued.Truncate(time.Hour).Add(6*time.Hour)) || kept.SchemaVersion != schema.Version {
+		t.Errorf("kept %+v, want the 6h and 24h points", kept.Points)
+	}
+	if report.Scanned != 1 || report.Deleted != 1 || report.PointsKept != 2 {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestPollen_RollsUpDays(t *testing.T) {
+	var snaps []testutil.Doc[repository.PollenSnapshot]
+	for d := range 3 {
+		for _, h := range []int{11, 19} { // 06:00 and 14:00 in Chicago
+			at := time.Date(2026, 5, 1+d, h, 0, 0, 0, time.UTC)
+			snaps = append(snaps, testutil.Doc[repository.PollenSnapshot]{
+				ID:   fmt.Sprintf("p-%d-%d", d, h),
+				Data: repository.PollenSnapshot{LocationID: "house-nick", CollectedAt: at, OverallIndex: d + 1, Types: []schema.PollenType{{Code: "TREE", Index: d + 1}}},
+			})
+		}
+	}
+	store := &testutil.MockStore{Snapshots: snaps}
+	svc := NewRetentionService(store, testCfg, locations)
+
+	report, err := svc.Pollen(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.PollenRollups) != 3 || report.Deleted != 6 {
+		t.Fatalf("rollups = %d, report = %+v; want three days of two", len(store.PollenRollups), report)
+	}
+	r := store.PollenRollups[1].Rollup
+	if r.LocationID != "house-nick" || r.Date != "2026-05-02" || r.Snapshots != 2 || r.Types[0].Index.Mean != 2 {
+		t.Errorf("second day = %+v", r)
+	}
+}
+
+func TestRun_ContinuesPastAFailedPass(t *testing.T) {
+	store := &testutil.MockStore{
+		Observations: hourly("house-nick", time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 19, 0, 0, 0, 0, time.UTC)),
+		Runs:         []testutil.Doc[repository.ForecastRun]{{ID: "run1", Data: repository.ForecastRun{IssuedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}}},
+		RollUpErr:    errors.New("permission denied"),
+	}
+	svc := NewRetentionService(store, testCfg, locations)
+
+	reports, err := svc.Run(context.Background(), now)
+	if err == nil {
+		t.Fatal("Run should report the failed passes")
+	}
+	if len(reports) != 3 || reports[2].Collection != shared.PollenRawCollection {
+		t.Errorf("reports = %+v, want one per archive", reports)
+	}
+	if reports[0].Deleted != 0 {
+		t.Errorf("a failed roll-up was reported as deleted: %+v", reports[0])
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("default config: %v", err)
+	}
+	short := DefaultConfig()
+	short.ForecastDays = 7
+	if err := short.Validate(); err == nil {
+		t.Error("a window under MinRetainDays should be rejected")
+	}
+	noLeads := DefaultConfig()
+	noLeads.ForecastLeads = nil
+	if err := noLeads.Validate(); err == nil {
+		t.Error("an empty lead list should be rejected")
+	}
+}
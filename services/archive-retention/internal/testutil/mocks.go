@@ -0,0 +1,83 @@
+package testutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/archive-retention/internal/repository"
+)
+
+// Doc is one archived document and its ID.
+type Doc[T any] struct {
+	ID   string
+	Data T
+}
+
+// Committed is one roll-up: the rollup written and the originals deleted.
+type Committed[R any] struct {
+	Rollup    R
+	Originals []string
+}
+
+// MockStore implements repository.Store over in-memory archives, which must
+// be given oldest first. Scans apply the cutoff; roll-ups are recorded in
+// the Committed fields and leave the archives as they are. RollUpErr fails
+// every roll-up.
+type MockStore struct {
+	Observations []Doc[repository.Observation]
+	Runs         []Doc[repository.ForecastRun]
+	Snapshots    []Doc[repository.PollenSnapshot]
+	RollUpErr    error
+
+	WeatherRollups  []Committed[repository.WeatherRollup]
+	PollenRollups   []Committed[repository.PollenRollup]
+	ForecastRollups []Committed[repository.ForecastRun]
+}
+
+func (m *MockStore) ScanObservations(ctx context.Context, before time.Time, fn func(id string, p repository.Observation) error) error {
+	return scan(m.Observations, before, func(p repository.Observation) time.Time { return p.Timestamp }, fn)
+}
+
+func (m *MockStore) ScanForecastRuns(ctx context.Context, before time.Time, fn func(id string, r repository.ForecastRun) error) error {
+	return scan(m.Runs, before, func(r repository.ForecastRun) time.Time { return r.IssuedAt }, fn)
+}
+
+func (m *MockStore) ScanPollenSnapshots(ctx context.Context, before time.Time, fn func(id string, snap repository.PollenSnapshot) error) error {
+	return scan(m.Snapshots, before, func(s repository.PollenSnapshot) time.Time { return s.CollectedAt }, fn)
+}
+
+func (m *MockStore) RollUpObservations(ctx context.Context, r repository.WeatherRollup, originals []string) error {
+	if m.RollUpErr != nil {
+		return m.RollUpErr
+	}
+	m.WeatherRollups = append(m.WeatherRollups, Committed[repository.WeatherRollup]{r, originals})
+	return nil
+}
+
+func (m *MockStore) RollUpPollen(ctx context.Context, r repository.PollenRollup, originals []string) error {
+	if m.RollUpErr != nil {
+		return m.RollUpErr
+	}
+	m.PollenRollups = append(m.PollenRollups, Committed[repository.PollenRollup]{r, originals})
+	return nil
+}
+
+func (m *MockStore) RollUpForecastRun(ctx context.Context, id string, r repository.ForecastRun) error {
+	if m.RollUpErr != nil {
+		return m.RollUpErr
+	}
+	m.ForecastRollups = append(m.ForecastRollups, Committed[repository.ForecastRun]{r, []string{id}})
+	return nil
+}
+
+func scan[T any](docs []Doc[T], before time.Time, at func(T) time.Time, fn func(string, T) error) error {
+	for _, d := range docs {
+		if !at(d.Data).Before(before) {
+			continue
+		}
+		if err := fn(d.ID, d.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/nickfang/personal-dashboard/services/dashboard-api/internal/clients"
 	"github.com/nickfang/personal-dashboard/services/dashboard-api/internal/handlers"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
 )
 
 func main() {
@@ -61,11 +62,25 @@ func main() {
 	defer pollenClient.Close()
 	defer forecastClient.Close()
 
+	// The registry is read per request, so a location added or renamed is
+	// served without a restart. The same store backs /v1/locations.
+	locations, err := registry.Open(context.Background(), os.Getenv("GCP_PROJECT_ID"))
+	if err != nil {
+		slog.Error("Failed to open location registry", "error", err)
+		os.Exit(1)
+	}
+	defer locations.Close()
+
 	// 4. Initialize Handlers
-	dashboardHandler := handlers.NewDashboardHandler(weatherClient, pollenClient, forecastClient)
+	dashboardHandler := handlers.NewDashboardHandler(weatherClient, pollenClient, forecastClient, locations)
+	locationHandler := handlers.NewLocationHandler(locations)
 
 	// 5. Initialize Router
-	router := app.NewRouter(dashboardHandler)
+	adminToken := os.Getenv("LOCATIONS_ADMIN_TOKEN")
+	if adminToken == "" {
+		slog.Warn("LOCATIONS_ADMIN_TOKEN not set, location write routes are disabled")
+	}
+	router := app.NewRouter(dashboardHandler, locationHandler, adminToken)
 
 	// 6. Start Server
 	server := &http.Server{
@@ -9,7 +9,9 @@ import (
 	customMiddleware "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/middleware"
 )
 
-func NewRouter(dashboardHandler *handlers.DashboardHandler) *chi.Mux {
+// NewRouter wires the API. adminToken guards the location write routes; see
+// middleware.RequireBearerToken.
+func NewRouter(dashboardHandler *handlers.DashboardHandler, locationHandler *handlers.LocationHandler, adminToken string) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Global Middleware
@@ -22,6 +24,22 @@ func NewRouter(dashboardHandler *handlers.DashboardHandler) *chi.Mux {
 	r.Route("/v1", func(r chi.Router) {
 		r.Get("/dashboard", dashboardHandler.GetDashboard)
 		r.Get("/dashboard/{locationID}", dashboardHandler.GetDashboardByLocation)
+		r.Get("/pressure/{locationID}/history", dashboardHandler.GetPressureHistory)
+		r.Get("/pollen/{locationID}/forecast", dashboardHandler.GetPollenForecast)
+		r.Get("/pollen/{locationID}/history", dashboardHandler.GetPollenHistory)
+		r.Get("/alerts/history", dashboardHandler.GetAlertHistory)
+		r.Get("/alerts/{alertID}/events", dashboardHandler.GetAlertEvents)
+
+		r.Route("/locations", func(r chi.Router) {
+			r.Get("/", locationHandler.ListLocations)
+			r.Get("/{locationID}", locationHandler.GetLocation)
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequireBearerToken(adminToken))
+				r.Post("/", locationHandler.CreateLocation)
+				r.Patch("/{locationID}", locationHandler.UpdateLocation)
+				r.Delete("/{locationID}", locationHandler.DeleteLocation)
+			})
+		})
 		// r.Get("/weather", dashboardHandler.GetWeather)
 		// r.Get("/pollen", dashboardHandler.GetPollen)
 	})
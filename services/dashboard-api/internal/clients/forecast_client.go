@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"time"
 
 	pb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/weather-provider/v1"
 	"google.golang.org/api/idtoken"
@@ -11,6 +12,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/credentials/oauth"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ForecastClient wraps the ForecastService RPC, served by weather-provider
@@ -74,3 +76,32 @@ func (c *ForecastClient) GetAllForecasts(ctx context.Context) ([]*pb.Forecast, e
 	}
 	return resp.Forecasts, nil
 }
+
+// ListAlertHistory returns archived forecast alerts, newest first. Empty IDs,
+// zero start/end and a zero limit leave the corresponding filter unset.
+func (c *ForecastClient) ListAlertHistory(ctx context.Context, locationID, ruleID string, start, end time.Time, limit int) ([]*pb.ArchivedAlert, error) {
+	req := &pb.ListAlertHistoryRequest{LocationId: locationID, RuleId: ruleID, Limit: int32(limit)}
+	if !start.IsZero() {
+		req.StartTime = timestamppb.New(start)
+	}
+	if !end.IsZero() {
+		req.EndTime = timestamppb.New(end)
+	}
+	resp, err := c.client.ListAlertHistory(ctx, req)
+	if err != nil {
+		slog.Error("Failed to list alert history", "error", err)
+		return nil, err
+	}
+	return resp.Alerts, nil
+}
+
+// ListAlertEvents returns one forecast alert's lifecycle events, oldest
+// first.
+func (c *ForecastClient) ListAlertEvents(ctx context.Context, alertID string) ([]*pb.AlertEvent, error) {
+	resp, err := c.client.ListAlertEvents(ctx, &pb.ListAlertEventsRequest{AlertId: alertID})
+	if err != nil {
+		slog.Error("Failed to list alert events", "alert", alertID, "error", err)
+		return nil, err
+	}
+	return resp.Events, nil
+}
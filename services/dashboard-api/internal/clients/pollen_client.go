@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"time"
 
 	pb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/pollen-provider/v1"
 	"google.golang.org/api/idtoken"
@@ -11,6 +12,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/credentials/oauth"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type PollenClient struct {
@@ -75,3 +77,30 @@ func (c *PollenClient) GetPollenReport(ctx context.Context, locationID string) (
 	}
 	return resp.Report, nil
 }
+
+func (c *PollenClient) GetPollenForecast(ctx context.Context, locationID string) (*pb.PollenForecast, error) {
+	resp, err := c.client.GetPollenForecast(ctx, &pb.GetPollenForecastRequest{LocationId: locationID})
+	if err != nil {
+		slog.Error("Failed to get pollen forecast", "error", err)
+		return nil, err
+	}
+	return resp.Forecast, nil
+}
+
+// GetPollenHistory returns a location's stored snapshots, oldest first. An
+// empty code and zero start/end leave the corresponding filter unset.
+func (c *PollenClient) GetPollenHistory(ctx context.Context, locationID, code string, start, end time.Time) ([]*pb.PollenReport, error) {
+	req := &pb.GetPollenHistoryRequest{LocationId: locationID, Code: code}
+	if !start.IsZero() {
+		req.StartTime = timestamppb.New(start)
+	}
+	if !end.IsZero() {
+		req.EndTime = timestamppb.New(end)
+	}
+	resp, err := c.client.GetPollenHistory(ctx, req)
+	if err != nil {
+		slog.Error("Failed to get pollen history", "error", err)
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
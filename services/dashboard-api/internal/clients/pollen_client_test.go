@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	pb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/pollen-provider/v1"
 	"google.golang.org/grpc"
@@ -59,6 +60,30 @@ func (m *mockPollenServer) GetPollenReport(ctx context.Context, req *pb.GetPolle
 	}, nil
 }
 
+func (m *mockPollenServer) GetPollenForecast(ctx context.Context, req *pb.GetPollenForecastRequest) (*pb.GetPollenForecastResponse, error) {
+	return &pb.GetPollenForecastResponse{
+		Forecast: &pb.PollenForecast{
+			LocationId: req.LocationId,
+			Days: []*pb.PollenForecastDay{
+				{Date: "2026-04-02", OverallIndex: 3, OverallCategory: "Moderate", DominantType: "TREE"},
+				{Date: "2026-04-03", OverallIndex: 5, OverallCategory: "Very High", DominantType: "TREE"},
+			},
+		},
+	}, nil
+}
+
+// GetPollenHistory echoes the request's filters back through the first
+// snapshot so the test can check what the client sent.
+func (m *mockPollenServer) GetPollenHistory(ctx context.Context, req *pb.GetPollenHistoryRequest) (*pb.GetPollenHistoryResponse, error) {
+	echo := &pb.PollenReport{LocationId: req.LocationId, DominantType: req.Code, CollectedAt: req.StartTime}
+	if req.EndTime != nil {
+		echo.OverallCategory = "has-end"
+	}
+	return &pb.GetPollenHistoryResponse{
+		Snapshots: []*pb.PollenReport{echo, {LocationId: req.LocationId}},
+	}, nil
+}
+
 // setupPollenTestClient creates an in-memory gRPC server and returns a connected PollenClient
 func setupPollenTestClient(t *testing.T) *PollenClient {
 	t.Helper()
@@ -138,3 +163,46 @@ func TestPollenClient_GetPollenReports(t *testing.T) {
 		t.Errorf("Expected second location house-nita, got %s", reports[1].LocationId)
 	}
 }
+
+func TestPollenClient_GetPollenForecast(t *testing.T) {
+	client := setupPollenTestClient(t)
+
+	forecast, err := client.GetPollenForecast(context.Background(), "house-nick")
+	if err != nil {
+		t.Fatalf("GetPollenForecast failed: %v", err)
+	}
+
+	if forecast.LocationId != "house-nick" {
+		t.Errorf("Expected location house-nick, got %s", forecast.LocationId)
+	}
+	if len(forecast.Days) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(forecast.Days))
+	}
+	if forecast.Days[1].Date != "2026-04-03" || forecast.Days[1].OverallIndex != 5 {
+		t.Errorf("Expected day 2 to be 2026-04-03 at 5, got %s at %d", forecast.Days[1].Date, forecast.Days[1].OverallIndex)
+	}
+}
+
+func TestPollenClient_GetPollenHistory(t *testing.T) {
+	client := setupPollenTestClient(t)
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	snapshots, err := client.GetPollenHistory(context.Background(), "house-nick", "JUNIPER", start, time.Time{})
+	if err != nil {
+		t.Fatalf("GetPollenHistory failed: %v", err)
+	}
+
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	echo := snapshots[0]
+	if echo.LocationId != "house-nick" || echo.DominantType != "JUNIPER" {
+		t.Errorf("Expected location and code to be sent, got %s/%s", echo.LocationId, echo.DominantType)
+	}
+	if !echo.CollectedAt.AsTime().Equal(start) {
+		t.Errorf("Expected start_time %v to be sent, got %v", start, echo.CollectedAt.AsTime())
+	}
+	if echo.OverallCategory == "has-end" {
+		t.Error("Expected a zero end to leave end_time unset")
+	}
+}
@@ -93,3 +93,12 @@ func (c *WeatherClient) GetAllLastWeather(ctx context.Context) ([]*pb.Weather, e
 	}
 	return resp.Weather, nil
 }
+
+func (c *WeatherClient) GetPressureHistory(ctx context.Context, locationId string) ([]*pb.PressurePoint, error) {
+	resp, err := c.client.GetPressureHistory(ctx, &pb.GetPressureHistoryRequest{LocationId: locationId})
+	if err != nil {
+		slog.Error("Failed to get pressure history", "error", err)
+		return nil, err
+	}
+	return resp.Points, nil
+}
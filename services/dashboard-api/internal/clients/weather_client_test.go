@@ -34,6 +34,16 @@ func (m *mockWeatherServer) GetAllPressureStats(ctx context.Context, req *pb.Get
 	}, nil
 }
 
+func (m *mockWeatherServer) GetPressureHistory(ctx context.Context, req *pb.GetPressureHistoryRequest) (*pb.GetPressureHistoryResponse, error) {
+	return &pb.GetPressureHistoryResponse{
+		LocationId: req.LocationId,
+		Points: []*pb.PressurePoint{
+			{PressureMb: 1013.2, HumidityPercent: 70},
+			{PressureMb: 1012.4, HumidityPercent: 74},
+		},
+	}, nil
+}
+
 // setupTestClient creates an in-memory gRPC server and returns a connected WeatherClient
 func setupTestClient(t *testing.T) *WeatherClient {
 	t.Helper()
@@ -101,3 +111,19 @@ func TestWeatherClient_GetPressureStats(t *testing.T) {
 		t.Errorf("Expected second location house-nita, got %s", stats[1].LocationId)
 	}
 }
+
+func TestWeatherClient_GetPressureHistory(t *testing.T) {
+	client := setupTestClient(t)
+
+	points, err := client.GetPressureHistory(context.Background(), "house-nick")
+	if err != nil {
+		t.Fatalf("GetPressureHistory failed: %v", err)
+	}
+
+	if len(points) != 2 {
+		t.Fatalf("Expected 2 points, got %d", len(points))
+	}
+	if points[1].PressureMb != 1012.4 {
+		t.Errorf("Expected second point 1012.4 mb, got %v", points[1].PressureMb)
+	}
+}
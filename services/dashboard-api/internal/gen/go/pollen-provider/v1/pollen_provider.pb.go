@@ -428,6 +428,361 @@ func (x *GetPollenReportResponse) GetReport() *PollenReport {
 	return nil
 }
 
+// PollenForecastDay is one day of the Google Pollen API outlook.
+type PollenForecastDay struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Calendar date at the location, as YYYY-MM-DD.
+	Date            string         `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	OverallIndex    int32          `protobuf:"varint,2,opt,name=overall_index,json=overallIndex,proto3" json:"overall_index,omitempty"`
+	OverallCategory string         `protobuf:"bytes,3,opt,name=overall_category,json=overallCategory,proto3" json:"overall_category,omitempty"`
+	DominantType    string         `protobuf:"bytes,4,opt,name=dominant_type,json=dominantType,proto3" json:"dominant_type,omitempty"`
+	Types           []*PollenType  `protobuf:"bytes,5,rep,name=types,proto3" json:"types,omitempty"`
+	Plants          []*PollenPlant `protobuf:"bytes,6,rep,name=plants,proto3" json:"plants,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PollenForecastDay) Reset() {
+	*x = PollenForecastDay{}
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollenForecastDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollenForecastDay) ProtoMessage() {}
+
+func (x *PollenForecastDay) ProtoReflect() protoreflect.Message {
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollenForecastDay.ProtoReflect.Descriptor instead.
+func (*PollenForecastDay) Descriptor() ([]byte, []int) {
+	return file_pollen_provider_v1_pollen_provider_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PollenForecastDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *PollenForecastDay) GetOverallIndex() int32 {
+	if x != nil {
+		return x.OverallIndex
+	}
+	return 0
+}
+
+func (x *PollenForecastDay) GetOverallCategory() string {
+	if x != nil {
+		return x.OverallCategory
+	}
+	return ""
+}
+
+func (x *PollenForecastDay) GetDominantType() string {
+	if x != nil {
+		return x.DominantType
+	}
+	return ""
+}
+
+func (x *PollenForecastDay) GetTypes() []*PollenType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *PollenForecastDay) GetPlants() []*PollenPlant {
+	if x != nil {
+		return x.Plants
+	}
+	return nil
+}
+
+type PollenForecast struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	LocationId string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// When the outlook was fetched.
+	CollectedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=collected_at,json=collectedAt,proto3" json:"collected_at,omitempty"`
+	// Today first, up to five days.
+	Days          []*PollenForecastDay `protobuf:"bytes,3,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollenForecast) Reset() {
+	*x = PollenForecast{}
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollenForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollenForecast) ProtoMessage() {}
+
+func (x *PollenForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollenForecast.ProtoReflect.Descriptor instead.
+func (*PollenForecast) Descriptor() ([]byte, []int) {
+	return file_pollen_provider_v1_pollen_provider_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PollenForecast) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *PollenForecast) GetCollectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CollectedAt
+	}
+	return nil
+}
+
+func (x *PollenForecast) GetDays() []*PollenForecastDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type GetPollenForecastRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPollenForecastRequest) Reset() {
+	*x = GetPollenForecastRequest{}
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPollenForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollenForecastRequest) ProtoMessage() {}
+
+func (x *GetPollenForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollenForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetPollenForecastRequest) Descriptor() ([]byte, []int) {
+	return file_pollen_provider_v1_pollen_provider_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPollenForecastRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type GetPollenForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Forecast      *PollenForecast        `protobuf:"bytes,1,opt,name=forecast,proto3" json:"forecast,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPollenForecastResponse) Reset() {
+	*x = GetPollenForecastResponse{}
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPollenForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollenForecastResponse) ProtoMessage() {}
+
+func (x *GetPollenForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollenForecastResponse.ProtoReflect.Descriptor instead.
+func (*GetPollenForecastResponse) Descriptor() ([]byte, []int) {
+	return file_pollen_provider_v1_pollen_provider_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPollenForecastResponse) GetForecast() *PollenForecast {
+	if x != nil {
+		return x.Forecast
+	}
+	return nil
+}
+
+type GetPollenHistoryRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	LocationId string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// Optional plant or type code ("JUNIPER", "TREE"), matched case-insensitively.
+	// When set, each snapshot's types and plants are narrowed to that code, and
+	// overall_index, overall_category and dominant_type, which summarize every
+	// code, are left unset.
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// Optional [start_time, end_time) bounds on collected_at. Unset bounds are
+	// open; when both are set, start_time must be before end_time.
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPollenHistoryRequest) Reset() {
+	*x = GetPollenHistoryRequest{}
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPollenHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollenHistoryRequest) ProtoMessage() {}
+
+func (x *GetPollenHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollenHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPollenHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pollen_provider_v1_pollen_provider_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPollenHistoryRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *GetPollenHistoryRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetPollenHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetPollenHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type GetPollenHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first. At most two weeks are stored (two readings a day).
+	Snapshots     []*PollenReport `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPollenHistoryResponse) Reset() {
+	*x = GetPollenHistoryResponse{}
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPollenHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollenHistoryResponse) ProtoMessage() {}
+
+func (x *GetPollenHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pollen_provider_v1_pollen_provider_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollenHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPollenHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_pollen_provider_v1_pollen_provider_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPollenHistoryResponse) GetSnapshots() []*PollenReport {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
 var File_pollen_provider_v1_pollen_provider_proto protoreflect.FileDescriptor
 
 const file_pollen_provider_v1_pollen_provider_proto_rawDesc = "" +
@@ -461,10 +816,38 @@ const file_pollen_provider_v1_pollen_provider_proto_rawDesc = "" +
 	"\vlocation_id\x18\x01 \x01(\tR\n" +
 	"locationId\"S\n" +
 	"\x17GetPollenReportResponse\x128\n" +
-	"\x06report\x18\x01 \x01(\v2 .pollen_provider.v1.PollenReportR\x06report2\xf3\x01\n" +
+	"\x06report\x18\x01 \x01(\v2 .pollen_provider.v1.PollenReportR\x06report\"\x8b\x02\n" +
+	"\x11PollenForecastDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12#\n" +
+	"\roverall_index\x18\x02 \x01(\x05R\foverallIndex\x12)\n" +
+	"\x10overall_category\x18\x03 \x01(\tR\x0foverallCategory\x12#\n" +
+	"\rdominant_type\x18\x04 \x01(\tR\fdominantType\x124\n" +
+	"\x05types\x18\x05 \x03(\v2\x1e.pollen_provider.v1.PollenTypeR\x05types\x127\n" +
+	"\x06plants\x18\x06 \x03(\v2\x1f.pollen_provider.v1.PollenPlantR\x06plants\"\xab\x01\n" +
+	"\x0ePollenForecast\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12=\n" +
+	"\fcollected_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\x129\n" +
+	"\x04days\x18\x03 \x03(\v2%.pollen_provider.v1.PollenForecastDayR\x04days\";\n" +
+	"\x18GetPollenForecastRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\"[\n" +
+	"\x19GetPollenForecastResponse\x12>\n" +
+	"\bforecast\x18\x01 \x01(\v2\".pollen_provider.v1.PollenForecastR\bforecast\"\xc0\x01\n" +
+	"\x17GetPollenHistoryRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"Z\n" +
+	"\x18GetPollenHistoryResponse\x12>\n" +
+	"\tsnapshots\x18\x01 \x03(\v2 .pollen_provider.v1.PollenReportR\tsnapshots2\xd4\x03\n" +
 	"\rPollenService\x12v\n" +
 	"\x13GetAllPollenReports\x12..pollen_provider.v1.GetAllPollenReportsRequest\x1a/.pollen_provider.v1.GetAllPollenReportsResponse\x12j\n" +
-	"\x0fGetPollenReport\x12*.pollen_provider.v1.GetPollenReportRequest\x1a+.pollen_provider.v1.GetPollenReportResponseB\xf4\x01\n" +
+	"\x0fGetPollenReport\x12*.pollen_provider.v1.GetPollenReportRequest\x1a+.pollen_provider.v1.GetPollenReportResponse\x12p\n" +
+	"\x11GetPollenForecast\x12,.pollen_provider.v1.GetPollenForecastRequest\x1a-.pollen_provider.v1.GetPollenForecastResponse\x12m\n" +
+	"\x10GetPollenHistory\x12+.pollen_provider.v1.GetPollenHistoryRequest\x1a,.pollen_provider.v1.GetPollenHistoryResponseB\xf4\x01\n" +
 	"\x16com.pollen_provider.v1B\x13PollenProviderProtoP\x01Z`github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/pollen-provider/v1\xa2\x02\x03PXX\xaa\x02\x11PollenProvider.V1\xca\x02\x11PollenProvider\\V1\xe2\x02\x1dPollenProvider\\V1\\GPBMetadata\xea\x02\x12PollenProvider::V1b\x06proto3"
 
 var (
@@ -479,7 +862,7 @@ func file_pollen_provider_v1_pollen_provider_proto_rawDescGZIP() []byte {
 	return file_pollen_provider_v1_pollen_provider_proto_rawDescData
 }
 
-var file_pollen_provider_v1_pollen_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_pollen_provider_v1_pollen_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_pollen_provider_v1_pollen_provider_proto_goTypes = []any{
 	(*PollenReport)(nil),                // 0: pollen_provider.v1.PollenReport
 	(*PollenType)(nil),                  // 1: pollen_provider.v1.PollenType
@@ -488,23 +871,41 @@ var file_pollen_provider_v1_pollen_provider_proto_goTypes = []any{
 	(*GetAllPollenReportsResponse)(nil), // 4: pollen_provider.v1.GetAllPollenReportsResponse
 	(*GetPollenReportRequest)(nil),      // 5: pollen_provider.v1.GetPollenReportRequest
 	(*GetPollenReportResponse)(nil),     // 6: pollen_provider.v1.GetPollenReportResponse
-	(*timestamppb.Timestamp)(nil),       // 7: google.protobuf.Timestamp
+	(*PollenForecastDay)(nil),           // 7: pollen_provider.v1.PollenForecastDay
+	(*PollenForecast)(nil),              // 8: pollen_provider.v1.PollenForecast
+	(*GetPollenForecastRequest)(nil),    // 9: pollen_provider.v1.GetPollenForecastRequest
+	(*GetPollenForecastResponse)(nil),   // 10: pollen_provider.v1.GetPollenForecastResponse
+	(*GetPollenHistoryRequest)(nil),     // 11: pollen_provider.v1.GetPollenHistoryRequest
+	(*GetPollenHistoryResponse)(nil),    // 12: pollen_provider.v1.GetPollenHistoryResponse
+	(*timestamppb.Timestamp)(nil),       // 13: google.protobuf.Timestamp
 }
 var file_pollen_provider_v1_pollen_provider_proto_depIdxs = []int32{
-	7, // 0: pollen_provider.v1.PollenReport.collected_at:type_name -> google.protobuf.Timestamp
-	1, // 1: pollen_provider.v1.PollenReport.types:type_name -> pollen_provider.v1.PollenType
-	2, // 2: pollen_provider.v1.PollenReport.plants:type_name -> pollen_provider.v1.PollenPlant
-	0, // 3: pollen_provider.v1.GetAllPollenReportsResponse.reports:type_name -> pollen_provider.v1.PollenReport
-	0, // 4: pollen_provider.v1.GetPollenReportResponse.report:type_name -> pollen_provider.v1.PollenReport
-	3, // 5: pollen_provider.v1.PollenService.GetAllPollenReports:input_type -> pollen_provider.v1.GetAllPollenReportsRequest
-	5, // 6: pollen_provider.v1.PollenService.GetPollenReport:input_type -> pollen_provider.v1.GetPollenReportRequest
-	4, // 7: pollen_provider.v1.PollenService.GetAllPollenReports:output_type -> pollen_provider.v1.GetAllPollenReportsResponse
-	6, // 8: pollen_provider.v1.PollenService.GetPollenReport:output_type -> pollen_provider.v1.GetPollenReportResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	13, // 0: pollen_provider.v1.PollenReport.collected_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: pollen_provider.v1.PollenReport.types:type_name -> pollen_provider.v1.PollenType
+	2,  // 2: pollen_provider.v1.PollenReport.plants:type_name -> pollen_provider.v1.PollenPlant
+	0,  // 3: pollen_provider.v1.GetAllPollenReportsResponse.reports:type_name -> pollen_provider.v1.PollenReport
+	0,  // 4: pollen_provider.v1.GetPollenReportResponse.report:type_name -> pollen_provider.v1.PollenReport
+	1,  // 5: pollen_provider.v1.PollenForecastDay.types:type_name -> pollen_provider.v1.PollenType
+	2,  // 6: pollen_provider.v1.PollenForecastDay.plants:type_name -> pollen_provider.v1.PollenPlant
+	13, // 7: pollen_provider.v1.PollenForecast.collected_at:type_name -> google.protobuf.Timestamp
+	7,  // 8: pollen_provider.v1.PollenForecast.days:type_name -> pollen_provider.v1.PollenForecastDay
+	8,  // 9: pollen_provider.v1.GetPollenForecastResponse.forecast:type_name -> pollen_provider.v1.PollenForecast
+	13, // 10: pollen_provider.v1.GetPollenHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	13, // 11: pollen_provider.v1.GetPollenHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 12: pollen_provider.v1.GetPollenHistoryResponse.snapshots:type_name -> pollen_provider.v1.PollenReport
+	3,  // 13: pollen_provider.v1.PollenService.GetAllPollenReports:input_type -> pollen_provider.v1.GetAllPollenReportsRequest
+	5,  // 14: pollen_provider.v1.PollenService.GetPollenReport:input_type -> pollen_provider.v1.GetPollenReportRequest
+	9,  // 15: pollen_provider.v1.PollenService.GetPollenForecast:input_type -> pollen_provider.v1.GetPollenForecastRequest
+	11, // 16: pollen_provider.v1.PollenService.GetPollenHistory:input_type -> pollen_provider.v1.GetPollenHistoryRequest
+	4,  // 17: pollen_provider.v1.PollenService.GetAllPollenReports:output_type -> pollen_provider.v1.GetAllPollenReportsResponse
+	6,  // 18: pollen_provider.v1.PollenService.GetPollenReport:output_type -> pollen_provider.v1.GetPollenReportResponse
+	10, // 19: pollen_provider.v1.PollenService.GetPollenForecast:output_type -> pollen_provider.v1.GetPollenForecastResponse
+	12, // 20: pollen_provider.v1.PollenService.GetPollenHistory:output_type -> pollen_provider.v1.GetPollenHistoryResponse
+	17, // [17:21] is the sub-list for method output_type
+	13, // [13:17] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_pollen_provider_v1_pollen_provider_proto_init() }
@@ -518,7 +919,7 @@ func file_pollen_provider_v1_pollen_provider_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pollen_provider_v1_pollen_provider_proto_rawDesc), len(file_pollen_provider_v1_pollen_provider_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
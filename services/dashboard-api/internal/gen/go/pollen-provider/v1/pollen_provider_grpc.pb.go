@@ -21,6 +21,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PollenService_GetAllPollenReports_FullMethodName = "/pollen_provider.v1.PollenService/GetAllPollenReports"
 	PollenService_GetPollenReport_FullMethodName     = "/pollen_provider.v1.PollenService/GetPollenReport"
+	PollenService_GetPollenForecast_FullMethodName   = "/pollen_provider.v1.PollenService/GetPollenForecast"
+	PollenService_GetPollenHistory_FullMethodName    = "/pollen_provider.v1.PollenService/GetPollenHistory"
 )
 
 // PollenServiceClient is the client API for PollenService service.
@@ -29,6 +31,8 @@ const (
 type PollenServiceClient interface {
 	GetAllPollenReports(ctx context.Context, in *GetAllPollenReportsRequest, opts ...grpc.CallOption) (*GetAllPollenReportsResponse, error)
 	GetPollenReport(ctx context.Context, in *GetPollenReportRequest, opts ...grpc.CallOption) (*GetPollenReportResponse, error)
+	GetPollenForecast(ctx context.Context, in *GetPollenForecastRequest, opts ...grpc.CallOption) (*GetPollenForecastResponse, error)
+	GetPollenHistory(ctx context.Context, in *GetPollenHistoryRequest, opts ...grpc.CallOption) (*GetPollenHistoryResponse, error)
 }
 
 type pollenServiceClient struct {
@@ -59,12 +63,34 @@ func (c *pollenServiceClient) GetPollenReport(ctx context.Context, in *GetPollen
 	return out, nil
 }
 
+func (c *pollenServiceClient) GetPollenForecast(ctx context.Context, in *GetPollenForecastRequest, opts ...grpc.CallOption) (*GetPollenForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPollenForecastResponse)
+	err := c.cc.Invoke(ctx, PollenService_GetPollenForecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollenServiceClient) GetPollenHistory(ctx context.Context, in *GetPollenHistoryRequest, opts ...grpc.CallOption) (*GetPollenHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPollenHistoryResponse)
+	err := c.cc.Invoke(ctx, PollenService_GetPollenHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PollenServiceServer is the server API for PollenService service.
 // All implementations must embed UnimplementedPollenServiceServer
 // for forward compatibility.
 type PollenServiceServer interface {
 	GetAllPollenReports(context.Context, *GetAllPollenReportsRequest) (*GetAllPollenReportsResponse, error)
 	GetPollenReport(context.Context, *GetPollenReportRequest) (*GetPollenReportResponse, error)
+	GetPollenForecast(context.Context, *GetPollenForecastRequest) (*GetPollenForecastResponse, error)
+	GetPollenHistory(context.Context, *GetPollenHistoryRequest) (*GetPollenHistoryResponse, error)
 	mustEmbedUnimplementedPollenServiceServer()
 }
 
@@ -81,6 +107,12 @@ func (UnimplementedPollenServiceServer) GetAllPollenReports(context.Context, *Ge
 func (UnimplementedPollenServiceServer) GetPollenReport(context.Context, *GetPollenReportRequest) (*GetPollenReportResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPollenReport not implemented")
 }
+func (UnimplementedPollenServiceServer) GetPollenForecast(context.Context, *GetPollenForecastRequest) (*GetPollenForecastResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPollenForecast not implemented")
+}
+func (UnimplementedPollenServiceServer) GetPollenHistory(context.Context, *GetPollenHistoryRequest) (*GetPollenHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPollenHistory not implemented")
+}
 func (UnimplementedPollenServiceServer) mustEmbedUnimplementedPollenServiceServer() {}
 func (UnimplementedPollenServiceServer) testEmbeddedByValue()                       {}
 
@@ -138,6 +170,42 @@ func _PollenService_GetPollenReport_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PollenService_GetPollenForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollenForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollenServiceServer).GetPollenForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollenService_GetPollenForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollenServiceServer).GetPollenForecast(ctx, req.(*GetPollenForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollenService_GetPollenHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollenHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollenServiceServer).GetPollenHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollenService_GetPollenHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollenServiceServer).GetPollenHistory(ctx, req.(*GetPollenHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PollenService_ServiceDesc is the grpc.ServiceDesc for PollenService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +221,14 @@ var PollenService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPollenReport",
 			Handler:    _PollenService_GetPollenReport_Handler,
 		},
+		{
+			MethodName: "GetPollenForecast",
+			Handler:    _PollenService_GetPollenForecast_Handler,
+		},
+		{
+			MethodName: "GetPollenHistory",
+			Handler:    _PollenService_GetPollenHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pollen-provider/v1/pollen_provider.proto",
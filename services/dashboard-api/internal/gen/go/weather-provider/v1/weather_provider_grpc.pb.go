@@ -23,6 +23,7 @@ const (
 	PressureStatsService_GetPressureStats_FullMethodName    = "/weather_provider.v1.PressureStatsService/GetPressureStats"
 	PressureStatsService_GetAllLastWeather_FullMethodName   = "/weather_provider.v1.PressureStatsService/GetAllLastWeather"
 	PressureStatsService_GetLastWeather_FullMethodName      = "/weather_provider.v1.PressureStatsService/GetLastWeather"
+	PressureStatsService_GetPressureHistory_FullMethodName  = "/weather_provider.v1.PressureStatsService/GetPressureHistory"
 )
 
 // PressureStatsServiceClient is the client API for PressureStatsService service.
@@ -33,6 +34,7 @@ type PressureStatsServiceClient interface {
 	GetPressureStats(ctx context.Context, in *GetPressureStatsRequest, opts ...grpc.CallOption) (*GetPressureStatsResponse, error)
 	GetAllLastWeather(ctx context.Context, in *GetAllLastWeatherRequest, opts ...grpc.CallOption) (*GetAllLastWeatherResponse, error)
 	GetLastWeather(ctx context.Context, in *GetLastWeatherRequest, opts ...grpc.CallOption) (*GetLastWeatherResponse, error)
+	GetPressureHistory(ctx context.Context, in *GetPressureHistoryRequest, opts ...grpc.CallOption) (*GetPressureHistoryResponse, error)
 }
 
 type pressureStatsServiceClient struct {
@@ -83,6 +85,16 @@ func (c *pressureStatsServiceClient) GetLastWeather(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *pressureStatsServiceClient) GetPressureHistory(ctx context.Context, in *GetPressureHistoryRequest, opts ...grpc.CallOption) (*GetPressureHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPressureHistoryResponse)
+	err := c.cc.Invoke(ctx, PressureStatsService_GetPressureHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PressureStatsServiceServer is the server API for PressureStatsService service.
 // All implementations must embed UnimplementedPressureStatsServiceServer
 // for forward compatibility.
@@ -91,6 +103,7 @@ type PressureStatsServiceServer interface {
 	GetPressureStats(context.Context, *GetPressureStatsRequest) (*GetPressureStatsResponse, error)
 	GetAllLastWeather(context.Context, *GetAllLastWeatherRequest) (*GetAllLastWeatherResponse, error)
 	GetLastWeather(context.Context, *GetLastWeatherRequest) (*GetLastWeatherResponse, error)
+	GetPressureHistory(context.Context, *GetPressureHistoryRequest) (*GetPressureHistoryResponse, error)
 	mustEmbedUnimplementedPressureStatsServiceServer()
 }
 
@@ -113,6 +126,9 @@ func (UnimplementedPressureStatsServiceServer) GetAllLastWeather(context.Context
 func (UnimplementedPressureStatsServiceServer) GetLastWeather(context.Context, *GetLastWeatherRequest) (*GetLastWeatherResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLastWeather not implemented")
 }
+func (UnimplementedPressureStatsServiceServer) GetPressureHistory(context.Context, *GetPressureHistoryRequest) (*GetPressureHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPressureHistory not implemented")
+}
 func (UnimplementedPressureStatsServiceServer) mustEmbedUnimplementedPressureStatsServiceServer() {}
 func (UnimplementedPressureStatsServiceServer) testEmbeddedByValue()                              {}
 
@@ -206,6 +222,24 @@ func _PressureStatsService_GetLastWeather_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PressureStatsService_GetPressureHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPressureHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PressureStatsServiceServer).GetPressureHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PressureStatsService_GetPressureHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PressureStatsServiceServer).GetPressureHistory(ctx, req.(*GetPressureHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PressureStatsService_ServiceDesc is the grpc.ServiceDesc for PressureStatsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +263,124 @@ var PressureStatsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLastWeather",
 			Handler:    _PressureStatsService_GetLastWeather_Handler,
 		},
+		{
+			MethodName: "GetPressureHistory",
+			Handler:    _PressureStatsService_GetPressureHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "weather-provider/v1/weather_provider.proto",
 }
+
+const (
+	RawWeatherService_QueryRawWeather_FullMethodName = "/weather_provider.v1.RawWeatherService/QueryRawWeather"
+)
+
+// RawWeatherServiceClient is the client API for RawWeatherService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RawWeatherService reads the append-only weather_raw archive. It is meant
+// for analysis jobs rather than the dashboard, so results are streamed a
+// Firestore page at a time instead of being loaded into memory at once.
+type RawWeatherServiceClient interface {
+	QueryRawWeather(ctx context.Context, in *QueryRawWeatherRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueryRawWeatherResponse], error)
+}
+
+type rawWeatherServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRawWeatherServiceClient(cc grpc.ClientConnInterface) RawWeatherServiceClient {
+	return &rawWeatherServiceClient{cc}
+}
+
+func (c *rawWeatherServiceClient) QueryRawWeather(ctx context.Context, in *QueryRawWeatherRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueryRawWeatherResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RawWeatherService_ServiceDesc.Streams[0], RawWeatherService_QueryRawWeather_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[QueryRawWeatherRequest, QueryRawWeatherResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RawWeatherService_QueryRawWeatherClient = grpc.ServerStreamingClient[QueryRawWeatherResponse]
+
+// RawWeatherServiceServer is the server API for RawWeatherService service.
+// All implementations must embed UnimplementedRawWeatherServiceServer
+// for forward compatibility.
+//
+// RawWeatherService reads the append-only weather_raw archive. It is meant
+// for analysis jobs rather than the dashboard, so results are streamed a
+// Firestore page at a time instead of being loaded into memory at once.
+type RawWeatherServiceServer interface {
+	QueryRawWeather(*QueryRawWeatherRequest, grpc.ServerStreamingServer[QueryRawWeatherResponse]) error
+	mustEmbedUnimplementedRawWeatherServiceServer()
+}
+
+// UnimplementedRawWeatherServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRawWeatherServiceServer struct{}
+
+func (UnimplementedRawWeatherServiceServer) QueryRawWeather(*QueryRawWeatherRequest, grpc.ServerStreamingServer[QueryRawWeatherResponse]) error {
+	return status.Error(codes.Unimplemented, "method QueryRawWeather not implemented")
+}
+func (UnimplementedRawWeatherServiceServer) mustEmbedUnimplementedRawWeatherServiceServer() {}
+func (UnimplementedRawWeatherServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeRawWeatherServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RawWeatherServiceServer will
+// result in compilation errors.
+type UnsafeRawWeatherServiceServer interface {
+	mustEmbedUnimplementedRawWeatherServiceServer()
+}
+
+func RegisterRawWeatherServiceServer(s grpc.ServiceRegistrar, srv RawWeatherServiceServer) {
+	// If the following call panics, it indicates UnimplementedRawWeatherServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RawWeatherService_ServiceDesc, srv)
+}
+
+func _RawWeatherService_QueryRawWeather_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRawWeatherRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RawWeatherServiceServer).QueryRawWeather(m, &grpc.GenericServerStream[QueryRawWeatherRequest, QueryRawWeatherResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RawWeatherService_QueryRawWeatherServer = grpc.ServerStreamingServer[QueryRawWeatherResponse]
+
+// RawWeatherService_ServiceDesc is the grpc.ServiceDesc for RawWeatherService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RawWeatherService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "weather_provider.v1.RawWeatherService",
+	HandlerType: (*RawWeatherServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "QueryRawWeather",
+			Handler:       _RawWeatherService_QueryRawWeather_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "weather-provider/v1/weather_provider.proto",
+}
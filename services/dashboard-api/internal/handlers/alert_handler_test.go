@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	weatherPb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/weather-provider/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingForecastClient captures the alert history filters the handler
+// forwards.
+type recordingForecastClient struct {
+	mockForecastClient
+	location, rule string
+	start, end     time.Time
+	limit          int
+}
+
+func (m *recordingForecastClient) ListAlertHistory(ctx context.Context, locationID, ruleID string, start, end time.Time, limit int) ([]*weatherPb.ArchivedAlert, error) {
+	m.location, m.rule, m.start, m.end, m.limit = locationID, ruleID, start, end, limit
+	return m.mockForecastClient.ListAlertHistory(ctx, locationID, ruleID, start, end, limit)
+}
+
+func alertHistoryRequest(rawQuery string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/alerts/history", nil)
+	req.URL.RawQuery = rawQuery
+	return req
+}
+
+func TestDashboardHandler_GetAlertHistory(t *testing.T) {
+	forecast := &recordingForecastClient{}
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, forecast, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetAlertHistory(rr, alertHistoryRequest("location=house-nick&rule=pressure-drop-3h&start=2026-06-01T00:00:00Z&end=2026-06-13T00:00:00-05:00&limit=20"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	if forecast.location != "house-nick" || forecast.rule != "pressure-drop-3h" || forecast.limit != 20 {
+		t.Errorf("forwarded location=%q rule=%q limit=%d", forecast.location, forecast.rule, forecast.limit)
+	}
+	if want := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC); !forecast.start.Equal(want) {
+		t.Errorf("forwarded start = %v, want %v", forecast.start, want)
+	}
+	if want := time.Date(2026, 6, 13, 5, 0, 0, 0, time.UTC); !forecast.end.Equal(want) {
+		t.Errorf("forwarded end = %v, want %v", forecast.end, want)
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	alerts, ok := resp["alerts"].([]interface{})
+	if !ok || len(alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %v", resp["alerts"])
+	}
+	archived := alerts[0].(map[string]interface{})
+	alert := archived["alert"].(map[string]interface{})
+	if alert["status"] != "expired" || archived["peakSeverity"] != "severe" || archived["peakValue"] != -8.0 {
+		t.Errorf("Expected an expired alert that peaked severe at -8, got %v", archived)
+	}
+}
+
+func TestDashboardHandler_GetAlertHistory_NoFilters(t *testing.T) {
+	forecast := &recordingForecastClient{}
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, forecast, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetAlertHistory(rr, alertHistoryRequest(""))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if forecast.location != "" || forecast.rule != "" || !forecast.start.IsZero() || !forecast.end.IsZero() || forecast.limit != 0 {
+		t.Errorf("expected no filters forwarded, got %+v", forecast)
+	}
+}
+
+func TestDashboardHandler_GetAlertHistory_BadParams(t *testing.T) {
+	for _, query := range []string{"start=yesterday", "end=2026-06-01", "limit=ten", "limit=-1"} {
+		t.Run(query, func(t *testing.T) {
+			handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
+
+			rr := httptest.NewRecorder()
+			handler.GetAlertHistory(rr, alertHistoryRequest(query))
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_GetAlertHistory_GrpcError(t *testing.T) {
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &errorForecastClient{err: status.Error(codes.InvalidArgument, "start_time must be before end_time")}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetAlertHistory(rr, alertHistoryRequest("start=2026-06-02T00:00:00Z&end=2026-06-01T00:00:00Z"))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func alertEventsRequest(alertID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/alerts/"+alertID+"/events", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("alertID", alertID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestDashboardHandler_GetAlertEvents(t *testing.T) {
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetAlertEvents(rr, alertEventsRequest("alert-1"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	events, ok := resp["events"].([]interface{})
+	if !ok || len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %v", resp["events"])
+	}
+	delivered := events[1].(map[string]interface{})
+	if delivered["alertId"] != "alert-1" || delivered["type"] != "delivered" || delivered["notifiedAt"] == nil {
+		t.Errorf("Expected alert-1's delivery, got %v", delivered)
+	}
+}
+
+func TestDashboardHandler_GetAlertEvents_GrpcError(t *testing.T) {
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &errorForecastClient{err: status.Error(codes.Unavailable, "down")}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetAlertEvents(rr, alertEventsRequest("alert-1"))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", rr.Code)
+	}
+}
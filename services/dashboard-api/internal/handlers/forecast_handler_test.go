@@ -56,6 +56,36 @@ func (m *mockForecastClient) GetAllForecasts(ctx context.Context) ([]*weatherPb.
 	return []*weatherPb.Forecast{f}, nil
 }
 
+func (m *mockForecastClient) ListAlertHistory(ctx context.Context, locationID, ruleID string, start, end time.Time, limit int) ([]*weatherPb.ArchivedAlert, error) {
+	base := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	return []*weatherPb.ArchivedAlert{
+		{
+			Alert: &weatherPb.Alert{
+				Id:          "alert-1",
+				LocationId:  "house-nick",
+				RuleId:      "pressure-drop-3h",
+				Severity:    "warning",
+				Value:       -5.5,
+				WindowStart: timestamppb.New(base),
+				WindowEnd:   timestamppb.New(base.Add(3 * time.Hour)),
+				Status:      "expired",
+			},
+			PeakValue:    -8.0,
+			PeakSeverity: "severe",
+			NotifiedAt:   timestamppb.New(base.Add(-6 * time.Hour)),
+			ArchivedAt:   timestamppb.New(base.Add(6 * time.Hour)),
+		},
+	}, nil
+}
+
+func (m *mockForecastClient) ListAlertEvents(ctx context.Context, alertID string) ([]*weatherPb.AlertEvent, error) {
+	base := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	return []*weatherPb.AlertEvent{
+		{AlertId: alertID, Type: "created", At: timestamppb.New(base), Status: "active", Value: -5.5},
+		{AlertId: alertID, Type: "delivered", At: timestamppb.New(base.Add(time.Hour)), Status: "active", Value: -5.5, NotifiedAt: timestamppb.New(base.Add(time.Hour))},
+	}, nil
+}
+
 type errorForecastClient struct {
 	err error
 }
@@ -68,6 +98,14 @@ func (m *errorForecastClient) GetAllForecasts(ctx context.Context) ([]*weatherPb
 	return nil, m.err
 }
 
+func (m *errorForecastClient) ListAlertHistory(ctx context.Context, locationID, ruleID string, start, end time.Time, limit int) ([]*weatherPb.ArchivedAlert, error) {
+	return nil, m.err
+}
+
+func (m *errorForecastClient) ListAlertEvents(ctx context.Context, alertID string) ([]*weatherPb.AlertEvent, error) {
+	return nil, m.err
+}
+
 // emptyForecastClient simulates a deployment where the forecast collector
 // hasn't run yet: the cache collection scan comes back empty.
 type emptyForecastClient struct{}
@@ -80,10 +118,18 @@ func (m *emptyForecastClient) GetAllForecasts(ctx context.Context) ([]*weatherPb
 	return nil, nil
 }
 
+func (m *emptyForecastClient) ListAlertHistory(ctx context.Context, locationID, ruleID string, start, end time.Time, limit int) ([]*weatherPb.ArchivedAlert, error) {
+	return nil, nil
+}
+
+func (m *emptyForecastClient) ListAlertEvents(ctx context.Context, alertID string) ([]*weatherPb.AlertEvent, error) {
+	return nil, nil
+}
+
 // --- Forecast aggregation tests ---
 
 func TestDashboardHandler_GetDashboard_IncludesForecastAndAlerts(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -145,7 +191,7 @@ func TestDashboardHandler_GetDashboard_IncludesForecastAndAlerts(t *testing.T) {
 }
 
 func TestDashboardHandler_GetDashboard_NoForecastsYet_Tolerated(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &emptyForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &emptyForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -177,6 +223,7 @@ func TestDashboardHandler_GetDashboard_ForecastUnavailable_Fatal(t *testing.T) {
 		&mockWeatherClient{},
 		&mockPollenClient{},
 		&errorForecastClient{err: status.Error(codes.Unavailable, "weather-provider down")},
+		&mockLocations{},
 	)
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
@@ -193,7 +240,7 @@ func TestDashboardHandler_GetDashboard_ForecastUnavailable_Fatal(t *testing.T) {
 }
 
 func TestDashboardHandler_GetDashboardByLocation_IncludesForecast(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 	req := requestWithLocationID(t, "house-nick")
 	rr := httptest.NewRecorder()
 
@@ -230,7 +277,7 @@ func TestDashboardHandler_GetDashboardByLocation_IncludesForecast(t *testing.T)
 }
 
 func TestDashboardHandler_GetDashboard_CurlText_IncludesForecast(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -9,9 +9,33 @@ import (
 
 	pollenPb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/pollen-provider/v1"
 	pressurePb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/weather-provider/v1"
+	"github.com/nickfang/personal-dashboard/services/shared"
 )
 
-func formatPressureText(pressureStats []*pressurePb.PressureStat) map[string]string {
+// textTimeLayout is the curl output's timestamp layout. The abbreviation is
+// part of it because each block renders in its own location's zone.
+const textTimeLayout = "2006.01.02 15:04:05 MST"
+
+// zonesByLocation maps each registry location ID to its timezone.
+func zonesByLocation(registry []shared.Location) map[string]*time.Location {
+	zones := make(map[string]*time.Location, len(registry))
+	for _, l := range registry {
+		zones[l.ID] = l.TimeZone()
+	}
+	return zones
+}
+
+// formatLocalTime renders t in zone. The server runs in UTC, so time.Local
+// would be wrong for every location; a nil zone — a location missing from
+// the registry, or a failed registry read — renders UTC explicitly.
+func formatLocalTime(t time.Time, zone *time.Location) string {
+	if zone == nil {
+		zone = time.UTC
+	}
+	return t.In(zone).Format(textTimeLayout)
+}
+
+func formatPressureText(pressureStats []*pressurePb.PressureStat, zones map[string]*time.Location) map[string]string {
 	pressureByLocation := make(map[string]string)
 	sortedPressureStats := slices.Clone(pressureStats)
 	slices.SortFunc(sortedPressureStats, func(a, b *pressurePb.PressureStat) int {
@@ -21,7 +45,7 @@ func formatPressureText(pressureStats []*pressurePb.PressureStat) map[string]str
 		location := pressureStat.LocationId
 		var pressureText strings.Builder
 
-		pressureText.WriteString(fmt.Sprintf("Pressure: %s\n", pressureStat.LastUpdated.AsTime().Local().Format("2006.01.02 15:04:05")))
+		pressureText.WriteString(fmt.Sprintf("Pressure: %s\n", formatLocalTime(pressureStat.LastUpdated.AsTime(), zones[location])))
 		pressureText.WriteString(fmt.Sprintf("  %s\n", pressureStat.Trend))
 		pressureText.WriteString(fmt.Sprintf("  Deltas: %.2f(1h), %.2f(3h), %.2f(6h) %.2f(12h) %.2f(24h)\n", pressureStat.Delta_1H, pressureStat.Delta_3H, pressureStat.Delta_6H, pressureStat.Delta_12H, pressureStat.Delta_24H))
 		pressureByLocation[location] = pressureText.String()
@@ -29,7 +53,7 @@ func formatPressureText(pressureStats []*pressurePb.PressureStat) map[string]str
 	return pressureByLocation
 }
 
-func formatWeatherText(weathers []*pressurePb.Weather) map[string]string {
+func formatWeatherText(weathers []*pressurePb.Weather, zones map[string]*time.Location) map[string]string {
 	weatherByLocation := make(map[string]string)
 	sortedWeathers := slices.Clone(weathers)
 	slices.SortFunc(sortedWeathers, func(a, b *pressurePb.Weather) int {
@@ -38,7 +62,7 @@ func formatWeatherText(weathers []*pressurePb.Weather) map[string]string {
 	for _, weather := range sortedWeathers {
 		location := weather.LocationId
 		var weatherText strings.Builder
-		weatherText.WriteString(fmt.Sprintf("Weather: %s\n", weather.LastUpdated.AsTime().Local().Format("2006.01.02 15:04:05")))
+		weatherText.WriteString(fmt.Sprintf("Weather: %s\n", formatLocalTime(weather.LastUpdated.AsTime(), zones[location])))
 		weatherText.WriteString(fmt.Sprintf("  Temp: %.2fF\n", weather.TempF))
 		weatherText.WriteString(fmt.Sprintf("  Feels Like: %.2fF\n", weather.TempFeelF))
 		weatherText.WriteString(fmt.Sprintf("  Humidity: %d%%\n", weather.HumidityPercent))
@@ -49,7 +73,7 @@ func formatWeatherText(weathers []*pressurePb.Weather) map[string]string {
 	return weatherByLocation
 }
 
-func formatPollenText(pollenReports []*pollenPb.PollenReport) map[string]string {
+func formatPollenText(pollenReports []*pollenPb.PollenReport, zones map[string]*time.Location) map[string]string {
 	pollenByLocation := make(map[string]string)
 	sortedPollenReports := slices.Clone(pollenReports)
 	slices.SortFunc(sortedPollenReports, func(a, b *pollenPb.PollenReport) int {
@@ -62,7 +86,7 @@ func formatPollenText(pollenReports []*pollenPb.PollenReport) map[string]string
 		}
 		location := pollenReport.LocationId
 		var pollenText strings.Builder
-		pollenText.WriteString(fmt.Sprintf("Pollen: %s\n", pollenReport.CollectedAt.AsTime().Local().Format("2006.01.02 15:04:05")))
+		pollenText.WriteString(fmt.Sprintf("Pollen: %s\n", formatLocalTime(pollenReport.CollectedAt.AsTime(), zones[location])))
 		sortedPlants := slices.Clone(pollenReport.Plants)
 		slices.SortFunc(sortedPlants, func(a, b *pollenPb.PollenPlant) int {
 			return int(b.Index) - int(a.Index)
@@ -169,7 +193,7 @@ func forecastTrend(points []*pressurePb.ForecastPoint) string {
 	}
 }
 
-func formatForecastText(forecasts []*pressurePb.Forecast) map[string]string {
+func formatForecastText(forecasts []*pressurePb.Forecast, zones map[string]*time.Location) map[string]string {
 	forecastByLocation := make(map[string]string)
 	sortedForecasts := slices.Clone(forecasts)
 	slices.SortFunc(sortedForecasts, func(a, b *pressurePb.Forecast) int {
@@ -179,7 +203,7 @@ func formatForecastText(forecasts []*pressurePb.Forecast) map[string]string {
 		var text strings.Builder
 		issuedAt := "unknown"
 		if forecast.IssuedAt != nil {
-			issuedAt = forecast.IssuedAt.AsTime().Local().Format("2006.01.02 15:04:05")
+			issuedAt = formatLocalTime(forecast.IssuedAt.AsTime(), zones[forecast.LocationId])
 		}
 		text.WriteString(fmt.Sprintf("Forecast: %s\n", issuedAt))
 
@@ -209,11 +233,12 @@ func formatForecastText(forecasts []*pressurePb.Forecast) map[string]string {
 	return forecastByLocation
 }
 
-func formatDashboardText(pressureStats []*pressurePb.PressureStat, pollenReports []*pollenPb.PollenReport, lastWeathers []*pressurePb.Weather, forecasts []*pressurePb.Forecast) (string, error) {
-	pressureByLocation := formatPressureText(pressureStats)
-	pollenByLocation := formatPollenText(pollenReports)
-	weatherByLocation := formatWeatherText(lastWeathers)
-	forecastByLocation := formatForecastText(forecasts)
+func formatDashboardText(pressureStats []*pressurePb.PressureStat, pollenReports []*pollenPb.PollenReport, lastWeathers []*pressurePb.Weather, forecasts []*pressurePb.Forecast, registry []shared.Location) (string, error) {
+	zones := zonesByLocation(registry)
+	pressureByLocation := formatPressureText(pressureStats, zones)
+	pollenByLocation := formatPollenText(pollenReports, zones)
+	weatherByLocation := formatWeatherText(lastWeathers, zones)
+	forecastByLocation := formatForecastText(forecasts, zones)
 
 	locations := make(map[string]struct{})
 	for location := range pressureByLocation {
@@ -226,9 +251,20 @@ func formatDashboardText(pressureStats []*pressurePb.PressureStat, pollenReports
 		locations[location] = struct{}{}
 	}
 
+	names := make(map[string]string, len(registry))
+	for _, l := range registry {
+		if l.Name != "" {
+			names[l.ID] = fmt.Sprintf("%s (%s)", l.Name, l.ID)
+		}
+	}
+
 	var data strings.Builder
 	for _, location := range slices.Sorted(maps.Keys(locations)) {
-		data.WriteString(fmt.Sprintf("---------------- %s ----------------\n", location))
+		header := location
+		if name, ok := names[location]; ok {
+			header = name
+		}
+		data.WriteString(fmt.Sprintf("---------------- %s ----------------\n", header))
 		data.WriteString(weatherByLocation[location])
 		data.WriteString(pressureByLocation[location])
 		data.WriteString(forecastByLocation[location])
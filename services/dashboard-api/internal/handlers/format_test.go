@@ -8,6 +8,7 @@ import (
 
 	pollenPb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/pollen-provider/v1"
 	weatherPb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/weather-provider/v1"
+	"github.com/nickfang/personal-dashboard/services/shared"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -15,7 +16,7 @@ import (
 
 func TestFormatPressureText(t *testing.T) {
 	fixedTime := time.Date(2025, 3, 15, 14, 30, 0, 0, time.UTC)
-	localFormatted := fixedTime.Local().Format("2006.01.02 15:04:05")
+	utcFormatted := fixedTime.Format(textTimeLayout)
 
 	pressureStats := []*weatherPb.PressureStat{
 		{
@@ -30,16 +31,16 @@ func TestFormatPressureText(t *testing.T) {
 		},
 	}
 
-	result := formatPressureText(pressureStats)
+	result := formatPressureText(pressureStats, nil)
 
 	text, ok := result["house-nick"]
 	if !ok {
 		t.Fatal("expected 'house-nick' key in result map")
 	}
 
-	// Timestamp formatted as local time
-	if !strings.Contains(text, fmt.Sprintf("Pressure: %s", localFormatted)) {
-		t.Errorf("expected pressure timestamp in local time, got:\n%s", text)
+	// Without a registry zone the timestamp renders in UTC
+	if !strings.Contains(text, fmt.Sprintf("Pressure: %s", utcFormatted)) {
+		t.Errorf("expected pressure timestamp in UTC, got:\n%s", text)
 	}
 
 	// Trend
@@ -61,7 +62,7 @@ func TestFormatPressureText_MultipleLocations(t *testing.T) {
 		{LocationId: "house-mom", Trend: "falling", Delta_1H: -0.3, Delta_3H: -0.8, Delta_6H: -1.2, LastUpdated: fixedTime},
 	}
 
-	result := formatPressureText(pressureStats)
+	result := formatPressureText(pressureStats, nil)
 
 	if _, ok := result["house-nick"]; !ok {
 		t.Error("expected 'house-nick' key in result map")
@@ -84,7 +85,7 @@ func TestFormatPressureText_ZeroDeltas(t *testing.T) {
 		{LocationId: "house-nick", Trend: "steady", LastUpdated: fixedTime},
 	}
 
-	result := formatPressureText(pressureStats)
+	result := formatPressureText(pressureStats, nil)
 	text := result["house-nick"]
 
 	if !strings.Contains(text, "Deltas: 0.00(1h), 0.00(3h), 0.00(6h) 0.00(12h) 0.00(24h)") {
@@ -96,7 +97,7 @@ func TestFormatPressureText_ZeroDeltas(t *testing.T) {
 
 func TestFormatWeatherText(t *testing.T) {
 	fixedTime := time.Date(2025, 3, 15, 14, 30, 0, 0, time.UTC)
-	localFormatted := fixedTime.Local().Format("2006.01.02 15:04:05")
+	utcFormatted := fixedTime.Format(textTimeLayout)
 
 	weathers := []*weatherPb.Weather{
 		{
@@ -112,16 +113,16 @@ func TestFormatWeatherText(t *testing.T) {
 		},
 	}
 
-	result := formatWeatherText(weathers)
+	result := formatWeatherText(weathers, nil)
 
 	text, ok := result["house-nick"]
 	if !ok {
 		t.Fatal("expected 'house-nick' key in result map")
 	}
 
-	// Timestamp formatted as local time
-	if !strings.Contains(text, fmt.Sprintf("Weather: %s", localFormatted)) {
-		t.Errorf("expected weather timestamp in local time, got:\n%s", text)
+	// Without a registry zone the timestamp renders in UTC
+	if !strings.Contains(text, fmt.Sprintf("Weather: %s", utcFormatted)) {
+		t.Errorf("expected weather timestamp in UTC without a location zone, got:\n%s", text)
 	}
 
 	// Temperature
@@ -158,7 +159,7 @@ func TestFormatWeatherText_MultipleLocations(t *testing.T) {
 		{LocationId: "house-mom", TempC: 18.0, TempF: 64.4, LastUpdated: fixedTime},
 	}
 
-	result := formatWeatherText(weathers)
+	result := formatWeatherText(weathers, nil)
 
 	if _, ok := result["house-nick"]; !ok {
 		t.Error("expected 'house-nick' key in result map")
@@ -181,7 +182,7 @@ func TestFormatWeatherText_ZeroValues(t *testing.T) {
 		{LocationId: "house-nick", LastUpdated: fixedTime},
 	}
 
-	result := formatWeatherText(weathers)
+	result := formatWeatherText(weathers, nil)
 	text := result["house-nick"]
 
 	if !strings.Contains(text, "Temp: 0.00F") {
@@ -196,7 +197,7 @@ func TestFormatWeatherText_ZeroValues(t *testing.T) {
 
 func TestFormatPollenText(t *testing.T) {
 	fixedTime := time.Date(2025, 3, 15, 14, 30, 0, 0, time.UTC)
-	localFormatted := fixedTime.Local().Format("2006.01.02 15:04:05")
+	utcFormatted := fixedTime.Format(textTimeLayout)
 
 	pollenReports := []*pollenPb.PollenReport{
 		{
@@ -210,7 +211,7 @@ func TestFormatPollenText(t *testing.T) {
 		},
 	}
 
-	result := formatPollenText(pollenReports)
+	result := formatPollenText(pollenReports, nil)
 
 	text, ok := result["house-nick"]
 	if !ok {
@@ -218,8 +219,8 @@ func TestFormatPollenText(t *testing.T) {
 	}
 
 	// Header with timestamp
-	if !strings.Contains(text, fmt.Sprintf("Pollen: %s", localFormatted)) {
-		t.Errorf("expected pollen timestamp in local time, got:\n%s", text)
+	if !strings.Contains(text, fmt.Sprintf("Pollen: %s", utcFormatted)) {
+		t.Errorf("expected pollen timestamp in UTC without a location zone, got:\n%s", text)
 	}
 
 	// Plants with Index > 0 are included
@@ -252,7 +253,7 @@ func TestFormatPollenText_GroupedByIndex(t *testing.T) {
 		},
 	}
 
-	result := formatPollenText(pollenReports)
+	result := formatPollenText(pollenReports, nil)
 	text := result["house-nick"]
 
 	// Plants with the same index should be on the same line with a category label
@@ -301,7 +302,7 @@ func TestFormatPollenText_SortedByIndexDescending(t *testing.T) {
 		},
 	}
 
-	result := formatPollenText(pollenReports)
+	result := formatPollenText(pollenReports, nil)
 	text := result["house-nick"]
 
 	// Higher index should appear first
@@ -332,7 +333,7 @@ func TestFormatPollenText_EmptyPlants(t *testing.T) {
 		},
 	}
 
-	result := formatPollenText(pollenReports)
+	result := formatPollenText(pollenReports, nil)
 	text, ok := result["house-nick"]
 	if !ok {
 		t.Fatal("expected 'house-nick' key in result map")
@@ -347,7 +348,7 @@ func TestFormatPollenText_EmptyPlants(t *testing.T) {
 
 func TestFormatDashboardText(t *testing.T) {
 	fixedTime := time.Date(2025, 3, 15, 14, 30, 0, 0, time.UTC)
-	localFormatted := fixedTime.Local().Format("2006.01.02 15:04:05")
+	utcFormatted := fixedTime.Format(textTimeLayout)
 
 	pressureStats := []*weatherPb.PressureStat{
 		{
@@ -381,7 +382,7 @@ func TestFormatDashboardText(t *testing.T) {
 		},
 	}
 
-	result, err := formatDashboardText(pressureStats, pollenReports, lastWeathers, nil)
+	result, err := formatDashboardText(pressureStats, pollenReports, lastWeathers, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -392,13 +393,13 @@ func TestFormatDashboardText(t *testing.T) {
 	}
 
 	// Weather, pressure and pollen sections combined
-	if !strings.Contains(result, fmt.Sprintf("Weather: %s", localFormatted)) {
+	if !strings.Contains(result, fmt.Sprintf("Weather: %s", utcFormatted)) {
 		t.Errorf("expected weather section, got:\n%s", result)
 	}
-	if !strings.Contains(result, fmt.Sprintf("Pressure: %s", localFormatted)) {
+	if !strings.Contains(result, fmt.Sprintf("Pressure: %s", utcFormatted)) {
 		t.Errorf("expected pressure section, got:\n%s", result)
 	}
-	if !strings.Contains(result, fmt.Sprintf("Pollen: %s", localFormatted)) {
+	if !strings.Contains(result, fmt.Sprintf("Pollen: %s", utcFormatted)) {
 		t.Errorf("expected pollen section, got:\n%s", result)
 	}
 }
@@ -421,7 +422,7 @@ func TestFormatDashboardText_MultipleLocations(t *testing.T) {
 		{LocationId: "house-mom", TempC: 18.0, TempF: 64.4, LastUpdated: fixedTime},
 	}
 
-	result, err := formatDashboardText(pressureStats, pollenReports, lastWeathers, nil)
+	result, err := formatDashboardText(pressureStats, pollenReports, lastWeathers, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -475,7 +476,7 @@ func TestFormatForecastText_DeltasLowAndActiveAlertsOnly(t *testing.T) {
 		},
 	}
 
-	byLocation := formatForecastText(forecasts)
+	byLocation := formatForecastText(forecasts, nil)
 	text, ok := byLocation["house-nick"]
 	if !ok {
 		t.Fatal("missing house-nick forecast text")
@@ -497,3 +498,54 @@ func TestFormatForecastText_DeltasLowAndActiveAlertsOnly(t *testing.T) {
 		t.Errorf("resolved alerts must not render, got:\n%s", text)
 	}
 }
+
+func TestFormatDashboardText_UsesRegistryNames(t *testing.T) {
+	fixedTime := timestamppb.New(time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC))
+	pressureStats := []*weatherPb.PressureStat{
+		{LocationId: "house-nick", Trend: "rising", LastUpdated: fixedTime},
+		{LocationId: "house-mom", Trend: "falling", LastUpdated: fixedTime},
+	}
+	registry := []shared.Location{{ID: "house-nick", Name: "Nick's house"}}
+
+	result, err := formatDashboardText(pressureStats, nil, nil, nil, registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "---------------- Nick's house (house-nick) ----------------") {
+		t.Errorf("expected the registry name in the separator, got:\n%s", result)
+	}
+	if !strings.Contains(result, "---------------- house-mom ----------------") {
+		t.Errorf("expected an unregistered location to fall back to its ID, got:\n%s", result)
+	}
+}
+
+func TestFormatDashboardText_UsesRegistryTimezones(t *testing.T) {
+	fixedTime := timestamppb.New(time.Date(2025, 7, 15, 18, 30, 0, 0, time.UTC))
+	pressureStats := []*weatherPb.PressureStat{
+		{LocationId: "house-nick", Trend: "rising", LastUpdated: fixedTime},
+		{LocationId: "cabin", Trend: "steady", LastUpdated: fixedTime},
+		{LocationId: "house-mom", Trend: "falling", LastUpdated: fixedTime},
+	}
+	forecasts := []*weatherPb.Forecast{{LocationId: "cabin", IssuedAt: fixedTime}}
+	registry := []shared.Location{
+		{ID: "house-nick", Timezone: "America/Chicago"},
+		{ID: "cabin", Timezone: "America/Denver"},
+	}
+
+	result, err := formatDashboardText(pressureStats, nil, nil, forecasts, registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Pressure: 2025.07.15 13:30:00 CDT",
+		"Pressure: 2025.07.15 12:30:00 MDT",
+		"Forecast: 2025.07.15 12:30:00 MDT",
+		"Pressure: 2025.07.15 18:30:00 UTC", // house-mom is not in the registry
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q, got:\n%s", want, result)
+		}
+	}
+}
@@ -3,8 +3,12 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"golang.org/x/sync/errgroup"
@@ -20,29 +24,41 @@ type WeatherFetcher interface {
 	GetPressureStats(ctx context.Context) ([]*pressurePb.PressureStat, error)
 	GetLastWeather(ctx context.Context, locationID string) (*pressurePb.Weather, error)
 	GetAllLastWeather(ctx context.Context) ([]*pressurePb.Weather, error)
+	GetPressureHistory(ctx context.Context, locationID string) ([]*pressurePb.PressurePoint, error)
 }
 
 type PollenFetcher interface {
 	GetPollenReport(ctx context.Context, locationID string) (*pollenPb.PollenReport, error)
 	GetPollenReports(ctx context.Context) ([]*pollenPb.PollenReport, error)
+	GetPollenForecast(ctx context.Context, locationID string) (*pollenPb.PollenForecast, error)
+	GetPollenHistory(ctx context.Context, locationID, code string, start, end time.Time) ([]*pollenPb.PollenReport, error)
 }
 
 type ForecastFetcher interface {
 	GetForecast(ctx context.Context, locationID string) (*pressurePb.Forecast, error)
 	GetAllForecasts(ctx context.Context) ([]*pressurePb.Forecast, error)
+	ListAlertHistory(ctx context.Context, locationID, ruleID string, start, end time.Time, limit int) ([]*pressurePb.ArchivedAlert, error)
+	ListAlertEvents(ctx context.Context, alertID string) ([]*pressurePb.AlertEvent, error)
+}
+
+// LocationLister reads the location registry (see shared/registry).
+type LocationLister interface {
+	Locations(ctx context.Context) ([]shared.Location, error)
 }
 
 type DashboardHandler struct {
 	weatherClient  WeatherFetcher
 	pollenClient   PollenFetcher
 	forecastClient ForecastFetcher
+	locations      LocationLister
 }
 
-func NewDashboardHandler(wc WeatherFetcher, pc PollenFetcher, fc ForecastFetcher) *DashboardHandler {
+func NewDashboardHandler(wc WeatherFetcher, pc PollenFetcher, fc ForecastFetcher, locations LocationLister) *DashboardHandler {
 	return &DashboardHandler{
 		weatherClient:  wc,
 		pollenClient:   pc,
 		forecastClient: fc,
+		locations:      locations,
 	}
 }
 
@@ -115,6 +131,15 @@ func aggregateForecasts(forecasts []*pressurePb.Forecast) (map[string]json.RawMe
 	return forecastData, alertData, nil
 }
 
+// aggregateLocations keys the registry by ID like every other map in the
+// response. Disabled locations are kept: their data may still be cached.
+func aggregateLocations(locations []shared.Location) map[string]shared.Location {
+	out := make(map[string]shared.Location, len(locations))
+	for _, l := range locations {
+		out[l.ID] = l
+	}
+	return out
+}
 
 func (h *DashboardHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
 	// 1. Fetch data from clients
@@ -155,6 +180,20 @@ func (h *DashboardHandler) GetDashboard(w http.ResponseWriter, r *http.Request)
 		return err
 	})
 
+	// The registry only supplies names, so a failed read degrades the
+	// response to bare IDs rather than failing it.
+	var locations []shared.Location
+	g.Go(func() error {
+		var err error
+		regCtx, cancel := context.WithTimeout(ctx, shared.RPCClientTimeout)
+		defer cancel()
+		locations, err = h.locations.Locations(regCtx)
+		if err != nil {
+			slog.Warn("Failed to read location registry", "error", err)
+		}
+		return nil
+	})
+
 	if err := g.Wait(); err != nil {
 		RespondWithGrpcError(w, err, "Failed to fetch dashboard data")
 		return
@@ -162,7 +201,7 @@ func (h *DashboardHandler) GetDashboard(w http.ResponseWriter, r *http.Request)
 
 	// 2. Respond with text/plain if the user agent is curl
 	if strings.Contains(r.Header.Get("User-Agent"), "curl") {
-		body, err := formatDashboardText(pressureStats, pollenReports, allLastWeather, forecasts)
+		body, err := formatDashboardText(pressureStats, pollenReports, allLastWeather, forecasts, locations)
 		if err != nil {
 			http.Error(w, "Failed to format text dashboard", http.StatusInternalServerError)
 			return
@@ -198,11 +237,12 @@ func (h *DashboardHandler) GetDashboard(w http.ResponseWriter, r *http.Request)
 	// by embedding them verbatim, so the protojson output passes through).
 	// Marshal to buffer first so we can return a clean 500 if encoding fails.
 	buf, err := json.Marshal(map[string]any{
-		"weather":  aggregatedLastWeather,
-		"pressure": aggregatedPressure,
-		"pollen":   aggregatedPollen,
-		"forecast": aggregatedForecast,
-		"alerts":   aggregatedAlerts,
+		"weather":   aggregatedLastWeather,
+		"pressure":  aggregatedPressure,
+		"pollen":    aggregatedPollen,
+		"forecast":  aggregatedForecast,
+		"alerts":    aggregatedAlerts,
+		"locations": aggregateLocations(locations),
 	})
 	if err != nil {
 		http.Error(w, "Failed to encode JSON", http.StatusInternalServerError)
@@ -352,3 +392,158 @@ func (h *DashboardHandler) GetDashboardByLocation(w http.ResponseWriter, r *http
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(buf)
 }
+
+// GetPressureHistory serves one location's observed pressure series, oldest
+// first, for sparklines.
+func (h *DashboardHandler) GetPressureHistory(w http.ResponseWriter, r *http.Request) {
+	locationID := chi.URLParam(r, "locationID")
+
+	rpcCtx, cancel := context.WithTimeout(r.Context(), shared.RPCClientTimeout)
+	defer cancel()
+	points, err := h.weatherClient.GetPressureHistory(rpcCtx, locationID)
+	if err != nil {
+		RespondWithGrpcError(w, err, "Failed to fetch pressure history")
+		return
+	}
+
+	buf, err := protoMarshaler.Marshal(&pressurePb.GetPressureHistoryResponse{LocationId: locationID, Points: points})
+	if err != nil {
+		http.Error(w, "Failed to encode pressure history", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf)
+}
+
+// GetPollenForecast serves one location's multi-day pollen outlook. Unlike the
+// dashboard fan-outs there is a single upstream call, so a NotFound maps
+// straight to a 404.
+func (h *DashboardHandler) GetPollenForecast(w http.ResponseWriter, r *http.Request) {
+	locationID := chi.URLParam(r, "locationID")
+
+	rpcCtx, cancel := context.WithTimeout(r.Context(), shared.RPCClientTimeout)
+	defer cancel()
+	forecast, err := h.pollenClient.GetPollenForecast(rpcCtx, locationID)
+	if err != nil {
+		RespondWithGrpcError(w, err, "Failed to fetch pollen forecast")
+		return
+	}
+
+	buf, err := protoMarshaler.Marshal(forecast)
+	if err != nil {
+		http.Error(w, "Failed to encode pollen forecast", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf)
+}
+
+// GetPollenHistory serves one location's stored pollen snapshots, oldest
+// first. Optional query params: code (a plant or type code such as JUNIPER
+// or TREE) and start/end (RFC 3339, inclusive).
+func (h *DashboardHandler) GetPollenHistory(w http.ResponseWriter, r *http.Request) {
+	locationID := chi.URLParam(r, "locationID")
+	query := r.URL.Query()
+
+	start, err := parseTimeParam(query.Get("start"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid start: %v", err), http.StatusBadRequest)
+		return
+	}
+	end, err := parseTimeParam(query.Get("end"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid end: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	rpcCtx, cancel := context.WithTimeout(r.Context(), shared.RPCClientTimeout)
+	defer cancel()
+	snapshots, err := h.pollenClient.GetPollenHistory(rpcCtx, locationID, query.Get("code"), start, end)
+	if err != nil {
+		RespondWithGrpcError(w, err, "Failed to fetch pollen history")
+		return
+	}
+
+	buf, err := protoMarshaler.Marshal(&pollenPb.GetPollenHistoryResponse{Snapshots: snapshots})
+	if err != nil {
+		http.Error(w, "Failed to encode pollen history", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf)
+}
+
+// GetAlertHistory serves archived forecast alerts, newest first. Optional
+// query params: location, rule (a rule ID such as pressure-drop-3h),
+// start/end (RFC 3339, bounding the alert's window start; start inclusive,
+// end exclusive) and limit (default 100, at most 1000).
+func (h *DashboardHandler) GetAlertHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	start, err := parseTimeParam(query.Get("start"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid start: %v", err), http.StatusBadRequest)
+		return
+	}
+	end, err := parseTimeParam(query.Get("end"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid end: %v", err), http.StatusBadRequest)
+		return
+	}
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, fmt.Sprintf("Invalid limit: %q", raw), http.StatusBadRequest)
+			return
+		}
+	}
+
+	rpcCtx, cancel := context.WithTimeout(r.Context(), shared.RPCClientTimeout)
+	defer cancel()
+	alerts, err := h.forecastClient.ListAlertHistory(rpcCtx, query.Get("location"), query.Get("rule"), start, end, limit)
+	if err != nil {
+		RespondWithGrpcError(w, err, "Failed to fetch alert history")
+		return
+	}
+
+	buf, err := protoMarshaler.Marshal(&pressurePb.ListAlertHistoryResponse{Alerts: alerts})
+	if err != nil {
+		http.Error(w, "Failed to encode alert history", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf)
+}
+
+// GetAlertEvents serves one forecast alert's lifecycle events, oldest first:
+// when it was created, updated, escalated, resolved, reactivated, delivered
+// and pruned. An ID with no events returns an empty list.
+func (h *DashboardHandler) GetAlertEvents(w http.ResponseWriter, r *http.Request) {
+	alertID := chi.URLParam(r, "alertID")
+
+	rpcCtx, cancel := context.WithTimeout(r.Context(), shared.RPCClientTimeout)
+	defer cancel()
+	events, err := h.forecastClient.ListAlertEvents(rpcCtx, alertID)
+	if err != nil {
+		RespondWithGrpcError(w, err, "Failed to fetch alert events")
+		return
+	}
+
+	buf, err := protoMarshaler.Marshal(&pressurePb.ListAlertEventsResponse{Events: events})
+	if err != nil {
+		http.Error(w, "Failed to encode alert events", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf)
+}
+
+// parseTimeParam parses an optional RFC 3339 query value; empty yields the
+// zero time, which the clients treat as "unbounded".
+func parseTimeParam(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
@@ -90,6 +90,14 @@ func (m *mockWeatherClient) GetAllLastWeather(ctx context.Context) ([]*weatherPb
 	}, nil
 }
 
+func (m *mockWeatherClient) GetPressureHistory(ctx context.Context, locationID string) ([]*weatherPb.PressurePoint, error) {
+	start := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	return []*weatherPb.PressurePoint{
+		{Timestamp: timestamppb.New(start), PressureMb: 1013.2, HumidityPercent: 70, TempF: 75.2, DewpointF: 64.4},
+		{Timestamp: timestamppb.New(start.Add(time.Hour)), PressureMb: 1012.4, HumidityPercent: 74, TempF: 74.3, DewpointF: 64.9},
+	}, nil
+}
+
 type errorWeatherClient struct {
 	err error
 }
@@ -110,6 +118,10 @@ func (m *errorWeatherClient) GetAllLastWeather(ctx context.Context) ([]*weatherP
 	return nil, m.err
 }
 
+func (m *errorWeatherClient) GetPressureHistory(ctx context.Context, locationID string) ([]*weatherPb.PressurePoint, error) {
+	return nil, m.err
+}
+
 // --- Pollen mocks ---
 
 type mockPollenClient struct{}
@@ -150,6 +162,24 @@ func (m *mockPollenClient) GetPollenReports(ctx context.Context) ([]*pollenPb.Po
 	}, nil
 }
 
+func (m *mockPollenClient) GetPollenForecast(ctx context.Context, locationID string) (*pollenPb.PollenForecast, error) {
+	return &pollenPb.PollenForecast{
+		LocationId:  locationID,
+		CollectedAt: timestamppb.Now(),
+		Days: []*pollenPb.PollenForecastDay{
+			{Date: "2026-04-02", OverallIndex: 3, OverallCategory: "Moderate", DominantType: "TREE"},
+			{Date: "2026-04-03", OverallIndex: 5, OverallCategory: "Very High", DominantType: "TREE"},
+		},
+	}, nil
+}
+
+func (m *mockPollenClient) GetPollenHistory(ctx context.Context, locationID, code string, start, end time.Time) ([]*pollenPb.PollenReport, error) {
+	return []*pollenPb.PollenReport{
+		{LocationId: locationID, CollectedAt: timestamppb.New(time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC)), OverallIndex: 3},
+		{LocationId: locationID, CollectedAt: timestamppb.New(time.Date(2026, 4, 1, 14, 0, 0, 0, time.UTC)), OverallIndex: 4},
+	}, nil
+}
+
 type errorPollenClient struct {
 	err error
 }
@@ -162,10 +192,29 @@ func (m *errorPollenClient) GetPollenReports(ctx context.Context) ([]*pollenPb.P
 	return nil, m.err
 }
 
+func (m *errorPollenClient) GetPollenForecast(ctx context.Context, locationID string) (*pollenPb.PollenForecast, error) {
+	return nil, m.err
+}
+
+func (m *errorPollenClient) GetPollenHistory(ctx context.Context, locationID, code string, start, end time.Time) ([]*pollenPb.PollenReport, error) {
+	return nil, m.err
+}
+
+// --- Location registry mock ---
+
+type mockLocations struct {
+	locations []shared.Location
+	err       error
+}
+
+func (m *mockLocations) Locations(ctx context.Context) ([]shared.Location, error) {
+	return m.locations, m.err
+}
+
 // --- Existing weather tests (updated to pass both mocks) ---
 
 func TestDashboardHandler_GetDashboard(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -201,7 +250,7 @@ func TestDashboardHandler_GetDashboard(t *testing.T) {
 }
 
 func TestDashboardHandler_GetDashboard_ProtojsonFormat(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -258,7 +307,7 @@ func TestDashboardHandler_GetDashboard_GrpcError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewDashboardHandler(&errorWeatherClient{err: tt.grpcErr}, &mockPollenClient{}, &mockForecastClient{})
+			handler := NewDashboardHandler(&errorWeatherClient{err: tt.grpcErr}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 			req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 			if err != nil {
@@ -278,7 +327,7 @@ func TestDashboardHandler_GetDashboard_GrpcError(t *testing.T) {
 // --- Weather (last weather) integration tests ---
 
 func TestDashboardHandler_GetDashboard_IncludesWeather(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -320,7 +369,7 @@ func TestDashboardHandler_GetDashboard_IncludesWeather(t *testing.T) {
 }
 
 func TestDashboardHandler_GetDashboard_WeatherProtojsonFormat(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -353,7 +402,7 @@ func TestDashboardHandler_GetDashboard_WeatherProtojsonFormat(t *testing.T) {
 // --- New pollen integration tests ---
 
 func TestDashboardHandler_GetDashboard_IncludesPollen(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -406,7 +455,7 @@ func TestDashboardHandler_GetDashboard_IncludesPollen(t *testing.T) {
 }
 
 func TestDashboardHandler_GetDashboard_PollenProtojsonFormat(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -466,7 +515,7 @@ func TestDashboardHandler_GetDashboard_PollenGrpcError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewDashboardHandler(&mockWeatherClient{}, &errorPollenClient{err: tt.grpcErr}, &mockForecastClient{})
+			handler := NewDashboardHandler(&mockWeatherClient{}, &errorPollenClient{err: tt.grpcErr}, &mockForecastClient{}, &mockLocations{})
 
 			req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 			if err != nil {
@@ -488,6 +537,7 @@ func TestDashboardHandler_GetDashboard_BothServicesFail(t *testing.T) {
 		&errorWeatherClient{err: status.Error(codes.Unavailable, "weather down")},
 		&errorPollenClient{err: status.Error(codes.DeadlineExceeded, "pollen timeout")},
 		&mockForecastClient{},
+		&mockLocations{},
 	)
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
@@ -554,6 +604,17 @@ func (m *slowWeatherClient) GetAllLastWeather(ctx context.Context) ([]*weatherPb
 	}
 }
 
+func (m *slowWeatherClient) GetPressureHistory(ctx context.Context, locationID string) ([]*weatherPb.PressurePoint, error) {
+	timer := time.NewTimer(m.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return []*weatherPb.PressurePoint{{PressureMb: 1013.2}}, nil
+	case <-ctx.Done():
+		return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+}
+
 // slowPollenClient simulates a provider that takes longer than the per-RPC timeout.
 type slowPollenClient struct {
 	delay time.Duration
@@ -581,11 +642,34 @@ func (m *slowPollenClient) GetPollenReports(ctx context.Context) ([]*pollenPb.Po
 	}
 }
 
+func (m *slowPollenClient) GetPollenHistory(ctx context.Context, locationID, code string, start, end time.Time) ([]*pollenPb.PollenReport, error) {
+	timer := time.NewTimer(m.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return []*pollenPb.PollenReport{{LocationId: locationID}}, nil
+	case <-ctx.Done():
+		return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+}
+
+func (m *slowPollenClient) GetPollenForecast(ctx context.Context, locationID string) (*pollenPb.PollenForecast, error) {
+	timer := time.NewTimer(m.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return &pollenPb.PollenForecast{LocationId: locationID}, nil
+	case <-ctx.Done():
+		return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+}
+
 func TestDashboardHandler_GetDashboard_SlowWeatherTimesOut(t *testing.T) {
 	handler := NewDashboardHandler(
 		&slowWeatherClient{delay: 10 * time.Second},
 		&mockPollenClient{},
 		&mockForecastClient{},
+		&mockLocations{},
 	)
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
@@ -611,6 +695,7 @@ func TestDashboardHandler_GetDashboard_SlowPollenTimesOut(t *testing.T) {
 		&mockWeatherClient{},
 		&slowPollenClient{delay: 10 * time.Second},
 		&mockForecastClient{},
+		&mockLocations{},
 	)
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
@@ -632,7 +717,7 @@ func TestDashboardHandler_GetDashboard_SlowPollenTimesOut(t *testing.T) {
 }
 
 func TestDashboardHandler_GetDashboard_CurlUserAgent_ReturnsText(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 
 	req, err := http.NewRequest("GET", "/api/v1/dashboard", nil)
 	if err != nil {
@@ -690,10 +775,59 @@ func keys(m map[string]interface{}) []string {
 	return result
 }
 
+// --- Location registry tests ---
+
+func TestDashboardHandler_GetDashboard_IncludesLocations(t *testing.T) {
+	registry := &mockLocations{locations: []shared.Location{
+		{ID: "house-nick", Name: "Nick's house", Lat: 30.26, Long: -97.67, Timezone: "America/Chicago"},
+	}}
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, registry)
+
+	req := httptest.NewRequest("GET", "/api/v1/dashboard", nil)
+	rr := httptest.NewRecorder()
+	handler.GetDashboard(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rr.Code)
+	}
+	var resp struct {
+		Locations map[string]shared.Location `json:"locations"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if got := resp.Locations["house-nick"]; got.Name != "Nick's house" || got.Timezone != "America/Chicago" {
+		t.Errorf("locations[house-nick] = %+v, want the registry entry", got)
+	}
+}
+
+func TestDashboardHandler_GetDashboard_RegistryErrorDegrades(t *testing.T) {
+	registry := &mockLocations{err: fmt.Errorf("firestore unavailable")}
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, registry)
+
+	req := httptest.NewRequest("GET", "/api/v1/dashboard", nil)
+	rr := httptest.NewRecorder()
+	handler.GetDashboard(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 when only the registry fails", rr.Code)
+	}
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if string(resp["locations"]) != "{}" {
+		t.Errorf("locations = %s, want an empty map", resp["locations"])
+	}
+	if _, ok := resp["pressure"]; !ok {
+		t.Error("the rest of the dashboard should still be served")
+	}
+}
+
 // --- GetDashboardByLocation tests ---
 
 func TestDashboardHandler_GetDashboardByLocation_Success(t *testing.T) {
-	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 	req := requestWithLocationID(t, "house-nick")
 	rr := httptest.NewRecorder()
 
@@ -775,7 +909,7 @@ func TestDashboardHandler_GetDashboardByLocation_GrpcError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewDashboardHandler(&errorWeatherClient{err: tt.grpcErr}, &mockPollenClient{}, &mockForecastClient{})
+			handler := NewDashboardHandler(&errorWeatherClient{err: tt.grpcErr}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 			req := requestWithLocationID(t, "house-nick")
 			rr := httptest.NewRecorder()
 
@@ -805,6 +939,9 @@ func (m *nilReturnWeatherClient) GetLastWeather(ctx context.Context, locationID
 func (m *nilReturnWeatherClient) GetAllLastWeather(ctx context.Context) ([]*weatherPb.Weather, error) {
 	return nil, nil
 }
+func (m *nilReturnWeatherClient) GetPressureHistory(ctx context.Context, locationID string) ([]*weatherPb.PressurePoint, error) {
+	return nil, nil
+}
 
 // TestDashboardHandler_GetDashboardByLocation_PartialData_WeatherMissingPollenPresent
 // locks in the partial-data contract: when a provider returns (nil, nil)
@@ -813,7 +950,7 @@ func (m *nilReturnWeatherClient) GetAllLastWeather(ctx context.Context) ([]*weat
 // present sections populated normally. This is the shape the CLI's
 // Response.Merge depends on (empty src maps are no-ops on the dst).
 func TestDashboardHandler_GetDashboardByLocation_PartialData_WeatherMissingPollenPresent(t *testing.T) {
-	handler := NewDashboardHandler(&nilReturnWeatherClient{}, &mockPollenClient{}, &mockForecastClient{})
+	handler := NewDashboardHandler(&nilReturnWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
 	req := requestWithLocationID(t, "house-nick")
 	rr := httptest.NewRecorder()
 
@@ -848,6 +985,7 @@ func TestDashboardHandler_GetDashboardByLocation_AllSectionsAbsent_Returns404(t
 		&errorWeatherClient{err: notFound},
 		&errorPollenClient{err: notFound},
 		&errorForecastClient{err: notFound},
+		&mockLocations{},
 	)
 	req := requestWithLocationID(t, "house-nick")
 	rr := httptest.NewRecorder()
@@ -869,6 +1007,7 @@ func TestDashboardHandler_GetDashboardByLocation_PollenNotFound_PartialData(t *t
 		&mockWeatherClient{},
 		&errorPollenClient{err: status.Error(codes.NotFound, "no pollen for location")},
 		&mockForecastClient{},
+		&mockLocations{},
 	)
 	req := requestWithLocationID(t, "house-nick")
 	rr := httptest.NewRecorder()
@@ -906,6 +1045,7 @@ func TestDashboardHandler_GetDashboardByLocation_PollenUnavailable_Fatal(t *test
 		&mockWeatherClient{},
 		&errorPollenClient{err: status.Error(codes.Unavailable, "pollen-provider down")},
 		&mockForecastClient{},
+		&mockLocations{},
 	)
 	req := requestWithLocationID(t, "house-nick")
 	rr := httptest.NewRecorder()
@@ -922,6 +1062,7 @@ func TestDashboardHandler_GetDashboardByLocation_SlowProviderTimesOut(t *testing
 		&slowWeatherClient{delay: 10 * time.Second},
 		&mockPollenClient{},
 		&mockForecastClient{},
+		&mockLocations{},
 	)
 	req := requestWithLocationID(t, "house-nick")
 	rr := httptest.NewRecorder()
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+// maxLocationBody bounds a create or update request body. A location is a
+// few hundred bytes; anything near this is not one.
+const maxLocationBody = 64 << 10
+
+// LocationStore manages the location registry; registry.Store satisfies it.
+type LocationStore interface {
+	LocationLister
+	Get(ctx context.Context, id string) (shared.Location, error)
+	Create(ctx context.Context, l shared.Location) error
+	Update(ctx context.Context, id string, fn registry.UpdateFunc) (shared.Location, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// LocationHandler serves /v1/locations. Collectors read the registry at the
+// start of each run, so a change made here is picked up on their next run
+// without a redeploy.
+type LocationHandler struct {
+	store LocationStore
+}
+
+func NewLocationHandler(store LocationStore) *LocationHandler {
+	return &LocationHandler{store: store}
+}
+
+// locationPatch is the body of PATCH /v1/locations/{locationID}. Only the
+// fields present are changed; Sources is merged key by key. There is no id
+// field: the ID keys every collection, so renaming means changing Name.
+type locationPatch struct {
+	Name     *string         `json:"name"`
+	Lat      *float64        `json:"lat"`
+	Long     *float64        `json:"long"`
+	Timezone *string         `json:"timezone"`
+	Disabled *bool           `json:"disabled"`
+	Sources  map[string]bool `json:"sources"`
+}
+
+func (p locationPatch) apply(l *shared.Location) {
+	if p.Name != nil {
+		l.Name = *p.Name
+	}
+	if p.Lat != nil {
+		l.Lat = *p.Lat
+	}
+	if p.Long != nil {
+		l.Long = *p.Long
+	}
+	if p.Timezone != nil {
+		l.Timezone = *p.Timezone
+	}
+	if p.Disabled != nil {
+		l.Disabled = *p.Disabled
+	}
+	if len(p.Sources) > 0 {
+		if l.Sources == nil {
+			l.Sources = make(map[string]bool, len(p.Sources))
+		}
+		for source, on := range p.Sources {
+			l.Sources[source] = on
+		}
+	}
+}
+
+func (h *LocationHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.store.Locations(r.Context())
+	if err != nil {
+		respondWithRegistryError(w, err, "Failed to list locations")
+		return
+	}
+	if locations == nil {
+		locations = []shared.Location{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"locations": locations})
+}
+
+func (h *LocationHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	l, err := h.store.Get(r.Context(), chi.URLParam(r, "locationID"))
+	if err != nil {
+		respondWithRegistryError(w, err, "Failed to get location")
+		return
+	}
+	writeJSON(w, http.StatusOK, l)
+}
+
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var l shared.Location
+	if err := decodeStrict(w, r, &l); err != nil {
+		http.Error(w, "Invalid location body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.store.Create(r.Context(), l); err != nil {
+		respondWithRegistryError(w, err, "Failed to create location")
+		return
+	}
+	slog.Info("Location created", "location", l.ID)
+	w.Header().Set("Location", "/v1/locations/"+l.ID)
+	writeJSON(w, http.StatusCreated, l)
+}
+
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	locationID := chi.URLParam(r, "locationID")
+	var patch locationPatch
+	if err := decodeStrict(w, r, &patch); err != nil {
+		http.Error(w, "Invalid location patch: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	l, err := h.store.Update(r.Context(), locationID, func(l *shared.Location) error {
+		patch.apply(l)
+		return nil
+	})
+	if err != nil {
+		respondWithRegistryError(w, err, "Failed to update location")
+		return
+	}
+	slog.Info("Location updated", "location", l.ID, "disabled", l.Disabled)
+	writeJSON(w, http.StatusOK, l)
+}
+
+// DeleteLocation removes the registry entry only; cached and raw data for the
+// location stay until retention removes them.
+func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	locationID := chi.URLParam(r, "locationID")
+	if err := h.store.Delete(r.Context(), locationID); err != nil {
+		respondWithRegistryError(w, err, "Failed to delete location")
+		return
+	}
+	slog.Info("Location deleted", "location", locationID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeStrict rejects unknown fields, so a misspelt field — or an attempt
+// to change the ID through PATCH — fails loudly instead of being ignored.
+func decodeStrict(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLocationBody))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// respondWithRegistryError maps registry errors to HTTP statuses, as
+// RespondWithGrpcError does for provider errors. Validation messages are
+// returned to the caller; anything else is logged and replaced.
+func respondWithRegistryError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, registry.ErrInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, registry.ErrNotFound):
+		http.Error(w, "Location not found", http.StatusNotFound)
+	case errors.Is(err, registry.ErrExists):
+		http.Error(w, "Location already exists", http.StatusConflict)
+	case errors.Is(err, registry.ErrReadOnly):
+		http.Error(w, "Location registry is read-only (LOCATIONS_FILE is set)", http.StatusConflict)
+	default:
+		slog.Error("registry_error", "error", err, "context", message)
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "Failed to encode JSON", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf)
+}
@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+// fakeLocationStore is an in-memory registry.Store that validates writes the
+// way the Firestore store does.
+type fakeLocationStore struct {
+	locations []shared.Location
+	err       error // returned by every call when set
+}
+
+func (f *fakeLocationStore) Locations(ctx context.Context) ([]shared.Location, error) {
+	return f.locations, f.err
+}
+
+func (f *fakeLocationStore) Get(ctx context.Context, id string) (shared.Location, error) {
+	if f.err != nil {
+		return shared.Location{}, f.err
+	}
+	for _, l := range f.locations {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return shared.Location{}, registry.ErrNotFound
+}
+
+func (f *fakeLocationStore) Create(ctx context.Context, l shared.Location) error {
+	if f.err != nil {
+		return f.err
+	}
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", registry.ErrInvalid, err)
+	}
+	if _, err := f.Get(ctx, l.ID); err == nil {
+		return registry.ErrExists
+	}
+	f.locations = append(f.locations, l)
+	return nil
+}
+
+func (f *fakeLocationStore) Update(ctx context.Context, id string, fn registry.UpdateFunc) (shared.Location, error) {
+	if f.err != nil {
+		return shared.Location{}, f.err
+	}
+	for i, l := range f.locations {
+		if l.ID != id {
+			continue
+		}
+		if err := fn(&l); err != nil {
+			return shared.Location{}, err
+		}
+		if err := l.Validate(); err != nil {
+			return shared.Location{}, fmt.Errorf("%w: %v", registry.ErrInvalid, err)
+		}
+		f.locations[i] = l
+		return l, nil
+	}
+	return shared.Location{}, registry.ErrNotFound
+}
+
+func (f *fakeLocationStore) Delete(ctx context.Context, id string) error {
+	if f.err != nil {
+		return f.err
+	}
+	for i, l := range f.locations {
+		if l.ID == id {
+			f.locations = append(f.locations[:i], f.locations[i+1:]...)
+			return nil
+		}
+	}
+	return registry.ErrNotFound
+}
+
+func newFakeLocationStore() *fakeLocationStore {
+	return &fakeLocationStore{locations: []shared.Location{
+		{ID: "house-nick", Name: "Nick's house", Lat: 30.26, Long: -97.67, Timezone: "America/Chicago"},
+	}}
+}
+
+func locationRequest(t *testing.T, method, locationID, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(method, "/v1/locations/"+locationID, strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	if locationID != "" {
+		rctx.URLParams.Add("locationID", locationID)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestLocationHandler_ListAndGet(t *testing.T) {
+	handler := NewLocationHandler(newFakeLocationStore())
+
+	rr := httptest.NewRecorder()
+	handler.ListLocations(rr, locationRequest(t, "GET", "", ""))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("list: expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Locations []shared.Location `json:"locations"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if len(resp.Locations) != 1 || resp.Locations[0].ID != "house-nick" {
+		t.Errorf("list returned %+v, want house-nick", resp.Locations)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.GetLocation(rr, locationRequest(t, "GET", "house-nick", ""))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"name":"Nick's house"`) {
+		t.Errorf("get: status %d, body %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	handler.GetLocation(rr, locationRequest(t, "GET", "nowhere", ""))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("get unknown: expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestLocationHandler_CreateLocation(t *testing.T) {
+	store := newFakeLocationStore()
+	handler := NewLocationHandler(store)
+
+	rr := httptest.NewRecorder()
+	handler.CreateLocation(rr, locationRequest(t, "POST", "", `{"id":"cabin","name":"Cabin","lat":35.6,"long":-83.5,"timezone":"America/New_York"}`))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("Location"); got != "/v1/locations/cabin" {
+		t.Errorf("Location header = %q, want /v1/locations/cabin", got)
+	}
+	if len(store.locations) != 2 {
+		t.Errorf("store holds %d locations, want 2", len(store.locations))
+	}
+}
+
+func TestLocationHandler_CreateLocation_Rejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"latitude out of range", `{"id":"cabin","lat":95,"long":0}`, http.StatusBadRequest, "latitude"},
+		{"bad id", `{"id":"Cabin One","lat":35,"long":0}`, http.StatusBadRequest, "lowercase"},
+		{"missing timezone", `{"id":"cabin","lat":35,"long":0}`, http.StatusBadRequest, "missing timezone"},
+		{"unknown field", `{"id":"cabin","lat":35,"long":0,"elevation":300}`, http.StatusBadRequest, "elevation"},
+		{"malformed JSON", `{"id":`, http.StatusBadRequest, "Invalid location body"},
+		{"duplicate id", `{"id":"house-nick","lat":30,"long":-97,"timezone":"America/Chicago"}`, http.StatusConflict, "already exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeLocationStore()
+			handler := NewLocationHandler(store)
+
+			rr := httptest.NewRecorder()
+			handler.CreateLocation(rr, locationRequest(t, "POST", "", tt.body))
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body %q should mention %q", rr.Body.String(), tt.expectedBody)
+			}
+			if len(store.locations) != 1 {
+				t.Errorf("a rejected create should not change the store, got %d locations", len(store.locations))
+			}
+		})
+	}
+}
+
+func TestLocationHandler_UpdateLocation(t *testing.T) {
+	store := newFakeLocationStore()
+	handler := NewLocationHandler(store)
+
+	rr := httptest.NewRecorder()
+	handler.UpdateLocation(rr, locationRequest(t, "PATCH", "house-nick", `{"name":"Home","sources":{"pollen":false}}`))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	got := store.locations[0]
+	if got.Name != "Home" || got.Enabled(shared.SourcePollen) || !got.Enabled(shared.SourceWeather) {
+		t.Errorf("after rename and pollen off, got %+v", got)
+	}
+	if got.Lat != 30.26 || got.Timezone != "America/Chicago" {
+		t.Errorf("fields absent from the patch should be kept, got %+v", got)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.UpdateLocation(rr, locationRequest(t, "PATCH", "house-nick", `{"disabled":true}`))
+	if rr.Code != http.StatusOK || !store.locations[0].Disabled {
+		t.Errorf("disable: status %d, location %+v", rr.Code, store.locations[0])
+	}
+	if on, ok := store.locations[0].Sources[shared.SourcePollen]; !ok || on {
+		t.Error("a patch without sources should keep the existing source switches")
+	}
+}
+
+func TestLocationHandler_UpdateLocation_Rejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		locationID     string
+		body           string
+		expectedStatus int
+	}{
+		{"id cannot change", "house-nick", `{"id":"house-new"}`, http.StatusBadRequest},
+		{"longitude out of range", "house-nick", `{"long":-200}`, http.StatusBadRequest},
+		{"unknown source", "house-nick", `{"sources":{"radar":false}}`, http.StatusBadRequest},
+		{"unknown location", "nowhere", `{"name":"Nowhere"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeLocationStore()
+			handler := NewLocationHandler(store)
+
+			rr := httptest.NewRecorder()
+			handler.UpdateLocation(rr, locationRequest(t, "PATCH", tt.locationID, tt.body))
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if got := store.locations[0]; got.ID != "house-nick" || got.Long != -97.67 || got.Sources != nil {
+				t.Errorf("a rejected update should not change the store, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestLocationHandler_DeleteLocation(t *testing.T) {
+	store := newFakeLocationStore()
+	handler := NewLocationHandler(store)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteLocation(rr, locationRequest(t, "DELETE", "house-nick", ""))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	if len(store.locations) != 0 {
+		t.Errorf("store still holds %+v", store.locations)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.DeleteLocation(rr, locationRequest(t, "DELETE", "house-nick", ""))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("second delete: expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestLocationHandler_DeleteLastLocationThenList(t *testing.T) {
+	// Against the real SQLite registry, not the fake: deleting the last
+	// location must leave an empty list, not a registry that fails to read.
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "registry.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	store := registry.NewSQLiteSource(db)
+	defer store.Close()
+	if err := store.Create(context.Background(), newFakeLocationStore().locations[0]); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+	handler := NewLocationHandler(store)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteLocation(rr, locationRequest(t, "DELETE", "house-nick", ""))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("delete: expected status 204, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ListLocations(rr, locationRequest(t, "GET", "", ""))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("list: expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	if body := strings.TrimSpace(rr.Body.String()); body != `{"locations":[]}` {
+		t.Errorf("list body = %s, want an empty list", body)
+	}
+}
+
+func TestLocationHandler_StoreErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"read-only file registry returns 409", registry.ErrReadOnly, http.StatusConflict},
+		{"Firestore failure returns 500", errors.New("firestore: unavailable"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewLocationHandler(&fakeLocationStore{err: tt.err})
+
+			rr := httptest.NewRecorder()
+			handler.DeleteLocation(rr, locationRequest(t, "DELETE", "house-nick", ""))
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if strings.Contains(rr.Body.String(), "firestore") {
+				t.Errorf("internal error details leaked: %q", rr.Body.String())
+			}
+		})
+	}
+}
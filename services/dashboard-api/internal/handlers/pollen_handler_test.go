@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pollenPb "github.com/nickfang/personal-dashboard/services/dashboard-api/internal/gen/go/pollen-provider/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingPollenClient captures the history filters the handler forwards.
+type recordingPollenClient struct {
+	mockPollenClient
+	code       string
+	start, end time.Time
+}
+
+func (m *recordingPollenClient) GetPollenHistory(ctx context.Context, locationID, code string, start, end time.Time) ([]*pollenPb.PollenReport, error) {
+	m.code, m.start, m.end = code, start, end
+	return m.mockPollenClient.GetPollenHistory(ctx, locationID, code, start, end)
+}
+
+// withQuery adds a raw query string to a request built by requestWithLocationID.
+func withQuery(req *http.Request, rawQuery string) *http.Request {
+	req.URL.RawQuery = rawQuery
+	return req
+}
+
+func TestDashboardHandler_GetPollenForecast(t *testing.T) {
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetPollenForecast(rr, requestWithLocationID(t, "house-nick"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if resp["locationId"] != "house-nick" {
+		t.Errorf("Expected locationId house-nick, got %v", resp["locationId"])
+	}
+	days, ok := resp["days"].([]interface{})
+	if !ok || len(days) != 2 {
+		t.Fatalf("Expected 2 days, got %v", resp["days"])
+	}
+	day := days[1].(map[string]interface{})
+	if day["date"] != "2026-04-03" || day["overallCategory"] != "Very High" {
+		t.Errorf("Expected day 2 to be 2026-04-03 Very High, got %v", day)
+	}
+}
+
+func TestDashboardHandler_GetPollenForecast_GrpcError(t *testing.T) {
+	tests := []struct {
+		name           string
+		grpcErr        error
+		expectedStatus int
+	}{
+		{"NotFound returns 404", status.Error(codes.NotFound, "no forecast"), http.StatusNotFound},
+		{"Unavailable returns 503", status.Error(codes.Unavailable, "pollen-provider down"), http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewDashboardHandler(&mockWeatherClient{}, &errorPollenClient{err: tt.grpcErr}, &mockForecastClient{}, &mockLocations{})
+
+			rr := httptest.NewRecorder()
+			handler.GetPollenForecast(rr, requestWithLocationID(t, "house-nick"))
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_GetPollenHistory(t *testing.T) {
+	pollen := &recordingPollenClient{}
+	handler := NewDashboardHandler(&mockWeatherClient{}, pollen, &mockForecastClient{}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	req := withQuery(requestWithLocationID(t, "house-nick"), "code=JUNIPER&start=2026-04-01T00:00:00Z&end=2026-04-02T12:00:00-05:00")
+	handler.GetPollenHistory(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	if pollen.code != "JUNIPER" {
+		t.Errorf("forwarded code = %q, want JUNIPER", pollen.code)
+	}
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC); !pollen.start.Equal(want) {
+		t.Errorf("forwarded start = %v, want %v", pollen.start, want)
+	}
+	if want := time.Date(2026, 4, 2, 17, 0, 0, 0, time.UTC); !pollen.end.Equal(want) {
+		t.Errorf("forwarded end = %v, want %v", pollen.end, want)
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	snapshots, ok := resp["snapshots"].([]interface{})
+	if !ok || len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %v", resp["snapshots"])
+	}
+	first := snapshots[0].(map[string]interface{})
+	if first["collectedAt"] != "2026-04-01T06:00:00Z" {
+		t.Errorf("Expected RFC 3339 collectedAt, got %v", first["collectedAt"])
+	}
+}
+
+func TestDashboardHandler_GetPollenHistory_NoFilters(t *testing.T) {
+	pollen := &recordingPollenClient{}
+	handler := NewDashboardHandler(&mockWeatherClient{}, pollen, &mockForecastClient{}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetPollenHistory(rr, requestWithLocationID(t, "house-nick"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if pollen.code != "" || !pollen.start.IsZero() || !pollen.end.IsZero() {
+		t.Errorf("expected no filters forwarded, got code=%q start=%v end=%v", pollen.code, pollen.start, pollen.end)
+	}
+}
+
+func TestDashboardHandler_GetPollenHistory_BadTime(t *testing.T) {
+	for _, query := range []string{"start=yesterday", "end=2026-04-01"} {
+		t.Run(query, func(t *testing.T) {
+			handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
+
+			rr := httptest.NewRecorder()
+			handler.GetPollenHistory(rr, withQuery(requestWithLocationID(t, "house-nick"), query))
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_GetPollenHistory_GrpcError(t *testing.T) {
+	handler := NewDashboardHandler(&mockWeatherClient{}, &errorPollenClient{err: status.Error(codes.NotFound, "no doc")}, &mockForecastClient{}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetPollenHistory(rr, requestWithLocationID(t, "house-nick"))
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
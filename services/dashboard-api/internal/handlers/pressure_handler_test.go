@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDashboardHandler_GetPressureHistory(t *testing.T) {
+	handler := NewDashboardHandler(&mockWeatherClient{}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
+
+	rr := httptest.NewRecorder()
+	handler.GetPressureHistory(rr, requestWithLocationID(t, "house-nick"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (body: %s)", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if resp["locationId"] != "house-nick" {
+		t.Errorf("Expected locationId house-nick, got %v", resp["locationId"])
+	}
+	points, ok := resp["points"].([]interface{})
+	if !ok || len(points) != 2 {
+		t.Fatalf("Expected 2 points, got %v", resp["points"])
+	}
+	first := points[0].(map[string]interface{})
+	if first["timestamp"] != "2026-06-12T06:00:00Z" {
+		t.Errorf("Expected RFC 3339 timestamp, got %v", first["timestamp"])
+	}
+	if first["pressureMb"] != 1013.2 {
+		t.Errorf("Expected pressureMb 1013.2, got %v", first["pressureMb"])
+	}
+	if _, ok := first["dewpointF"]; !ok {
+		t.Errorf("Expected camelCase 'dewpointF' from protojson, got keys: %v", keys(first))
+	}
+}
+
+func TestDashboardHandler_GetPressureHistory_GrpcError(t *testing.T) {
+	tests := []struct {
+		name           string
+		grpcErr        error
+		expectedStatus int
+	}{
+		{"NotFound returns 404", status.Error(codes.NotFound, "no history"), http.StatusNotFound},
+		{"Unavailable returns 503", status.Error(codes.Unavailable, "weather-provider down"), http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewDashboardHandler(&errorWeatherClient{err: tt.grpcErr}, &mockPollenClient{}, &mockForecastClient{}, &mockLocations{})
+
+			rr := httptest.NewRecorder()
+			handler.GetPressureHistory(rr, requestWithLocationID(t, "house-nick"))
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
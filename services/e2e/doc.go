@@ -0,0 +1,19 @@
+// Package e2e tests the pipeline as deployed: collectors write, providers
+// read, dashboard-api serves. shared/schema gives writers and readers the
+// same document structs, but only running them checks that what
+// weather-collector writes is what weather-provider decodes and dashboard-api
+// returns.
+//
+// The harness builds each service's real main package and runs it as a
+// subprocess; the services' internal packages cannot be imported from here.
+// The collectors fetch from the fake upstream (shared/fakeupstream), served
+// in-process so a test can switch scenario between runs, and every service
+// shares one SQLite file (STORAGE_BACKEND=sqlite) in the test's temp dir. The
+// providers and dashboard-api listen on free local ports, and the tests
+// assert on the /v1/dashboard JSON.
+//
+// Building the binaries takes a while on a cold cache; -short skips the
+// package.
+//
+//	cd services/e2e && go test ./...
+package e2e
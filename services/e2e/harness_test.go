@@ -0,0 +1,293 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/fakeupstream"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+// mains maps each service the harness runs, which is also its module
+// directory under services/, to its main package.
+var mains = map[string]string{
+	"weather-collector":  "./cmd",
+	"forecast-collector": "./cmd",
+	"pollen-collector":   "./cmd",
+	"weather-provider":   "./cmd/server",
+	"pollen-provider":    "./cmd/server",
+	"dashboard-api":      "./cmd/server",
+}
+
+// testLocation is the only location the registry holds, which keeps each
+// collector run to one fetch.
+var testLocation = shared.Location{
+	ID:       "e2e-austin",
+	Name:     "E2E Austin",
+	Lat:      30.2672,
+	Long:     -97.7431,
+	Timezone: "America/Chicago",
+}
+
+var (
+	buildOnce sync.Once
+	binDir    string
+	buildErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if binDir != "" {
+		os.RemoveAll(binDir)
+	}
+	os.Exit(code)
+}
+
+// build compiles every service once per test binary.
+func build(t *testing.T) string {
+	t.Helper()
+	buildOnce.Do(func() {
+		binDir, buildErr = os.MkdirTemp("", "e2e-bin-")
+		if buildErr != nil {
+			return
+		}
+		for name, pkg := range mains {
+			cmd := exec.Command("go", "build", "-o", filepath.Join(binDir, name), pkg)
+			cmd.Dir = filepath.Join("..", name)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				buildErr = fmt.Errorf("building %s: %v\n%s", name, err, out)
+				return
+			}
+		}
+	})
+	if buildErr != nil {
+		t.Fatal(buildErr)
+	}
+	return binDir
+}
+
+// stack is one pipeline: a fake upstream, a SQLite file seeded with
+// testLocation, and the services that run against them.
+type stack struct {
+	t        *testing.T
+	bin      string
+	dir      string
+	upstream *fakeupstream.Server
+	env      []string
+	apiURL   string
+}
+
+func newStack(t *testing.T, scenario string) *stack {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("end-to-end test skipped in -short mode")
+	}
+	s := &stack{t: t, bin: build(t), dir: t.TempDir()}
+
+	var err error
+	if s.upstream, err = fakeupstream.New(scenario); err != nil {
+		t.Fatal(err)
+	}
+	upstream := httptest.NewServer(s.upstream)
+	t.Cleanup(upstream.Close)
+
+	dbPath := filepath.Join(s.dir, "dashboard.db")
+	db, err := docstore.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.NewSQLiteSource(db).Put(context.Background(), testLocation); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	// Only what the services need: nothing from the developer's shell, such
+	// as GCP_PROJECT_ID or NOTIFY_ENABLED, leaks into the run.
+	s.env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + s.dir,
+		docstore.EnvBackend + "=" + docstore.BackendSQLite,
+		docstore.EnvPath + "=" + dbPath,
+		"GOOGLE_MAPS_API_KEY=e2e-fake-key",
+		"WEATHER_API_BASE_URL=" + upstream.URL,
+		"POLLEN_API_BASE_URL=" + upstream.URL,
+		"FORECAST_HORIZON_HOURS=48",
+	}
+	return s
+}
+
+// collect runs the three collectors once each, in the order the scheduler
+// staggers them.
+func (s *stack) collect() {
+	s.t.Helper()
+	for _, job := range []string{"weather-collector", "forecast-collector", "pollen-collector"} {
+		s.run(job)
+	}
+}
+
+// run runs a job to completion and fails the test if it exits non-zero.
+func (s *stack) run(name string, env ...string) {
+	s.t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, filepath.Join(s.bin, name))
+	cmd.Dir = s.dir // no .env for godotenv to find
+	cmd.Env = append(append([]string{}, s.env...), env...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		s.t.Fatalf("%s: %v\n%s", name, err, out)
+	}
+}
+
+// serve starts both providers and dashboard-api and waits until they accept
+// connections. They are stopped when the test ends.
+func (s *stack) serve() {
+	s.t.Helper()
+	weatherPort, pollenPort, apiPort := freePort(s.t), freePort(s.t), freePort(s.t)
+	s.start("weather-provider", "PORT="+weatherPort)
+	s.start("pollen-provider", "PORT="+pollenPort)
+	s.start("dashboard-api",
+		"PORT="+apiPort,
+		"WEATHER_PROVIDER_ADDR=localhost:"+weatherPort,
+		"POLLEN_PROVIDER_ADDR=localhost:"+pollenPort,
+	)
+	for _, port := range []string{weatherPort, pollenPort, apiPort} {
+		waitForPort(s.t, port)
+	}
+	s.apiURL = "http://localhost:" + apiPort
+}
+
+func (s *stack) start(name string, env ...string) {
+	s.t.Helper()
+	var out bytes.Buffer
+	cmd := exec.Command(filepath.Join(s.bin, name))
+	cmd.Dir = s.dir
+	cmd.Env = append(append([]string{}, s.env...), env...)
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Start(); err != nil {
+		s.t.Fatalf("starting %s: %v", name, err)
+	}
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+	s.t.Cleanup(func() {
+		cmd.Process.Signal(syscall.SIGTERM)
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			cmd.Process.Kill()
+			<-done
+		}
+		if s.t.Failed() {
+			s.t.Logf("%s output:\n%s", name, out.String())
+		}
+	})
+}
+
+// freePort returns a port nothing is listening on. Another process could
+// take it before the service binds it, which a local test run can live with.
+func freePort(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return fmt.Sprint(l.Addr().(*net.TCPAddr).Port)
+}
+
+func waitForPort(t *testing.T, port string) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", "localhost:"+port, time.Second)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("nothing listening on port %s after 30s", port)
+}
+
+// dashboard is the /v1/dashboard payload, as the frontend reads it:
+// protojson field names, RFC 3339 timestamps.
+type dashboard struct {
+	Weather map[string]struct {
+		LocationID           string    `json:"locationId"`
+		LastUpdated          time.Time `json:"lastUpdated"`
+		TempC                float64   `json:"tempC"`
+		HumidityPercent      int       `json:"humidityPercent"`
+		PressureMb           float64   `json:"pressureMb"`
+		PrecipitationPercent int       `json:"precipitationPercent"`
+	} `json:"weather"`
+	Pressure map[string]struct {
+		LocationID  string    `json:"locationId"`
+		LastUpdated time.Time `json:"lastUpdated"`
+		Trend       string    `json:"trend"`
+	} `json:"pressure"`
+	Pollen map[string]struct {
+		LocationID      string    `json:"locationId"`
+		CollectedAt     time.Time `json:"collectedAt"`
+		OverallIndex    int       `json:"overallIndex"`
+		OverallCategory string    `json:"overallCategory"`
+		DominantType    string    `json:"dominantType"`
+		Types           []struct {
+			Code     string `json:"code"`
+			Index    int    `json:"index"`
+			Category string `json:"category"`
+		} `json:"types"`
+	} `json:"pollen"`
+	Forecast map[string]struct {
+		LocationID string    `json:"locationId"`
+		IssuedAt   time.Time `json:"issuedAt"`
+		Points     []struct {
+			ValidTime  time.Time `json:"validTime"`
+			PressureMb float64   `json:"pressureMb"`
+		} `json:"points"`
+	} `json:"forecast"`
+	Alerts map[string][]struct {
+		ID       string  `json:"id"`
+		RuleID   string  `json:"ruleId"`
+		Severity string  `json:"severity"`
+		Value    float64 `json:"value"`
+		Status   string  `json:"status"`
+		Message  string  `json:"message"`
+	} `json:"alerts"`
+	Locations map[string]shared.Location `json:"locations"`
+}
+
+func (s *stack) dashboard() dashboard {
+	s.t.Helper()
+	resp, err := http.Get(s.apiURL + "/v1/dashboard")
+	if err != nil {
+		s.t.Fatalf("GET /v1/dashboard: %v", err)
+	}
+	defer resp.Body.Close()
+	var body bytes.Buffer
+	body.ReadFrom(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		s.t.Fatalf("GET /v1/dashboard: status %d: %s", resp.StatusCode, strings.TrimSpace(body.String()))
+	}
+	var d dashboard
+	if err := json.Unmarshal(body.Bytes(), &d); err != nil {
+		s.t.Fatalf("decoding /v1/dashboard: %v", err)
+	}
+	return d
+}
@@ -0,0 +1,138 @@
+package e2e
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared/fakeupstream"
+)
+
+const forecastHours = 48 // FORECAST_HORIZON_HOURS in newStack
+
+func TestPipeline_Steady(t *testing.T) {
+	s := newStack(t, fakeupstream.ScenarioSteady)
+	started := time.Now()
+	s.collect()
+	s.serve()
+
+	d := s.dashboard()
+	id := testLocation.ID
+
+	if got := d.Locations[id].Name; got != testLocation.Name {
+		t.Errorf("locations[%s].name = %q, want %q", id, got, testLocation.Name)
+	}
+
+	w, ok := d.Weather[id]
+	if !ok {
+		t.Fatalf("weather has no entry for %s: %+v", id, d.Weather)
+	}
+	if w.LastUpdated.Before(started.Add(-time.Second)) {
+		t.Errorf("weather lastUpdated %v predates the run", w.LastUpdated)
+	}
+	if w.PressureMb < 1000 || w.HumidityPercent == 0 || w.PrecipitationPercent != 10 {
+		t.Errorf("weather = %+v, want the fake's readings", w)
+	}
+	if _, ok := d.Pressure[id]; !ok {
+		t.Errorf("pressure has no entry for %s", id)
+	}
+
+	f, ok := d.Forecast[id]
+	if !ok {
+		t.Fatalf("forecast has no entry for %s", id)
+	}
+	if len(f.Points) != forecastHours {
+		t.Errorf("forecast has %d points, want %d", len(f.Points), forecastHours)
+	}
+	// One fake, two collectors, two providers: the steady pressure must
+	// arrive the same by both routes.
+	if len(f.Points) > 0 && f.Points[0].PressureMb != w.PressureMb {
+		t.Errorf("forecast pressure %.1f, current %.1f, want equal under steady", f.Points[0].PressureMb, w.PressureMb)
+	}
+	if n := len(d.Alerts[id]); n != 0 {
+		t.Errorf("steady weather raised %d alerts: %+v", n, d.Alerts[id])
+	}
+
+	p, ok := d.Pollen[id]
+	if !ok {
+		t.Fatalf("pollen has no entry for %s", id)
+	}
+	if p.OverallIndex != 3 || p.OverallCategory != "Moderate" || p.DominantType != "TREE" || len(p.Types) != 3 {
+		t.Errorf("pollen = %+v, want moderate tree pollen across three types", p)
+	}
+}
+
+func TestPipeline_PressureDropRaisesAnAlert(t *testing.T) {
+	s := newStack(t, fakeupstream.ScenarioSteady)
+	s.collect()
+	s.serve()
+	if n := len(s.dashboard().Alerts[testLocation.ID]); n != 0 {
+		t.Fatalf("steady forecast raised %d alerts", n)
+	}
+
+	// The providers read per request, so the next collection shows up
+	// without a restart.
+	if err := s.upstream.SetScenario(fakeupstream.ScenarioPressureDrop); err != nil {
+		t.Fatal(err)
+	}
+	const runs = 4
+	for range runs {
+		s.run("weather-collector")
+	}
+	s.run("forecast-collector")
+
+	d := s.dashboard()
+	alerts := d.Alerts[testLocation.ID]
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1: %+v", len(alerts), alerts)
+	}
+	a := alerts[0]
+	if a.RuleID != "pressure-drop-3h" || a.Severity != "severe" || a.Status != "active" || a.Message == "" {
+		t.Errorf("alert = %+v, want an active severe pressure-drop-3h", a)
+	}
+
+	// The fake drops 3.5 mb per reading after the first, and the forecast
+	// starts at the pressure the readings started from.
+	f := d.Forecast[testLocation.ID]
+	if len(f.Points) == 0 {
+		t.Fatal("no forecast points")
+	}
+	want := f.Points[0].PressureMb - 3.5*(runs-1)
+	if got := d.Weather[testLocation.ID].PressureMb; math.Abs(got-want) > 0.05 {
+		t.Errorf("current pressure %.1f after %d readings, want %.1f", got, runs, want)
+	}
+}
+
+func TestPipeline_MissingHoursAreNotFilled(t *testing.T) {
+	s := newStack(t, fakeupstream.ScenarioMissingHours)
+	s.collect()
+	s.serve()
+
+	f := s.dashboard().Forecast[testLocation.ID]
+	if want := forecastHours - forecastHours/6; len(f.Points) != want {
+		t.Fatalf("forecast has %d points, want %d (every sixth hour missing)", len(f.Points), want)
+	}
+	for i := 1; i < len(f.Points); i++ {
+		if !f.Points[i].ValidTime.After(f.Points[i-1].ValidTime) {
+			t.Fatalf("points out of order at %d: %v then %v", i, f.Points[i-1].ValidTime, f.Points[i].ValidTime)
+		}
+	}
+}
+
+func TestPipeline_CollectorsRetryThroughErrorBursts(t *testing.T) {
+	s := newStack(t, fakeupstream.ScenarioBurst)
+	s.collect() // each fetch sees a 429 and a 503 before it succeeds
+	s.serve()
+
+	d := s.dashboard()
+	id := testLocation.ID
+	if _, ok := d.Weather[id]; !ok {
+		t.Error("weather missing after a burst")
+	}
+	if got := len(d.Forecast[id].Points); got != forecastHours {
+		t.Errorf("forecast has %d points after a burst, want %d", got, forecastHours)
+	}
+	if _, ok := d.Pollen[id]; !ok {
+		t.Error("pollen missing after a burst")
+	}
+}
@@ -0,0 +1,209 @@
+// Command backtest replays archived forecast runs through alert detection
+// and merging with one or more candidate rule sets, and prints, as JSON
+// Lines, how many notifications each would have sent, their lead times and
+// how often its alerts flapped. With -score it also checks each candidate's
+// alerts against the weather_raw observations, as cmd/skill does for the
+// archived ones. It reads whichever backend STORAGE_BACKEND selects.
+//
+//	go run ./cmd/backtest -start 2026-05-01 -rules deployed.json -rules tighter.json
+//	go run ./cmd/backtest -location house-nick -pressure-drop 4,5,6 -pressure-window 3,6 -score
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/service"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+func main() {
+	// Logs go to stderr so the reports themselves can be piped.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	if err := godotenv.Load(); err != nil {
+		slog.Debug("No .env file found, using system environment variables", "error", err)
+	}
+
+	var candidates []service.Candidate
+	project := flag.String("project", os.Getenv("GCP_PROJECT_ID"), "GCP project ID (default $GCP_PROJECT_ID)")
+	location := flag.String("location", "", "only replay this location ID (default every location enabled for forecasts)")
+	start := flag.String("start", "", "inclusive start on issued_at, RFC 3339 or YYYY-MM-DD in UTC (default 30 days before -end)")
+	end := flag.String("end", "", "exclusive end on issued_at, RFC 3339 or YYYY-MM-DD in UTC (default now)")
+	flag.Func("rules", "candidate rules file, as DETECTION_RULES_FILE takes; repeatable", func(path string) error {
+		cfg, err := service.LoadDetectionConfig(path)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, service.Candidate{Name: filepath.Base(path), Detection: cfg})
+		return nil
+	})
+	drops := flag.String("pressure-drop", "", "comma-separated warning drops in mb to sweep the default pressure rule over")
+	windows := flag.String("pressure-window", "", "comma-separated window hours to sweep the default pressure rule over (default 3)")
+	deliveries := flag.Bool("deliveries", false, "include every simulated delivery in the reports")
+	score := flag.Bool("score", false, "score each candidate's alerts against the observations over the same range")
+	flag.Parse()
+
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		fail("Invalid storage backend", "error", err)
+	}
+	if *project == "" && !useSQLite {
+		fail("Missing project: set -project or GCP_PROJECT_ID")
+	}
+	sweep, err := pressureSweep(*drops, *windows)
+	if err != nil {
+		fail("Invalid pressure sweep", "error", err)
+	}
+	candidates = append(candidates, sweep...)
+	if len(candidates) == 0 {
+		candidates = []service.Candidate{{Name: "default", Detection: service.DefaultDetectionConfig()}}
+	}
+	endAt := time.Now()
+	if *end != "" {
+		if endAt, err = parseTime(*end); err != nil {
+			fail("Invalid -end", "error", err)
+		}
+	}
+	startAt := endAt.AddDate(0, 0, -30)
+	if *start != "" {
+		if startAt, err = parseTime(*start); err != nil {
+			fail("Invalid -start", "error", err)
+		}
+	}
+	if !startAt.Before(endAt) {
+		fail("-start must be before -end")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	locations, err := registry.Load(ctx, *project, shared.SourceForecast)
+	if err != nil {
+		fail("Failed to load locations", "error", err)
+	}
+	if *location != "" {
+		locations = only(locations, *location)
+		if len(locations) == 0 {
+			fail("Location is not enabled for forecasts", "location", *location)
+		}
+	}
+
+	reader, err := repository.OpenReader(ctx, *project)
+	if err != nil {
+		fail("Failed to create reader", "error", err)
+	}
+	defer reader.Close()
+
+	// Scoring reads observations a rule window either side of the range, as
+	// cmd/skill does, wide enough for every candidate's rules.
+	var margin time.Duration
+	reports := make([]*service.BacktestReport, len(candidates))
+	for i, c := range candidates {
+		reports[i] = service.NewBacktestReport(c.Name)
+		if *score {
+			reports[i].Skill = service.NewSkillReport(startAt, endAt)
+			margin = max(margin, c.Detection.ScoringMargin())
+		}
+	}
+	for _, loc := range locations {
+		runs, err := reader.ReadRuns(ctx, loc.ID, startAt, endAt)
+		if err != nil {
+			fail("Failed to read forecast runs", "location", loc.ID, "error", err)
+		}
+		slog.Info("Replaying", "location", loc.ID, "runs", len(runs))
+		for i, c := range candidates {
+			reports[i].Replay(loc, runs, c.Detection)
+		}
+		if !*score {
+			continue
+		}
+		observations, err := reader.ReadObservations(ctx, loc.ID, startAt.Add(-margin), endAt.Add(margin))
+		if err != nil {
+			fail("Failed to read observations", "location", loc.ID, "error", err)
+		}
+		for i, c := range candidates {
+			reports[i].Score(loc, observations, c.Detection)
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for _, r := range reports {
+		r.Finish()
+		if !*deliveries {
+			r.Deliveries = nil
+		}
+		if err := enc.Encode(r); err != nil {
+			fail("Failed to write report", "error", err)
+		}
+	}
+	slog.Info("Backtest complete", "start", startAt, "end", endAt, "locations", len(locations), "candidates", len(candidates))
+}
+
+// pressureSweep builds the -pressure-drop × -pressure-window candidates;
+// none when no drops are given.
+func pressureSweep(drops, windows string) ([]service.Candidate, error) {
+	if drops == "" {
+		if windows != "" {
+			return nil, fmt.Errorf("-pressure-window needs -pressure-drop")
+		}
+		return nil, nil
+	}
+	var dropsMb []float64
+	for _, s := range strings.Split(drops, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("drop %q is not a positive number", s)
+		}
+		dropsMb = append(dropsMb, v)
+	}
+	windowHours := []int{service.DefaultDetectionConfig().Rules[0].WindowHours}
+	if windows != "" {
+		windowHours = nil
+		for _, s := range strings.Split(windows, ",") {
+			v, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("window %q is not a positive number of hours", s)
+			}
+			windowHours = append(windowHours, v)
+		}
+	}
+	return service.PressureSweep(dropsMb, windowHours), nil
+}
+
+func only(locations []shared.Location, id string) []shared.Location {
+	for _, l := range locations {
+		if l.ID == id {
+			return []shared.Location{l}
+		}
+	}
+	return nil
+}
+
+// parseTime accepts RFC 3339 or a bare date, read as midnight UTC.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func fail(msg string, args ...any) {
+	slog.Error(msg, args...)
+	fmt.Fprintln(os.Stderr, "Run with -h for usage.")
+	os.Exit(1)
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
@@ -14,7 +15,8 @@ import (
 	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/service"
 	"github.com/nickfang/personal-dashboard/services/shared"
-	"github.com/nickfang/personal-dashboard/services/shared/notify"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
 )
 
 const defaultHorizonHours = 72
@@ -25,58 +27,84 @@ func main() {
 	if err := godotenv.Load(); err != nil {
 		slog.Debug("No .env file found, using system environment variables", "error", err)
 	}
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
 	apiKey := os.Getenv("GOOGLE_MAPS_API_KEY")
 	projectID := os.Getenv("GCP_PROJECT_ID")
-	if apiKey == "" || projectID == "" {
+	if apiKey == "" || (projectID == "" && !useSQLite) {
 		slog.Error("Missing required env vars", "vars", "GOOGLE_MAPS_API_KEY, GCP_PROJECT_ID")
 		os.Exit(1)
 	}
-	detectionCfg := service.DefaultDetectionConfig()
-	detectionCfg.DropThresholdMb = envFloat("PRESSURE_DROP_MB", detectionCfg.DropThresholdMb)
-	detectionCfg.SevereThresholdMb = envFloat("PRESSURE_SEVERE_MB", detectionCfg.SevereThresholdMb)
-	detectionCfg.WindowHours = envInt("PRESSURE_WINDOW_HOURS", detectionCfg.WindowHours)
+	detectionCfg, err := loadDetectionConfig()
+	if err != nil {
+		slog.Error("Invalid detection rules", "error", err)
+		os.Exit(1)
+	}
 	cfg := service.Config{
 		HorizonHours: envInt("FORECAST_HORIZON_HOURS", defaultHorizonHours),
 		Detection:    detectionCfg,
 	}
 
 	ctx := context.Background()
-	writer, err := repository.NewFirestoreWriter(ctx, projectID)
+	locations, err := registry.Load(ctx, projectID, shared.SourceForecast)
 	if err != nil {
-		slog.Error("Failed to create firestore writer", "error", err)
+		slog.Error("Failed to load locations", "error", err)
+		os.Exit(1)
+	}
+	if len(locations) == 0 {
+		slog.Warn("No locations enabled, nothing to collect", "source", shared.SourceForecast)
+		return
+	}
+	if unknown := detectionCfg.UnknownOverrideLocations(locations); len(unknown) > 0 {
+		slog.Warn("Detection overrides name locations that are not enabled for forecasts", "locations", unknown)
+	}
+
+	writer, err := repository.OpenWriter(ctx, projectID)
+	if err != nil {
+		slog.Error("Failed to create writer", "error", err)
 		os.Exit(1)
 	}
 	defer writer.Close()
 
 	httpClient := &http.Client{Timeout: 15 * time.Second}
-	fetcher := api.New(httpClient)
-	collector := service.NewCollectorService(fetcher, writer, newSender(), cfg)
-	if err := collectAll(ctx, apiKey, collector, shared.Locations); err != nil {
+	fetcher := api.NewWithBaseURL(httpClient, os.Getenv("WEATHER_API_BASE_URL"))
+	collector := service.NewCollectorService(fetcher, writer, cfg)
+	if err := collectAll(ctx, apiKey, collector, locations); err != nil {
 		slog.Error("Collection failed", "error", err)
 		os.Exit(1)
 	}
 }
 
-// newSender builds the alert delivery sender from the environment.
+// loadDetectionConfig reads the rule set named by DETECTION_RULES_FILE,
+// falling back to the single default pressure-drop rule when unset.
 //
-// A disabled switch or any missing value yields a NopSender rather than an
-// error: the job has to keep collecting when delivery is unconfigured, which
-// is the normal case in local development.
-func newSender() notify.Sender {
-	if os.Getenv("NOTIFY_ENABLED") != "true" {
-		slog.Info("Alert delivery disabled", "reason", "NOTIFY_ENABLED not true")
-		return notify.NopSender{}
-	}
-	user := os.Getenv("NOTIFY_SMTP_USER")
-	password := os.Getenv("NOTIFY_SMTP_PASSWORD")
-	to := os.Getenv("NOTIFY_EMAIL_TO")
-	if user == "" || password == "" || to == "" {
-		slog.Warn("Alert delivery enabled but not configured, dropping notifications",
-			"vars", "NOTIFY_SMTP_USER, NOTIFY_SMTP_PASSWORD, NOTIFY_EMAIL_TO")
-		return notify.NopSender{}
-	}
-	slog.Info("Alert delivery enabled", "from", user, "to", to)
-	return notify.NewSMTPSender(user, password, to)
+// Unlike the numeric env vars, a bad rules file is fatal rather than
+// defaulted: silently running a different rule set than the one deployed is
+// worse than a failed run that shows up in the logs.
+func loadDetectionConfig() (service.DetectionConfig, error) {
+	path := os.Getenv("DETECTION_RULES_FILE")
+	if path == "" {
+		slog.Info("No rules file configured, using default rules", "var", "DETECTION_RULES_FILE")
+		return service.DefaultDetectionConfig(), nil
+	}
+	cfg, err := service.LoadDetectionConfig(path)
+	if err != nil {
+		return service.DetectionConfig{}, err
+	}
+	ids := make([]string, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		ids[i] = r.ID
+	}
+	overridden := make([]string, 0, len(cfg.Locations))
+	for id := range cfg.Locations {
+		overridden = append(overridden, id)
+	}
+	sort.Strings(overridden)
+	slog.Info("Loaded detection rules", "path", path, "rules", ids, "location_overrides", overridden)
+	return cfg, nil
 }
 
 // envInt reads an integer env var, falling back to a default when unset or invalid.
@@ -93,20 +121,6 @@ func envInt(name string, fallback int) int {
 	return v
 }
 
-// envFloat reads a float env var, falling back to a default when unset or invalid.
-func envFloat(name string, fallback float64) float64 {
-	raw := os.Getenv(name)
-	if raw == "" {
-		return fallback
-	}
-	v, err := strconv.ParseFloat(raw, 64)
-	if err != nil || v <= 0 {
-		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
-		return fallback
-	}
-	return v
-}
-
 func collectAll(ctx context.Context, apiKey string, collector *service.CollectorService, locations []shared.Location) error {
 	if len(locations) == 0 {
 		return fmt.Errorf("no locations provided")
@@ -10,7 +10,6 @@ import (
 	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/service"
 	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/testutil"
 	"github.com/nickfang/personal-dashboard/services/shared"
-	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
 func happyHour() api.ForecastHour {
@@ -51,7 +50,7 @@ var testLocations = []shared.Location{
 }
 
 func TestCollectAll_AllLocationsSucceed(t *testing.T) {
-	collector := service.NewCollectorService(happyFetcher(), happyWriter(), notify.NopSender{}, testConfig())
+	collector := service.NewCollectorService(happyFetcher(), happyWriter(), testConfig())
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err != nil {
@@ -71,7 +70,7 @@ func TestCollectAll_PartialFailure(t *testing.T) {
 		},
 	}
 
-	collector := service.NewCollectorService(fetcher, happyWriter(), notify.NopSender{}, testConfig())
+	collector := service.NewCollectorService(fetcher, happyWriter(), testConfig())
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err != nil {
@@ -80,7 +79,7 @@ func TestCollectAll_PartialFailure(t *testing.T) {
 }
 
 func TestCollectAll_AllLocationsFail(t *testing.T) {
-	collector := service.NewCollectorService(failingFetcher(), happyWriter(), notify.NopSender{}, testConfig())
+	collector := service.NewCollectorService(failingFetcher(), happyWriter(), testConfig())
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err == nil {
@@ -89,7 +88,7 @@ func TestCollectAll_AllLocationsFail(t *testing.T) {
 }
 
 func TestCollectAll_EmptyLocations(t *testing.T) {
-	collector := service.NewCollectorService(happyFetcher(), happyWriter(), notify.NopSender{}, testConfig())
+	collector := service.NewCollectorService(happyFetcher(), happyWriter(), testConfig())
 
 	err := collectAll(context.Background(), "test-key", collector, []shared.Location{})
 	if err == nil {
@@ -97,29 +96,6 @@ func TestCollectAll_EmptyLocations(t *testing.T) {
 	}
 }
 
-func TestEnvFloat(t *testing.T) {
-	tests := []struct {
-		name  string
-		value string
-		want  float64
-	}{
-		{"unset", "", 5.0},
-		{"valid", "7.5", 7.5},
-		{"invalid", "abc", 5.0},
-		{"negative", "-2", 5.0},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.value != "" {
-				t.Setenv("TEST_THRESHOLD", tt.value)
-			}
-			if got := envFloat("TEST_THRESHOLD", 5.0); got != tt.want {
-				t.Errorf("envFloat(%q) = %v, want %v", tt.value, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestEnvInt(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -143,37 +119,21 @@ func TestEnvInt(t *testing.T) {
 	}
 }
 
-func TestNewSender(t *testing.T) {
-	const (
-		user     = "me@gmail.com"
-		password = "app-password"
-		to       = "you@gmail.com"
-	)
-	tests := []struct {
-		name                    string
-		enabled, user, pass, to string
-		wantSMTP                bool
-	}{
-		{name: "unset defaults to no delivery"},
-		{name: "disabled", enabled: "false", user: user, pass: password, to: to},
-		{name: "enabled and configured", enabled: "true", user: user, pass: password, to: to, wantSMTP: true},
-		{name: "missing password", enabled: "true", user: user, to: to},
-		{name: "missing user", enabled: "true", pass: password, to: to},
-		{name: "missing recipient", enabled: "true", user: user, pass: password},
+func TestLoadDetectionConfig(t *testing.T) {
+	t.Setenv("DETECTION_RULES_FILE", "")
+	cfg, err := loadDetectionConfig()
+	if err != nil || len(cfg.Rules) != 1 || cfg.Rules[0].ID != "pressure-drop-3h" {
+		t.Errorf("unset: cfg = %+v, err = %v, want the default rule", cfg, err)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Setenv("NOTIFY_ENABLED", tt.enabled)
-			t.Setenv("NOTIFY_SMTP_USER", tt.user)
-			t.Setenv("NOTIFY_SMTP_PASSWORD", tt.pass)
-			t.Setenv("NOTIFY_EMAIL_TO", tt.to)
-
-			// Anything short of fully configured must degrade to a no-op
-			// sender, not an error: the job still has to collect.
-			_, isSMTP := newSender().(*notify.SMTPSender)
-			if isSMTP != tt.wantSMTP {
-				t.Errorf("newSender() SMTP = %v, want %v", isSMTP, tt.wantSMTP)
-			}
-		})
+
+	t.Setenv("DETECTION_RULES_FILE", "../rules.json")
+	cfg, err = loadDetectionConfig()
+	if err != nil || len(cfg.Rules) < 2 {
+		t.Errorf("shipped file: cfg = %+v, err = %v, want the full rule set", cfg, err)
+	}
+
+	t.Setenv("DETECTION_RULES_FILE", "does-not-exist.json")
+	if _, err := loadDetectionConfig(); err == nil {
+		t.Error("a missing rules file should be an error, not the defaults")
 	}
 }
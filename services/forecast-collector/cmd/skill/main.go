@@ -0,0 +1,155 @@
+// Command skill scores archived pressure alerts against what the barometer
+// then did: for each location and rule, how many alerts were borne out by
+// the weather_raw observations (hits), how many were not (false alarms), how
+// many observed drops no alert covered (misses), and the lead time of the
+// hits that were delivered. It prints one JSON report and reads whichever
+// backend STORAGE_BACKEND selects.
+//
+// Only alerts already archived can be scored, so -end defaults to, and is
+// clamped to, the latest window_start whose alerts have all left
+// forecast_cache. Observations are read a rule window either side of the
+// range so alerts at its edges can be judged; misses are counted only for
+// observed episodes starting inside it.
+//
+// To score a candidate rule set instead of what was deployed, run
+// cmd/backtest with -score.
+//
+//	go run ./cmd/skill -start 2026-05-01 -rules rules.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/service"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+// archiveLag is how long an ended alert can stay in forecast_cache: the
+// collector archives it on its next run, every 6 hours.
+const archiveLag = 6 * time.Hour
+
+func main() {
+	// Logs go to stderr so the report itself can be piped.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	if err := godotenv.Load(); err != nil {
+		slog.Debug("No .env file found, using system environment variables", "error", err)
+	}
+
+	project := flag.String("project", os.Getenv("GCP_PROJECT_ID"), "GCP project ID (default $GCP_PROJECT_ID)")
+	location := flag.String("location", "", "only score this location ID (default every location enabled for forecasts)")
+	start := flag.String("start", "", "inclusive start on window_start, RFC 3339 or YYYY-MM-DD in UTC (default 30 days before -end)")
+	end := flag.String("end", "", "exclusive end on window_start, RFC 3339 or YYYY-MM-DD in UTC (default, and at most, the latest fully archived window_start)")
+	rules := flag.String("rules", os.Getenv("DETECTION_RULES_FILE"), "rules file the alerts were raised under, for thresholds and misses (default $DETECTION_RULES_FILE, else the default rules)")
+	flag.Parse()
+
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		fail("Invalid storage backend", "error", err)
+	}
+	if *project == "" && !useSQLite {
+		fail("Missing project: set -project or GCP_PROJECT_ID")
+	}
+	cfg := service.DefaultDetectionConfig()
+	if *rules != "" {
+		if cfg, err = service.LoadDetectionConfig(*rules); err != nil {
+			fail("Invalid -rules", "error", err)
+		}
+	}
+	margin := cfg.ScoringMargin()
+	settled := time.Now().Add(-margin - archiveLag)
+	endAt := settled
+	if *end != "" {
+		if endAt, err = parseTime(*end); err != nil {
+			fail("Invalid -end", "error", err)
+		}
+		if endAt.After(settled) {
+			slog.Warn("Clamping -end: later alerts may not be archived yet", "end", endAt, "clamped", settled)
+			endAt = settled
+		}
+	}
+	startAt := endAt.AddDate(0, 0, -30)
+	if *start != "" {
+		if startAt, err = parseTime(*start); err != nil {
+			fail("Invalid -start", "error", err)
+		}
+	}
+	if !startAt.Before(endAt) {
+		fail("-start must be before -end")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	locations, err := registry.Load(ctx, *project, shared.SourceForecast)
+	if err != nil {
+		fail("Failed to load locations", "error", err)
+	}
+	if *location != "" {
+		locations = only(locations, *location)
+		if len(locations) == 0 {
+			fail("Location is not enabled for forecasts", "location", *location)
+		}
+	}
+
+	reader, err := repository.OpenReader(ctx, *project)
+	if err != nil {
+		fail("Failed to create reader", "error", err)
+	}
+	defer reader.Close()
+
+	report := service.NewSkillReport(startAt, endAt)
+	for _, loc := range locations {
+		records, err := reader.ReadAlertHistory(ctx, loc.ID, startAt, endAt)
+		if err != nil {
+			fail("Failed to read alert history", "location", loc.ID, "error", err)
+		}
+		observations, err := reader.ReadObservations(ctx, loc.ID, startAt.Add(-margin), endAt.Add(margin))
+		if err != nil {
+			fail("Failed to read observations", "location", loc.ID, "error", err)
+		}
+		slog.Info("Scoring", "location", loc.ID, "alerts", len(records), "observations", len(observations))
+		report.Score(loc, service.AlertsFromHistory(records), observations, cfg)
+	}
+	report.Finish()
+
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		fail("Failed to write report", "error", err)
+	}
+	slog.Info("Scoring complete", "start", startAt, "end", endAt, "locations", len(locations), "alerts", report.Total.Alerts)
+}
+
+func only(locations []shared.Location, id string) []shared.Location {
+	for _, l := range locations {
+		if l.ID == id {
+			return []shared.Location{l}
+		}
+	}
+	return nil
+}
+
+// parseTime accepts RFC 3339 or a bare date, read as midnight UTC.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func fail(msg string, args ...any) {
+	slog.Error(msg, args...)
+	fmt.Fprintln(os.Stderr, "Run with -h for usage.")
+	os.Exit(1)
+}
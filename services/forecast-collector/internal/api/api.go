@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nickfang/personal-dashboard/services/shared"
@@ -25,15 +26,28 @@ type nonRetryable struct{ error }
 // Client fetches hourly forecasts from the Google Weather API.
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
 }
 
-// New creates a new forecast API client.
+// DefaultBaseURL is the real API. WEATHER_API_BASE_URL overrides it, e.g.
+// to point at the fake upstream (services/shared/cmd/fakeupstream).
+const DefaultBaseURL = "https://weather.googleapis.com"
+
+// New creates a new forecast API client against DefaultBaseURL.
 func New(httpApi *http.Client) *Client {
-	return &Client{httpClient: httpApi}
+	return NewWithBaseURL(httpApi, "")
+}
+
+// NewWithBaseURL creates a client against baseURL, or DefaultBaseURL when it
+// is empty.
+func NewWithBaseURL(httpApi *http.Client, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpApi, baseURL: strings.TrimSuffix(baseURL, "/")}
 }
 
 func (c *Client) fetchPage(apiKey string, location shared.Location, horizonHours int, pageToken string) (*forecastHoursResponse, error) {
-	baseUrl := "https://weather.googleapis.com/v1/forecast/hours:lookup"
 	queryParams := url.Values{
 		"location.latitude":  {fmt.Sprintf("%f", location.Lat)},
 		"location.longitude": {fmt.Sprintf("%f", location.Long)},
@@ -43,7 +57,7 @@ func (c *Client) fetchPage(apiKey string, location shared.Location, horizonHours
 	if pageToken != "" {
 		queryParams.Set("pageToken", pageToken)
 	}
-	reqURL := baseUrl + "?" + queryParams.Encode()
+	reqURL := c.baseURL + "/v1/forecast/hours:lookup?" + queryParams.Encode()
 
 	req, err := http.NewRequest("GET", reqURL, nil)
 	if err != nil {
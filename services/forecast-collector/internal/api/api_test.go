@@ -5,11 +5,13 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/fakeupstream"
 )
 
 // roundTripFunc adapts a plain function into an http.RoundTripper.
@@ -245,3 +247,23 @@ func TestFetchPage_MalformedJSONIsNonRetryable(t *testing.T) {
 		t.Errorf("expected nonRetryable error for malformed JSON, got: %v", err)
 	}
 }
+
+func TestFetch_AgainstFakeUpstream(t *testing.T) {
+	srv, err := fakeupstream.New(fakeupstream.ScenarioSteady)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	hours, err := NewWithBaseURL(ts.Client(), ts.URL).Fetch("fake-key", testLocation, 72)
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	if len(hours) != 72 {
+		t.Fatalf("expected 72 hours across pages, got %d", len(hours))
+	}
+	if hours[0].Interval.StartTime.IsZero() || hours[0].AirPressure.MeanSeaLevelMillibars == 0 || hours[0].Precipitation.Probability.Percent == 0 {
+		t.Errorf("fake response decoded with zero fields: %+v", hours[0])
+	}
+}
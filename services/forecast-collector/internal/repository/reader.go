@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+	"google.golang.org/api/iterator"
+)
+
+// ArchiveReader reads the archives back for offline evaluation. The
+// collector itself never reads them; cmd/backtest and cmd/skill do. Every
+// read returns one location's documents oldest first.
+type ArchiveReader interface {
+	// ReadRuns returns the forecast runs issued in [start, end).
+	ReadRuns(ctx context.Context, locationID string, start, end time.Time) ([]ForecastRun, error)
+	// ReadObservations returns the weather_raw observations taken in
+	// [start, end).
+	ReadObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservedPoint, error)
+	// ReadAlertHistory returns the archived alerts whose window starts in
+	// [start, end).
+	ReadAlertHistory(ctx context.Context, locationID string, start, end time.Time) ([]AlertRecord, error)
+}
+
+// ReadCloser is an ArchiveReader that holds a connection.
+type ReadCloser interface {
+	ArchiveReader
+	Close() error
+}
+
+// OpenReader returns the archive reader for cmd/backtest and cmd/skill. The
+// writers implement it, so it opens the same backend OpenWriter does.
+func OpenReader(ctx context.Context, projectID string) (ReadCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteWriter(db), nil
+	}
+	fw, err := NewFirestoreWriter(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+// ReadRuns relies on the forecast_raw (location, issued_at) composite index.
+// Runs older than archive-retention's RETAIN_FORECAST_DAYS have moved to
+// forecast_rollup with most of their points trimmed, so they are not read.
+func (fw *FirestoreWriter) ReadRuns(ctx context.Context, locationID string, start, end time.Time) ([]ForecastRun, error) {
+	query := fw.client.Collection(shared.ForecastRawCollection).
+		Where("location", "==", locationID).
+		Where("issued_at", ">=", start).
+		Where("issued_at", "<", end).
+		OrderBy("issued_at", firestore.Asc)
+	runs, err := readAll[ForecastRun](ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading forecast runs for %s: %w", locationID, err)
+	}
+	return runs, nil
+}
+
+// ReadObservations relies on the weather_raw (location, timestamp) composite
+// index, and like ReadRuns reads only what retention has not yet rolled up.
+func (fw *FirestoreWriter) ReadObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservedPoint, error) {
+	query := fw.client.Collection(shared.WeatherRawCollection).
+		Where("location", "==", locationID).
+		Where("timestamp", ">=", start).
+		Where("timestamp", "<", end).
+		OrderBy("timestamp", firestore.Asc)
+	points, err := readAll[ObservedPoint](ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading observations for %s: %w", locationID, err)
+	}
+	return points, nil
+}
+
+// ReadAlertHistory relies on the alert_history (location, window_start desc)
+// composite index that serves weather-provider's ListAlertHistory, so it
+// reads newest first and reverses.
+func (fw *FirestoreWriter) ReadAlertHistory(ctx context.Context, locationID string, start, end time.Time) ([]AlertRecord, error) {
+	query := fw.client.Collection(shared.AlertHistoryCollection).
+		Where("location", "==", locationID).
+		Where("window_start", ">=", start).
+		Where("window_start", "<", end).
+		OrderBy("window_start", firestore.Desc)
+	records, err := readAll[AlertRecord](ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading alert history for %s: %w", locationID, err)
+	}
+	slices.Reverse(records)
+	return records, nil
+}
+
+// readAll decodes every document a query returns.
+func readAll[T any](ctx context.Context, query firestore.Query) ([]T, error) {
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	var out []T
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var item T
+		if err := doc.DataTo(&item); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", doc.Ref.Path, err)
+		}
+		if d, ok := any(&item).(schema.Document); ok {
+			schema.Read(doc.Ref.ID, d)
+		}
+		out = append(out, item)
+	}
+}
+
+func (sw *SQLiteWriter) ReadRuns(ctx context.Context, locationID string, start, end time.Time) ([]ForecastRun, error) {
+	runs, err := queryRange[ForecastRun](ctx, sw.db, shared.ForecastRawCollection, "issued_at", locationID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("reading forecast runs for %s: %w", locationID, err)
+	}
+	return runs, nil
+}
+
+func (sw *SQLiteWriter) ReadObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservedPoint, error) {
+	points, err := queryRange[ObservedPoint](ctx, sw.db, shared.WeatherRawCollection, "timestamp", locationID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("reading observations for %s: %w", locationID, err)
+	}
+	return points, nil
+}
+
+func (sw *SQLiteWriter) ReadAlertHistory(ctx context.Context, locationID string, start, end time.Time) ([]AlertRecord, error) {
+	records, err := queryRange[AlertRecord](ctx, sw.db, shared.AlertHistoryCollection, "window_start", locationID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("reading alert history for %s: %w", locationID, err)
+	}
+	return records, nil
+}
+
+// queryRange reads one location's documents with field in [start, end),
+// oldest first.
+func queryRange[T any](ctx context.Context, db *docstore.DB, collection, field, locationID string, start, end time.Time) ([]T, error) {
+	docs, err := db.Documents(ctx, docstore.Query{
+		Collection: collection,
+		Where: []docstore.Filter{
+			{Field: "location", Op: "==", Value: locationID},
+			{Field: field, Op: ">=", Value: start},
+			{Field: field, Op: "<", Value: end},
+		},
+		OrderBy: field,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out, err := docstore.DecodeAll[T](docs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		if d, ok := any(&out[i]).(schema.Document); ok {
+			schema.Read(docs[i].ID, d)
+		}
+	}
+	return out, nil
+}
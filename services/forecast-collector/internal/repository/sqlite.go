@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteWriter writes forecast runs, the forecast cache and its alerts to the
+// docstore file, as FirestoreWriter does.
+type SQLiteWriter struct {
+	db *docstore.DB
+}
+
+func NewSQLiteWriter(db *docstore.DB) *SQLiteWriter {
+	return &SQLiteWriter{db: db}
+}
+
+// OpenWriter returns the SQLite writer when docstore selects it, and
+// otherwise connects to weather-log, alongside the observations.
+func OpenWriter(ctx context.Context, projectID string) (WriteCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteWriter(db), nil
+	}
+	fw, err := NewFirestoreWriter(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+func (sw *SQLiteWriter) Close() error {
+	return sw.db.Close()
+}
+
+func (sw *SQLiteWriter) SaveRaw(ctx context.Context, run ForecastRun) error {
+	run.SchemaVersion = schema.Version
+	_, err := sw.db.Add(ctx, shared.ForecastRawCollection, run)
+	return err
+}
+
+// UpdateCache has FirestoreWriter.UpdateCache's semantics: merge sees the
+// stored alerts and its result, with the alerts it prunes archived and its
+// lifecycle events recorded, is written in the same transaction, which holds
+// the write lock throughout.
+func (sw *SQLiteWriter) UpdateCache(ctx context.Context, locationID string, run ForecastRun, merge MergeFunc) ([]shared.Alert, error) {
+	var committed []shared.Alert
+	err := sw.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		var prev ForecastCacheDoc
+		err := tx.Get(shared.ForecastCacheCollection, locationID, &prev)
+		if err != nil && !errors.Is(err, docstore.ErrNotFound) {
+			return fmt.Errorf("reading forecast cache doc: %w", err)
+		}
+		if err == nil {
+			if err := schema.Rewrite(locationID, &prev); err != nil {
+				return err
+			}
+		}
+		merged := merge(prev.Alerts)
+		if err := tx.Set(shared.ForecastCacheCollection, locationID, buildCacheDoc(run, merged)); err != nil {
+			return err
+		}
+		if err := schema.RecordAlertChanges(tx.Set, prev.Alerts, merged, run.IssuedAt); err != nil {
+			return err
+		}
+		committed = merged
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return committed, nil
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+func TestSQLiteWriter_UpdateCacheMergesStoredAlerts(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "forecast.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	run := ForecastRun{Location: "house-nick", IssuedAt: issuedAt}
+	first := shared.Alert{ID: "alert-1", Location: "house-nick", Status: shared.AlertStatusActive, IssuedAt: issuedAt}
+
+	var sawPrev [][]shared.Alert
+	merge := func(next []shared.Alert) MergeFunc {
+		return func(prev []shared.Alert) []shared.Alert {
+			sawPrev = append(sawPrev, prev)
+			return next
+		}
+	}
+
+	if _, err := writer.UpdateCache(ctx, "house-nick", run, merge([]shared.Alert{first})); err != nil {
+		t.Fatalf("first UpdateCache: %v", err)
+	}
+	resolved := first
+	resolved.Status = shared.AlertStatusResolved
+	committed, err := writer.UpdateCache(ctx, "house-nick", run, merge([]shared.Alert{resolved}))
+	if err != nil {
+		t.Fatalf("second UpdateCache: %v", err)
+	}
+
+	if len(sawPrev[0]) != 0 {
+		t.Errorf("first merge saw %v, want no prior alerts", sawPrev[0])
+	}
+	if len(sawPrev[1]) != 1 || sawPrev[1][0].ID != "alert-1" || !sawPrev[1][0].IssuedAt.Equal(issuedAt) {
+		t.Errorf("second merge saw %+v, want the stored alert", sawPrev[1])
+	}
+	if len(committed) != 1 || committed[0].Status != shared.AlertStatusResolved {
+		t.Errorf("committed = %+v, want the resolved alert", committed)
+	}
+
+	var doc ForecastCacheDoc
+	if err := db.Get(ctx, shared.ForecastCacheCollection, "house-nick", &doc); err != nil {
+		t.Fatalf("reading cache: %v", err)
+	}
+	if doc.Location != "house-nick" || len(doc.Alerts) != 1 || doc.Alerts[0].Status != shared.AlertStatusResolved {
+		t.Errorf("stored cache = %+v", doc)
+	}
+}
+
+func TestSQLiteWriter_UpdateCacheArchivesPrunedAlerts(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "forecast.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	notifiedAt := issuedAt.Add(time.Hour)
+	drop := shared.Alert{
+		ID: "alert-1", Location: "house-nick", RuleID: "pressure-drop-3h",
+		Severity: shared.AlertSeveritySevere, Value: -9, Status: shared.AlertStatusResolved,
+		WindowStart: issuedAt.Add(2 * time.Hour), WindowEnd: issuedAt.Add(5 * time.Hour), NotifiedAt: notifiedAt,
+	}
+	keep := func(alerts []shared.Alert) MergeFunc {
+		return func([]shared.Alert) []shared.Alert { return alerts }
+	}
+	run := ForecastRun{Location: "house-nick", IssuedAt: issuedAt}
+	if _, err := writer.UpdateCache(ctx, "house-nick", run, keep([]shared.Alert{drop})); err != nil {
+		t.Fatalf("first UpdateCache: %v", err)
+	}
+	if err := db.Get(ctx, shared.AlertHistoryCollection, "alert-1", &AlertRecord{}); err == nil {
+		t.Fatal("a live alert was archived")
+	}
+
+	run.IssuedAt = issuedAt.Add(6 * time.Hour)
+	if _, err := writer.UpdateCache(ctx, "house-nick", run, keep(nil)); err != nil {
+		t.Fatalf("second UpdateCache: %v", err)
+	}
+	var record AlertRecord
+	if err := db.Get(ctx, shared.AlertHistoryCollection, "alert-1", &record); err != nil {
+		t.Fatalf("reading alert_history/alert-1: %v", err)
+	}
+	if record.Status != shared.AlertStatusResolved || record.PeakSeverity != shared.AlertSeveritySevere || !record.NotifiedAt.Equal(notifiedAt) || !record.ArchivedAt.Equal(run.IssuedAt) {
+		t.Errorf("record = %+v, want the resolved, delivered alert archived at the second run", record)
+	}
+}
+
+func TestSQLiteWriter_UpdateCacheRecordsAlertEvents(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "forecast.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	alert := shared.Alert{
+		ID: "alert-1", Location: "house-nick", RuleID: "pressure-drop-3h", Status: shared.AlertStatusActive,
+		WindowStart: issuedAt.Add(2 * time.Hour), WindowEnd: issuedAt.Add(5 * time.Hour),
+	}
+	resolved := alert
+	resolved.Status = shared.AlertStatusResolved
+	for i, alerts := range [][]shared.Alert{{alert}, {resolved}, nil} {
+		run := ForecastRun{Location: "house-nick", IssuedAt: issuedAt.Add(time.Duration(i) * time.Hour)}
+		if _, err := writer.UpdateCache(ctx, "house-nick", run, func([]shared.Alert) []shared.Alert { return alerts }); err != nil {
+			t.Fatalf("UpdateCache %d: %v", i, err)
+		}
+	}
+
+	docs, err := db.Documents(ctx, docstore.Query{
+		Collection: shared.AlertEventsCollection,
+		Where:      []docstore.Filter{{Field: "alert_id", Op: "==", Value: "alert-1"}},
+		OrderBy:    "at",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := docstore.DecodeAll[AlertEventRecord](docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	want := []string{shared.AlertEventCreated, shared.AlertEventResolved, shared.AlertEventPruned}
+	if !slices.Equal(types, want) {
+		t.Errorf("events = %v, want %v", types, want)
+	}
+}
+
+func TestSQLiteWriter_ReadRuns(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "forecast.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	base := time.Date(2026, 6, 12, 0, 0, 0, 0, time.UTC)
+	for _, run := range []ForecastRun{
+		{Location: "house-nick", IssuedAt: base.Add(12 * time.Hour)},
+		{Location: "house-nick", IssuedAt: base},
+		{Location: "house-nita", IssuedAt: base.Add(6 * time.Hour)},
+		{Location: "house-nick", IssuedAt: base.Add(24 * time.Hour)},
+	} {
+		if err := writer.SaveRaw(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := writer.ReadRuns(ctx, "house-nick", base, base.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || !runs[0].IssuedAt.Equal(base) || !runs[1].IssuedAt.Equal(base.Add(12*time.Hour)) {
+		t.Errorf("runs = %+v, want house-nick's two runs in [start, end), oldest first", runs)
+	}
+}
+
+func TestSQLiteWriter_ReadObservationsAndAlertHistory(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "forecast.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	base := time.Date(2026, 6, 12, 0, 0, 0, 0, time.UTC)
+	for i, p := range []ObservedPoint{
+		{Location: "house-nick", Timestamp: base.Add(time.Hour), PressureMb: 1011},
+		{Location: "house-nick", Timestamp: base, PressureMb: 1013},
+		{Location: "house-nita", Timestamp: base, PressureMb: 1020},
+	} {
+		if err := db.Set(ctx, shared.WeatherRawCollection, fmt.Sprintf("obs-%d", i), p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, a := range []AlertRecord{
+		{ID: "late", Location: "house-nick", WindowStart: base.Add(6 * time.Hour)},
+		{ID: "early", Location: "house-nick", WindowStart: base.Add(time.Hour)},
+		{ID: "after", Location: "house-nick", WindowStart: base.Add(24 * time.Hour)},
+	} {
+		if err := db.Set(ctx, shared.AlertHistoryCollection, a.ID, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	obs, err := writer.ReadObservations(ctx, "house-nick", base, base.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(obs) != 2 || obs[0].PressureMb != 1013 || obs[1].PressureMb != 1011 {
+		t.Errorf("observations = %+v, want house-nick's two, oldest first", obs)
+	}
+	records, err := writer.ReadAlertHistory(ctx, "house-nick", base, base.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].ID != "early" || records[1].ID != "late" {
+		t.Errorf("alert history = %+v, want early then late", records)
+	}
+}
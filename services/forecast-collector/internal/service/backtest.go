@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// Candidate is one rule set under test, named for the report.
+type Candidate struct {
+	Name      string
+	Detection DetectionConfig
+}
+
+// PressureSweep builds one candidate per drop and window pair from the
+// default pressure-drop rule, keeping its severe-at-twice-warning ratio. The
+// rule ID names the window, as a deployed rule's would.
+func PressureSweep(dropsMb []float64, windowHours []int) []Candidate {
+	var candidates []Candidate
+	for _, w := range windowHours {
+		for _, drop := range dropsMb {
+			cfg := DefaultDetectionConfig()
+			r := &cfg.Rules[0]
+			r.ID = fmt.Sprintf("pressure-drop-%dh", w)
+			r.WindowHours = w
+			r.Warning = drop
+			r.Severe = 2 * drop
+			candidates = append(candidates, Candidate{Name: fmt.Sprintf("%s/%gmb", r.ID, drop), Detection: cfg})
+		}
+	}
+	return candidates
+}
+
+// Delivery is one notification a replay would have sent.
+type Delivery struct {
+	AlertID     string    `json:"alert_id"`
+	Location    string    `json:"location"`
+	RuleID      string    `json:"rule_id"`
+	Severity    string    `json:"severity"`
+	Value       float64   `json:"value"`
+	SentAt      time.Time `json:"sent_at"`
+	WindowStart time.Time `json:"window_start"`
+	LeadHours   float64   `json:"lead_hours"`
+	Escalation  bool      `json:"escalation"`
+}
+
+// LeadTimes summarizes how far ahead of each window's start its
+// notifications went out.
+type LeadTimes struct {
+	Min    float64 `json:"min"`
+	Median float64 `json:"median"`
+	Max    float64 `json:"max"`
+}
+
+// BacktestStats counts what a candidate did, overall or for one rule.
+type BacktestStats struct {
+	Alerts        int       `json:"alerts"`        // episodes raised
+	Notifications int       `json:"notifications"` // escalations included
+	Escalations   int       `json:"escalations"`   // re-sends after an alert worsened
+	Flaps         int       `json:"flaps"`         // resolved alerts detected again
+	LeadHours     LeadTimes `json:"lead_hours"`
+
+	leads []float64
+}
+
+// BacktestReport is one candidate's replay across every location fed to it.
+// Call Finish once all runs are replayed.
+type BacktestReport struct {
+	Candidate  string                    `json:"candidate"`
+	Runs       int                       `json:"runs"`
+	Total      BacktestStats             `json:"total"`
+	Rules      map[string]*BacktestStats `json:"rules"`
+	Deliveries []Delivery                `json:"deliveries,omitempty"`
+	Skill      *SkillReport              `json:"skill,omitempty"` // set by Score
+
+	// raised is every alert the replay raised, by location, as last detected
+	// and with NotifiedAt at its first delivery.
+	raised map[string][]shared.Alert
+}
+
+// NewBacktestReport starts an empty report for the named candidate.
+func NewBacktestReport(candidate string) *BacktestReport {
+	return &BacktestReport{Candidate: candidate, Rules: map[string]*BacktestStats{}, raised: map[string][]shared.Alert{}}
+}
+
+// Replay runs one location's archived forecasts through the collector and
+// notifier as deployed: each run, oldest first, goes through DetectAlerts
+// and shared.MergeAlerts against the alerts the previous run left, then
+// every active alert with no NotifiedAt is delivered at the run's IssuedAt.
+//
+// The notifier's observation gate is not simulated — it needs the barometer
+// readings of the day — so these are the notifications the rules would have
+// asked for, not the ones a held forecast would have let through.
+func (r *BacktestReport) Replay(location shared.Location, runs []repository.ForecastRun, cfg DetectionConfig) {
+	runs = slices.Clone(runs)
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].IssuedAt.Before(runs[j].IssuedAt) })
+	cfg = cfg.ForLocation(location.ID)
+
+	var alerts []shared.Alert
+	var order []string
+	latest := map[string]shared.Alert{}
+	firstSent := map[string]time.Time{}
+	for _, run := range runs {
+		r.Runs++
+		detected := DetectAlerts(location, run.Points, cfg, run.IssuedAt)
+		merged := shared.MergeAlerts(alerts, detected, run.IssuedAt)
+		for _, e := range shared.AlertEvents(alerts, merged, run.IssuedAt) {
+			switch e.Type {
+			case shared.AlertEventCreated:
+				r.count(e.Alert.RuleID, func(s *BacktestStats) { s.Alerts++ })
+			case shared.AlertEventReactivated:
+				r.count(e.Alert.RuleID, func(s *BacktestStats) { s.Flaps++ })
+			}
+		}
+		for i, a := range merged {
+			if a.Status != shared.AlertStatusActive || !a.NotifiedAt.IsZero() {
+				continue
+			}
+			r.deliver(a, run.IssuedAt, wasDelivered(alerts, a.ID))
+			merged[i].NotifiedAt = run.IssuedAt
+			if _, ok := firstSent[a.ID]; !ok {
+				firstSent[a.ID] = run.IssuedAt
+			}
+		}
+		for _, a := range merged {
+			if _, ok := latest[a.ID]; !ok {
+				order = append(order, a.ID)
+			}
+			latest[a.ID] = a
+		}
+		alerts = merged
+	}
+	for _, id := range order {
+		a := latest[id]
+		a.NotifiedAt = firstSent[id]
+		r.raised[location.ID] = append(r.raised[location.ID], a)
+	}
+}
+
+// Score checks the alerts Replay raised for the location against its
+// observations, as SkillReport.Score does for archived alerts. Lead times
+// run from each alert's first delivery. Set Skill to a NewSkillReport first
+// to bound the misses to the replayed range; otherwise every observed
+// episode can count.
+func (r *BacktestReport) Score(location shared.Location, observations []repository.ObservedPoint, cfg DetectionConfig) {
+	if r.Skill == nil {
+		r.Skill = NewSkillReport(time.Time{}, time.Time{})
+	}
+	r.Skill.Score(location, r.raised[location.ID], observations, cfg)
+}
+
+// wasDelivered reports whether the alert went out before, so sending it again
+// is an escalation.
+func wasDelivered(prev []shared.Alert, id string) bool {
+	for _, p := range prev {
+		if p.ID == id {
+			return !p.NotifiedAt.IsZero()
+		}
+	}
+	return false
+}
+
+func (r *BacktestReport) deliver(a shared.Alert, at time.Time, escalation bool) {
+	d := Delivery{
+		AlertID:     a.ID,
+		Location:    a.Location,
+		RuleID:      a.RuleID,
+		Severity:    a.Severity,
+		Value:       a.Value,
+		SentAt:      at,
+		WindowStart: a.WindowStart,
+		LeadHours:   a.WindowStart.Sub(at).Hours(),
+		Escalation:  escalation,
+	}
+	r.Deliveries = append(r.Deliveries, d)
+	r.count(a.RuleID, func(s *BacktestStats) {
+		s.Notifications++
+		if escalation {
+			s.Escalations++
+		}
+		s.leads = append(s.leads, d.LeadHours)
+	})
+}
+
+// count applies fn to the totals and to the rule's own stats.
+func (r *BacktestReport) count(ruleID string, fn func(*BacktestStats)) {
+	fn(&r.Total)
+	s, ok := r.Rules[ruleID]
+	if !ok {
+		s = &BacktestStats{}
+		r.Rules[ruleID] = s
+	}
+	fn(s)
+}
+
+// Finish summarizes the lead times.
+func (r *BacktestReport) Finish() {
+	r.Total.LeadHours = summarizeLeads(r.Total.leads)
+	for _, s := range r.Rules {
+		s.LeadHours = summarizeLeads(s.leads)
+	}
+	if r.Skill != nil {
+		r.Skill.Finish()
+	}
+}
+
+func summarizeLeads(leads []float64) LeadTimes {
+	if len(leads) == 0 {
+		return LeadTimes{}
+	}
+	leads = slices.Sorted(slices.Values(leads))
+	median := leads[len(leads)/2]
+	if len(leads)%2 == 0 {
+		median = (leads[len(leads)/2-1] + median) / 2
+	}
+	return LeadTimes{Min: leads[0], Median: median, Max: leads[len(leads)-1]}
+}
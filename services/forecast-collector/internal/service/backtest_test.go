@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
+)
+
+// backtestRun is a run issued at detectStart plus issuedHours, whose points
+// start at the same hour as every other run's so that the drop in pressures
+// describes the same weather from run to run.
+func backtestRun(issuedHours int, pressures ...float64) repository.ForecastRun {
+	return repository.ForecastRun{
+		Location: detectLocation.ID,
+		IssuedAt: detectStart.Add(time.Duration(issuedHours) * time.Hour),
+		Points:   hourlyPoints(pressures...),
+	}
+}
+
+func replay(cfg DetectionConfig, runs ...repository.ForecastRun) *BacktestReport {
+	r := NewBacktestReport("test")
+	r.Replay(detectLocation, runs, cfg)
+	r.Finish()
+	return r
+}
+
+func TestBacktest_RepeatedDetectionNotifiesOnce(t *testing.T) {
+	// The same 6 mb drop, 12h out, forecast by three runs six hours apart.
+	var runs []repository.ForecastRun
+	for i := range 3 {
+		runs = append(runs, backtestRun(-12+6*i, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1011, 1009, 1007, 1007))
+	}
+
+	r := replay(DefaultDetectionConfig(), runs...)
+
+	if r.Runs != 3 || r.Total.Alerts != 1 || r.Total.Notifications != 1 || r.Total.Flaps != 0 {
+		t.Errorf("total = %+v over %d runs, want one alert sent once", r.Total, r.Runs)
+	}
+	if len(r.Deliveries) != 1 || r.Deliveries[0].LeadHours != 24 {
+		t.Errorf("deliveries = %+v, want one sent by the first run, 24h ahead", r.Deliveries)
+	}
+	if s := r.Rules["pressure-drop-3h"]; s == nil || s.Notifications != 1 || s.LeadHours.Median != 24 {
+		t.Errorf("rule stats = %+v", s)
+	}
+}
+
+func TestBacktest_FlapAndEscalation(t *testing.T) {
+	drop := backtestRun(-18, 1013, 1011, 1009, 1007, 1007)
+	none := backtestRun(-12, 1013, 1013, 1013, 1013, 1013)
+	worse := backtestRun(-6, 1013, 1010, 1007, 1004, 1004)
+
+	r := replay(DefaultDetectionConfig(), drop, none, worse)
+
+	if r.Total.Alerts != 1 || r.Total.Flaps != 1 {
+		t.Errorf("alerts = %d, flaps = %d; want one alert that flapped once", r.Total.Alerts, r.Total.Flaps)
+	}
+	if r.Total.Notifications != 2 || r.Total.Escalations != 1 || !r.Deliveries[1].Escalation {
+		t.Errorf("total = %+v, want the worsened re-detection sent again as an escalation", r.Total)
+	}
+	if r.Total.LeadHours.Min != 6 || r.Total.LeadHours.Max != 18 {
+		t.Errorf("lead hours = %+v, want 18h then 6h", r.Total.LeadHours)
+	}
+}
+
+func TestBacktest_ThresholdChangesWhatIsSent(t *testing.T) {
+	run := backtestRun(0, 1013, 1011, 1009, 1007, 1007)
+	sweep := PressureSweep([]float64{5, 8}, []int{3})
+
+	if len(sweep) != 2 || sweep[1].Name != "pressure-drop-3h/8mb" || sweep[1].Detection.Rules[0].Severe != 16 {
+		t.Fatalf("sweep = %+v", sweep)
+	}
+	if got := replay(sweep[0].Detection, run).Total.Notifications; got != 1 {
+		t.Errorf("5 mb candidate sent %d, want 1", got)
+	}
+	if got := replay(sweep[1].Detection, run).Total.Notifications; got != 0 {
+		t.Errorf("8 mb candidate sent %d, want 0 for a 6 mb drop", got)
+	}
+}
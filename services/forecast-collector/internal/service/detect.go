@@ -12,58 +12,46 @@ import (
 // or shifted forecast hour, like the weather-collector's delta search.
 const windowTolerance = 30 * time.Minute
 
-// displayLocation is the timezone used in alert messages. Cloud Run runs in
-// UTC, so the local zone must be loaded explicitly (tzdata in the image).
-var displayLocation = func() *time.Location {
-	loc, err := time.LoadLocation("America/Chicago")
-	if err != nil {
-		return time.UTC
-	}
-	return loc
-}()
-
-// DetectionConfig holds the pressure-drop detection thresholds, sourced from env
-// so they can be tuned without a code change.
-type DetectionConfig struct {
-	DropThresholdMb   float64 // PRESSURE_DROP_MB: warning at this drop per window
-	SevereThresholdMb float64 // PRESSURE_SEVERE_MB: severe at this drop per window
-	WindowHours       int     // PRESSURE_WINDOW_HOURS: detection window length
-}
+// levelSpan is how long one forecast hour's value is taken to hold, so
+// consecutive qualifying hours of a level rule touch and coalesce.
+const levelSpan = time.Hour
 
-// DefaultDetectionConfig matches the values deployed via Terraform.
-func DefaultDetectionConfig() DetectionConfig {
-	return DetectionConfig{DropThresholdMb: 5, SevereThresholdMb: 10, WindowHours: 3}
-}
-
-func (c DetectionConfig) ruleID() string {
-	return fmt.Sprintf("pressure-drop-%dh", c.WindowHours)
-}
-
-// qualifyingWindow is one window position whose pressure delta crossed the
-// drop threshold.
+// qualifyingWindow is one span whose value crossed a rule's warning
+// threshold: a change window, or a single forecast hour for a level rule.
 type qualifyingWindow struct {
 	startIdx, endIdx int
-	delta            float64
+	start, end       time.Time
+	value            float64 // signed delta for change rules, the level itself for level rules
+	magnitude        float64 // value measured in the rule's direction
+}
+
+// DetectAlerts runs every rule in the set over the same forecast points and
+// returns their alerts together. Each rule's alerts carry its ID, so rules
+// never merge into one another even when they describe the same weather.
+// Messages read in the location's own timezone.
+func DetectAlerts(location shared.Location, points []repository.ForecastPoint, cfg DetectionConfig, now time.Time) []shared.Alert {
+	var alerts []shared.Alert
+	for _, r := range cfg.Rules {
+		alerts = append(alerts, detectRule(location, points, r, now)...)
+	}
+	return alerts
 }
 
-// DetectPressureAlerts slides a WindowHours window across the forecast and
-// coalesces overlapping qualifying windows into one alert per continuous
-// drop episode. The alert's Value is the steepest single-window delta and
-// its window spans the whole episode.
-func DetectPressureAlerts(locationID string, points []repository.ForecastPoint, cfg DetectionConfig, now time.Time) []shared.Alert {
-	if len(points) < 2 || cfg.WindowHours <= 0 || cfg.DropThresholdMb <= 0 {
+// detectRule slides the rule's window across the forecast and coalesces
+// overlapping qualifying windows into one alert per continuous episode. The
+// alert's Value is the most extreme single window and its window spans the
+// whole episode.
+func detectRule(location shared.Location, points []repository.ForecastPoint, r Rule, now time.Time) []shared.Alert {
+	m, ok := metrics[r.Metric]
+	if !ok || r.Warning <= 0 {
 		return nil
 	}
 
 	var wins []qualifyingWindow
 	for i := range points {
-		j := pointNearOffset(points, i, time.Duration(cfg.WindowHours)*time.Hour)
-		if j < 0 {
-			continue
-		}
-		delta := points[j].PressureMb - points[i].PressureMb
-		if delta <= -cfg.DropThresholdMb {
-			wins = append(wins, qualifyingWindow{startIdx: i, endIdx: j, delta: delta})
+		w, ok := r.window(points, i, m)
+		if ok && w.magnitude >= r.Warning {
+			wins = append(wins, w)
 		}
 	}
 	if len(wins) == 0 {
@@ -73,18 +61,47 @@ func DetectPressureAlerts(locationID string, points []repository.ForecastPoint,
 	var alerts []shared.Alert
 	episode := []qualifyingWindow{wins[0]}
 	for _, w := range wins[1:] {
-		last := episode[len(episode)-1]
-		if !points[w.startIdx].ValidTime.After(points[last.endIdx].ValidTime) {
+		if !w.start.After(episode[len(episode)-1].end) {
 			episode = append(episode, w)
 			continue
 		}
-		alerts = append(alerts, buildAlert(locationID, points, episode, cfg, now))
+		alerts = append(alerts, buildAlert(location, points, episode, r, m, now))
 		episode = []qualifyingWindow{w}
 	}
-	alerts = append(alerts, buildAlert(locationID, points, episode, cfg, now))
+	alerts = append(alerts, buildAlert(location, points, episode, r, m, now))
 	return alerts
 }
 
+// window measures the rule at points[i]. It reports false when a change
+// rule's window runs past the forecast or over a gap wider than the
+// tolerance.
+func (r Rule) window(points []repository.ForecastPoint, i int, m metric) (qualifyingWindow, bool) {
+	if r.Kind == KindLevel {
+		v := m.value(points[i])
+		return qualifyingWindow{
+			startIdx:  i,
+			endIdx:    i,
+			start:     points[i].ValidTime,
+			end:       points[i].ValidTime.Add(levelSpan),
+			value:     v,
+			magnitude: r.magnitude(v),
+		}, true
+	}
+	j := pointNearOffset(points, i, time.Duration(r.WindowHours)*time.Hour)
+	if j < 0 {
+		return qualifyingWindow{}, false
+	}
+	delta := m.value(points[j]) - m.value(points[i])
+	return qualifyingWindow{
+		startIdx:  i,
+		endIdx:    j,
+		start:     points[i].ValidTime,
+		end:       points[j].ValidTime,
+		value:     delta,
+		magnitude: r.magnitude(delta),
+	}, true
+}
+
 // pointNearOffset finds the index of the point closest to
 // points[i].ValidTime+offset within the tolerance, or -1 if none exists.
 func pointNearOffset(points []repository.ForecastPoint, i int, offset time.Duration) int {
@@ -108,28 +125,32 @@ func pointNearOffset(points []repository.ForecastPoint, i int, offset time.Durat
 }
 
 // buildAlert collapses one episode's qualifying windows into a single Alert.
-func buildAlert(locationID string, points []repository.ForecastPoint, episode []qualifyingWindow, cfg DetectionConfig, now time.Time) shared.Alert {
+func buildAlert(location shared.Location, points []repository.ForecastPoint, episode []qualifyingWindow, r Rule, m metric, now time.Time) shared.Alert {
 	steepest := episode[0]
 	for _, w := range episode[1:] {
-		if w.delta < steepest.delta {
+		if w.magnitude > steepest.magnitude {
 			steepest = w
 		}
 	}
 
 	severity := shared.AlertSeverityWarning
-	if steepest.delta <= -cfg.SevereThresholdMb {
+	if r.Severe > 0 && steepest.magnitude >= r.Severe {
 		severity = shared.AlertSeveritySevere
 	}
 
 	alert := shared.Alert{
-		Location:    locationID,
-		RuleID:      cfg.ruleID(),
+		Location:    location.ID,
+		RuleID:      r.ID,
+		Metric:      r.Metric,
+		Direction:   r.Direction,
+		Label:       m.label,
+		Unit:        m.unit,
 		Severity:    severity,
-		Value:       steepest.delta,
-		Threshold:   cfg.DropThresholdMb,
-		WindowStart: points[episode[0].startIdx].ValidTime,
-		WindowEnd:   points[episode[len(episode)-1].endIdx].ValidTime,
-		Message:     buildMessage(points, steepest, cfg),
+		Value:       steepest.value,
+		Threshold:   r.Warning,
+		WindowStart: episode[0].start,
+		WindowEnd:   episode[len(episode)-1].end,
+		Message:     buildMessage(points, steepest, r, m, location.TimeZone()),
 		Status:      shared.AlertStatusActive,
 		IssuedAt:    now,
 	}
@@ -138,17 +159,23 @@ func buildAlert(locationID string, points []repository.ForecastPoint, episode []
 }
 
 // buildMessage renders the client-facing alert text, anchored on the hour
-// where the steepest drop begins: "Thu 2 PM  -6.2 mb/3h  -8.1/6h". The
-// extended part covers twice the window and is omitted when the forecast
-// horizon doesn't reach that far.
-func buildMessage(points []repository.ForecastPoint, steepest qualifyingWindow, cfg DetectionConfig) string {
+// where the steepest window begins, in zone. Every deployed job runs in UTC,
+// so the zone comes from the location rather than the process.
+//
+// Change rules read "Thu 2 PM  -6.2 mb/3h  -8.1/6h". The extended part covers
+// twice the window and is omitted when the forecast horizon doesn't reach
+// that far. Level rules read "Thu 2 PM  gusts 62 kph".
+func buildMessage(points []repository.ForecastPoint, steepest qualifyingWindow, r Rule, m metric, zone *time.Location) string {
 	anchor := points[steepest.startIdx]
-	msg := fmt.Sprintf("%s  %+.1f mb/%dh",
-		anchor.ValidTime.In(displayLocation).Format("Mon 3 PM"), steepest.delta, cfg.WindowHours)
+	when := anchor.ValidTime.In(zone).Format("Mon 3 PM")
+	if r.Kind == KindLevel {
+		return fmt.Sprintf("%s  %s %.0f%s", when, m.label, steepest.value, m.unit)
+	}
 
-	extendedHours := 2 * cfg.WindowHours
+	msg := fmt.Sprintf("%s  %+.1f%s/%dh", when, steepest.value, m.unit, r.WindowHours)
+	extendedHours := 2 * r.WindowHours
 	if k := pointNearOffset(points, steepest.startIdx, time.Duration(extendedHours)*time.Hour); k >= 0 {
-		extendedDelta := points[k].PressureMb - anchor.PressureMb
+		extendedDelta := m.value(points[k]) - m.value(anchor)
 		msg += fmt.Sprintf("  %+.1f/%dh", extendedDelta, extendedHours)
 	}
 	return msg
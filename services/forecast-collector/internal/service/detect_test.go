@@ -11,6 +11,8 @@ import (
 
 var detectStart = time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
 
+var detectLocation = shared.Location{ID: "house-nick", Timezone: "America/Chicago"}
+
 // hourlyPoints builds one forecast point per hour from the given pressures.
 func hourlyPoints(pressures ...float64) []repository.ForecastPoint {
 	points := make([]repository.ForecastPoint, len(pressures))
@@ -24,7 +26,7 @@ func hourlyPoints(pressures ...float64) []repository.ForecastPoint {
 }
 
 func detect(points []repository.ForecastPoint) []shared.Alert {
-	return DetectPressureAlerts("house-nick", points, DefaultDetectionConfig(), detectStart)
+	return DetectAlerts(detectLocation, points, DefaultDetectionConfig(), detectStart)
 }
 
 func TestDetect_MonotonicDropProducesOneWarning(t *testing.T) {
@@ -52,6 +54,9 @@ func TestDetect_MonotonicDropProducesOneWarning(t *testing.T) {
 	if a.Location != "house-nick" || a.RuleID != "pressure-drop-3h" {
 		t.Errorf("identity fields = %q/%q", a.Location, a.RuleID)
 	}
+	if a.Metric != shared.MetricPressureMb || a.Direction != DirectionDrop || a.Label != "pressure" || a.Unit != " mb" {
+		t.Errorf("rule fields = %q/%q/%q/%q, want the rule's metric, direction, label and unit", a.Metric, a.Direction, a.Label, a.Unit)
+	}
 	if !strings.Contains(a.Message, "-6.0 mb/3h") {
 		t.Errorf("Message = %q, want it to contain the 3h drop", a.Message)
 	}
@@ -64,6 +69,30 @@ func TestDetect_MonotonicDropProducesOneWarning(t *testing.T) {
 	}
 }
 
+func TestDetect_MessageUsesTheLocationZone(t *testing.T) {
+	points := hourlyPoints(1013, 1011, 1009, 1007, 1007, 1007, 1007)
+	tests := []struct {
+		timezone string
+		want     string
+	}{
+		{"America/Los_Angeles", "Thu 11 PM"},
+		{"Europe/London", "Fri 7 AM"},
+		{"", "Fri 6 AM"}, // an unset zone renders UTC
+	}
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			loc := shared.Location{ID: "cabin", Timezone: tt.timezone}
+			alerts := DetectAlerts(loc, points, DefaultDetectionConfig(), detectStart)
+			if len(alerts) != 1 {
+				t.Fatalf("len = %d, want 1", len(alerts))
+			}
+			if !strings.HasPrefix(alerts[0].Message, tt.want+"  ") {
+				t.Errorf("Message = %q, want it anchored at %q", alerts[0].Message, tt.want)
+			}
+		})
+	}
+}
+
 func TestDetect_SmallDropIgnored(t *testing.T) {
 	points := hourlyPoints(1013, 1012.5, 1011.7, 1011, 1011, 1011, 1011)
 
@@ -189,3 +218,110 @@ func TestDetect_ToleratesMissingHour(t *testing.T) {
 		t.Errorf("Value = %v, want -6", alerts[0].Value)
 	}
 }
+
+func TestDetect_RiseRuleFiresOnRisingPressure(t *testing.T) {
+	cfg := DetectionConfig{Rules: []Rule{{
+		ID: "pressure-rise-3h", Kind: KindChange, Metric: "pressure_mb",
+		Direction: DirectionRise, WindowHours: 3, Warning: 5, Severe: 10,
+	}}}
+	points := hourlyPoints(1007, 1009, 1011, 1013, 1013, 1013, 1013)
+
+	alerts := DetectAlerts(detectLocation, points, cfg, detectStart)
+
+	if len(alerts) != 1 {
+		t.Fatalf("len = %d, want 1", len(alerts))
+	}
+	if alerts[0].RuleID != "pressure-rise-3h" || alerts[0].Value != 6 {
+		t.Errorf("alert = %s %v, want pressure-rise-3h +6", alerts[0].RuleID, alerts[0].Value)
+	}
+	if !strings.Contains(alerts[0].Message, "+6.0 mb/3h") {
+		t.Errorf("Message = %q, want the 3h rise", alerts[0].Message)
+	}
+}
+
+func TestDetect_AnyDirectionCatchesBothSwings(t *testing.T) {
+	cfg := DetectionConfig{Rules: []Rule{{
+		ID: "temp-swing-3h", Kind: KindChange, Metric: "temp_c",
+		Direction: DirectionAny, WindowHours: 3, Warning: 8,
+	}}}
+	temps := []float64{20, 17, 14, 11, 11, 11, 11, 11, 11, 14, 17, 20, 20}
+	points := make([]repository.ForecastPoint, len(temps))
+	for i, c := range temps {
+		points[i] = repository.ForecastPoint{ValidTime: detectStart.Add(time.Duration(i) * time.Hour), TempC: c}
+	}
+
+	alerts := DetectAlerts(detectLocation, points, cfg, detectStart)
+
+	if len(alerts) != 2 {
+		t.Fatalf("len = %d, want 2 (a fall and a rise)", len(alerts))
+	}
+	if alerts[0].Value != -9 || alerts[1].Value != 9 {
+		t.Errorf("values = %v, %v, want -9 then +9", alerts[0].Value, alerts[1].Value)
+	}
+	if !strings.Contains(alerts[0].Message, "-9.0 C/3h") {
+		t.Errorf("Message = %q, want the swing in C", alerts[0].Message)
+	}
+}
+
+func TestDetect_LevelRuleCoalescesConsecutiveHours(t *testing.T) {
+	cfg := DetectionConfig{Rules: []Rule{{
+		ID: "wind-gust", Kind: KindLevel, Metric: "wind_gust_kph", Warning: 60, Severe: 90,
+	}}}
+	gusts := []float64{30, 65, 95, 70, 40, 40, 62, 40}
+	points := make([]repository.ForecastPoint, len(gusts))
+	for i, g := range gusts {
+		points[i] = repository.ForecastPoint{ValidTime: detectStart.Add(time.Duration(i) * time.Hour), WindGustKph: g}
+	}
+
+	alerts := DetectAlerts(detectLocation, points, cfg, detectStart)
+
+	if len(alerts) != 2 {
+		t.Fatalf("len = %d, want 2 episodes", len(alerts))
+	}
+	a := alerts[0]
+	if !a.WindowStart.Equal(detectStart.Add(time.Hour)) || !a.WindowEnd.Equal(detectStart.Add(4*time.Hour)) {
+		t.Errorf("episode window = %v–%v, want hours 1–4", a.WindowStart, a.WindowEnd)
+	}
+	if a.Value != 95 || a.Severity != shared.AlertSeveritySevere {
+		t.Errorf("alert = %v %s, want the 95 kph peak as severe", a.Value, a.Severity)
+	}
+	if !strings.Contains(a.Message, "gusts 95 kph") {
+		t.Errorf("Message = %q, want the peak gust", a.Message)
+	}
+	if alerts[1].Severity != shared.AlertSeverityWarning {
+		t.Errorf("second episode Severity = %q, want warning", alerts[1].Severity)
+	}
+}
+
+func TestDetect_ZeroSevereNeverEscalates(t *testing.T) {
+	cfg := DetectionConfig{Rules: []Rule{{
+		ID: "precip-high", Kind: KindLevel, Metric: "precipitation_pct", Warning: 70,
+	}}}
+	points := []repository.ForecastPoint{{ValidTime: detectStart, PrecipitationPercent: 100}}
+
+	alerts := DetectAlerts(detectLocation, points, cfg, detectStart)
+
+	if len(alerts) != 1 || alerts[0].Severity != shared.AlertSeverityWarning {
+		t.Fatalf("alerts = %+v, want one warning", alerts)
+	}
+}
+
+func TestDetect_RulesRaiseSeparateAlerts(t *testing.T) {
+	cfg := DetectionConfig{Rules: []Rule{
+		{ID: "pressure-drop-3h", Kind: KindChange, Metric: "pressure_mb", Direction: DirectionDrop, WindowHours: 3, Warning: 5},
+		{ID: "pressure-drop-6h", Kind: KindChange, Metric: "pressure_mb", Direction: DirectionDrop, WindowHours: 6, Warning: 8},
+	}}
+	points := hourlyPoints(1013, 1011, 1009, 1007, 1005, 1003, 1001, 1001, 1001)
+
+	alerts := DetectAlerts(detectLocation, points, cfg, detectStart)
+
+	if len(alerts) != 2 {
+		t.Fatalf("len = %d, want one alert per rule", len(alerts))
+	}
+	if alerts[0].RuleID != "pressure-drop-3h" || alerts[1].RuleID != "pressure-drop-6h" {
+		t.Errorf("rules = %s, %s", alerts[0].RuleID, alerts[1].RuleID)
+	}
+	if alerts[0].ID == alerts[1].ID {
+		t.Error("alerts from different rules must have distinct IDs")
+	}
+}
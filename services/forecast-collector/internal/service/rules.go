@@ -0,0 +1,246 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// Rule kinds.
+const (
+	// KindChange compares a metric against its value WindowHours later.
+	KindChange = "change"
+	// KindLevel fires on any hour whose metric reaches the threshold.
+	KindLevel = "level"
+)
+
+// Change directions. A drop qualifies on a negative delta, a rise on a
+// positive one, and any on either.
+const (
+	DirectionDrop = shared.AlertDirectionDrop
+	DirectionRise = shared.AlertDirectionRise
+	DirectionAny  = shared.AlertDirectionAny
+)
+
+// metric is one ForecastPoint field a rule can watch, with the suffix used to
+// render it and the name it goes by in alert messages and on the alert, which
+// notify.FromAlert titles and formats it from. Messages stay ASCII (see
+// notify.FromAlert), so temperature is "C" rather than a degree sign.
+type metric struct {
+	unit  string
+	label string
+	value func(repository.ForecastPoint) float64
+}
+
+var metrics = map[string]metric{
+	shared.MetricPressureMb: {unit: " mb", label: "pressure", value: func(p repository.ForecastPoint) float64 { return p.PressureMb }},
+	"temp_c":                {unit: " C", label: "temp", value: func(p repository.ForecastPoint) float64 { return p.TempC }},
+	"wind_gust_kph":         {unit: " kph", label: "gusts", value: func(p repository.ForecastPoint) float64 { return p.WindGustKph }},
+	"precipitation_pct":     {unit: "%", label: "precip", value: func(p repository.ForecastPoint) float64 { return float64(p.PrecipitationPercent) }},
+}
+
+// Rule is one detection rule. Thresholds are magnitudes in the metric's unit:
+// a drop rule with Warning 5 fires on a fall of 5 mb or more.
+//
+// The ID becomes Alert.RuleID, so it is part of every stored alert's identity
+// and must stay stable once deployed; renaming a rule re-raises its open
+// episodes as new alerts.
+type Rule struct {
+	ID          string  `json:"id"`
+	Kind        string  `json:"kind"`
+	Metric      string  `json:"metric"`
+	Direction   string  `json:"direction,omitempty"`    // change rules only
+	WindowHours int     `json:"window_hours,omitempty"` // change rules only
+	Warning     float64 `json:"warning"`
+	Severe      float64 `json:"severe,omitempty"` // zero disables severe
+}
+
+// DetectionConfig is the rule set every forecast run is evaluated against,
+// with optional per-location adjustments keyed by location ID.
+//
+// Overrides live here rather than on shared.Location because they are this
+// service's vocabulary: the other services that range over the locations
+// have no use for a forecast rule ID.
+type DetectionConfig struct {
+	Rules     []Rule                      `json:"rules"`
+	Locations map[string]LocationOverride `json:"locations,omitempty"`
+}
+
+// LocationOverride adjusts the shared rule set for one location: retuning or
+// disabling rules by ID, and adding rules only that location runs.
+type LocationOverride struct {
+	Rules      map[string]RuleOverride `json:"rules,omitempty"`
+	ExtraRules []Rule                  `json:"extra_rules,omitempty"`
+}
+
+// RuleOverride retunes one shared rule for a location. Nil thresholds keep
+// the shared value; a Severe of 0 turns severe off for that location only.
+type RuleOverride struct {
+	Warning  *float64 `json:"warning,omitempty"`
+	Severe   *float64 `json:"severe,omitempty"`
+	Disabled bool     `json:"disabled,omitempty"`
+}
+
+// ForLocation returns the rules to run for one location: the shared set with
+// that location's overrides applied and its extra rules appended. The result
+// carries no overrides of its own.
+func (c DetectionConfig) ForLocation(locationID string) DetectionConfig {
+	o, ok := c.Locations[locationID]
+	if !ok {
+		return DetectionConfig{Rules: c.Rules}
+	}
+	rules := make([]Rule, 0, len(c.Rules)+len(o.ExtraRules))
+	for _, r := range c.Rules {
+		ro, ok := o.Rules[r.ID]
+		if ok && ro.Disabled {
+			continue
+		}
+		if ok && ro.Warning != nil {
+			r.Warning = *ro.Warning
+		}
+		if ok && ro.Severe != nil {
+			r.Severe = *ro.Severe
+		}
+		rules = append(rules, r)
+	}
+	return DetectionConfig{Rules: append(rules, o.ExtraRules...)}
+}
+
+// DefaultDetectionConfig is the original single pressure-drop rule, used when
+// no rules file is configured.
+func DefaultDetectionConfig() DetectionConfig {
+	return DetectionConfig{Rules: []Rule{{
+		ID:          "pressure-drop-3h",
+		Kind:        KindChange,
+		Metric:      "pressure_mb",
+		Direction:   DirectionDrop,
+		WindowHours: 3,
+		Warning:     5,
+		Severe:      10,
+	}}}
+}
+
+// LoadDetectionConfig reads a JSON rule set from path and validates it.
+func LoadDetectionConfig(path string) (DetectionConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return DetectionConfig{}, fmt.Errorf("reading rules file: %w", err)
+	}
+	var cfg DetectionConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return DetectionConfig{}, fmt.Errorf("parsing rules file %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return DetectionConfig{}, fmt.Errorf("rules file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate rejects a rule set that would silently detect nothing or collide
+// in storage. An empty set is allowed: it turns detection off.
+//
+// Overrides are checked against the rules they adjust, so a typo in a rule ID
+// fails the load instead of quietly leaving the shared value in force. Each
+// location's effective rule set is then validated in full. Location IDs are
+// not checked here: the registry changes between runs, and an override
+// outliving its location must not stop collection for the rest (see
+// UnknownOverrideLocations).
+func (c DetectionConfig) Validate() error {
+	if err := validateRules(c.Rules); err != nil {
+		return err
+	}
+	base := make(map[string]bool, len(c.Rules))
+	for _, r := range c.Rules {
+		base[r.ID] = true
+	}
+	for locationID, o := range c.Locations {
+		for id := range o.Rules {
+			if !base[id] {
+				return fmt.Errorf("location %s: override for unknown rule %s", locationID, id)
+			}
+		}
+		if err := validateRules(c.ForLocation(locationID).Rules); err != nil {
+			return fmt.Errorf("location %s: %w", locationID, err)
+		}
+	}
+	return nil
+}
+
+// UnknownOverrideLocations returns, sorted, the override keys that name none
+// of the given locations — usually a location removed from the registry, or
+// a typo.
+func (c DetectionConfig) UnknownOverrideLocations(locations []shared.Location) []string {
+	known := make(map[string]bool, len(locations))
+	for _, l := range locations {
+		known[l.ID] = true
+	}
+	var unknown []string
+	for id := range c.Locations {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func validateRules(rules []Rule) error {
+	seen := make(map[string]bool, len(rules))
+	for i, r := range rules {
+		if r.ID == "" {
+			return fmt.Errorf("rule %d: missing id", i)
+		}
+		if seen[r.ID] {
+			return fmt.Errorf("rule %s: duplicate id", r.ID)
+		}
+		seen[r.ID] = true
+		if _, ok := metrics[r.Metric]; !ok {
+			return fmt.Errorf("rule %s: unknown metric %q", r.ID, r.Metric)
+		}
+		switch r.Kind {
+		case KindChange:
+			if r.WindowHours <= 0 {
+				return fmt.Errorf("rule %s: change rules need a positive window_hours", r.ID)
+			}
+			switch r.Direction {
+			case DirectionDrop, DirectionRise, DirectionAny:
+			default:
+				return fmt.Errorf("rule %s: unknown direction %q", r.ID, r.Direction)
+			}
+		case KindLevel:
+			if r.WindowHours != 0 || r.Direction != "" {
+				return fmt.Errorf("rule %s: level rules take no window_hours or direction", r.ID)
+			}
+		default:
+			return fmt.Errorf("rule %s: unknown kind %q", r.ID, r.Kind)
+		}
+		if r.Warning <= 0 {
+			return fmt.Errorf("rule %s: warning must be positive", r.ID)
+		}
+		if r.Severe != 0 && r.Severe < r.Warning {
+			return fmt.Errorf("rule %s: severe %v is below warning %v", r.ID, r.Severe, r.Warning)
+		}
+	}
+	return nil
+}
+
+// magnitude is how far a value goes in the rule's direction; a negative
+// result never qualifies.
+func (r Rule) magnitude(v float64) float64 {
+	switch {
+	case r.Kind == KindLevel:
+		return v
+	case r.Direction == DirectionDrop:
+		return -v
+	case r.Direction == DirectionRise:
+		return v
+	case v < 0:
+		return -v
+	default:
+		return v
+	}
+}
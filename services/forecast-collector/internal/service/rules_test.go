@@ -0,0 +1,206 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+func TestLoadDetectionConfig_ShippedRulesFile(t *testing.T) {
+	cfg, err := LoadDetectionConfig("../../rules.json")
+	if err != nil {
+		t.Fatalf("shipped rules.json: %v", err)
+	}
+	// The deployed default rule must keep its ID, or every open pressure-drop
+	// alert would be re-raised as a new one on the first run.
+	if len(cfg.Rules) == 0 || cfg.Rules[0] != DefaultDetectionConfig().Rules[0] {
+		t.Errorf("first rule = %+v, want the default pressure-drop-3h rule", cfg.Rules)
+	}
+}
+
+func TestLoadDetectionConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadDetectionConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing file should fail")
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"rules": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDetectionConfig(bad); err == nil {
+		t.Error("malformed JSON should fail")
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"rules": [{"id": "x", "kind": "level", "metric": "humidity", "warning": 1}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDetectionConfig(invalid); err == nil || !strings.Contains(err.Error(), "unknown metric") {
+		t.Errorf("err = %v, want unknown metric", err)
+	}
+}
+
+func TestDetectionConfig_Validate(t *testing.T) {
+	drop := DefaultDetectionConfig().Rules[0]
+	gust := Rule{ID: "wind-gust", Kind: KindLevel, Metric: "wind_gust_kph", Warning: 60}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Rule)
+		rules   func(Rule) []Rule
+		wantErr string
+	}{
+		{name: "valid change rule", mutate: func(r *Rule) {}},
+		{name: "missing id", mutate: func(r *Rule) { r.ID = "" }, wantErr: "missing id"},
+		{name: "unknown kind", mutate: func(r *Rule) { r.Kind = "trend" }, wantErr: "unknown kind"},
+		{name: "unknown metric", mutate: func(r *Rule) { r.Metric = "humidity" }, wantErr: "unknown metric"},
+		{name: "zero window", mutate: func(r *Rule) { r.WindowHours = 0 }, wantErr: "window_hours"},
+		{name: "unknown direction", mutate: func(r *Rule) { r.Direction = "down" }, wantErr: "unknown direction"},
+		{name: "zero warning", mutate: func(r *Rule) { r.Warning = 0 }, wantErr: "warning must be positive"},
+		{name: "severe below warning", mutate: func(r *Rule) { r.Severe = 3 }, wantErr: "below warning"},
+		{name: "severe disabled", mutate: func(r *Rule) { r.Severe = 0 }},
+		{name: "duplicate id", rules: func(r Rule) []Rule { return []Rule{r, r} }, wantErr: "duplicate id"},
+		{name: "valid level rule", rules: func(Rule) []Rule { return []Rule{gust} }},
+		{
+			name: "level rule with window",
+			rules: func(Rule) []Rule {
+				g := gust
+				g.WindowHours = 3
+				return []Rule{g}
+			},
+			wantErr: "level rules take no",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := drop
+			var rules []Rule
+			if tt.rules != nil {
+				rules = tt.rules(r)
+			} else {
+				tt.mutate(&r)
+				rules = []Rule{r}
+			}
+			err := DetectionConfig{Rules: rules}.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDetectionConfig_UnknownOverrideLocations(t *testing.T) {
+	cfg := DefaultDetectionConfig()
+	cfg.Locations = map[string]LocationOverride{"house-nick": {}, "house-bob": {}, "barn": {}}
+	locations := []shared.Location{{ID: "house-nick"}, {ID: "house-nita"}}
+
+	got := cfg.UnknownOverrideLocations(locations)
+	if len(got) != 2 || got[0] != "barn" || got[1] != "house-bob" {
+		t.Errorf("UnknownOverrideLocations() = %v, want [barn house-bob]", got)
+	}
+}
+
+func TestDetectionConfig_EmptyIsValid(t *testing.T) {
+	if err := (DetectionConfig{}).Validate(); err != nil {
+		t.Errorf("empty rule set should disable detection, got %v", err)
+	}
+}
+
+func TestDetectionConfig_ForLocation(t *testing.T) {
+	warning, noSevere := 3.0, 0.0
+	gust := Rule{ID: "wind-gust", Kind: KindLevel, Metric: "wind_gust_kph", Warning: 60}
+	cfg := DetectionConfig{
+		Rules: []Rule{DefaultDetectionConfig().Rules[0], gust},
+		Locations: map[string]LocationOverride{
+			"house-nick": {
+				Rules: map[string]RuleOverride{
+					"pressure-drop-3h": {Warning: &warning, Severe: &noSevere},
+					"wind-gust":        {Disabled: true},
+				},
+				ExtraRules: []Rule{{ID: "precip-high", Kind: KindLevel, Metric: "precipitation_pct", Warning: 50}},
+			},
+		},
+	}
+
+	got := cfg.ForLocation("house-nick").Rules
+	if len(got) != 2 || got[0].ID != "pressure-drop-3h" || got[1].ID != "precip-high" {
+		t.Fatalf("rules = %+v, want retuned pressure-drop-3h then precip-high", got)
+	}
+	if got[0].Warning != 3 || got[0].Severe != 0 {
+		t.Errorf("pressure-drop-3h = %v/%v, want 3/0", got[0].Warning, got[0].Severe)
+	}
+	if got[0].WindowHours != 3 || got[0].Direction != DirectionDrop {
+		t.Error("an override must leave the fields it does not name untouched")
+	}
+
+	other := cfg.ForLocation("house-nita").Rules
+	if len(other) != 2 || other[0].Warning != 5 || other[1].ID != "wind-gust" {
+		t.Errorf("rules = %+v, want the shared set unchanged", other)
+	}
+	if cfg.Rules[0].Warning != 5 {
+		t.Error("ForLocation must not modify the shared rules")
+	}
+}
+
+func TestDetectionConfig_ValidateOverrides(t *testing.T) {
+	low := 2.0
+	tests := []struct {
+		name      string
+		locations map[string]LocationOverride
+		wantErr   string
+	}{
+		{
+			name: "unknown rule",
+			locations: map[string]LocationOverride{"house-nick": {Rules: map[string]RuleOverride{
+				"pressure-drop-6h": {Disabled: true},
+			}}},
+			wantErr: "unknown rule pressure-drop-6h",
+		},
+		{
+			name: "override leaves severe below warning",
+			locations: map[string]LocationOverride{"house-nick": {Rules: map[string]RuleOverride{
+				"pressure-drop-3h": {Severe: &low},
+			}}},
+			wantErr: "location house-nick: rule pressure-drop-3h: severe 2 is below warning 5",
+		},
+		{
+			name: "extra rule collides with a shared one",
+			locations: map[string]LocationOverride{"house-nick": {
+				ExtraRules: []Rule{DefaultDetectionConfig().Rules[0]},
+			}},
+			wantErr: "duplicate id",
+		},
+		{
+			name: "valid override",
+			locations: map[string]LocationOverride{"distribution-hall": {Rules: map[string]RuleOverride{
+				"pressure-drop-3h": {Disabled: true},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultDetectionConfig()
+			cfg.Locations = tt.locations
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
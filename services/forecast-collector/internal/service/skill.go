@@ -0,0 +1,226 @@
+package service
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// SkillStats scores pressure alerts against what the barometer did, overall
+// or for one location and rule.
+type SkillStats struct {
+	Alerts      int `json:"alerts"`
+	Hits        int `json:"hits"`         // the observed change reached the alert's threshold inside its window
+	FalseAlarms int `json:"false_alarms"` // it did not
+	Misses      int `json:"misses"`       // observed episodes no alert of the rule overlapped
+	Unverified  int `json:"unverified"`   // alerts whose window the observations do not cover
+	// LeadHours is from delivery to window start, over the hits that were
+	// delivered.
+	LeadHours LeadTimes `json:"lead_hours"`
+
+	leads []float64
+}
+
+// SkillScore is one location and rule's row in a SkillReport.
+type SkillScore struct {
+	Location string `json:"location"`
+	RuleID   string `json:"rule_id"`
+	SkillStats
+}
+
+// SkillReport scores alerts — archived, or raised by a backtest — across
+// every location fed to it. Call Finish once all locations are scored.
+type SkillReport struct {
+	Total  SkillStats    `json:"total"`
+	Scores []*SkillScore `json:"scores"` // by location, then rule
+
+	byKey      map[[2]string]*SkillScore
+	start, end time.Time
+}
+
+// NewSkillReport starts an empty report over [start, end): only observed
+// episodes starting in that range can count as misses. Observations should
+// reach ScoringMargin past end, so an alert starting just before it can be
+// judged; an episode in that margin belongs to the next range. Zero leaves
+// that side open.
+func NewSkillReport(start, end time.Time) *SkillReport {
+	return &SkillReport{Scores: []*SkillScore{}, byKey: map[[2]string]*SkillScore{}, start: start, end: end}
+}
+
+// ScoringMargin is how far past an alert's window_start the observations
+// must reach to judge it: the longest pressure rule window, plus the
+// tolerance either end of it is matched with.
+func (c DetectionConfig) ScoringMargin() time.Duration {
+	longest := 0
+	rules := slices.Clone(c.Rules)
+	for _, o := range c.Locations {
+		rules = append(rules, o.ExtraRules...)
+	}
+	for _, rule := range rules {
+		if rule.Metric == "pressure_mb" {
+			longest = max(longest, rule.WindowHours)
+		}
+	}
+	return time.Duration(longest)*time.Hour + windowTolerance
+}
+
+// Score checks one location's alerts against its weather_raw observations.
+// Only pressure_mb rules are scored, and each is judged by running it over
+// the observations as detection runs it over a forecast:
+//
+//   - An alert is a hit if the rule, at the alert's own Threshold, qualifies
+//     on the observations inside WindowStart..WindowEnd (widened by the
+//     detection tolerance), and a false alarm if not. Without an observation
+//     near both ends of the window it is unverified.
+//   - Every episode the rule finds in the observations, starting inside the
+//     report's range, that no alert of the rule overlaps is a miss.
+//
+// Lead time is taken from the alert's NotifiedAt; undelivered hits have none.
+func (r *SkillReport) Score(location shared.Location, alerts []shared.Alert, observations []repository.ObservedPoint, cfg DetectionConfig) {
+	points := observedPoints(observations)
+	for _, rule := range cfg.ForLocation(location.ID).Rules {
+		if rule.Metric != "pressure_mb" {
+			continue
+		}
+		var raised []shared.Alert
+		for _, a := range alerts {
+			if a.RuleID == rule.ID {
+				raised = append(raised, a)
+			}
+		}
+		for _, a := range raised {
+			r.count(location.ID, rule.ID, func(s *SkillStats) { s.Alerts++ })
+			verdict := judge(location, points, rule, a)
+			r.count(location.ID, rule.ID, func(s *SkillStats) {
+				switch verdict {
+				case verdictHit:
+					s.Hits++
+					if !a.NotifiedAt.IsZero() {
+						s.leads = append(s.leads, a.WindowStart.Sub(a.NotifiedAt).Hours())
+					}
+				case verdictFalseAlarm:
+					s.FalseAlarms++
+				default:
+					s.Unverified++
+				}
+			})
+		}
+		for _, observed := range detectRule(location, points, rule, time.Time{}) {
+			if !r.inRange(observed.WindowStart) {
+				continue
+			}
+			if !slices.ContainsFunc(raised, func(a shared.Alert) bool { return overlaps(a, observed) }) {
+				r.count(location.ID, rule.ID, func(s *SkillStats) { s.Misses++ })
+			}
+		}
+	}
+}
+
+type verdict int
+
+const (
+	verdictUnverified verdict = iota
+	verdictHit
+	verdictFalseAlarm
+)
+
+// judge runs the rule, at the alert's threshold, over the observations in the
+// alert's window.
+func judge(location shared.Location, points []repository.ForecastPoint, rule Rule, a shared.Alert) verdict {
+	from, to := a.WindowStart.Add(-windowTolerance), a.WindowEnd.Add(windowTolerance)
+	var inWindow []repository.ForecastPoint
+	for _, p := range points {
+		if !p.ValidTime.Before(from) && !p.ValidTime.After(to) {
+			inWindow = append(inWindow, p)
+		}
+	}
+	at := func(p repository.ForecastPoint) time.Time { return p.ValidTime }
+	if shared.NearestIndex(inWindow, at, a.WindowStart, windowTolerance) < 0 || shared.NearestIndex(inWindow, at, a.WindowEnd, windowTolerance) < 0 {
+		return verdictUnverified
+	}
+	if a.Threshold > 0 {
+		rule.Warning = a.Threshold
+	}
+	if len(detectRule(location, inWindow, rule, time.Time{})) > 0 {
+		return verdictHit
+	}
+	return verdictFalseAlarm
+}
+
+// overlaps reports whether an observed episode falls within the alert's
+// window, give or take the detection tolerance.
+func overlaps(a, observed shared.Alert) bool {
+	return !observed.WindowStart.After(a.WindowEnd.Add(windowTolerance)) &&
+		!observed.WindowEnd.Before(a.WindowStart.Add(-windowTolerance))
+}
+
+// observedPoints turns observations into the points detection reads, oldest
+// first. Readings with no pressure are dropped rather than read as 0 mb.
+func observedPoints(observations []repository.ObservedPoint) []repository.ForecastPoint {
+	var points []repository.ForecastPoint
+	for _, o := range observations {
+		if o.PressureMb == 0 {
+			continue
+		}
+		points = append(points, repository.ForecastPoint{ValidTime: o.Timestamp, PressureMb: o.PressureMb})
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].ValidTime.Before(points[j].ValidTime) })
+	return points
+}
+
+// AlertsFromHistory returns archived alerts in the form Score takes.
+// NotifiedAt on an archived alert is its last delivery, so an escalated
+// alert's lead is measured from the escalation.
+func AlertsFromHistory(records []repository.AlertRecord) []shared.Alert {
+	alerts := make([]shared.Alert, len(records))
+	for i, rec := range records {
+		alerts[i] = shared.Alert{
+			ID:          rec.ID,
+			Location:    rec.Location,
+			RuleID:      rec.RuleID,
+			Severity:    rec.Severity,
+			Value:       rec.Value,
+			Threshold:   rec.Threshold,
+			WindowStart: rec.WindowStart,
+			WindowEnd:   rec.WindowEnd,
+			Status:      rec.Status,
+			IssuedAt:    rec.IssuedAt,
+			NotifiedAt:  rec.NotifiedAt,
+		}
+	}
+	return alerts
+}
+
+func (r *SkillReport) inRange(t time.Time) bool {
+	return (r.start.IsZero() || !t.Before(r.start)) && (r.end.IsZero() || t.Before(r.end))
+}
+
+// count applies fn to the totals and to the location and rule's row.
+func (r *SkillReport) count(locationID, ruleID string, fn func(*SkillStats)) {
+	fn(&r.Total)
+	key := [2]string{locationID, ruleID}
+	s, ok := r.byKey[key]
+	if !ok {
+		s = &SkillScore{Location: locationID, RuleID: ruleID}
+		r.byKey[key] = s
+		r.Scores = append(r.Scores, s)
+	}
+	fn(&s.SkillStats)
+}
+
+// Finish orders the rows and summarizes the lead times.
+func (r *SkillReport) Finish() {
+	sort.Slice(r.Scores, func(i, j int) bool {
+		if r.Scores[i].Location != r.Scores[j].Location {
+			return r.Scores[i].Location < r.Scores[j].Location
+		}
+		return r.Scores[i].RuleID < r.Scores[j].RuleID
+	})
+	r.Total.LeadHours = summarizeLeads(r.Total.leads)
+	for _, s := range r.Scores {
+		s.LeadHours = summarizeLeads(s.leads)
+	}
+}
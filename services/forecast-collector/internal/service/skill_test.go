@@ -0,0 +1,132 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// observed builds one observation per hour from detectStart, five minutes
+// past the hour as the weather collector's readings land.
+func observed(pressures ...float64) []repository.ObservedPoint {
+	obs := make([]repository.ObservedPoint, len(pressures))
+	for i, p := range pressures {
+		obs[i] = repository.ObservedPoint{
+			Location:   detectLocation.ID,
+			Timestamp:  detectStart.Add(time.Duration(i)*time.Hour + 5*time.Minute),
+			PressureMb: p,
+		}
+	}
+	return obs
+}
+
+// pressureAlert is a pressure-drop-3h alert over hours [from, to) of
+// detectStart.
+func pressureAlert(from, to int, threshold float64) shared.Alert {
+	a := shared.Alert{
+		Location:    detectLocation.ID,
+		RuleID:      "pressure-drop-3h",
+		Threshold:   threshold,
+		WindowStart: detectStart.Add(time.Duration(from) * time.Hour),
+		WindowEnd:   detectStart.Add(time.Duration(to) * time.Hour),
+	}
+	a.ID = a.ComputeID()
+	return a
+}
+
+func TestSkill_HitFalseAlarmMissAndUnverified(t *testing.T) {
+	// 6 mb drops over hours 0-3 and 10-13, flat otherwise, observed to hour 20.
+	obs := observed(1013, 1011, 1009, 1007, 1007, 1007, 1007, 1007, 1007, 1007, 1007, 1005, 1003, 1001, 1001, 1001, 1001, 1001, 1001, 1001, 1001)
+	hit := pressureAlert(0, 3, 5)
+	hit.NotifiedAt = detectStart.Add(-12 * time.Hour)
+	falseAlarm := pressureAlert(6, 9, 5)
+	notYet := pressureAlert(30, 33, 5)
+
+	r := NewSkillReport(time.Time{}, time.Time{})
+	r.Score(detectLocation, []shared.Alert{hit, falseAlarm, notYet}, obs, DefaultDetectionConfig())
+	r.Finish()
+
+	want := SkillStats{Alerts: 3, Hits: 1, FalseAlarms: 1, Misses: 1, Unverified: 1, LeadHours: LeadTimes{Min: 12, Median: 12, Max: 12}}
+	if got := r.Total; got.Alerts != want.Alerts || got.Hits != want.Hits || got.FalseAlarms != want.FalseAlarms || got.Misses != want.Misses || got.Unverified != want.Unverified || got.LeadHours != want.LeadHours {
+		t.Errorf("total = %+v, want %+v", got, want)
+	}
+	if len(r.Scores) != 1 || r.Scores[0].Location != "house-nick" || r.Scores[0].RuleID != "pressure-drop-3h" || r.Scores[0].Hits != 1 {
+		t.Errorf("scores = %+v, want one house-nick/pressure-drop-3h row", r.Scores)
+	}
+}
+
+func TestSkill_JudgedAtTheAlertsThreshold(t *testing.T) {
+	// A 6 mb drop against an alert raised under an 8 mb override.
+	obs := observed(1013, 1011, 1009, 1007, 1007)
+
+	r := NewSkillReport(time.Time{}, time.Time{})
+	r.Score(detectLocation, []shared.Alert{pressureAlert(0, 3, 8)}, obs, DefaultDetectionConfig())
+	r.Finish()
+
+	if r.Total.FalseAlarms != 1 || r.Total.Hits != 0 {
+		t.Errorf("total = %+v, want a false alarm at the alert's own threshold", r.Total)
+	}
+}
+
+func TestSkill_SkipsRulesNotOnPressure(t *testing.T) {
+	cfg := DetectionConfig{Rules: []Rule{{ID: "wind-gust", Kind: KindLevel, Metric: "wind_gust_kph", Warning: 60}}}
+	gust := pressureAlert(0, 3, 60)
+	gust.RuleID = "wind-gust"
+
+	r := NewSkillReport(time.Time{}, time.Time{})
+	r.Score(detectLocation, []shared.Alert{gust}, observed(1013, 1011, 1009, 1007), cfg)
+	r.Finish()
+
+	if r.Total.Alerts != 0 || len(r.Scores) != 0 {
+		t.Errorf("report = %+v, want gust alerts left unscored", r)
+	}
+}
+
+func TestBacktest_ScoreLeadFromFirstDelivery(t *testing.T) {
+	drop := backtestRun(-18, 1013, 1011, 1009, 1007, 1007)
+	none := backtestRun(-12, 1013, 1013, 1013, 1013, 1013)
+	worse := backtestRun(-6, 1013, 1010, 1007, 1004, 1004)
+
+	r := NewBacktestReport("test")
+	r.Replay(detectLocation, []repository.ForecastRun{drop, none, worse}, DefaultDetectionConfig())
+	r.Score(detectLocation, observed(1013, 1010, 1007, 1004, 1004), DefaultDetectionConfig())
+	r.Finish()
+
+	if r.Skill == nil || r.Skill.Total.Hits != 1 || r.Skill.Total.LeadHours.Max != 18 {
+		t.Errorf("skill = %+v, want one hit led from the first delivery, 18h ahead", r.Skill)
+	}
+}
+
+func TestSkill_RangeEdges(t *testing.T) {
+	// A drop over hours 8-11, alerted and archived, and another over hours
+	// 13-16 whose alert is still live in forecast_cache, past the range's end
+	// at hour 10. Observations reach past the end, as cmd/skill reads them.
+	obs := observed(1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1013, 1011, 1009, 1007, 1007, 1007, 1005, 1003, 1001)
+	archived := []shared.Alert{pressureAlert(8, 11, 5)}
+
+	r := NewSkillReport(detectStart, detectStart.Add(10*time.Hour))
+	r.Score(detectLocation, archived, obs, DefaultDetectionConfig())
+	r.Finish()
+	if got := r.Total; got.Alerts != 1 || got.Hits != 1 || got.Unverified != 0 || got.Misses != 0 {
+		t.Errorf("total = %+v, want the edge alert judged a hit and the live drop not a miss", got)
+	}
+
+	unbounded := NewSkillReport(time.Time{}, time.Time{})
+	unbounded.Score(detectLocation, archived, obs, DefaultDetectionConfig())
+	if unbounded.Total.Misses != 1 {
+		t.Errorf("unbounded misses = %d, want the live drop counted", unbounded.Total.Misses)
+	}
+}
+
+func TestDetectionConfig_ScoringMargin(t *testing.T) {
+	cfg := DefaultDetectionConfig()
+	cfg.Locations = map[string]LocationOverride{"cabin": {ExtraRules: []Rule{
+		{ID: "pressure-drop-12h", Kind: KindChange, Metric: "pressure_mb", Direction: "drop", WindowHours: 12, Warning: 8},
+		{ID: "temp-swing-24h", Kind: KindChange, Metric: "temp_c", WindowHours: 24, Warning: 10},
+	}}}
+	if got, want := cfg.ScoringMargin(), 12*time.Hour+windowTolerance; got != want {
+		t.Errorf("ScoringMargin() = %v, want %v from the longest pressure rule", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/service"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+func main() {
+	shared.InitLogging()
+
+	if err := godotenv.Load(); err != nil {
+		slog.Debug("No .env file found, using system environment variables", "error", err)
+	}
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
+	projectID := os.Getenv("GCP_PROJECT_ID")
+	if projectID == "" && !useSQLite {
+		slog.Error("Missing required env vars", "vars", "GCP_PROJECT_ID")
+		os.Exit(1)
+	}
+	cfg := service.DefaultScoreConfig()
+	cfg.WindowDays = envInt("VERIFY_WINDOW_DAYS", cfg.WindowDays)
+	cfg.MaxLeadHours = envInt("VERIFY_MAX_LEAD_HOURS", cfg.MaxLeadHours)
+
+	ctx := context.Background()
+	locations, err := registry.Load(ctx, projectID, shared.SourceForecast)
+	if err != nil {
+		slog.Error("Failed to load locations", "error", err)
+		os.Exit(1)
+	}
+	if len(locations) == 0 {
+		slog.Warn("No locations enabled, nothing to verify", "source", shared.SourceForecast)
+		return
+	}
+
+	store, err := repository.OpenStore(ctx, projectID)
+	if err != nil {
+		slog.Error("Failed to create store", "error", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	// Pinned once so every location is scored over the same window.
+	now := time.Now()
+
+	verifier := service.NewVerifierService(store, cfg)
+	if err := verifyAll(ctx, verifier, locations, now); err != nil {
+		slog.Error("Verification failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// envInt reads an integer env var, falling back to a default when unset or invalid.
+func envInt(name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+		return fallback
+	}
+	return v
+}
+
+// verifyAll is partial-failure tolerant, matching the collectors: a location
+// that cannot be scored is logged and skipped, and the run only fails when
+// every location does.
+func verifyAll(ctx context.Context, verifier *service.VerifierService, locations []shared.Location, now time.Time) error {
+	if len(locations) == 0 {
+		return fmt.Errorf("no locations provided")
+	}
+	successCount := 0
+	for _, loc := range locations {
+		if _, err := verifier.Verify(ctx, loc, now); err != nil {
+			slog.Error("Failed to verify location", "location", loc.ID, "error", err)
+			continue
+		}
+		successCount++
+	}
+	if successCount == 0 {
+		return fmt.Errorf("all %d locations failed", len(locations))
+	}
+	slog.Info("Verification complete", "succeeded", successCount, "total", len(locations))
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/service"
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/testutil"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+var testLocations = []shared.Location{
+	{ID: "house-nick", Lat: 30.0, Long: -97.0},
+	{ID: "house-nita", Lat: 31.0, Long: -98.0},
+}
+
+func TestVerifyAll_AllLocationsSucceed(t *testing.T) {
+	store := &testutil.MockStore{}
+	verifier := service.NewVerifierService(store, service.DefaultScoreConfig())
+
+	if err := verifyAll(context.Background(), verifier, testLocations, time.Now()); err != nil {
+		t.Fatalf("verifyAll() returned error: %v", err)
+	}
+	if len(store.Saved) != 2 {
+		t.Errorf("saved %d docs, want one per location", len(store.Saved))
+	}
+}
+
+func TestVerifyAll_PartialFailureContinues(t *testing.T) {
+	calls := 0
+	store := &testutil.MockStore{
+		ReadRunsFn: func(ctx context.Context, id string, start, end time.Time) ([]repository.ForecastRun, error) {
+			calls++
+			if calls == 1 {
+				return nil, fmt.Errorf("firestore unavailable")
+			}
+			return nil, nil
+		},
+	}
+	verifier := service.NewVerifierService(store, service.DefaultScoreConfig())
+
+	if err := verifyAll(context.Background(), verifier, testLocations, time.Now()); err != nil {
+		t.Fatalf("verifyAll() should succeed with partial failures, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("read %d locations, want 2 — a failure must not stop the loop", calls)
+	}
+}
+
+func TestVerifyAll_AllFail(t *testing.T) {
+	store := &testutil.MockStore{SaveErr: fmt.Errorf("permission denied")}
+	verifier := service.NewVerifierService(store, service.DefaultScoreConfig())
+
+	if err := verifyAll(context.Background(), verifier, testLocations, time.Now()); err == nil {
+		t.Fatal("verifyAll() should fail when every location fails")
+	}
+}
+
+func TestVerifyAll_NoLocations(t *testing.T) {
+	verifier := service.NewVerifierService(&testutil.MockStore{}, service.DefaultScoreConfig())
+
+	if err := verifyAll(context.Background(), verifier, nil, time.Now()); err == nil {
+		t.Fatal("verifyAll() should fail with no locations")
+	}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteStore reads the forecast and observation archives, and writes the
+// accuracy records, in the docstore file, as FirestoreStore does.
+type SQLiteStore struct {
+	db *docstore.DB
+}
+
+func NewSQLiteStore(db *docstore.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// OpenStore returns the SQLite store when docstore selects it, and otherwise
+// connects to weather-log, which holds both the forecasts and the
+// observations they are verified against.
+func OpenStore(ctx context.Context, projectID string) (StoreCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteStore(db), nil
+	}
+	fs, err := NewFirestoreStore(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) ReadRuns(ctx context.Context, locationID string, start, end time.Time) ([]ForecastRun, error) {
+	runs, err := queryRange[ForecastRun](ctx, s.db, shared.ForecastRawCollection, "issued_at", locationID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("reading forecast runs for %s: %w", locationID, err)
+	}
+	return runs, nil
+}
+
+func (s *SQLiteStore) ReadObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservedPoint, error) {
+	points, err := queryRange[ObservedPoint](ctx, s.db, shared.WeatherRawCollection, "timestamp", locationID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("reading observations for %s: %w", locationID, err)
+	}
+	return points, nil
+}
+
+func (s *SQLiteStore) SaveAccuracy(ctx context.Context, doc AccuracyDoc) error {
+	return s.db.Set(ctx, shared.ForecastAccuracyCollection, doc.Location, doc)
+}
+
+// queryRange reads one location's documents with field in [start, end),
+// oldest first — the same query FirestoreStore runs against its indexes.
+func queryRange[T any](ctx context.Context, db *docstore.DB, collection, field, locationID string, start, end time.Time) ([]T, error) {
+	docs, err := db.Documents(ctx, docstore.Query{
+		Collection: collection,
+		Where: []docstore.Filter{
+			{Field: "location", Op: "==", Value: locationID},
+			{Field: field, Op: ">=", Value: start},
+			{Field: field, Op: "<", Value: end},
+		},
+		OrderBy: field,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out, err := docstore.DecodeAll[T](docs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		if d, ok := any(&out[i]).(schema.Document); ok {
+			schema.Read(docs[i].ID, d)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+	"google.golang.org/api/iterator"
+)
+
+// Store reads the two append-only archives and writes the accuracy scores
+// derived from them. Both reads return documents oldest first, which
+// shared.NearestIndex requires.
+type Store interface {
+	ReadRuns(ctx context.Context, locationID string, start, end time.Time) ([]ForecastRun, error)
+	ReadObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservedPoint, error)
+	SaveAccuracy(ctx context.Context, doc AccuracyDoc) error
+}
+
+// StoreCloser is a Store that holds a connection.
+type StoreCloser interface {
+	Store
+	Close() error
+}
+
+type FirestoreStore struct {
+	client *firestore.Client
+}
+
+// NewFirestoreStore connects to the weather database, which holds forecast_raw,
+// weather_raw and forecast_accuracy.
+func NewFirestoreStore(ctx context.Context, projectID string) (*FirestoreStore, error) {
+	client, err := firestore.NewClientWithDatabase(ctx, projectID, shared.WeatherDatabaseID)
+	if err != nil {
+		return nil, err
+	}
+	return &FirestoreStore{client: client}, nil
+}
+
+func (s *FirestoreStore) Close() error {
+	return s.client.Close()
+}
+
+// ReadRuns returns the runs issued in [start, end). It relies on the
+// forecast_raw (location, issued_at) composite index.
+func (s *FirestoreStore) ReadRuns(ctx context.Context, locationID string, start, end time.Time) ([]ForecastRun, error) {
+	query := s.client.Collection(shared.ForecastRawCollection).
+		Where("location", "==", locationID).
+		Where("issued_at", ">=", start).
+		Where("issued_at", "<", end).
+		OrderBy("issued_at", firestore.Asc)
+	runs, err := readAll[ForecastRun](ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading forecast runs for %s: %w", locationID, err)
+	}
+	return runs, nil
+}
+
+// ReadObservations returns the observations taken in [start, end). It relies
+// on the weather_raw (location, timestamp) composite index.
+func (s *FirestoreStore) ReadObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservedPoint, error) {
+	query := s.client.Collection(shared.WeatherRawCollection).
+		Where("location", "==", locationID).
+		Where("timestamp", ">=", start).
+		Where("timestamp", "<", end).
+		OrderBy("timestamp", firestore.Asc)
+	points, err := readAll[ObservedPoint](ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading observations for %s: %w", locationID, err)
+	}
+	return points, nil
+}
+
+func (s *FirestoreStore) SaveAccuracy(ctx context.Context, doc AccuracyDoc) error {
+	_, err := s.client.Collection(shared.ForecastAccuracyCollection).Doc(doc.Location).Set(ctx, doc)
+	return err
+}
+
+// readAll decodes every document a query returns. The verification window
+// bounds the result: a month of 6-hourly runs is ~120 documents per location.
+func readAll[T any](ctx context.Context, query firestore.Query) ([]T, error) {
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	var out []T
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var item T
+		if err := doc.DataTo(&item); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", doc.Ref.Path, err)
+		}
+		if d, ok := any(&item).(schema.Document); ok {
+			schema.Read(doc.Ref.ID, d)
+		}
+		out = append(out, item)
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// The archives this job scores, forecast_raw and weather_raw, are defined
+// once in shared/schema. ObservedPoint is a weather_raw observation.
+type (
+	ForecastPoint = schema.ForecastPoint
+	ForecastRun   = schema.ForecastRun
+	ObservedPoint = schema.WeatherPoint
+)
+
+// ErrorStats summarises forecast minus observed over Count matched pairs. A
+// positive Bias means the forecast ran high.
+type ErrorStats struct {
+	Count int     `firestore:"count"`
+	Bias  float64 `firestore:"bias"`
+	MAE   float64 `firestore:"mae"`
+	RMSE  float64 `firestore:"rmse"`
+}
+
+// LeadScore is the accuracy of every run's forecast for one lead time.
+type LeadScore struct {
+	LeadHours  int        `firestore:"lead_hours"`
+	PressureMb ErrorStats `firestore:"pressure_mb"`
+	TempC      ErrorStats `firestore:"temp_c"`
+}
+
+// AccuracyDoc is stored at forecast_accuracy/{locationID} and fully replaced
+// on each run. It scores every run issued in [WindowStart, WindowEnd).
+type AccuracyDoc struct {
+	Location    string      `firestore:"location"`
+	ComputedAt  time.Time   `firestore:"computed_at"`
+	WindowStart time.Time   `firestore:"window_start"`
+	WindowEnd   time.Time   `firestore:"window_end"`
+	Runs        int         `firestore:"runs"`
+	Leads       []LeadScore `firestore:"leads"` // Ascending by LeadHours; leads with no matched pairs are omitted
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// matchTolerance is how far a forecast point may sit from the requested lead,
+// and an observation from the forecast point, and still be paired. This is
+// detection's 30 minutes rather than the display paths' 45: a score averaged
+// over pairs taken up to 45 minutes apart would blur the very lead times it
+// is meant to separate.
+const matchTolerance = 30 * time.Minute
+
+// ScoreConfig holds the scoring parameters, sourced from env.
+type ScoreConfig struct {
+	MaxLeadHours int // VERIFY_MAX_LEAD_HOURS: score leads 1h..MaxLeadHours
+	WindowDays   int // VERIFY_WINDOW_DAYS: score runs issued this many days back
+}
+
+// DefaultScoreConfig covers forecast-collector's full 72h horizon over the
+// last 30 days.
+func DefaultScoreConfig() ScoreConfig {
+	return ScoreConfig{MaxLeadHours: 72, WindowDays: 30}
+}
+
+// errorAccumulator sums forecast-minus-observed errors for one variable.
+type errorAccumulator struct {
+	n                  int
+	sum, sumAbs, sumSq float64
+}
+
+func (a *errorAccumulator) add(forecast, observed float64) {
+	e := forecast - observed
+	a.n++
+	a.sum += e
+	a.sumAbs += math.Abs(e)
+	a.sumSq += e * e
+}
+
+func (a errorAccumulator) stats() repository.ErrorStats {
+	if a.n == 0 {
+		return repository.ErrorStats{}
+	}
+	n := float64(a.n)
+	return repository.ErrorStats{
+		Count: a.n,
+		Bias:  a.sum / n,
+		MAE:   a.sumAbs / n,
+		RMSE:  math.Sqrt(a.sumSq / n),
+	}
+}
+
+// ScoreRuns pairs every run's forecast at each lead 1h..MaxLeadHours with the
+// observation nearest its valid time and returns bias, MAE and RMSE per lead
+// for pressure and temperature.
+//
+// Both inputs must be ordered oldest first. A lead is skipped for a run when
+// the run has no point near it or no observation was taken near that point —
+// for recent runs, the later leads simply have not happened yet. Leads with
+// no pairs at all are omitted from the result.
+func ScoreRuns(runs []repository.ForecastRun, observations []repository.ObservedPoint, maxLeadHours int) []repository.LeadScore {
+	if maxLeadHours <= 0 {
+		return nil
+	}
+	pressure := make([]errorAccumulator, maxLeadHours+1)
+	temp := make([]errorAccumulator, maxLeadHours+1)
+
+	pointTime := func(p repository.ForecastPoint) time.Time { return p.ValidTime }
+	observedTime := func(o repository.ObservedPoint) time.Time { return o.Timestamp }
+
+	for _, run := range runs {
+		for lead := 1; lead <= maxLeadHours; lead++ {
+			target := run.IssuedAt.Add(time.Duration(lead) * time.Hour)
+			pi := shared.NearestIndex(run.Points, pointTime, target, matchTolerance)
+			if pi < 0 {
+				continue
+			}
+			point := run.Points[pi]
+			oi := shared.NearestIndex(observations, observedTime, point.ValidTime, matchTolerance)
+			if oi < 0 {
+				continue
+			}
+			observed := observations[oi]
+			pressure[lead].add(point.PressureMb, observed.PressureMb)
+			temp[lead].add(point.TempC, observed.TempC)
+		}
+	}
+
+	var scores []repository.LeadScore
+	for lead := 1; lead <= maxLeadHours; lead++ {
+		if pressure[lead].n == 0 {
+			continue
+		}
+		scores = append(scores, repository.LeadScore{
+			LeadHours:  lead,
+			PressureMb: pressure[lead].stats(),
+			TempC:      temp[lead].stats(),
+		})
+	}
+	return scores
+}
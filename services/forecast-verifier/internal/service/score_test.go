@@ -0,0 +1,116 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/repository"
+)
+
+var issued = time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)
+
+// hourlyRun builds a run whose points start at the issue hour, with pressure
+// and temperature given per lead.
+func hourlyRun(at time.Time, pressure, temp []float64) repository.ForecastRun {
+	run := repository.ForecastRun{Location: "house-nick", IssuedAt: at}
+	for i := range pressure {
+		run.Points = append(run.Points, repository.ForecastPoint{
+			ValidTime:  at.Add(time.Duration(i) * time.Hour),
+			PressureMb: pressure[i],
+			TempC:      temp[i],
+		})
+	}
+	return run
+}
+
+// hourlyObs builds observations on the hour from start.
+func hourlyObs(start time.Time, pressure, temp []float64) []repository.ObservedPoint {
+	var out []repository.ObservedPoint
+	for i := range pressure {
+		out = append(out, repository.ObservedPoint{
+			Timestamp:  start.Add(time.Duration(i) * time.Hour),
+			PressureMb: pressure[i],
+			TempC:      temp[i],
+		})
+	}
+	return out
+}
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestScoreRuns_StatsPerLead(t *testing.T) {
+	// Two runs, six hours apart, both scored at lead 1h and 2h.
+	runs := []repository.ForecastRun{
+		hourlyRun(issued, []float64{1010, 1012, 1009}, []float64{20, 21, 22}),
+		hourlyRun(issued.Add(6*time.Hour), []float64{1010, 1008, 1010}, []float64{20, 19, 22}),
+	}
+	obs := hourlyObs(issued, []float64{1010, 1010, 1010, 0, 0, 0, 1010, 1010, 1010}, []float64{20, 20, 20, 0, 0, 0, 20, 20, 20})
+
+	scores := ScoreRuns(runs, obs, 2)
+
+	if len(scores) != 2 {
+		t.Fatalf("got %d leads, want 2: %+v", len(scores), scores)
+	}
+	lead1 := scores[0]
+	if lead1.LeadHours != 1 {
+		t.Fatalf("scores[0].LeadHours = %d, want 1", lead1.LeadHours)
+	}
+	// Pressure errors at 1h: +2 and -2.
+	p := lead1.PressureMb
+	if p.Count != 2 || !approx(p.Bias, 0) || !approx(p.MAE, 2) || !approx(p.RMSE, 2) {
+		t.Errorf("1h pressure = %+v, want n=2 bias=0 mae=2 rmse=2", p)
+	}
+	// Temperature errors at 1h: +1 and -1.
+	if tc := lead1.TempC; tc.Count != 2 || !approx(tc.Bias, 0) || !approx(tc.MAE, 1) {
+		t.Errorf("1h temp = %+v, want n=2 bias=0 mae=1", tc)
+	}
+	// Pressure errors at 2h: -1 and 0.
+	p2 := scores[1].PressureMb
+	if !approx(p2.Bias, -0.5) || !approx(p2.MAE, 0.5) || !approx(p2.RMSE, math.Sqrt(0.5)) {
+		t.Errorf("2h pressure = %+v, want bias=-0.5 mae=0.5 rmse=√0.5", p2)
+	}
+}
+
+func TestScoreRuns_SkipsUnobservedLeads(t *testing.T) {
+	runs := []repository.ForecastRun{hourlyRun(issued, []float64{1010, 1011, 1012, 1013}, []float64{20, 20, 20, 20})}
+	// Observations stop after the first hour: leads 2h and 3h have not happened.
+	obs := hourlyObs(issued, []float64{1010, 1010}, []float64{20, 20})
+
+	scores := ScoreRuns(runs, obs, 3)
+
+	if len(scores) != 1 || scores[0].LeadHours != 1 {
+		t.Fatalf("got %+v, want only lead 1h", scores)
+	}
+}
+
+func TestScoreRuns_ObservationOutsideTolerance(t *testing.T) {
+	runs := []repository.ForecastRun{hourlyRun(issued, []float64{1010, 1011}, []float64{20, 20})}
+	// The only observation near lead 1h is 40 minutes late.
+	obs := []repository.ObservedPoint{{Timestamp: issued.Add(time.Hour + 40*time.Minute), PressureMb: 1000}}
+
+	if scores := ScoreRuns(runs, obs, 1); len(scores) != 0 {
+		t.Errorf("got %+v, want no pairs beyond the 30 minute tolerance", scores)
+	}
+}
+
+func TestScoreRuns_LeadMeasuredFromIssueTime(t *testing.T) {
+	// Issued 20 minutes past the hour: lead 1h resolves to the next whole
+	// hour's point, and the point — not the target — is matched to an
+	// observation.
+	run := hourlyRun(issued, []float64{1010, 1015}, []float64{20, 20})
+	run.IssuedAt = issued.Add(20 * time.Minute)
+	obs := hourlyObs(issued, []float64{1010, 1012}, []float64{20, 20})
+
+	scores := ScoreRuns([]repository.ForecastRun{run}, obs, 1)
+
+	if len(scores) != 1 || !approx(scores[0].PressureMb.Bias, 3) {
+		t.Fatalf("got %+v, want 1h bias +3 from the 07:00 point", scores)
+	}
+}
+
+func TestScoreRuns_NoRuns(t *testing.T) {
+	if scores := ScoreRuns(nil, hourlyObs(issued, []float64{1010}, []float64{20}), 72); scores != nil {
+		t.Errorf("got %+v, want nil", scores)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// summaryLeads are the lead times logged on every run. 24h is the one the
+// pressure-drop alerts depend on; the others bracket it.
+var summaryLeads = []int{6, 24, 48, 72}
+
+// VerifierService scores archived forecasts against what was later observed.
+type VerifierService struct {
+	store repository.Store
+	cfg   ScoreConfig
+}
+
+func NewVerifierService(store repository.Store, cfg ScoreConfig) *VerifierService {
+	return &VerifierService{store: store, cfg: cfg}
+}
+
+// Verify scores the runs one location issued in the window ending at now and
+// replaces its forecast_accuracy document with the result. A location with
+// no archived runs still gets a document with no leads, so a reader can tell
+// "scored, nothing to score" from "never scored".
+func (s *VerifierService) Verify(ctx context.Context, location shared.Location, now time.Time) (repository.AccuracyDoc, error) {
+	start := now.AddDate(0, 0, -s.cfg.WindowDays)
+
+	runs, err := s.store.ReadRuns(ctx, location.ID, start, now)
+	if err != nil {
+		return repository.AccuracyDoc{}, err
+	}
+	observations, err := s.store.ReadObservations(ctx, location.ID, start, now)
+	if err != nil {
+		return repository.AccuracyDoc{}, err
+	}
+
+	doc := repository.AccuracyDoc{
+		Location:    location.ID,
+		ComputedAt:  now,
+		WindowStart: start,
+		WindowEnd:   now,
+		Runs:        len(runs),
+		Leads:       ScoreRuns(runs, observations, s.cfg.MaxLeadHours),
+	}
+	if err := s.store.SaveAccuracy(ctx, doc); err != nil {
+		return repository.AccuracyDoc{}, fmt.Errorf("saving accuracy for %s: %w", location.ID, err)
+	}
+	logAccuracy(doc, len(observations))
+	return doc, nil
+}
+
+func logAccuracy(doc repository.AccuracyDoc, observations int) {
+	attrs := []any{
+		"location", doc.Location,
+		"runs", doc.Runs,
+		"observations", observations,
+		"leads_scored", len(doc.Leads),
+	}
+	byLead := make(map[int]repository.LeadScore, len(doc.Leads))
+	for _, l := range doc.Leads {
+		byLead[l.LeadHours] = l
+	}
+	for _, h := range summaryLeads {
+		l, ok := byLead[h]
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("lead_%02dh", h)
+		attrs = append(attrs,
+			key+"_n", l.PressureMb.Count,
+			key+"_pressure_bias_mb", l.PressureMb.Bias,
+			key+"_pressure_rmse_mb", l.PressureMb.RMSE,
+			key+"_temp_rmse_c", l.TempC.RMSE,
+		)
+	}
+	slog.Info("forecast accuracy", attrs...)
+}
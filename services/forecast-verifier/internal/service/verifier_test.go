@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/testutil"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+var loc = shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
+
+func TestVerify_SavesScoresOverWindow(t *testing.T) {
+	now := issued.Add(48 * time.Hour)
+	var runsStart, runsEnd, obsStart, obsEnd time.Time
+	store := &testutil.MockStore{
+		ReadRunsFn: func(ctx context.Context, id string, start, end time.Time) ([]repository.ForecastRun, error) {
+			runsStart, runsEnd = start, end
+			return []repository.ForecastRun{hourlyRun(issued, []float64{1010, 1012}, []float64{20, 21})}, nil
+		},
+		ReadObservationsFn: func(ctx context.Context, id string, start, end time.Time) ([]repository.ObservedPoint, error) {
+			obsStart, obsEnd = start, end
+			return hourlyObs(issued, []float64{1010, 1010}, []float64{20, 20}), nil
+		},
+	}
+	svc := NewVerifierService(store, ScoreConfig{MaxLeadHours: 72, WindowDays: 7})
+
+	doc, err := svc.Verify(context.Background(), loc, now)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	wantStart := now.AddDate(0, 0, -7)
+	if !runsStart.Equal(wantStart) || !runsEnd.Equal(now) || !obsStart.Equal(wantStart) || !obsEnd.Equal(now) {
+		t.Errorf("read windows runs=%v..%v obs=%v..%v, want %v..%v", runsStart, runsEnd, obsStart, obsEnd, wantStart, now)
+	}
+	if len(store.Saved) != 1 {
+		t.Fatalf("saved %d docs, want 1", len(store.Saved))
+	}
+	saved := store.Saved[0]
+	if saved.Location != "house-nick" || saved.Runs != 1 || !saved.ComputedAt.Equal(now) {
+		t.Errorf("saved = %+v", saved)
+	}
+	if len(saved.Leads) != 1 || saved.Leads[0].PressureMb.Bias != 2 {
+		t.Errorf("leads = %+v, want one 1h lead with +2 mb bias", saved.Leads)
+	}
+	if doc.Runs != saved.Runs {
+		t.Error("returned doc differs from the saved one")
+	}
+}
+
+func TestVerify_NoRunsStillSaved(t *testing.T) {
+	store := &testutil.MockStore{}
+	svc := NewVerifierService(store, DefaultScoreConfig())
+
+	if _, err := svc.Verify(context.Background(), loc, issued); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if len(store.Saved) != 1 || store.Saved[0].Runs != 0 || len(store.Saved[0].Leads) != 0 {
+		t.Errorf("saved = %+v, want one empty doc", store.Saved)
+	}
+}
+
+func TestVerify_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		store *testutil.MockStore
+	}{
+		{"runs read fails", &testutil.MockStore{
+			ReadRunsFn: func(ctx context.Context, id string, start, end time.Time) ([]repository.ForecastRun, error) {
+				return nil, errors.New("firestore down")
+			},
+		}},
+		{"observations read fails", &testutil.MockStore{
+			ReadObservationsFn: func(ctx context.Context, id string, start, end time.Time) ([]repository.ObservedPoint, error) {
+				return nil, errors.New("firestore down")
+			},
+		}},
+		{"save fails", &testutil.MockStore{SaveErr: errors.New("permission denied")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewVerifierService(tt.store, DefaultScoreConfig())
+			if _, err := svc.Verify(context.Background(), loc, issued); err == nil {
+				t.Error("expected an error")
+			}
+			if len(tt.store.Saved) != 0 {
+				t.Error("nothing should be saved on failure")
+			}
+		})
+	}
+}
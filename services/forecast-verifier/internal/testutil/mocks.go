@@ -0,0 +1,40 @@
+package testutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/forecast-verifier/internal/repository"
+)
+
+// MockStore implements repository.Store for testing. Unset read funcs return
+// empty archives; saved documents are recorded in Saved.
+type MockStore struct {
+	ReadRunsFn         func(ctx context.Context, locationID string, start, end time.Time) ([]repository.ForecastRun, error)
+	ReadObservationsFn func(ctx context.Context, locationID string, start, end time.Time) ([]repository.ObservedPoint, error)
+	SaveErr            error
+
+	Saved []repository.AccuracyDoc
+}
+
+func (m *MockStore) ReadRuns(ctx context.Context, locationID string, start, end time.Time) ([]repository.ForecastRun, error) {
+	if m.ReadRunsFn == nil {
+		return nil, nil
+	}
+	return m.ReadRunsFn(ctx, locationID, start, end)
+}
+
+func (m *MockStore) ReadObservations(ctx context.Context, locationID string, start, end time.Time) ([]repository.ObservedPoint, error) {
+	if m.ReadObservationsFn == nil {
+		return nil, nil
+	}
+	return m.ReadObservationsFn(ctx, locationID, start, end)
+}
+
+func (m *MockStore) SaveAccuracy(ctx context.Context, doc repository.AccuracyDoc) error {
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	m.Saved = append(m.Saved, doc)
+	return nil
+}
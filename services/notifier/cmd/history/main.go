@@ -0,0 +1,102 @@
+// Command history prints one location's stored observations as JSON Lines,
+// oldest first, for offline analysis of forecast divergence. It reads
+// whichever backend STORAGE_BACKEND selects.
+//
+//	go run ./cmd/history -location house-nick -start 2026-06-01 -end 2026-07-01
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/nickfang/personal-dashboard/services/notifier/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+func main() {
+	// Logs go to stderr so the records themselves can be piped.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	if err := godotenv.Load(); err != nil {
+		slog.Debug("No .env file found, using system environment variables", "error", err)
+	}
+
+	project := flag.String("project", os.Getenv("GCP_PROJECT_ID"), "GCP project ID (default $GCP_PROJECT_ID)")
+	location := flag.String("location", "", "location ID (required)")
+	start := flag.String("start", "", "inclusive start, RFC 3339 or YYYY-MM-DD in UTC (default 7 days before -end)")
+	end := flag.String("end", "", "exclusive end, RFC 3339 or YYYY-MM-DD in UTC (default now)")
+	flag.Parse()
+
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		fail("Invalid storage backend", "error", err)
+	}
+	if *project == "" && !useSQLite {
+		fail("Missing project: set -project or GCP_PROJECT_ID")
+	}
+	if *location == "" {
+		fail("Missing -location")
+	}
+	endAt := time.Now()
+	if *end != "" {
+		t, err := parseTime(*end)
+		if err != nil {
+			fail("Invalid -end", "error", err)
+		}
+		endAt = t
+	}
+	startAt := endAt.AddDate(0, 0, -7)
+	if *start != "" {
+		t, err := parseTime(*start)
+		if err != nil {
+			fail("Invalid -start", "error", err)
+		}
+		startAt = t
+	}
+	if !startAt.Before(endAt) {
+		fail("-start must be before -end")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	store, err := repository.OpenStore(ctx, *project)
+	if err != nil {
+		fail("Failed to create store", "error", err)
+	}
+	defer store.Close()
+
+	records, err := store.ListObservations(ctx, *location, startAt, endAt)
+	if err != nil {
+		fail("Failed to list observations", "error", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			fail("Failed to write record", "error", err)
+		}
+	}
+	slog.Info("History complete", "location", *location, "start", startAt, "end", endAt, "records", len(records))
+}
+
+// parseTime accepts RFC 3339 or a bare date, read as midnight UTC.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func fail(msg string, args ...any) {
+	slog.Error(msg, args...)
+	fmt.Fprintln(os.Stderr, "Run with -h for usage.")
+	os.Exit(1)
+}
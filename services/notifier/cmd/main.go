@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/service"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
 )
 
 func main() {
@@ -19,16 +23,34 @@ func main() {
 	if err := godotenv.Load(); err != nil {
 		slog.Debug("No .env file found, using system environment variables", "error", err)
 	}
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
 	projectID := os.Getenv("GCP_PROJECT_ID")
-	if projectID == "" {
+	if projectID == "" && !useSQLite {
 		slog.Error("Missing required env vars", "vars", "GCP_PROJECT_ID")
 		os.Exit(1)
 	}
+	gate := service.DefaultGateConfig()
+	gate.MaxDivergenceMb = envFloat("NOTIFY_MAX_DIVERGENCE_MB", gate.MaxDivergenceMb)
+	gate.MaxForecastAgeMin = envInt("NOTIFY_MAX_FORECAST_AGE_MIN", gate.MaxForecastAgeMin)
 
 	ctx := context.Background()
-	store, err := repository.NewFirestoreStore(ctx, projectID)
+	locations, err := registry.Load(ctx, projectID, shared.SourceNotify)
 	if err != nil {
-		slog.Error("Failed to create firestore store", "error", err)
+		slog.Error("Failed to load locations", "error", err)
+		os.Exit(1)
+	}
+	if len(locations) == 0 {
+		slog.Warn("No locations enabled, nothing to observe", "source", shared.SourceNotify)
+		return
+	}
+
+	store, err := repository.OpenStore(ctx, projectID)
+	if err != nil {
+		slog.Error("Failed to create store", "error", err)
 		os.Exit(1)
 	}
 	defer store.Close()
@@ -37,13 +59,63 @@ func main() {
 	// that straddles the hour must not split across two.
 	now := time.Now()
 
-	notifier := service.NewNotifierService(store)
-	if err := observeAll(ctx, notifier, shared.Locations, now); err != nil {
+	notifier := service.NewNotifierService(store, newSender(), gate)
+	if err := observeAll(ctx, notifier, locations, now); err != nil {
 		slog.Error("Observation failed", "error", err)
 		os.Exit(1)
 	}
 }
 
+// newSender builds the alert delivery sender from the environment.
+//
+// A disabled switch or any missing value yields a NopSender rather than an
+// error: the job has to keep observing when delivery is unconfigured, which
+// is the normal case in local development.
+func newSender() notify.Sender {
+	if os.Getenv("NOTIFY_ENABLED") != "true" {
+		slog.Info("Alert delivery disabled", "reason", "NOTIFY_ENABLED not true")
+		return notify.NopSender{}
+	}
+	user := os.Getenv("NOTIFY_SMTP_USER")
+	password := os.Getenv("NOTIFY_SMTP_PASSWORD")
+	to := os.Getenv("NOTIFY_EMAIL_TO")
+	if user == "" || password == "" || to == "" {
+		slog.Warn("Alert delivery enabled but not configured, dropping notifications",
+			"vars", "NOTIFY_SMTP_USER, NOTIFY_SMTP_PASSWORD, NOTIFY_EMAIL_TO")
+		return notify.NopSender{}
+	}
+	slog.Info("Alert delivery enabled", "from", user, "to", to)
+	return notify.NewSMTPSender(user, password, to)
+}
+
+// envInt reads an integer env var, falling back to a default when unset or invalid.
+func envInt(name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+		return fallback
+	}
+	return v
+}
+
+// envFloat reads a float env var, falling back to a default when unset or invalid.
+func envFloat(name string, fallback float64) float64 {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v <= 0 {
+		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+		return fallback
+	}
+	return v
+}
+
 // observeAll is partial-failure tolerant, matching the collectors: a location
 // that cannot be read is logged and skipped, and the run only fails when
 // every location does.
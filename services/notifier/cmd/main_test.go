@@ -10,6 +10,7 @@ import (
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/service"
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/testutil"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
 var testLocations = []shared.Location{
@@ -32,7 +33,7 @@ func healthyStore() *testutil.MockStore {
 }
 
 func TestObserveAll_AllLocationsSucceed(t *testing.T) {
-	notifier := service.NewNotifierService(healthyStore())
+	notifier := service.NewNotifierService(healthyStore(), notify.NopSender{}, service.DefaultGateConfig())
 
 	if err := observeAll(context.Background(), notifier, testLocations, time.Now()); err != nil {
 		t.Fatalf("observeAll() returned error: %v", err)
@@ -50,7 +51,7 @@ func TestObserveAll_PartialFailureContinues(t *testing.T) {
 			return &repository.ForecastCacheDoc{IssuedAt: time.Now()}, nil
 		},
 	}
-	notifier := service.NewNotifierService(store)
+	notifier := service.NewNotifierService(store, notify.NopSender{}, service.DefaultGateConfig())
 
 	if err := observeAll(context.Background(), notifier, testLocations, time.Now()); err != nil {
 		t.Fatalf("observeAll() should succeed with partial failures, got: %v", err)
@@ -66,7 +67,7 @@ func TestObserveAll_AllLocationsFail(t *testing.T) {
 			return nil, fmt.Errorf("firestore unavailable")
 		},
 	}
-	notifier := service.NewNotifierService(store)
+	notifier := service.NewNotifierService(store, notify.NopSender{}, service.DefaultGateConfig())
 
 	if err := observeAll(context.Background(), notifier, testLocations, time.Now()); err == nil {
 		t.Fatal("observeAll() should return an error when every location fails")
@@ -74,7 +75,7 @@ func TestObserveAll_AllLocationsFail(t *testing.T) {
 }
 
 func TestObserveAll_EmptyLocations(t *testing.T) {
-	notifier := service.NewNotifierService(healthyStore())
+	notifier := service.NewNotifierService(healthyStore(), notify.NopSender{}, service.DefaultGateConfig())
 
 	if err := observeAll(context.Background(), notifier, nil, time.Now()); err == nil {
 		t.Fatal("observeAll() should return an error when no locations are provided")
@@ -89,7 +90,7 @@ func TestObserveAll_SharesOneEvaluationTime(t *testing.T) {
 			return &repository.ForecastCacheDoc{IssuedAt: time.Now().Add(-90 * time.Minute)}, nil
 		},
 	}
-	notifier := service.NewNotifierService(store)
+	notifier := service.NewNotifierService(store, notify.NopSender{}, service.DefaultGateConfig())
 
 	now := time.Now()
 	var seen []time.Time
@@ -105,3 +106,38 @@ func TestObserveAll_SharesOneEvaluationTime(t *testing.T) {
 		t.Errorf("evaluation times = %v, want both equal to the pinned %v", seen, now)
 	}
 }
+
+func TestNewSender(t *testing.T) {
+	const (
+		user     = "me@gmail.com"
+		password = "app-password"
+		to       = "you@gmail.com"
+	)
+	tests := []struct {
+		name                    string
+		enabled, user, pass, to string
+		wantSMTP                bool
+	}{
+		{name: "unset defaults to no delivery"},
+		{name: "disabled", enabled: "false", user: user, pass: password, to: to},
+		{name: "enabled and configured", enabled: "true", user: user, pass: password, to: to, wantSMTP: true},
+		{name: "missing password", enabled: "true", user: user, to: to},
+		{name: "missing user", enabled: "true", pass: password, to: to},
+		{name: "missing recipient", enabled: "true", user: user, pass: password},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NOTIFY_ENABLED", tt.enabled)
+			t.Setenv("NOTIFY_SMTP_USER", tt.user)
+			t.Setenv("NOTIFY_SMTP_PASSWORD", tt.pass)
+			t.Setenv("NOTIFY_EMAIL_TO", tt.to)
+
+			// Anything short of fully configured must degrade to a no-op
+			// sender, not an error: the job still has to observe.
+			_, isSMTP := newSender().(*notify.SMTPSender)
+			if isSMTP != tt.wantSMTP {
+				t.Errorf("newSender() SMTP = %v, want %v", isSMTP, tt.wantSMTP)
+			}
+		})
+	}
+}
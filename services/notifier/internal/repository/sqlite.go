@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteStore reads the weather and forecast caches, marks their alerts, and
+// appends observations in the docstore file, as FirestoreStore does.
+type SQLiteStore struct {
+	db *docstore.DB
+}
+
+func NewSQLiteStore(db *docstore.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// OpenStore returns the SQLite store when docstore selects it, and otherwise
+// connects to weather-log, which holds both caches it reads and marks.
+func OpenStore(ctx context.Context, projectID string) (StoreCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteStore(db), nil
+	}
+	fs, err := NewFirestoreStore(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) ReadObservation(ctx context.Context, locationID string) (*WeatherCacheDoc, error) {
+	var out WeatherCacheDoc
+	err := s.db.Get(ctx, shared.WeatherCacheCollection, locationID, &out)
+	if errors.Is(err, docstore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading weather cache for %s: %w", locationID, err)
+	}
+	schema.Read(locationID, &out)
+	return &out, nil
+}
+
+func (s *SQLiteStore) ReadForecast(ctx context.Context, locationID string) (*ForecastCacheDoc, error) {
+	var out ForecastCacheDoc
+	err := s.db.Get(ctx, shared.ForecastCacheCollection, locationID, &out)
+	if errors.Is(err, docstore.ErrNotFound) {
+		return nil, fmt.Errorf("no forecast cache document for %s", locationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading forecast cache for %s: %w", locationID, err)
+	}
+	schema.Read(locationID, &out)
+	return &out, nil
+}
+
+// MarkNotified writes only the alerts fields of the forecast and weather
+// cache docs, and records delivered and covered events, as FirestoreStore
+// does.
+func (s *SQLiteStore) MarkNotified(ctx context.Context, locationID string, alertIDs, covered []string, at time.Time) error {
+	if len(alertIDs) == 0 && len(covered) == 0 {
+		return nil
+	}
+	return s.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		var cached *ForecastCacheDoc
+		var c ForecastCacheDoc
+		err := tx.Get(shared.ForecastCacheCollection, locationID, &c)
+		if err != nil && !errors.Is(err, docstore.ErrNotFound) {
+			return fmt.Errorf("reading forecast cache doc: %w", err)
+		} else if err == nil {
+			if err := schema.Rewrite(locationID, &c); err != nil {
+				return err
+			}
+			cached = &c
+		}
+		var weather *WeatherCacheDoc
+		var w WeatherCacheDoc
+		err = tx.Get(shared.WeatherCacheCollection, locationID, &w)
+		if err != nil && !errors.Is(err, docstore.ErrNotFound) {
+			return fmt.Errorf("reading weather cache doc: %w", err)
+		} else if err == nil {
+			weather = &w
+		}
+
+		var events []shared.AlertEvent
+		if cached != nil {
+			updated := applyNotifiedAt(cached.Alerts, alertIDs, at)
+			if err := tx.Update(shared.ForecastCacheCollection, locationID, "alerts", updated); err != nil {
+				return err
+			}
+			events = shared.AlertEvents(cached.Alerts, updated, at)
+		}
+		if observed, stamped := observedNotified(weather, alertIDs, covered, at); len(observed) > 0 {
+			if err := schema.Rewrite(locationID, weather); err != nil {
+				return err
+			}
+			if err := tx.Update(shared.WeatherCacheCollection, locationID, "alerts", stamped); err != nil {
+				return err
+			}
+			events = append(events, observed...)
+		}
+		return schema.RecordEvents(tx.Set, events)
+	})
+}
+
+func (s *SQLiteStore) RecordHeld(ctx context.Context, alerts []shared.Alert, at time.Time) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	return s.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		return schema.RecordEvents(tx.Set, heldEvents(alerts, at))
+	})
+}
+
+func (s *SQLiteStore) SaveObservation(ctx context.Context, rec ObservationRecord) error {
+	if _, err := s.db.Add(ctx, shared.NotifierObservationsCollection, rec); err != nil {
+		return fmt.Errorf("saving observation for %s: %w", rec.Location, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) ListObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservationRecord, error) {
+	docs, err := s.db.Documents(ctx, docstore.Query{
+		Collection: shared.NotifierObservationsCollection,
+		Where: []docstore.Filter{
+			{Field: "location", Op: "==", Value: locationID},
+			{Field: "evaluated_at", Op: ">=", Value: start},
+			{Field: "evaluated_at", Op: "<", Value: end},
+		},
+		OrderBy: "evaluated_at",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing observations for %s: %w", locationID, err)
+	}
+	return docstore.DecodeAll[ObservationRecord](docs)
+}
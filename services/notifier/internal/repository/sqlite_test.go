@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+func openTestStore(t *testing.T) (*SQLiteStore, *docstore.DB) {
+	t.Helper()
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "notifier.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	store := NewSQLiteStore(db)
+	t.Cleanup(func() { store.Close() })
+	return store, db
+}
+
+func TestSQLiteStore_MarkNotifiedWritesOnlyAlerts(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+
+	// Seeded with a field this package does not model, standing in for
+	// anything forecast-collector stores that the notifier must not drop.
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	if err := db.Set(ctx, shared.ForecastCacheCollection, "house-nick", map[string]any{
+		"location":  "house-nick",
+		"issued_at": issuedAt,
+		"points":    []ForecastPoint{{PressureMb: 1012.5}},
+		"alerts":    []shared.Alert{{ID: "a"}, {ID: "b"}},
+		"extra":     "kept",
+	}); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+
+	at := issuedAt.Add(time.Hour)
+	if err := store.MarkNotified(ctx, "house-nick", []string{"b"}, nil, at); err != nil {
+		t.Fatalf("MarkNotified: %v", err)
+	}
+
+	var raw map[string]any
+	if err := db.Get(ctx, shared.ForecastCacheCollection, "house-nick", &raw); err != nil {
+		t.Fatalf("reading back: %v", err)
+	}
+	if raw["extra"] != "kept" {
+		t.Errorf("extra = %v, want the field left as stored", raw["extra"])
+	}
+	got, err := store.ReadForecast(ctx, "house-nick")
+	if err != nil {
+		t.Fatalf("ReadForecast: %v", err)
+	}
+	if len(got.Points) != 1 || !got.Alerts[0].NotifiedAt.IsZero() || !got.Alerts[1].NotifiedAt.Equal(at) {
+		t.Errorf("after MarkNotified got %+v", got)
+	}
+
+	// Nothing to stamp is not an error, and must not create the doc.
+	if err := store.MarkNotified(ctx, "cabin", []string{"a"}, nil, at); err != nil {
+		t.Errorf("MarkNotified without a forecast cache doc: %v", err)
+	}
+	if _, err := store.ReadForecast(ctx, "cabin"); err == nil {
+		t.Error("MarkNotified created a forecast cache doc for cabin")
+	}
+}
+
+func TestSQLiteStore_MarkNotifiedRecordsDelivery(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	if err := db.Set(ctx, shared.ForecastCacheCollection, "house-nick", ForecastCacheDoc{
+		Location: "house-nick",
+		IssuedAt: issuedAt,
+		Alerts:   []shared.Alert{{ID: "a", Location: "house-nick"}, {ID: "b", Location: "house-nick"}},
+	}); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+
+	at := issuedAt.Add(time.Hour)
+	if err := store.MarkNotified(ctx, "house-nick", []string{"b"}, nil, at); err != nil {
+		t.Fatalf("MarkNotified: %v", err)
+	}
+	docs, err := db.Documents(ctx, docstore.Query{Collection: shared.AlertEventsCollection})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := docstore.DecodeAll[AlertEventRecord](docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].AlertID != "b" || events[0].Type != shared.AlertEventDelivered || !events[0].At.Equal(at) || events[0].Location != "house-nick" {
+		t.Errorf("events = %+v, want one delivered event for b", events)
+	}
+}
+
+func TestSQLiteStore_MarkNotifiedStampsObservedAlerts(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	if err := db.Set(ctx, shared.ForecastCacheCollection, "house-nick", ForecastCacheDoc{
+		Location: "house-nick",
+		IssuedAt: issuedAt,
+		Alerts:   []shared.Alert{{ID: "a", Location: "house-nick"}},
+	}); err != nil {
+		t.Fatalf("seeding forecast: %v", err)
+	}
+	if err := db.Set(ctx, shared.WeatherCacheCollection, "house-nick", WeatherCacheDoc{
+		LastUpdated: issuedAt,
+		Alerts:      []shared.Alert{{ID: "observed", Location: "house-nick"}},
+	}); err != nil {
+		t.Fatalf("seeding weather: %v", err)
+	}
+
+	at := issuedAt.Add(time.Hour)
+	if err := store.MarkNotified(ctx, "house-nick", []string{"a", "observed"}, nil, at); err != nil {
+		t.Fatalf("MarkNotified: %v", err)
+	}
+	forecast, err := store.ReadForecast(ctx, "house-nick")
+	if err != nil {
+		t.Fatalf("ReadForecast: %v", err)
+	}
+	weather, err := store.ReadObservation(ctx, "house-nick")
+	if err != nil {
+		t.Fatalf("ReadObservation: %v", err)
+	}
+	if !forecast.Alerts[0].NotifiedAt.Equal(at) || len(weather.Alerts) != 1 || !weather.Alerts[0].NotifiedAt.Equal(at) {
+		t.Errorf("forecast alerts %+v, weather alerts %+v, want both stamped", forecast.Alerts, weather.Alerts)
+	}
+	docs, err := db.Documents(ctx, docstore.Query{Collection: shared.AlertEventsCollection})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Errorf("got %d events, want a delivered event for each alert", len(docs))
+	}
+}
+
+func TestSQLiteStore_MarkNotifiedRecordsCoveredAlerts(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	if err := db.Set(ctx, shared.ForecastCacheCollection, "house-nick", ForecastCacheDoc{
+		Location: "house-nick",
+		IssuedAt: issuedAt,
+		Alerts:   []shared.Alert{{ID: "a", Location: "house-nick", NotifiedAt: issuedAt}},
+	}); err != nil {
+		t.Fatalf("seeding forecast: %v", err)
+	}
+	if err := db.Set(ctx, shared.WeatherCacheCollection, "house-nick", WeatherCacheDoc{
+		LastUpdated: issuedAt,
+		Alerts:      []shared.Alert{{ID: "observed", Location: "house-nick"}},
+	}); err != nil {
+		t.Fatalf("seeding weather: %v", err)
+	}
+
+	at := issuedAt.Add(time.Hour)
+	if err := store.MarkNotified(ctx, "house-nick", nil, []string{"observed"}, at); err != nil {
+		t.Fatalf("MarkNotified: %v", err)
+	}
+	weather, err := store.ReadObservation(ctx, "house-nick")
+	if err != nil {
+		t.Fatalf("ReadObservation: %v", err)
+	}
+	if len(weather.Alerts) != 1 || !weather.Alerts[0].NotifiedAt.Equal(at) {
+		t.Errorf("weather alerts %+v, want the covered alert stamped", weather.Alerts)
+	}
+	docs, err := db.Documents(ctx, docstore.Query{Collection: shared.AlertEventsCollection})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := docstore.DecodeAll[AlertEventRecord](docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].AlertID != "observed" || events[0].Type != shared.AlertEventCovered {
+		t.Errorf("events = %+v, want one covered event for the observed alert", events)
+	}
+}
+
+func TestSQLiteStore_MarkNotifiedWithoutForecast(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	lastUpdated := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	if err := db.Set(ctx, shared.WeatherCacheCollection, "house-nick", WeatherCacheDoc{
+		LastUpdated: lastUpdated,
+		Alerts:      []shared.Alert{{ID: "observed", Location: "house-nick"}},
+	}); err != nil {
+		t.Fatalf("seeding weather: %v", err)
+	}
+
+	at := lastUpdated.Add(time.Hour)
+	if err := store.MarkNotified(ctx, "house-nick", []string{"observed"}, nil, at); err != nil {
+		t.Fatalf("MarkNotified: %v", err)
+	}
+	weather, err := store.ReadObservation(ctx, "house-nick")
+	if err != nil {
+		t.Fatalf("ReadObservation: %v", err)
+	}
+	if len(weather.Alerts) != 1 || !weather.Alerts[0].NotifiedAt.Equal(at) {
+		t.Errorf("weather alerts %+v, want the observed alert stamped", weather.Alerts)
+	}
+	if _, err := store.ReadForecast(ctx, "house-nick"); err == nil {
+		t.Error("MarkNotified should not create a forecast cache doc")
+	}
+}
+
+func TestSQLiteStore_RecordHeldLeavesAlertsPending(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	issuedAt := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	alert := shared.Alert{ID: "a", Location: "house-nick", Status: shared.AlertStatusActive}
+	if err := db.Set(ctx, shared.ForecastCacheCollection, "house-nick", ForecastCacheDoc{
+		Location: "house-nick",
+		IssuedAt: issuedAt,
+		Alerts:   []shared.Alert{alert},
+	}); err != nil {
+		t.Fatalf("seeding forecast: %v", err)
+	}
+
+	at := issuedAt.Add(time.Hour)
+	if err := store.RecordHeld(ctx, []shared.Alert{alert}, at); err != nil {
+		t.Fatalf("RecordHeld: %v", err)
+	}
+	forecast, err := store.ReadForecast(ctx, "house-nick")
+	if err != nil {
+		t.Fatalf("ReadForecast: %v", err)
+	}
+	if len(forecast.Alerts) != 1 || !forecast.Alerts[0].NotifiedAt.IsZero() {
+		t.Errorf("forecast alerts %+v, want the held alert still pending", forecast.Alerts)
+	}
+	docs, err := db.Documents(ctx, docstore.Query{Collection: shared.AlertEventsCollection})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := docstore.DecodeAll[AlertEventRecord](docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].AlertID != "a" || events[0].Type != shared.AlertEventHeld || !events[0].At.Equal(at) {
+		t.Errorf("events = %+v, want one held event for the alert", events)
+	}
+}
+
+func TestSQLiteStore_ReadMissing(t *testing.T) {
+	store, _ := openTestStore(t)
+	ctx := context.Background()
+
+	if obs, err := store.ReadObservation(ctx, "house-nick"); obs != nil || err != nil {
+		t.Errorf("ReadObservation = %v, %v, want nil, nil for a missing cache doc", obs, err)
+	}
+	if _, err := store.ReadForecast(ctx, "house-nick"); err == nil {
+		t.Error("ReadForecast should fail for a missing cache doc")
+	}
+}
+
+func TestSQLiteStore_ListObservations(t *testing.T) {
+	store, _ := openTestStore(t)
+	ctx := context.Background()
+
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	// Saved out of order, and with a second location in the same window.
+	for _, h := range []int{3, 0, 1, 2} {
+		rec := ObservationRecord{Location: "house-nick", EvaluatedAt: base.Add(time.Duration(h) * time.Hour), ForecastAgeMin: h}
+		if err := store.SaveObservation(ctx, rec); err != nil {
+			t.Fatalf("SaveObservation: %v", err)
+		}
+	}
+	if err := store.SaveObservation(ctx, ObservationRecord{Location: "cabin", EvaluatedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("SaveObservation: %v", err)
+	}
+
+	got, err := store.ListObservations(ctx, "house-nick", base.Add(time.Hour), base.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("ListObservations: %v", err)
+	}
+	if len(got) != 2 || got[0].ForecastAgeMin != 1 || got[1].ForecastAgeMin != 2 {
+		t.Errorf("ListObservations = %+v, want hours 1 and 2 in order (end exclusive)", got)
+	}
+}
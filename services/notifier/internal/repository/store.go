@@ -3,21 +3,27 @@ package repository
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-// Store reads the cache documents this job observes. The interface exposes no
-// writes: this job records what it sees and delivers nothing (see #79, #80).
+// Store reads the cache documents this job observes, stamps delivery onto
+// forecast_cache and weather_cache alerts, and appends what it saw to
+// notifier_observations. MarkNotified is its only write to documents another
+// service owns, and it touches only their alerts fields; the events it and
+// RecordHeld append to alert_events are new documents.
 //
-// That is a code-level guarantee, not an IAM one. The shared cloud-run-job
-// module grants every job service account project-wide roles/datastore.user,
-// which includes write, so nothing at the infrastructure layer stops this job
-// from modifying documents owned by the collectors. Narrowing it to
-// roles/datastore.viewer needs a variable on that module.
+// That boundary is a code-level guarantee, not an IAM one. The shared
+// cloud-run-job module grants every job service account project-wide
+// roles/datastore.user, so nothing at the infrastructure layer stops this job
+// from modifying other fields or collections.
 type Store interface {
 	// ReadObservation returns the latest observed conditions, or nil when
 	// the location has no cache document yet. A missing observation is a
@@ -28,6 +34,35 @@ type Store interface {
 	// ReadForecast returns the latest forecast. A missing forecast means
 	// there is nothing to observe against, so it is an error.
 	ReadForecast(ctx context.Context, locationID string) (*ForecastCacheDoc, error)
+
+	// MarkNotified records delivery against the listed alert IDs, whichever
+	// cache document — forecast or observed — holds them, with a delivered
+	// event for each in alert_events. The covered IDs are observed alerts
+	// that were not sent because a forecast alert already was; they are
+	// stamped the same way, so they stop being pending, with a covered event
+	// instead. Either cache document may be missing; only the IDs found are
+	// stamped.
+	MarkNotified(ctx context.Context, locationID string, alertIDs, covered []string, at time.Time) error
+
+	// RecordHeld appends a held event to alert_events for each alert the
+	// gate held back. The alerts themselves are not written: a held alert
+	// stays pending.
+	RecordHeld(ctx context.Context, alerts []shared.Alert, at time.Time) error
+
+	// SaveObservation appends one record. Records are never updated, so a
+	// retried run leaves two records for the same evaluation rather than
+	// overwriting the first.
+	SaveObservation(ctx context.Context, rec ObservationRecord) error
+
+	// ListObservations returns one location's records evaluated in
+	// [start, end), oldest first.
+	ListObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservationRecord, error)
+}
+
+// StoreCloser is a Store that holds a connection.
+type StoreCloser interface {
+	Store
+	Close() error
 }
 
 type FirestoreStore struct {
@@ -60,6 +95,7 @@ func (s *FirestoreStore) ReadObservation(ctx context.Context, locationID string)
 	if err := doc.DataTo(&out); err != nil {
 		return nil, fmt.Errorf("decoding weather cache for %s: %w", locationID, err)
 	}
+	schema.Read(locationID, &out)
 	return &out, nil
 }
 
@@ -75,5 +111,172 @@ func (s *FirestoreStore) ReadForecast(ctx context.Context, locationID string) (*
 	if err := doc.DataTo(&out); err != nil {
 		return nil, fmt.Errorf("decoding forecast cache for %s: %w", locationID, err)
 	}
+	schema.Read(locationID, &out)
 	return &out, nil
 }
+
+// MarkNotified re-reads the cache doc inside a transaction and matches by ID
+// rather than by position, so a forecast-collector run that reordered or
+// replaced the alert set in between cannot be clobbered. Status is
+// untouched: it tracks whether the condition is present, which delivery does
+// not change.
+//
+// Only the alerts field is written — points carries 72 forecast hours owned
+// by forecast-collector, and there is no reason to rewrite it. The weather
+// cache doc is read too, for weather-collector's observed alerts, and written
+// only when one of them was delivered or covered. A location missing either
+// doc is fine: the notifier still delivers observed alerts when the forecast
+// cannot be read. The events go to alert_events in the same transaction, so the log
+// cannot claim a delivery the cache does not show, or miss one it does.
+func (s *FirestoreStore) MarkNotified(ctx context.Context, locationID string, alertIDs, covered []string, at time.Time) error {
+	if len(alertIDs) == 0 && len(covered) == 0 {
+		return nil
+	}
+	cacheRef := s.client.Collection(shared.ForecastCacheCollection).Doc(locationID)
+	weatherRef := s.client.Collection(shared.WeatherCacheCollection).Doc(locationID)
+	return s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Firestore transactions must do every read before any write.
+		var cached *ForecastCacheDoc
+		doc, err := tx.Get(cacheRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("reading forecast cache doc: %w", err)
+		} else if err == nil {
+			cached = &ForecastCacheDoc{}
+			if err := doc.DataTo(cached); err != nil {
+				return err
+			}
+			if err := schema.Rewrite(locationID, cached); err != nil {
+				return err
+			}
+		}
+		var weather *WeatherCacheDoc
+		weatherDoc, err := tx.Get(weatherRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("reading weather cache doc: %w", err)
+		} else if err == nil {
+			weather = &WeatherCacheDoc{}
+			if err := weatherDoc.DataTo(weather); err != nil {
+				return err
+			}
+		}
+
+		var events []shared.AlertEvent
+		if cached != nil {
+			updated := applyNotifiedAt(cached.Alerts, alertIDs, at)
+			if err := tx.Update(cacheRef, []firestore.Update{
+				{Path: "alerts", Value: updated},
+			}); err != nil {
+				return err
+			}
+			events = shared.AlertEvents(cached.Alerts, updated, at)
+		}
+		if observed, stamped := observedNotified(weather, alertIDs, covered, at); len(observed) > 0 {
+			if err := schema.Rewrite(locationID, weather); err != nil {
+				return err
+			}
+			if err := tx.Update(weatherRef, []firestore.Update{
+				{Path: "alerts", Value: stamped},
+			}); err != nil {
+				return err
+			}
+			events = append(events, observed...)
+		}
+		return schema.RecordEvents(func(collection, id string, v any) error {
+			return tx.Set(s.client.Collection(collection).Doc(id), v)
+		}, events)
+	})
+}
+
+// RecordHeld writes the events in one transaction, so a run records all of
+// its held alerts or none.
+func (s *FirestoreStore) RecordHeld(ctx context.Context, alerts []shared.Alert, at time.Time) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	return s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return schema.RecordEvents(func(collection, id string, v any) error {
+			return tx.Set(s.client.Collection(collection).Doc(id), v)
+		}, heldEvents(alerts, at))
+	})
+}
+
+// heldEvents returns a held event for each alert, unchanged on both sides.
+func heldEvents(alerts []shared.Alert, at time.Time) []shared.AlertEvent {
+	events := make([]shared.AlertEvent, len(alerts))
+	for i, a := range alerts {
+		events[i] = shared.AlertEvent{Type: shared.AlertEventHeld, At: at, Alert: a, Prev: a}
+	}
+	return events
+}
+
+// observedNotified stamps at onto the weather cache doc's listed and covered
+// alerts. It returns their events — delivered, or covered for the covered
+// IDs — empty when none of the IDs are observed alerts and the doc need not
+// be written, and the stamped alerts.
+func observedNotified(weather *WeatherCacheDoc, alertIDs, covered []string, at time.Time) ([]shared.AlertEvent, []shared.Alert) {
+	if weather == nil || len(weather.Alerts) == 0 {
+		return nil, nil
+	}
+	stamped := applyNotifiedAt(weather.Alerts, append(slices.Clone(alertIDs), covered...), at)
+	events := shared.AlertEvents(weather.Alerts, stamped, at)
+	for i := range events {
+		if events[i].Type == shared.AlertEventDelivered && slices.Contains(covered, events[i].Alert.ID) {
+			events[i].Type = shared.AlertEventCovered
+		}
+	}
+	return events, stamped
+}
+
+// applyNotifiedAt stamps at onto the alerts whose IDs are listed, leaving
+// every other alert as stored.
+func applyNotifiedAt(alerts []shared.Alert, alertIDs []string, at time.Time) []shared.Alert {
+	wanted := make(map[string]bool, len(alertIDs))
+	for _, id := range alertIDs {
+		wanted[id] = true
+	}
+	updated := make([]shared.Alert, len(alerts))
+	copy(updated, alerts)
+	for i := range updated {
+		if wanted[updated[i].ID] {
+			updated[i].NotifiedAt = at
+		}
+	}
+	return updated
+}
+
+func (s *FirestoreStore) SaveObservation(ctx context.Context, rec ObservationRecord) error {
+	_, _, err := s.client.Collection(shared.NotifierObservationsCollection).Add(ctx, rec)
+	if err != nil {
+		return fmt.Errorf("saving observation for %s: %w", rec.Location, err)
+	}
+	return nil
+}
+
+// ListObservations relies on the notifier_observations (location,
+// evaluated_at) composite index. An hourly job writes ~720 records per
+// location a month, small enough to read in one pass.
+func (s *FirestoreStore) ListObservations(ctx context.Context, locationID string, start, end time.Time) ([]ObservationRecord, error) {
+	iter := s.client.Collection(shared.NotifierObservationsCollection).
+		Where("location", "==", locationID).
+		Where("evaluated_at", ">=", start).
+		Where("evaluated_at", "<", end).
+		OrderBy("evaluated_at", firestore.Asc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var out []ObservationRecord
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing observations for %s: %w", locationID, err)
+		}
+		var rec ObservationRecord
+		if err := doc.DataTo(&rec); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", doc.Ref.Path, err)
+		}
+		out = append(out, rec)
+	}
+}
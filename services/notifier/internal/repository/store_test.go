@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+func TestApplyNotifiedAt(t *testing.T) {
+	at := time.Date(2026, 6, 12, 12, 0, 0, 0, time.UTC)
+	earlier := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	stored := []shared.Alert{
+		{ID: "delivered-now", Status: shared.AlertStatusActive},
+		{ID: "untouched", Status: shared.AlertStatusActive},
+		{ID: "delivered-before", Status: shared.AlertStatusActive, NotifiedAt: earlier},
+	}
+
+	got := applyNotifiedAt(stored, []string{"delivered-now"}, at)
+
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3 — the whole set is rewritten, not just the marked ones", len(got))
+	}
+	if !got[0].NotifiedAt.Equal(at) {
+		t.Errorf("delivered-now NotifiedAt = %v, want %v", got[0].NotifiedAt, at)
+	}
+	if !got[1].NotifiedAt.IsZero() {
+		t.Errorf("untouched NotifiedAt = %v, want zero", got[1].NotifiedAt)
+	}
+	if !got[2].NotifiedAt.Equal(earlier) {
+		t.Errorf("delivered-before NotifiedAt = %v, want its stored %v", got[2].NotifiedAt, earlier)
+	}
+	if got[0].Status != shared.AlertStatusActive {
+		t.Errorf("Status = %q, want active — delivery does not change whether the condition is present", got[0].Status)
+	}
+	if !stored[0].NotifiedAt.IsZero() {
+		t.Error("applyNotifiedAt mutated its input")
+	}
+}
+
+func TestApplyNotifiedAt_UnknownIDIsIgnored(t *testing.T) {
+	at := time.Date(2026, 6, 12, 12, 0, 0, 0, time.UTC)
+	stored := []shared.Alert{{ID: "a", Status: shared.AlertStatusActive}}
+
+	// A concurrent run can replace the alert set between delivery and
+	// marking; matching by ID means the stale ID is simply dropped.
+	got := applyNotifiedAt(stored, []string{"gone"}, at)
+
+	if len(got) != 1 || !got[0].NotifiedAt.IsZero() {
+		t.Errorf("got %v, want the stored set unchanged", got)
+	}
+}
@@ -3,43 +3,60 @@ package repository
 import (
 	"time"
 
-	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
 )
 
-// The structs below mirror documents owned by other services:
-// weather_cache is written by weather-collector, forecast_cache by
-// forecast-collector. Firestore ignores document fields absent from the
-// destination struct, so these declare only what this job reads rather than
-// duplicating the owners' full schemas. weather-provider does the same thing
-// for the same reason.
-//
-// The cost of mirroring is that a shape change upstream fails at decode time
-// rather than compile time. Keep these minimal so there is less to drift.
+// weather_cache is written by weather-collector and forecast_cache by
+// forecast-collector. Both are defined once, in shared/schema, so this job
+// decodes every field the collectors write. MarkNotified writes either doc's
+// alerts back whole, so it refuses a document from a newer schema rather than
+// drop what this build cannot decode.
+type (
+	WeatherCacheDoc  = schema.WeatherCacheDoc
+	ForecastPoint    = schema.ForecastPoint
+	ForecastCacheDoc = schema.ForecastCacheDoc
+	AlertEventRecord = schema.AlertEventRecord
+)
 
-// ObservedPoint is the subset of weather-collector's WeatherPoint this job
-// uses.
-type ObservedPoint struct {
-	Timestamp  time.Time `firestore:"timestamp"`
-	PressureMb float64   `firestore:"pressure_mb"`
+// ObservationRecord is one notifier_observations document. This job owns the
+// collection: one document is appended per location per run, so divergence
+// can be analyzed over weeks without scraping Cloud Logging.
+//
+// Offsets are stored as whole hours rather than a time.Duration so the
+// documents read naturally outside Go.
+type ObservationRecord struct {
+	Location         string          `firestore:"location" json:"location"`
+	EvaluatedAt      time.Time       `firestore:"evaluated_at" json:"evaluated_at"`
+	Observed         *ObservedRecord `firestore:"observed" json:"observed"`
+	ForecastIssuedAt time.Time       `firestore:"forecast_issued_at" json:"forecast_issued_at"`
+	ForecastAgeMin   int             `firestore:"forecast_age_min" json:"forecast_age_min"`
+	DivergenceMb     *float64        `firestore:"divergence_mb" json:"divergence_mb"`
+	Forward          []ForwardRecord `firestore:"forward" json:"forward"`
+	Alerts           []AlertRecord   `firestore:"alerts" json:"alerts"`
+	Suppressed       string          `firestore:"suppressed" json:"suppressed"`
+	Delivered        []string        `firestore:"delivered" json:"delivered"`
 }
 
-// WeatherCacheDoc mirrors weather_cache/{locationID}.
-type WeatherCacheDoc struct {
-	LastUpdated time.Time     `firestore:"last_updated"`
-	Current     ObservedPoint `firestore:"current"`
+type ObservedRecord struct {
+	PressureMb float64   `firestore:"pressure_mb" json:"pressure_mb"`
+	At         time.Time `firestore:"at" json:"at"`
+	AgeMin     int       `firestore:"age_min" json:"age_min"`
 }
 
-// ForecastPoint is the subset of forecast-collector's ForecastPoint this job
-// uses.
-type ForecastPoint struct {
-	ValidTime  time.Time `firestore:"valid_time"`
-	PressureMb float64   `firestore:"pressure_mb"`
+type ForwardRecord struct {
+	OffsetHours int       `firestore:"offset_hours" json:"offset_hours"`
+	DeltaMb     *float64  `firestore:"delta_mb" json:"delta_mb"`
+	MatchedAt   time.Time `firestore:"matched_at" json:"matched_at"`
 }
 
-// ForecastCacheDoc mirrors forecast_cache/{locationID}.
-type ForecastCacheDoc struct {
-	Location string          `firestore:"location"`
-	IssuedAt time.Time       `firestore:"issued_at"`
-	Points   []ForecastPoint `firestore:"points"`
-	Alerts   []shared.Alert  `firestore:"alerts"`
+type AlertRecord struct {
+	ID            string    `firestore:"id" json:"id"`
+	RuleID        string    `firestore:"rule_id" json:"rule_id"`
+	Severity      string    `firestore:"severity" json:"severity"`
+	Status        string    `firestore:"status" json:"status"`
+	Value         float64   `firestore:"value" json:"value"`
+	WindowStart   time.Time `firestore:"window_start" json:"window_start"`
+	WindowEnd     time.Time `firestore:"window_end" json:"window_end"`
+	NotifiedAt    time.Time `firestore:"notified_at" json:"notified_at"`
+	HoursToWindow float64   `firestore:"hours_to_window" json:"hours_to_window"`
 }
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"math"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// Reasons the gate holds a location's alerts back. They are stored on the
+// observation record, so they are part of its schema.
+const (
+	SuppressedStale      = "forecast_stale"
+	SuppressedDivergence = "divergence"
+)
+
+// GateConfig bounds when the forecast is trusted enough to deliver the
+// alerts detected on it, sourced from env.
+type GateConfig struct {
+	MaxDivergenceMb   float64 // NOTIFY_MAX_DIVERGENCE_MB: hold alerts when |observed - forecast| exceeds this
+	MaxForecastAgeMin int     // NOTIFY_MAX_FORECAST_AGE_MIN: hold alerts when the forecast is older than this
+}
+
+// DefaultGateConfig allows one missed forecast-collector run (every 6h)
+// before the forecast counts as stale. The divergence bound sits below the
+// 5 mb warning threshold: a forecast already that far off the barometer is
+// not one whose predicted drop is worth waking someone for.
+func DefaultGateConfig() GateConfig {
+	return GateConfig{MaxDivergenceMb: 3, MaxForecastAgeMin: 9 * 60}
+}
+
+// Gate returns why an observation's alerts should be held back, or "" when
+// they may be delivered.
+//
+// Holding is not dropping: a held alert keeps a zero NotifiedAt and is
+// reconsidered on the next hourly run, so a fresh forecast or a barometer
+// that comes back into line releases it. Missing evidence does not hold an
+// alert — with no observation or no forecast point near it there is no
+// divergence to measure, and silence is the worse failure for an alerting
+// system.
+func Gate(o Observation, cfg GateConfig) string {
+	if o.ForecastAgeMin > cfg.MaxForecastAgeMin {
+		return SuppressedStale
+	}
+	if o.DivergenceMb != nil && math.Abs(*o.DivergenceMb) > cfg.MaxDivergenceMb {
+		return SuppressedDivergence
+	}
+	return ""
+}
+
+// pendingAlerts returns the alerts that are in the current forecast and have
+// not been delivered. Clearing NotifiedAt re-arms an alert, which is how
+// shared.MergeAlerts makes an escalation re-deliver.
+func pendingAlerts(alerts []shared.Alert) []shared.Alert {
+	var pending []shared.Alert
+	for _, a := range alerts {
+		if a.Status == shared.AlertStatusActive && a.NotifiedAt.IsZero() {
+			pending = append(pending, a)
+		}
+	}
+	return pending
+}
+
+// coveredByForecast reports whether a delivered forecast pressure-drop alert
+// already told the user about an observed drop: one whose window overlaps
+// the observed alert's and whose worst severity is at least as bad. A
+// pressure-drop alert is one watching pressure_mb for a drop, whatever its
+// rule is called. An observed drop the forecast missed, or one that turned
+// out severe where the forecast said warning, is not covered.
+func coveredByForecast(observed shared.Alert, forecast []shared.Alert) bool {
+	for _, f := range forecast {
+		if f.Metric != shared.MetricPressureMb || f.Direction != shared.AlertDirectionDrop || f.NotifiedAt.IsZero() {
+			continue
+		}
+		if !f.WindowStart.Before(observed.WindowEnd) || !observed.WindowStart.Before(f.WindowEnd) {
+			continue
+		}
+		if _, severity := f.Peak(); observed.Severity != shared.AlertSeveritySevere || severity == shared.AlertSeveritySevere {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+func divergence(mb float64) *float64 { return &mb }
+
+func TestGate(t *testing.T) {
+	cfg := GateConfig{MaxDivergenceMb: 3, MaxForecastAgeMin: 540}
+	tests := []struct {
+		name string
+		obs  Observation
+		want string
+	}{
+		{name: "fresh and in line", obs: Observation{ForecastAgeMin: 60, DivergenceMb: divergence(0.4)}},
+		{name: "at the age bound", obs: Observation{ForecastAgeMin: 540, DivergenceMb: divergence(0)}},
+		{name: "stale", obs: Observation{ForecastAgeMin: 541, DivergenceMb: divergence(0)}, want: SuppressedStale},
+		{name: "diverged high", obs: Observation{ForecastAgeMin: 60, DivergenceMb: divergence(3.5)}, want: SuppressedDivergence},
+		{name: "diverged low", obs: Observation{ForecastAgeMin: 60, DivergenceMb: divergence(-3.5)}, want: SuppressedDivergence},
+		// Staleness wins: divergence against a stale forecast says nothing
+		// about the run that will replace it.
+		{name: "stale and diverged", obs: Observation{ForecastAgeMin: 600, DivergenceMb: divergence(5)}, want: SuppressedStale},
+		// No observation means no evidence against the forecast; the alert
+		// goes out rather than being silently held.
+		{name: "no divergence measured", obs: Observation{ForecastAgeMin: 60}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Gate(tt.obs, cfg); got != tt.want {
+				t.Errorf("Gate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoveredByForecast(t *testing.T) {
+	start := testNow
+	delivered := testNow.Add(-12 * time.Hour)
+	observed := shared.Alert{
+		RuleID: "pressure-drop-observed-3h", Severity: shared.AlertSeverityWarning,
+		WindowStart: start, WindowEnd: start.Add(6 * time.Hour),
+	}
+	forecast := func(metric, direction, severity string, from int, notifiedAt time.Time) shared.Alert {
+		return shared.Alert{
+			Metric: metric, Direction: direction, Severity: severity, NotifiedAt: notifiedAt,
+			WindowStart: start.Add(time.Duration(from) * time.Hour), WindowEnd: start.Add(time.Duration(from+3) * time.Hour),
+		}
+	}
+	severe := observed
+	severe.Severity = shared.AlertSeveritySevere
+
+	tests := []struct {
+		name     string
+		observed shared.Alert
+		forecast shared.Alert
+		want     bool
+	}{
+		{"delivered overlapping drop", observed, forecast(shared.MetricPressureMb, shared.AlertDirectionDrop, shared.AlertSeverityWarning, 1, delivered), true},
+		{"undelivered", observed, forecast(shared.MetricPressureMb, shared.AlertDirectionDrop, shared.AlertSeverityWarning, 1, time.Time{}), false},
+		{"no overlap", observed, forecast(shared.MetricPressureMb, shared.AlertDirectionDrop, shared.AlertSeverityWarning, 6, delivered), false},
+		{"pressure rise", observed, forecast(shared.MetricPressureMb, shared.AlertDirectionRise, shared.AlertSeverityWarning, 1, delivered), false},
+		{"other metric", observed, forecast("wind_gust_kph", "", shared.AlertSeverityWarning, 1, delivered), false},
+		{"observed worse", severe, forecast(shared.MetricPressureMb, shared.AlertDirectionDrop, shared.AlertSeverityWarning, 1, delivered), false},
+		{"forecast as bad", severe, forecast(shared.MetricPressureMb, shared.AlertDirectionDrop, shared.AlertSeveritySevere, 1, delivered), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := coveredByForecast(tt.observed, []shared.Alert{tt.forecast}); got != tt.want {
+				t.Errorf("coveredByForecast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
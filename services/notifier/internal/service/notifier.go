@@ -4,58 +4,143 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/shared"
-	"time"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
-// NotifierService reads the cache documents for a location and records what
-// it sees.
+// NotifierService reads the cache documents for a location, delivers the
+// forecast's alerts when the observation says the forecast can be trusted,
+// delivers weather-collector's observed-pressure alerts the forecast did not
+// already cover, and records what it saw.
 //
-// It sends nothing. Delivery lives in forecast-collector and stays there
-// until the gate is designed (#79, #80); this job exists to make that design
-// answerable from measurements rather than argument. It holds no credentials,
-// so sending is not merely disabled but structurally unavailable.
+// Delivery moved here from forecast-collector, which runs every 6 hours and
+// so could only send on first detection. Running hourly against the
+// barometer lets the gate hold an alert while the forecast it came from is
+// stale or has already drifted from reality (#79, #80).
 type NotifierService struct {
-	store repository.Store
+	store  repository.Store
+	sender notify.Sender
+	gate   GateConfig
 }
 
-func NewNotifierService(store repository.Store) *NotifierService {
-	return &NotifierService{store: store}
+func NewNotifierService(store repository.Store, sender notify.Sender, gate GateConfig) *NotifierService {
+	return &NotifierService{store: store, sender: sender, gate: gate}
 }
 
-// Observe reads one location, logs its state, and returns the record it
-// built. Returns an error only when the location could not be read at all.
+// Observe reads one location, delivers its pending alerts unless the gate
+// holds them, logs its state, appends it to notifier_observations, and
+// returns the record it built. Returns an error when the location could not
+// be read or the record could not be saved; delivery failures are logged
+// rather than returned, so a notification problem does not hide the record.
 //
-// The Observation is returned rather than discarded so callers can assert on
-// it, and so the delivery gate can be layered on here without restructuring
-// when #79 and #80 land.
+// A forecast that cannot be read still fails the location, but only after
+// its observed alerts have gone out: they come from the barometer, and a
+// forecast-collector outage is no reason to sit on a drop it already shows.
 func (s *NotifierService) Observe(ctx context.Context, location shared.Location, now time.Time) (Observation, error) {
-	forecast, err := s.store.ReadForecast(ctx, location.ID)
+	// A missing observation is recorded, not fatal: weather-collector may not
+	// have run for this location, and knowing that is itself a finding.
+	observed, err := s.store.ReadObservation(ctx, location.ID)
 	if err != nil {
 		return Observation{}, err
 	}
+	var observedAlerts []shared.Alert
+	if observed != nil {
+		observedAlerts = observed.Alerts
+	}
+
+	forecast, err := s.store.ReadForecast(ctx, location.ID)
 	// The two Store reads have deliberately opposite nil conventions —
 	// ReadObservation returns (nil, nil) for a missing document, ReadForecast
 	// returns an error — and only a doc comment enforces the difference. Turn
 	// a violation into this location's failure rather than a panic, which
 	// would take down the other locations with it.
-	if forecast == nil {
-		return Observation{}, fmt.Errorf("store returned no forecast and no error for %s", location.ID)
+	if err == nil && forecast == nil {
+		err = fmt.Errorf("store returned no forecast and no error for %s", location.ID)
 	}
-	// A missing observation is recorded, not fatal: weather-collector may not
-	// have run for this location, and knowing that is itself a finding.
-	observed, err := s.store.ReadObservation(ctx, location.ID)
 	if err != nil {
+		// With no forecast alerts the gate has nothing to hold, and no
+		// forecast alert covers an observed one.
+		s.deliver(ctx, Observation{Location: location.ID, Now: now}, nil, observedAlerts, location.TimeZone())
 		return Observation{}, err
 	}
 
 	obs := BuildObservation(location.ID, observed, forecast, now)
+	obs.Suppressed, obs.Delivered = s.deliver(ctx, obs, forecast.Alerts, observedAlerts, location.TimeZone())
+	// Logged before saving so a failed write still leaves the evidence in
+	// Cloud Logging.
 	logObservation(obs)
+	if err := s.store.SaveObservation(ctx, obs.Record()); err != nil {
+		return Observation{}, err
+	}
 	return obs, nil
 }
 
+// deliver sends every pending forecast alert unless the gate holds them, and
+// every pending observed alert coveredByForecast does not rule out, then
+// records which ones went out, which were covered and which were held. It
+// returns the gate's reason when forecast alerts were held, and the IDs that
+// were sent. zone is the location's, for the message body.
+//
+// A covered alert is marked as well, so it stops being pending: otherwise it
+// would be re-checked every run and never reach alert_events. Marking it does
+// not stop a later severity upgrade going out, since MergeAlerts re-arms an
+// escalated alert.
+//
+// Observed alerts skip the gate. It asks whether the forecast still matches
+// the barometer, and an observed alert is the barometer: a drop the forecast
+// missed is exactly when the two diverge.
+//
+// Order matters: deliver, then mark. If marking fails the alert re-delivers
+// on the next run, whereas marking first risks recording a delivery that
+// never happened — the worse failure for an alerting system.
+func (s *NotifierService) deliver(ctx context.Context, o Observation, forecast, observed []shared.Alert, zone *time.Location) (string, []string) {
+	var send []shared.Alert
+	var reason string
+	if pending := pendingAlerts(forecast); len(pending) > 0 {
+		if reason = Gate(o, s.gate); reason != "" {
+			slog.Info("Held alerts", "location", o.Location, "reason", reason, "alerts", len(pending))
+			// Held alerts stay pending, so a failure here loses only their
+			// history for this run.
+			if err := s.store.RecordHeld(ctx, pending, o.Now); err != nil {
+				slog.Error("Failed to record held alerts", "location", o.Location, "error", err)
+			}
+		} else {
+			send = pending
+		}
+	}
+	var covered []string
+	for _, a := range pendingAlerts(observed) {
+		if coveredByForecast(a, forecast) {
+			slog.Debug("Observed alert already covered by a forecast alert", "location", o.Location, "alert", a.ID)
+			covered = append(covered, a.ID)
+			continue
+		}
+		send = append(send, a)
+	}
+
+	var delivered []string
+	for _, a := range send {
+		if err := s.sender.Send(ctx, notify.FromAlert(a, zone)); err != nil {
+			slog.Error("Failed to deliver alert", "location", o.Location, "alert", a.ID, "error", err)
+			continue
+		}
+		slog.Info("Delivered alert", "location", o.Location, "alert", a.ID, "severity", a.Severity)
+		delivered = append(delivered, a.ID)
+	}
+	if len(delivered) == 0 && len(covered) == 0 {
+		return reason, nil
+	}
+	if err := s.store.MarkNotified(ctx, o.Location, delivered, covered, o.Now); err != nil {
+		// The alerts will re-deliver on the next run, and the covered ones
+		// be checked again.
+		slog.Error("Failed to mark alerts as notified", "location", o.Location, "alerts", delivered, "covered", covered, "error", err)
+	}
+	return reason, delivered
+}
+
 func logObservation(o Observation) {
 	attrs := []any{
 		"location", o.Location,
@@ -71,6 +156,9 @@ func logObservation(o Observation) {
 	} else {
 		attrs = append(attrs, "observed_missing", true)
 	}
+	if o.DivergenceMb != nil {
+		attrs = append(attrs, "divergence_mb", *o.DivergenceMb)
+	}
 	for _, d := range o.Forward {
 		key := fmt.Sprintf("fwd_%02dh", int(d.Offset.Hours()))
 		if d.DeltaMb != nil {
@@ -79,6 +167,12 @@ func logObservation(o Observation) {
 			attrs = append(attrs, key, nil)
 		}
 	}
+	if o.Suppressed != "" {
+		attrs = append(attrs, "suppressed", o.Suppressed)
+	}
+	if len(o.Delivered) > 0 {
+		attrs = append(attrs, "delivered", o.Delivered)
+	}
 	slog.Info("observation", attrs...)
 
 	for _, a := range o.Alerts {
@@ -3,16 +3,22 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/testutil"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
 var testLocation = shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 
+func newTestService(store *testutil.MockStore) *NotifierService {
+	return NewNotifierService(store, &testutil.MockSender{}, DefaultGateConfig())
+}
+
 func TestObserve_ReadsBothCaches(t *testing.T) {
 	var forecastFor, observationFor string
 	store := &testutil.MockStore{
@@ -26,7 +32,7 @@ func TestObserve_ReadsBothCaches(t *testing.T) {
 		},
 	}
 
-	if _, err := NewNotifierService(store).Observe(context.Background(), testLocation, testNow); err != nil {
+	if _, err := newTestService(store).Observe(context.Background(), testLocation, testNow); err != nil {
 		t.Fatalf("Observe() returned error: %v", err)
 	}
 	if forecastFor != "house-nick" || observationFor != "house-nick" {
@@ -46,7 +52,7 @@ func TestObserve_MissingObservationSucceeds(t *testing.T) {
 		},
 	}
 
-	if _, err := NewNotifierService(store).Observe(context.Background(), testLocation, testNow); err != nil {
+	if _, err := newTestService(store).Observe(context.Background(), testLocation, testNow); err != nil {
 		t.Fatalf("Observe() should tolerate a missing observation, got: %v", err)
 	}
 }
@@ -56,17 +62,37 @@ func TestObserve_MissingForecastFails(t *testing.T) {
 		ReadForecastFn: func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
 			return nil, fmt.Errorf("no forecast cache document for %s", id)
 		},
-		ReadObservationFn: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
-			t.Fatal("observation should not be read when the forecast is missing")
-			return nil, nil
-		},
 	}
 
-	if _, err := NewNotifierService(store).Observe(context.Background(), testLocation, testNow); err == nil {
+	if _, err := newTestService(store).Observe(context.Background(), testLocation, testNow); err == nil {
 		t.Fatal("Observe() should fail when there is no forecast to observe against")
 	}
 }
 
+func TestObserve_MissingForecastStillDeliversObservedAlerts(t *testing.T) {
+	var marked []string
+	sender := &testutil.MockSender{}
+	store := deliveryStore(nil, &marked, nil)
+	store.ReadForecastFn = func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
+		return nil, fmt.Errorf("no forecast cache document for %s", id)
+	}
+	store.ReadObservationFn = func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+		doc := observedAt(1009, 0)
+		doc.Alerts = []shared.Alert{{ID: "observed", RuleID: "pressure-drop-observed-3h", Status: shared.AlertStatusActive}}
+		return doc, nil
+	}
+
+	if _, err := NewNotifierService(store, sender, DefaultGateConfig()).Observe(context.Background(), testLocation, testNow); err == nil {
+		t.Fatal("Observe() should still fail when there is no forecast to observe against")
+	}
+	if got := deliveredIDs(sender.Sent); len(got) != 1 || got[0] != "observed" {
+		t.Errorf("delivered %v, want [observed] without a forecast", got)
+	}
+	if len(marked) != 1 || marked[0] != "observed" {
+		t.Errorf("MarkNotified got %v, want [observed]", marked)
+	}
+}
+
 func TestObserve_ObservationReadErrorPropagates(t *testing.T) {
 	store := &testutil.MockStore{
 		ReadForecastFn: func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
@@ -79,7 +105,252 @@ func TestObserve_ObservationReadErrorPropagates(t *testing.T) {
 
 	// A read failure is different from an absent document: the first means
 	// something is broken, the second means nothing has run yet.
-	if _, err := NewNotifierService(store).Observe(context.Background(), testLocation, testNow); err == nil {
+	if _, err := newTestService(store).Observe(context.Background(), testLocation, testNow); err == nil {
 		t.Fatal("Observe() should propagate a failed observation read")
 	}
 }
+
+func TestObserve_SavesRecord(t *testing.T) {
+	var saved []repository.ObservationRecord
+	store := &testutil.MockStore{
+		ReadForecastFn: func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
+			return &repository.ForecastCacheDoc{IssuedAt: testNow, Points: forecastPoints(1013, 1012, 1011, 1010)}, nil
+		},
+		ReadObservationFn: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+			return observedAt(1012, 5*time.Minute), nil
+		},
+		SaveObservationFn: func(ctx context.Context, rec repository.ObservationRecord) error {
+			saved = append(saved, rec)
+			return nil
+		},
+	}
+
+	if _, err := newTestService(store).Observe(context.Background(), testLocation, testNow); err != nil {
+		t.Fatalf("Observe() returned error: %v", err)
+	}
+	if len(saved) != 1 {
+		t.Fatalf("saved %d records, want 1", len(saved))
+	}
+	rec := saved[0]
+	if rec.Location != "house-nick" || !rec.EvaluatedAt.Equal(testNow) {
+		t.Errorf("saved record for %q at %v, want house-nick at %v", rec.Location, rec.EvaluatedAt, testNow)
+	}
+	if rec.Observed == nil || rec.Observed.PressureMb != 1012 {
+		t.Errorf("saved observed = %+v, want 1012 mb", rec.Observed)
+	}
+}
+
+func TestObserve_SaveErrorFails(t *testing.T) {
+	store := &testutil.MockStore{
+		ReadForecastFn: func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
+			return &repository.ForecastCacheDoc{IssuedAt: testNow}, nil
+		},
+		SaveObservationFn: func(ctx context.Context, rec repository.ObservationRecord) error {
+			return fmt.Errorf("firestore unavailable")
+		},
+	}
+
+	// The record is the point of the run; a location whose record was lost
+	// counts as failed so an all-locations outage fails the job.
+	if _, err := newTestService(store).Observe(context.Background(), testLocation, testNow); err == nil {
+		t.Fatal("Observe() should fail when the record cannot be saved")
+	}
+}
+
+// deliveryStore returns a store whose forecast carries the given alerts and
+// whose observation sits on the forecast, so the gate passes unless a test
+// says otherwise. MarkNotified records the delivered IDs it was handed.
+func deliveryStore(alerts []shared.Alert, marked *[]string, markErr error) *testutil.MockStore {
+	return &testutil.MockStore{
+		ReadForecastFn: func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
+			return &repository.ForecastCacheDoc{IssuedAt: testNow, Points: forecastPoints(1013), Alerts: alerts}, nil
+		},
+		ReadObservationFn: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+			return observedAt(1013, 0), nil
+		},
+		MarkNotifiedFn: func(ctx context.Context, locationID string, alertIDs, covered []string, at time.Time) error {
+			*marked = append(*marked, alertIDs...)
+			return markErr
+		},
+	}
+}
+
+func deliveredIDs(sent []notify.Notification) []string {
+	ids := make([]string, len(sent))
+	for i, n := range sent {
+		ids[i] = n.Alert.ID
+	}
+	return ids
+}
+
+func TestObserve_DeliversOnlyUndeliveredActiveAlerts(t *testing.T) {
+	past := testNow.Add(-6 * time.Hour)
+	alerts := []shared.Alert{
+		{ID: "undelivered", Status: shared.AlertStatusActive},
+		{ID: "already-delivered", Status: shared.AlertStatusActive, NotifiedAt: past},
+		{ID: "resolved", Status: shared.AlertStatusResolved, NotifiedAt: past},
+		{ID: "resolved-undelivered", Status: shared.AlertStatusResolved},
+	}
+	var marked []string
+	sender := &testutil.MockSender{}
+
+	obs, err := NewNotifierService(deliveryStore(alerts, &marked, nil), sender, DefaultGateConfig()).
+		Observe(context.Background(), testLocation, testNow)
+	if err != nil {
+		t.Fatalf("Observe() returned error: %v", err)
+	}
+
+	if got := deliveredIDs(sender.Sent); len(got) != 1 || got[0] != "undelivered" {
+		t.Errorf("delivered %v, want only [undelivered]", got)
+	}
+	if len(marked) != 1 || marked[0] != "undelivered" {
+		t.Errorf("MarkNotified got %v, want [undelivered]", marked)
+	}
+	if len(obs.Delivered) != 1 || obs.Delivered[0] != "undelivered" {
+		t.Errorf("Observation.Delivered = %v, want [undelivered]", obs.Delivered)
+	}
+}
+
+func TestObserve_GateHoldsAlerts(t *testing.T) {
+	alerts := []shared.Alert{{ID: "undelivered", Status: shared.AlertStatusActive}}
+	var marked []string
+	sender := &testutil.MockSender{}
+	store := deliveryStore(alerts, &marked, nil)
+	// The barometer reads 4 mb below the forecast for the same hour.
+	store.ReadObservationFn = func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+		return observedAt(1009, 0), nil
+	}
+	var held []shared.Alert
+	store.RecordHeldFn = func(ctx context.Context, alerts []shared.Alert, at time.Time) error {
+		held = append(held, alerts...)
+		return nil
+	}
+
+	obs, err := NewNotifierService(store, sender, DefaultGateConfig()).Observe(context.Background(), testLocation, testNow)
+	if err != nil {
+		t.Fatalf("Observe() returned error: %v", err)
+	}
+
+	if len(sender.Sent) != 0 || len(marked) != 0 {
+		t.Errorf("sent %v and marked %v, want nothing while the forecast has diverged", deliveredIDs(sender.Sent), marked)
+	}
+	if obs.Suppressed != SuppressedDivergence {
+		t.Errorf("Observation.Suppressed = %q, want %q", obs.Suppressed, SuppressedDivergence)
+	}
+	if len(held) != 1 || held[0].ID != "undelivered" {
+		t.Errorf("recorded held %+v, want the held alert", held)
+	}
+}
+
+func TestObserve_ObservedAlertsBypassTheGate(t *testing.T) {
+	forecast := []shared.Alert{{ID: "forecast", Status: shared.AlertStatusActive}}
+	var marked []string
+	sender := &testutil.MockSender{}
+	store := deliveryStore(forecast, &marked, nil)
+	// The barometer has dropped 4 mb below the forecast, and weather-collector
+	// has raised the drop the forecast missed.
+	store.ReadObservationFn = func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+		doc := observedAt(1009, 0)
+		doc.Alerts = []shared.Alert{{ID: "observed", RuleID: "pressure-drop-observed-3h", Status: shared.AlertStatusActive}}
+		return doc, nil
+	}
+
+	obs, err := NewNotifierService(store, sender, DefaultGateConfig()).Observe(context.Background(), testLocation, testNow)
+	if err != nil {
+		t.Fatalf("Observe() returned error: %v", err)
+	}
+
+	if got := deliveredIDs(sender.Sent); len(got) != 1 || got[0] != "observed" {
+		t.Errorf("delivered %v, want only [observed] while the forecast's are held", got)
+	}
+	if len(marked) != 1 || marked[0] != "observed" {
+		t.Errorf("MarkNotified got %v, want [observed]", marked)
+	}
+	if obs.Suppressed != SuppressedDivergence {
+		t.Errorf("Observation.Suppressed = %q, want %q for the held forecast alert", obs.Suppressed, SuppressedDivergence)
+	}
+	if len(obs.Alerts) != 2 {
+		t.Errorf("Observation.Alerts = %+v, want the forecast and observed alerts", obs.Alerts)
+	}
+}
+
+func TestObserve_ObservedAlertCoveredByForecast(t *testing.T) {
+	window := testNow.Add(-2 * time.Hour)
+	forecast := []shared.Alert{{
+		ID: "forecast", RuleID: "pressure-drop-3h", Metric: shared.MetricPressureMb, Direction: shared.AlertDirectionDrop,
+		Severity: shared.AlertSeverityWarning, Status: shared.AlertStatusActive,
+		WindowStart: window, WindowEnd: window.Add(3 * time.Hour), NotifiedAt: testNow.Add(-12 * time.Hour),
+	}}
+	var marked, covered []string
+	sender := &testutil.MockSender{}
+	store := deliveryStore(forecast, &marked, nil)
+	// The observed alert lives in the store's weather cache doc, and
+	// MarkNotified stamps it there as the real stores do.
+	observed := []shared.Alert{{
+		ID: "observed", RuleID: "pressure-drop-observed-3h", Severity: shared.AlertSeverityWarning, Status: shared.AlertStatusActive,
+		WindowStart: window.Add(time.Hour), WindowEnd: window.Add(6 * time.Hour),
+	}}
+	store.ReadObservationFn = func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+		doc := observedAt(1013, 0)
+		doc.Alerts = slices.Clone(observed)
+		return doc, nil
+	}
+	store.MarkNotifiedFn = func(ctx context.Context, locationID string, alertIDs, coveredIDs []string, at time.Time) error {
+		marked = append(marked, alertIDs...)
+		covered = append(covered, coveredIDs...)
+		for i := range observed {
+			if slices.Contains(coveredIDs, observed[i].ID) {
+				observed[i].NotifiedAt = at
+			}
+		}
+		return nil
+	}
+
+	svc := NewNotifierService(store, sender, DefaultGateConfig())
+	if _, err := svc.Observe(context.Background(), testLocation, testNow); err != nil {
+		t.Fatalf("Observe() returned error: %v", err)
+	}
+	if len(sender.Sent) != 0 || len(marked) != 0 {
+		t.Errorf("sent %v and marked %v, want nothing for a drop the forecast already delivered", deliveredIDs(sender.Sent), marked)
+	}
+	if len(covered) != 1 || covered[0] != "observed" {
+		t.Errorf("covered %v, want [observed] recorded so it stops being pending", covered)
+	}
+
+	// The next run finds the alert stamped and leaves it alone.
+	if _, err := svc.Observe(context.Background(), testLocation, testNow.Add(time.Hour)); err != nil {
+		t.Fatalf("second Observe() returned error: %v", err)
+	}
+	if len(sender.Sent) != 0 || len(covered) != 1 {
+		t.Errorf("second run sent %v and covered %v, want the covered alert not picked up again", deliveredIDs(sender.Sent), covered)
+	}
+}
+
+func TestObserve_SendFailureDoesNotFailRun(t *testing.T) {
+	alerts := []shared.Alert{{ID: "undelivered", Status: shared.AlertStatusActive}}
+	var marked []string
+	sender := &testutil.MockSender{Err: fmt.Errorf("smtp unavailable")}
+
+	_, err := NewNotifierService(deliveryStore(alerts, &marked, nil), sender, DefaultGateConfig()).
+		Observe(context.Background(), testLocation, testNow)
+	if err != nil {
+		t.Fatalf("Observe() should not fail when delivery fails, got: %v", err)
+	}
+	if len(marked) != 0 {
+		t.Errorf("MarkNotified got %v, want nothing marked when the send failed", marked)
+	}
+}
+
+func TestObserve_MarkNotifiedFailureDoesNotFailRun(t *testing.T) {
+	alerts := []shared.Alert{{ID: "undelivered", Status: shared.AlertStatusActive}}
+	var marked []string
+	sender := &testutil.MockSender{}
+
+	store := deliveryStore(alerts, &marked, fmt.Errorf("firestore unavailable"))
+	if _, err := NewNotifierService(store, sender, DefaultGateConfig()).Observe(context.Background(), testLocation, testNow); err != nil {
+		t.Fatalf("Observe() should not fail when marking fails, got: %v", err)
+	}
+	if len(sender.Sent) != 1 {
+		t.Errorf("delivered %d alerts, want 1 — the alert re-delivers next run", len(sender.Sent))
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"slices"
 	"time"
 
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/repository"
@@ -20,13 +21,10 @@ const matchTolerance = 45 * time.Minute
 // Observation is one location's state at one evaluation: what the barometer
 // reads now, what the forecast expects, and how far apart they are.
 //
-// It records facts, not verdicts. Deciding what is worth sending is issue
-// #80, and the point of this job is to gather evidence for that decision
-// rather than to bake in a rule that has not been validated.
-//
-// It deliberately does not record forecast error. Observations and forecasts
-// both come from weather.googleapis.com and agree to ~0.01 mb for the same
-// hour, so that measurement is zero by construction — see #80.
+// BuildObservation fills in the facts. The service then records the one
+// verdict this job makes — whether the delivery gate held the location's
+// alerts back — alongside them, so the gate can be tuned from the same
+// records it decided on.
 type Observation struct {
 	Location string
 	Now      time.Time
@@ -37,11 +35,25 @@ type Observation struct {
 	ForecastIssuedAt time.Time
 	ForecastAgeMin   int
 
+	// DivergenceMb is the observed reading minus the forecast for the hour
+	// it was taken. The forecast and observation both come from
+	// weather.googleapis.com, so this is ~0 for a fresh forecast and grows
+	// with ForecastAgeMin as the real atmosphere departs from the run — see
+	// #80. Nil when there is no observation or no forecast point near it.
+	DivergenceMb *float64
+
 	// Forward holds the change from the observed reading to the forecast at
 	// each offset. Nil deltas mean no forecast point fell within tolerance.
 	Forward []ForwardDelta
 
 	Alerts []AlertState
+
+	// Suppressed is the gate's reason for holding back this location's
+	// undelivered alerts, or empty when none were held.
+	Suppressed string
+
+	// Delivered lists the IDs of the alerts sent on this evaluation.
+	Delivered []string
 }
 
 type ObservedReading struct {
@@ -88,11 +100,17 @@ func BuildObservation(locationID string, observed *repository.WeatherCacheDoc, f
 		Alerts:           make([]AlertState, 0, len(forecast.Alerts)),
 	}
 
+	alerts := forecast.Alerts
 	if observed != nil {
+		alerts = append(slices.Clip(alerts), observed.Alerts...)
 		obs.Observed = &ObservedReading{
-			PressureMb: observed.Current.PressureMb,
-			At:         observed.Current.Timestamp,
-			AgeMin:     minutesSince(observed.Current.Timestamp, now),
+			PressureMb: observed.CurrentValue.PressureMb,
+			At:         observed.CurrentValue.Timestamp,
+			AgeMin:     minutesSince(observed.CurrentValue.Timestamp, now),
+		}
+		if forecastMb, ok := forecastAt(forecast.Points, obs.Observed.At); ok {
+			divergence := obs.Observed.PressureMb - forecastMb
+			obs.DivergenceMb = &divergence
 		}
 	}
 
@@ -113,7 +131,9 @@ func BuildObservation(locationID string, observed *repository.WeatherCacheDoc, f
 		obs.Forward = append(obs.Forward, d)
 	}
 
-	for _, a := range forecast.Alerts {
+	// Observed alerts are recorded after the forecast's; their rule IDs tell
+	// them apart.
+	for _, a := range alerts {
 		obs.Alerts = append(obs.Alerts, AlertState{
 			ID:            a.ID,
 			RuleID:        a.RuleID,
@@ -130,6 +150,39 @@ func BuildObservation(locationID string, observed *repository.WeatherCacheDoc, f
 	return obs
 }
 
+// Record converts an Observation into its stored form.
+func (o Observation) Record() repository.ObservationRecord {
+	rec := repository.ObservationRecord{
+		Location:         o.Location,
+		EvaluatedAt:      o.Now,
+		ForecastIssuedAt: o.ForecastIssuedAt,
+		ForecastAgeMin:   o.ForecastAgeMin,
+		DivergenceMb:     o.DivergenceMb,
+		Forward:          make([]repository.ForwardRecord, 0, len(o.Forward)),
+		Alerts:           make([]repository.AlertRecord, 0, len(o.Alerts)),
+		Suppressed:       o.Suppressed,
+		Delivered:        o.Delivered,
+	}
+	if o.Observed != nil {
+		rec.Observed = &repository.ObservedRecord{
+			PressureMb: o.Observed.PressureMb,
+			At:         o.Observed.At,
+			AgeMin:     o.Observed.AgeMin,
+		}
+	}
+	for _, d := range o.Forward {
+		rec.Forward = append(rec.Forward, repository.ForwardRecord{
+			OffsetHours: int(d.Offset.Hours()),
+			DeltaMb:     d.DeltaMb,
+			MatchedAt:   d.MatchedAt,
+		})
+	}
+	for _, a := range o.Alerts {
+		rec.Alerts = append(rec.Alerts, repository.AlertRecord(a))
+	}
+	return rec
+}
+
 // forecastAt returns the forecast pressure nearest the given time.
 func forecastAt(points []repository.ForecastPoint, target time.Time) (float64, bool) {
 	if i := nearestPoint(points, target); i >= 0 {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
 )
 
 var testNow = time.Date(2026, 6, 12, 12, 0, 0, 0, time.UTC)
@@ -25,7 +26,7 @@ func forecastPoints(pressures ...float64) []repository.ForecastPoint {
 func observedAt(pressure float64, ago time.Duration) *repository.WeatherCacheDoc {
 	return &repository.WeatherCacheDoc{
 		LastUpdated: testNow.Add(-ago),
-		Current: repository.ObservedPoint{
+		CurrentValue: schema.WeatherPoint{
 			Timestamp:  testNow.Add(-ago),
 			PressureMb: pressure,
 		},
@@ -180,3 +181,54 @@ func TestBuildObservation_MapsAlertsWithLeadTime(t *testing.T) {
 		t.Errorf("a2 NotifiedAt = %v, want %v", o.Alerts[1].NotifiedAt, notified)
 	}
 }
+
+func TestObservationRecord_StoresOffsetsAsHours(t *testing.T) {
+	forecast := &repository.ForecastCacheDoc{IssuedAt: testNow, Points: forecastPoints(1013, 1012, 1011, 1010)}
+
+	rec := BuildObservation("house-nick", observedAt(1013, 0), forecast, testNow).Record()
+
+	if len(rec.Forward) != len(Offsets) {
+		t.Fatalf("recorded %d forward deltas, want %d", len(rec.Forward), len(Offsets))
+	}
+	if rec.Forward[0].OffsetHours != 3 || rec.Forward[0].DeltaMb == nil || *rec.Forward[0].DeltaMb != -3 {
+		t.Errorf("forward[0] = %+v, want +3h at -3 mb", rec.Forward[0])
+	}
+	// Beyond the forecast's four hours: kept as a row with no delta, so a
+	// reader can tell "no point" from "not evaluated".
+	if last := rec.Forward[len(rec.Forward)-1]; last.OffsetHours != 24 || last.DeltaMb != nil {
+		t.Errorf("forward[last] = %+v, want +24h with nil delta", last)
+	}
+}
+
+func TestObservationRecord_MissingObservationStaysNil(t *testing.T) {
+	forecast := &repository.ForecastCacheDoc{IssuedAt: testNow, Points: forecastPoints(1013)}
+
+	rec := BuildObservation("house-nick", nil, forecast, testNow).Record()
+
+	if rec.Observed != nil {
+		t.Errorf("Observed = %+v, want nil when weather_cache had no document", rec.Observed)
+	}
+}
+
+func TestBuildObservation_DivergenceAtObservedHour(t *testing.T) {
+	// Forecast issued six hours ago; the observation 10 minutes ago lands on
+	// the point for now, which forecast 1010 against a reading of 1012.
+	forecast := &repository.ForecastCacheDoc{
+		IssuedAt: testNow.Add(-6 * time.Hour),
+		Points:   forecastPoints(1010, 1009),
+	}
+
+	o := BuildObservation("house-nick", observedAt(1012, 10*time.Minute), forecast, testNow)
+
+	if o.DivergenceMb == nil || *o.DivergenceMb != 2 {
+		t.Errorf("DivergenceMb = %v, want +2 (observed above forecast)", o.DivergenceMb)
+	}
+}
+
+func TestBuildObservation_NoDivergenceWithoutObservation(t *testing.T) {
+	forecast := &repository.ForecastCacheDoc{IssuedAt: testNow, Points: forecastPoints(1013)}
+
+	if o := BuildObservation("house-nick", nil, forecast, testNow); o.DivergenceMb != nil {
+		t.Errorf("DivergenceMb = %v, want nil with no observation", *o.DivergenceMb)
+	}
+}
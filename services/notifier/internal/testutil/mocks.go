@@ -2,15 +2,23 @@ package testutil
 
 import (
 	"context"
+	"time"
 
 	"github.com/nickfang/personal-dashboard/services/notifier/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
 // MockStore implements repository.Store for testing. Unset funcs return
-// zero values, so a test supplies only the reads it cares about.
+// zero values, so a test supplies only the reads it cares about; unset
+// writes succeed.
 type MockStore struct {
-	ReadObservationFn func(ctx context.Context, locationID string) (*repository.WeatherCacheDoc, error)
-	ReadForecastFn    func(ctx context.Context, locationID string) (*repository.ForecastCacheDoc, error)
+	ReadObservationFn  func(ctx context.Context, locationID string) (*repository.WeatherCacheDoc, error)
+	ReadForecastFn     func(ctx context.Context, locationID string) (*repository.ForecastCacheDoc, error)
+	MarkNotifiedFn     func(ctx context.Context, locationID string, alertIDs, covered []string, at time.Time) error
+	RecordHeldFn       func(ctx context.Context, alerts []shared.Alert, at time.Time) error
+	SaveObservationFn  func(ctx context.Context, rec repository.ObservationRecord) error
+	ListObservationsFn func(ctx context.Context, locationID string, start, end time.Time) ([]repository.ObservationRecord, error)
 }
 
 func (m *MockStore) ReadObservation(ctx context.Context, locationID string) (*repository.WeatherCacheDoc, error) {
@@ -26,3 +34,46 @@ func (m *MockStore) ReadForecast(ctx context.Context, locationID string) (*repos
 	}
 	return m.ReadForecastFn(ctx, locationID)
 }
+
+func (m *MockStore) MarkNotified(ctx context.Context, locationID string, alertIDs, covered []string, at time.Time) error {
+	if m.MarkNotifiedFn == nil {
+		return nil
+	}
+	return m.MarkNotifiedFn(ctx, locationID, alertIDs, covered, at)
+}
+
+func (m *MockStore) RecordHeld(ctx context.Context, alerts []shared.Alert, at time.Time) error {
+	if m.RecordHeldFn == nil {
+		return nil
+	}
+	return m.RecordHeldFn(ctx, alerts, at)
+}
+
+func (m *MockStore) SaveObservation(ctx context.Context, rec repository.ObservationRecord) error {
+	if m.SaveObservationFn == nil {
+		return nil
+	}
+	return m.SaveObservationFn(ctx, rec)
+}
+
+func (m *MockStore) ListObservations(ctx context.Context, locationID string, start, end time.Time) ([]repository.ObservationRecord, error) {
+	if m.ListObservationsFn == nil {
+		return nil, nil
+	}
+	return m.ListObservationsFn(ctx, locationID, start, end)
+}
+
+// MockSender implements notify.Sender for testing: it records what it was
+// asked to deliver and can be made to fail.
+type MockSender struct {
+	Sent []notify.Notification
+	Err  error
+}
+
+func (m *MockSender) Send(ctx context.Context, n notify.Notification) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Sent = append(m.Sent, n)
+	return nil
+}
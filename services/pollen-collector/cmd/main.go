@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -13,6 +14,9 @@ import (
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/service"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
 )
 
 func main() {
@@ -21,30 +25,87 @@ func main() {
 	if err := godotenv.Load(); err != nil {
 		slog.Debug("No .env file found, using system environment variables", "error", err)
 	}
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
 	apiKey := os.Getenv("GOOGLE_MAPS_API_KEY")
 	projectID := os.Getenv("GCP_PROJECT_ID")
-	if apiKey == "" || projectID == "" {
+	if apiKey == "" || (projectID == "" && !useSQLite) {
 		slog.Error("Missing required env vars", "vars", "GOOGLE_MAPS_API_KEY, GCP_PROJECT_ID")
 		os.Exit(1)
 	}
+	detectionCfg := service.DefaultDetectionConfig()
+	detectionCfg.HighIndex = envInt("POLLEN_HIGH_INDEX", detectionCfg.HighIndex)
+	detectionCfg.SevereIndex = envInt("POLLEN_SEVERE_INDEX", detectionCfg.SevereIndex)
+	detectionCfg.JumpLevels = envInt("POLLEN_JUMP_LEVELS", detectionCfg.JumpLevels)
+	detectionCfg.WindowHours = envInt("POLLEN_WINDOW_HOURS", detectionCfg.WindowHours)
+	cfg := service.Config{Detection: detectionCfg}
 
 	ctx := context.Background()
-	writer, err := repository.NewFirestoreWriter(ctx, projectID)
+	locations, err := registry.Load(ctx, projectID, shared.SourcePollen)
+	if err != nil {
+		slog.Error("Failed to load locations", "error", err)
+		os.Exit(1)
+	}
+	if len(locations) == 0 {
+		slog.Warn("No locations enabled, nothing to collect", "source", shared.SourcePollen)
+		return
+	}
+
+	writer, err := repository.OpenWriter(ctx, projectID)
 	if err != nil {
-		slog.Error("Failed to create firestore writer", "error", err)
+		slog.Error("Failed to create writer", "error", err)
 		os.Exit(1)
 	}
 	defer writer.Close()
 
 	httpClient := &http.Client{Timeout: 15 * time.Second}
-	fetcher := api.New(httpClient)
-	collector := service.NewCollectorService(fetcher, writer)
-	if err := collectAll(ctx, apiKey, collector, shared.Locations); err != nil {
+	fetcher := api.NewWithBaseURL(httpClient, os.Getenv("POLLEN_API_BASE_URL"))
+	collector := service.NewCollectorService(fetcher, writer, newSender(), cfg)
+	if err := collectAll(ctx, apiKey, collector, locations); err != nil {
 		slog.Error("Collection failed", "error", err)
 		os.Exit(1)
 	}
 }
 
+// newSender builds the alert delivery sender from the environment.
+//
+// A disabled switch or any missing value yields a NopSender rather than an
+// error: the job has to keep collecting when delivery is unconfigured, which
+// is the normal case in local development.
+func newSender() notify.Sender {
+	if os.Getenv("NOTIFY_ENABLED") != "true" {
+		slog.Info("Alert delivery disabled", "reason", "NOTIFY_ENABLED not true")
+		return notify.NopSender{}
+	}
+	user := os.Getenv("NOTIFY_SMTP_USER")
+	password := os.Getenv("NOTIFY_SMTP_PASSWORD")
+	to := os.Getenv("NOTIFY_EMAIL_TO")
+	if user == "" || password == "" || to == "" {
+		slog.Warn("Alert delivery enabled but not configured, dropping notifications",
+			"vars", "NOTIFY_SMTP_USER, NOTIFY_SMTP_PASSWORD, NOTIFY_EMAIL_TO")
+		return notify.NopSender{}
+	}
+	slog.Info("Alert delivery enabled", "from", user, "to", to)
+	return notify.NewSMTPSender(user, password, to)
+}
+
+// envInt reads an integer env var, falling back to a default when unset or invalid.
+func envInt(name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+		return fallback
+	}
+	return v
+}
+
 func collectAll(ctx context.Context, apiKey string, collector *service.CollectorService, locations []shared.Location) error {
 	if len(locations) == 0 {
 		return fmt.Errorf("no locations provided")
@@ -10,6 +10,7 @@ import (
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/service"
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/testutil"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
 func happyFetcher() *testutil.MockFetcher {
@@ -31,8 +32,8 @@ func happyWriter() *testutil.MockWriter {
 		SaveRawFn: func(ctx context.Context, snapshot repository.PollenSnapshot) error {
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot) error {
-			return nil
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
+			return nil, nil
 		},
 	}
 }
@@ -51,7 +52,7 @@ var testLocations = []shared.Location{
 }
 
 func TestRun_AllLocationsSucceed(t *testing.T) {
-	collector := service.NewCollectorService(happyFetcher(), happyWriter())
+	collector := service.NewCollectorService(happyFetcher(), happyWriter(), notify.NopSender{}, service.Config{Detection: service.DefaultDetectionConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err != nil {
@@ -78,7 +79,7 @@ func TestRun_PartialFailure(t *testing.T) {
 		},
 	}
 
-	collector := service.NewCollectorService(fetcher, happyWriter())
+	collector := service.NewCollectorService(fetcher, happyWriter(), notify.NopSender{}, service.Config{Detection: service.DefaultDetectionConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err != nil {
@@ -87,7 +88,7 @@ func TestRun_PartialFailure(t *testing.T) {
 }
 
 func TestRun_AllLocationsFail(t *testing.T) {
-	collector := service.NewCollectorService(failingFetcher(), happyWriter())
+	collector := service.NewCollectorService(failingFetcher(), happyWriter(), notify.NopSender{}, service.Config{Detection: service.DefaultDetectionConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 
@@ -97,7 +98,7 @@ func TestRun_AllLocationsFail(t *testing.T) {
 }
 
 func TestRun_EmptyLocations(t *testing.T) {
-	collector := service.NewCollectorService(happyFetcher(), happyWriter())
+	collector := service.NewCollectorService(happyFetcher(), happyWriter(), notify.NopSender{}, service.Config{Detection: service.DefaultDetectionConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, []shared.Location{})
 
@@ -6,11 +6,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nickfang/personal-dashboard/services/shared"
 )
 
+// ForecastDays is how many days of outlook to request. Five is the most the
+// Pollen API returns; day one is today and feeds the current snapshot.
+const ForecastDays = 5
+
 // Fetcher defines the interface for fetching pollen data from an external API.
 type Fetcher interface {
 	Fetch(apiKey string, location shared.Location) (*PollenAPIResponse, error)
@@ -22,21 +28,34 @@ type nonRetryable struct{ error }
 // Client fetches pollen data from the Google Pollen API.
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
 }
 
-// New creates a new pollen API client.
+// DefaultBaseURL is the real API. POLLEN_API_BASE_URL overrides it, e.g.
+// to point at the fake upstream (services/shared/cmd/fakeupstream).
+const DefaultBaseURL = "https://pollen.googleapis.com"
+
+// New creates a new pollen API client against DefaultBaseURL.
 func New(httpClient *http.Client) *Client {
-	return &Client{httpClient: httpClient}
+	return NewWithBaseURL(httpClient, "")
+}
+
+// NewWithBaseURL creates a client against baseURL, or DefaultBaseURL when it
+// is empty.
+func NewWithBaseURL(httpClient *http.Client, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimSuffix(baseURL, "/")}
 }
 
 func (c *Client) fetchPollen(apiKey string, location shared.Location) (*PollenAPIResponse, error) {
-	baseUrl := "https://pollen.googleapis.com/v1/forecast:lookup"
 	queryParams := url.Values{
 		"location.latitude":  {fmt.Sprintf("%f", location.Lat)},
 		"location.longitude": {fmt.Sprintf("%f", location.Long)},
-		"days":               {"1"},
+		"days":               {strconv.Itoa(ForecastDays)},
 	}
-	url := baseUrl + "?" + queryParams.Encode()
+	url := c.baseURL + "/v1/forecast:lookup?" + queryParams.Encode()
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/fakeupstream"
 )
 
 // roundTripFunc adapts a plain function into an http.RoundTripper.
@@ -70,6 +72,28 @@ func TestFetch_APIKeyInHeaderNotURL(t *testing.T) {
 	}
 }
 
+func TestFetch_RequestsMultiDayOutlook(t *testing.T) {
+	var capturedReq *http.Request
+
+	c := New(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			capturedReq = req.Clone(req.Context())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(validPollenJSON)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	})
+
+	if _, err := c.Fetch("test-key", shared.Location{ID: "test-loc", Lat: 30.0, Long: -97.0}); err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	if got := capturedReq.URL.Query().Get("days"); got != "5" {
+		t.Errorf("days = %q, want 5", got)
+	}
+}
+
 func TestFetch_ErrorDoesNotLeakAPIKey(t *testing.T) {
 	const testAPIKey = "test-secret-key-12345"
 
@@ -131,3 +155,23 @@ func TestFetch_NonRetryableStatusCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestFetch_AgainstFakeUpstream(t *testing.T) {
+	srv, err := fakeupstream.New(fakeupstream.ScenarioSteady)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	data, err := NewWithBaseURL(ts.Client(), ts.URL).Fetch("fake-key", shared.Location{ID: "test-loc", Lat: 30.0, Long: -97.0})
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	if len(data.DailyInfo) != ForecastDays {
+		t.Fatalf("expected %d days, got %d", ForecastDays, len(data.DailyInfo))
+	}
+	if len(data.DailyInfo[0].PollenTypeInfo) == 0 || data.DailyInfo[0].PollenTypeInfo[0].IndexInfo.Category == "" {
+		t.Errorf("fake response decoded without pollen types: %+v", data.DailyInfo[0])
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteWriter writes pollen snapshots, the pollen cache and its alerts to
+// the docstore file, as FirestoreWriter does.
+type SQLiteWriter struct {
+	db *docstore.DB
+}
+
+func NewSQLiteWriter(db *docstore.DB) *SQLiteWriter {
+	return &SQLiteWriter{db: db}
+}
+
+// OpenWriter returns the SQLite writer when docstore selects it, and
+// otherwise connects to pollen-log.
+func OpenWriter(ctx context.Context, projectID string) (WriteCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteWriter(db), nil
+	}
+	fw, err := NewFirestoreWriter(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+func (sw *SQLiteWriter) Close() error {
+	return sw.db.Close()
+}
+
+func (sw *SQLiteWriter) SaveRaw(ctx context.Context, snapshot PollenSnapshot) error {
+	snapshot.SchemaVersion = schema.Version
+	_, err := sw.db.Add(ctx, shared.PollenRawCollection, snapshot)
+	return err
+}
+
+func (sw *SQLiteWriter) UpdateCache(ctx context.Context, locationID string, snapshot PollenSnapshot, forecast []PollenForecastDay, merge MergeFunc) ([]shared.Alert, error) {
+	var committed []shared.Alert
+	err := sw.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		cache := PollenCacheDoc{History: []PollenSnapshot{}}
+		err := tx.Get(shared.PollenCacheCollection, locationID, &cache)
+		if err != nil && !errors.Is(err, docstore.ErrNotFound) {
+			return fmt.Errorf("reading cache doc: %w", err)
+		}
+		if err == nil {
+			if err := schema.Rewrite(locationID, &cache); err != nil {
+				return err
+			}
+		}
+		prev := cache.Alerts
+		cache = appendSnapshot(cache, snapshot, forecast, merge)
+		if err := tx.Set(shared.PollenCacheCollection, locationID, cache); err != nil {
+			return err
+		}
+		if err := schema.RecordAlertChanges(tx.Set, prev, cache.Alerts, snapshot.CollectedAt); err != nil {
+			return err
+		}
+		committed = cache.Alerts
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return committed, nil
+}
+
+// MarkNotified writes only the alerts field, and records delivered events,
+// as FirestoreWriter does.
+func (sw *SQLiteWriter) MarkNotified(ctx context.Context, locationID string, alertIDs []string, at time.Time) error {
+	if len(alertIDs) == 0 {
+		return nil
+	}
+	return sw.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		var cached PollenCacheDoc
+		err := tx.Get(shared.PollenCacheCollection, locationID, &cached)
+		if errors.Is(err, docstore.ErrNotFound) {
+			return fmt.Errorf("pollen cache doc for %s not found", locationID)
+		} else if err != nil {
+			return fmt.Errorf("reading pollen cache doc: %w", err)
+		}
+		updated := applyNotifiedAt(cached.Alerts, alertIDs, at)
+		if err := tx.Update(shared.PollenCacheCollection, locationID, "alerts", updated); err != nil {
+			return err
+		}
+		return schema.RecordEvents(tx.Set, shared.AlertEvents(cached.Alerts, updated, at))
+	})
+}
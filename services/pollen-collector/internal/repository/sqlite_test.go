@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+func TestSQLiteWriter_UpdateCacheAndMarkNotified(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "pollen.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	base := time.Date(2026, 4, 2, 12, 0, 0, 0, time.UTC)
+	alert := shared.Alert{ID: "spike-1", Location: "house-nick", Status: shared.AlertStatusActive}
+	var historyLens []int
+	merge := func(history []PollenSnapshot, prev []shared.Alert) []shared.Alert {
+		historyLens = append(historyLens, len(history))
+		return append(prev[:0:0], alert)
+	}
+	for i := 0; i < 2; i++ {
+		snapshot := PollenSnapshot{LocationID: "house-nick", CollectedAt: base.Add(time.Duration(i) * 12 * time.Hour), OverallIndex: i + 1}
+		forecast := []PollenForecastDay{{Date: "2026-04-02", OverallIndex: i + 1}}
+		if _, err := writer.UpdateCache(ctx, "house-nick", snapshot, forecast, merge); err != nil {
+			t.Fatalf("UpdateCache #%d: %v", i, err)
+		}
+	}
+	if historyLens[0] != 0 || historyLens[1] != 1 {
+		t.Errorf("merge saw history lengths %v, want [0 1] (before the append)", historyLens)
+	}
+
+	at := base.Add(13 * time.Hour)
+	if err := writer.MarkNotified(ctx, "house-nick", []string{"spike-1"}, at); err != nil {
+		t.Fatalf("MarkNotified: %v", err)
+	}
+	var cache PollenCacheDoc
+	if err := db.Get(ctx, shared.PollenCacheCollection, "house-nick", &cache); err != nil {
+		t.Fatalf("reading cache: %v", err)
+	}
+	if len(cache.History) != 2 || cache.CurrentValue.OverallIndex != 2 || cache.Forecast[0].OverallIndex != 2 {
+		t.Errorf("cache = %+v, want two snapshots and the latest forecast", cache)
+	}
+	if len(cache.Alerts) != 1 || !cache.Alerts[0].NotifiedAt.Equal(at) {
+		t.Errorf("alerts = %+v, want spike-1 notified at %v", cache.Alerts, at)
+	}
+
+	docs, err := db.Documents(ctx, docstore.Query{
+		Collection: shared.AlertEventsCollection,
+		Where:      []docstore.Filter{{Field: "alert_id", Op: "==", Value: "spike-1"}},
+		OrderBy:    "at",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := docstore.DecodeAll[AlertEventRecord](docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Type != shared.AlertEventCreated || events[1].Type != shared.AlertEventDelivered || !events[1].NotifiedAt.Equal(at) {
+		t.Errorf("events = %+v, want spike-1 created, then delivered at %v", events, at)
+	}
+
+	if err := writer.MarkNotified(ctx, "cabin", []string{"spike-1"}, at); err == nil {
+		t.Error("MarkNotified on a location without a cache doc should fail")
+	}
+}
+
+func TestSQLiteWriter_UpdateCacheArchivesPrunedAlerts(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "pollen.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	base := time.Date(2026, 4, 2, 12, 0, 0, 0, time.UTC)
+	spike := shared.Alert{ID: "spike-1", Location: "house-nick", Value: 4, Severity: shared.AlertSeverityWarning, Status: shared.AlertStatusActive, WindowStart: base, WindowEnd: base.Add(12 * time.Hour)}
+	merge := func(detected []shared.Alert, now time.Time) MergeFunc {
+		return func(_ []PollenSnapshot, prev []shared.Alert) []shared.Alert {
+			return shared.MergeAlerts(prev, detected, now)
+		}
+	}
+	snapshot := PollenSnapshot{LocationID: "house-nick", CollectedAt: base}
+	if _, err := writer.UpdateCache(ctx, "house-nick", snapshot, nil, merge([]shared.Alert{spike}, base)); err != nil {
+		t.Fatalf("first UpdateCache: %v", err)
+	}
+	// A day later the spike's window has passed: the merge prunes it.
+	snapshot.CollectedAt = base.Add(24 * time.Hour)
+	committed, err := writer.UpdateCache(ctx, "house-nick", snapshot, nil, merge(nil, snapshot.CollectedAt))
+	if err != nil {
+		t.Fatalf("second UpdateCache: %v", err)
+	}
+	if len(committed) != 0 {
+		t.Errorf("committed = %+v, want the spike pruned", committed)
+	}
+
+	var record AlertRecord
+	if err := db.Get(ctx, shared.AlertHistoryCollection, "spike-1", &record); err != nil {
+		t.Fatalf("reading alert_history/spike-1: %v", err)
+	}
+	if record.Status != shared.AlertStatusExpired || record.PeakValue != 4 || !record.ArchivedAt.Equal(snapshot.CollectedAt) {
+		t.Errorf("record = %+v, want the spike expired, archived at the second collection", record)
+	}
+}
@@ -1,36 +1,17 @@
 package repository
 
-import "time"
+import "github.com/nickfang/personal-dashboard/services/shared/schema"
 
-const MaxHistoryPoints = 28 // 14 days × 2 readings/day
+// The stored documents are defined once, in shared/schema, for this
+// collector and every service that reads them.
+type (
+	StoredPollenType  = schema.PollenType
+	StoredPollenPlant = schema.PollenPlant
+	PollenSnapshot    = schema.PollenSnapshot
+	PollenForecastDay = schema.PollenForecastDay
+	PollenCacheDoc    = schema.PollenCacheDoc
+	AlertRecord       = schema.AlertRecord
+	AlertEventRecord  = schema.AlertEventRecord
+)
 
-type StoredPollenType struct {
-	Code     string `firestore:"code"`
-	Index    int    `firestore:"index"`
-	Category string `firestore:"category"`
-	InSeason bool   `firestore:"in_season"`
-}
-
-type StoredPollenPlant struct {
-	Code        string `firestore:"code"`
-	DisplayName string `firestore:"display_name"`
-	Index       int    `firestore:"index"`
-	Category    string `firestore:"category"`
-	InSeason    bool   `firestore:"in_season"`
-}
-
-type PollenSnapshot struct {
-	LocationID      string              `firestore:"location_id"`
-	CollectedAt     time.Time           `firestore:"collected_at"`
-	OverallIndex    int                 `firestore:"overall_index"`
-	OverallCategory string              `firestore:"overall_category"`
-	DominantType    string              `firestore:"dominant_type"`
-	Types           []StoredPollenType  `firestore:"types"`
-	Plants          []StoredPollenPlant `firestore:"plants"`
-}
-
-type PollenCacheDoc struct {
-	LastUpdated  time.Time        `firestore:"last_updated"`
-	CurrentValue PollenSnapshot   `firestore:"current"`
-	History      []PollenSnapshot `firestore:"history"`
-}
+const MaxHistoryPoints = schema.MaxPollenHistory
@@ -3,18 +3,36 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"cloud.google.com/go/firestore"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
 )
 
+// MergeFunc detects alerts for the incoming snapshot against the stored
+// history and reconciles them with the previously stored alert set. Like
+// forecast-collector's MergeFunc it runs inside the repository's transaction;
+// unlike it, detection happens here too, because a spike is measured against
+// history that is only read consistently inside that transaction.
+//
+// history is the stored series before the incoming snapshot is appended.
+type MergeFunc func(history []PollenSnapshot, prev []shared.Alert) []shared.Alert
+
 // Writer defines the interface for writing pollen data to storage.
 type Writer interface {
 	SaveRaw(ctx context.Context, snapshot PollenSnapshot) error
-	UpdateCache(ctx context.Context, locationID string, snapshot PollenSnapshot) error
+	UpdateCache(ctx context.Context, locationID string, snapshot PollenSnapshot, forecast []PollenForecastDay, merge MergeFunc) ([]shared.Alert, error)
+	MarkNotified(ctx context.Context, locationID string, alertIDs []string, at time.Time) error
+}
+
+// WriteCloser is a Writer that holds a connection.
+type WriteCloser interface {
+	Writer
+	Close() error
 }
 
 type FirestoreWriter struct {
@@ -34,14 +52,22 @@ func (fw *FirestoreWriter) Close() error {
 }
 
 func (fw *FirestoreWriter) SaveRaw(ctx context.Context, snapshot PollenSnapshot) error {
+	snapshot.SchemaVersion = schema.Version
 	_, _, err := fw.client.Collection(shared.PollenRawCollection).Add(ctx, snapshot)
 	return err
 }
 
-func (fw *FirestoreWriter) UpdateCache(ctx context.Context, locationID string, snapshot PollenSnapshot) error {
+// UpdateCache appends the snapshot to the location's history, replaces the
+// stored forecast, and returns the merged alert set that was committed. The
+// committed set is returned rather than captured through the MergeFunc
+// closure because Firestore may run the transaction more than once. Alerts
+// the merge prunes are archived to alert_history, and the merge's lifecycle
+// events recorded to alert_events, in the same transaction.
+func (fw *FirestoreWriter) UpdateCache(ctx context.Context, locationID string, snapshot PollenSnapshot, forecast []PollenForecastDay, merge MergeFunc) ([]shared.Alert, error) {
 	cacheRef := fw.client.Collection(shared.PollenCacheCollection).Doc(locationID)
 
-	return fw.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+	var committed []shared.Alert
+	err := fw.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
 		doc, err := tx.Get(cacheRef)
 		var cache PollenCacheDoc
 		if status.Code(err) == codes.NotFound {
@@ -52,16 +78,99 @@ func (fw *FirestoreWriter) UpdateCache(ctx context.Context, locationID string, s
 			if err := doc.DataTo(&cache); err != nil {
 				return err
 			}
+			if err := schema.Rewrite(locationID, &cache); err != nil {
+				return err
+			}
 		}
 
-		cache.History = append(cache.History, snapshot)
-		if len(cache.History) > MaxHistoryPoints {
-			cache.History = cache.History[len(cache.History)-MaxHistoryPoints:]
+		prev := cache.Alerts
+		cache = appendSnapshot(cache, snapshot, forecast, merge)
+		if err := tx.Set(cacheRef, cache); err != nil {
+			return err
+		}
+		if err := schema.RecordAlertChanges(fw.setter(tx), prev, cache.Alerts, snapshot.CollectedAt); err != nil {
+			return err
 		}
+		committed = cache.Alerts
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return committed, nil
+}
+
+// appendSnapshot runs merge against the stored history and alerts, then
+// appends the snapshot and replaces the forecast. Every backend calls it
+// between its transactional read and write.
+func appendSnapshot(cache PollenCacheDoc, snapshot PollenSnapshot, forecast []PollenForecastDay, merge MergeFunc) PollenCacheDoc {
+	alerts := merge(cache.History, cache.Alerts)
 
-		cache.LastUpdated = snapshot.CollectedAt
-		cache.CurrentValue = snapshot
+	cache.History = append(cache.History, snapshot)
+	if len(cache.History) > MaxHistoryPoints {
+		cache.History = cache.History[len(cache.History)-MaxHistoryPoints:]
+	}
+
+	cache.SchemaVersion = schema.Version
+	cache.LocationID = snapshot.LocationID
+	cache.LastUpdated = snapshot.CollectedAt
+	cache.CurrentValue = snapshot
+	cache.Forecast = forecast
+	cache.Alerts = alerts
+	return cache
+}
 
-		return tx.Set(cacheRef, cache)
+// MarkNotified records delivery against the listed alert IDs, matching by ID
+// inside a transaction so a concurrent run that replaced the alert set cannot
+// be clobbered. Only the alerts field of the cache doc is written, and a
+// delivered event is recorded for each alert stamped.
+func (fw *FirestoreWriter) MarkNotified(ctx context.Context, locationID string, alertIDs []string, at time.Time) error {
+	if len(alertIDs) == 0 {
+		return nil
+	}
+	cacheRef := fw.client.Collection(shared.PollenCacheCollection).Doc(locationID)
+	return fw.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(cacheRef)
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("pollen cache doc for %s not found", locationID)
+		} else if err != nil {
+			return fmt.Errorf("reading pollen cache doc: %w", err)
+		}
+		var cached PollenCacheDoc
+		if err := doc.DataTo(&cached); err != nil {
+			return err
+		}
+		updated := applyNotifiedAt(cached.Alerts, alertIDs, at)
+		if err := tx.Update(cacheRef, []firestore.Update{
+			{Path: "alerts", Value: updated},
+		}); err != nil {
+			return err
+		}
+		return schema.RecordEvents(fw.setter(tx), shared.AlertEvents(cached.Alerts, updated, at))
 	})
 }
+
+// setter writes documents of this database within tx, for the schema
+// package's alert records.
+func (fw *FirestoreWriter) setter(tx *firestore.Transaction) schema.SetFunc {
+	return func(collection, id string, v any) error {
+		return tx.Set(fw.client.Collection(collection).Doc(id), v)
+	}
+}
+
+// applyNotifiedAt stamps at onto the alerts whose IDs are listed, leaving
+// every other alert as stored.
+func applyNotifiedAt(alerts []shared.Alert, alertIDs []string, at time.Time) []shared.Alert {
+	wanted := make(map[string]bool, len(alertIDs))
+	for _, id := range alertIDs {
+		wanted[id] = true
+	}
+	updated := make([]shared.Alert, len(alerts))
+	copy(updated, alerts)
+	for i := range updated {
+		if wanted[updated[i].ID] {
+			updated[i].NotifiedAt = at
+		}
+	}
+	return updated
+}
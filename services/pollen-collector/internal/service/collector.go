@@ -3,22 +3,32 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/api"
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
+// Config holds the collector's tuning, kept separate from the interfaces it
+// depends on, as in forecast-collector.
+type Config struct {
+	Detection DetectionConfig // Pollen alert thresholds
+}
+
 // CollectorService orchestrates the pollen collection flow.
 type CollectorService struct {
 	fetcher api.Fetcher
 	writer  repository.Writer
+	sender  notify.Sender
+	cfg     Config
 }
 
 // NewCollectorService creates a new CollectorService with injected dependencies.
-func NewCollectorService(fetcher api.Fetcher, writer repository.Writer) *CollectorService {
-	return &CollectorService{fetcher: fetcher, writer: writer}
+func NewCollectorService(fetcher api.Fetcher, writer repository.Writer, sender notify.Sender, cfg Config) *CollectorService {
+	return &CollectorService{fetcher: fetcher, writer: writer, sender: sender, cfg: cfg}
 }
 
 // Collect fetches pollen data for a location, maps it, and writes to storage.
@@ -29,31 +39,88 @@ func (s *CollectorService) Collect(ctx context.Context, apiKey string, location
 	}
 
 	snapshot := MapToSnapshot(location.ID, apiResp, time.Now())
+	forecast := MapToForecast(apiResp)
 
 	if err := s.writer.SaveRaw(ctx, snapshot); err != nil {
 		return fmt.Errorf("saving raw pollen data for %s: %w", location.ID, err)
 	}
 
-	if err := s.writer.UpdateCache(ctx, location.ID, snapshot); err != nil {
+	// Detection needs the stored history, so unlike forecast-collector both
+	// detection and merge run inside the repository's transaction.
+	merge := func(history []repository.PollenSnapshot, prev []shared.Alert) []shared.Alert {
+		detected := DetectPollenAlerts(location.ID, snapshot, history, s.cfg.Detection, snapshot.CollectedAt)
+		return shared.MergeAlerts(prev, detected, snapshot.CollectedAt)
+	}
+	committed, err := s.writer.UpdateCache(ctx, location.ID, snapshot, forecast, merge)
+	if err != nil {
 		return fmt.Errorf("updating pollen cache for %s: %w", location.ID, err)
 	}
 
+	// Delivery failures are logged rather than returned: a notification
+	// problem should not make a successful collection look like a failed one.
+	s.deliver(ctx, location, committed, snapshot.CollectedAt)
 	return nil
 }
 
+// deliver sends every active, undelivered alert and then records which ones
+// went out. Deliver-then-mark means a marking failure re-sends next run
+// rather than recording a delivery that never happened.
+func (s *CollectorService) deliver(ctx context.Context, location shared.Location, alerts []shared.Alert, at time.Time) {
+	var delivered []string
+	for _, a := range alerts {
+		if a.Status != shared.AlertStatusActive || !a.NotifiedAt.IsZero() {
+			continue
+		}
+		if err := s.sender.Send(ctx, notify.FromAlert(a, location.TimeZone())); err != nil {
+			slog.Error("Failed to deliver alert", "location", location.ID, "alert", a.ID, "error", err)
+			continue
+		}
+		slog.Info("Delivered alert", "location", location.ID, "alert", a.ID, "rule", a.RuleID, "severity", a.Severity)
+		delivered = append(delivered, a.ID)
+	}
+	if len(delivered) == 0 {
+		return
+	}
+	if err := s.writer.MarkNotified(ctx, location.ID, delivered, at); err != nil {
+		// The alerts will re-deliver on the next run.
+		slog.Error("Failed to mark alerts as notified", "location", location.ID, "alerts", delivered, "error", err)
+	}
+}
+
 // MapToSnapshot converts an API response into a PollenSnapshot for storage.
 // It computes the overall summary (highest UPI across the 3 pollen types).
 func MapToSnapshot(locationID string, apiResp *api.PollenAPIResponse, now time.Time) repository.PollenSnapshot {
-	today := apiResp.DailyInfo[0]
+	today := mapDay(apiResp.DailyInfo[0])
+	return repository.PollenSnapshot{
+		LocationID:      locationID,
+		CollectedAt:     now,
+		OverallIndex:    today.OverallIndex,
+		OverallCategory: today.OverallCategory,
+		DominantType:    today.DominantType,
+		Types:           today.Types,
+		Plants:          today.Plants,
+	}
+}
+
+// MapToForecast converts every day of an API response, today included, into
+// the per-day outlook stored alongside the current snapshot.
+func MapToForecast(apiResp *api.PollenAPIResponse) []repository.PollenForecastDay {
+	days := make([]repository.PollenForecastDay, 0, len(apiResp.DailyInfo))
+	for _, d := range apiResp.DailyInfo {
+		days = append(days, mapDay(d))
+	}
+	return days
+}
 
-	snapshot := repository.PollenSnapshot{
-		LocationID:  locationID,
-		CollectedAt: now,
+// mapDay maps one day of the API response and computes its overall summary.
+func mapDay(info api.DailyInfo) repository.PollenForecastDay {
+	day := repository.PollenForecastDay{
+		Date: fmt.Sprintf("%04d-%02d-%02d", info.Date.Year, info.Date.Month, info.Date.Day),
 	}
 
 	// Map pollen types
-	for _, t := range today.PollenTypeInfo {
-		snapshot.Types = append(snapshot.Types, repository.StoredPollenType{
+	for _, t := range info.PollenTypeInfo {
+		day.Types = append(day.Types, repository.StoredPollenType{
 			Code:     t.Code,
 			Index:    t.IndexInfo.Value,
 			Category: t.IndexInfo.Category,
@@ -62,8 +129,8 @@ func MapToSnapshot(locationID string, apiResp *api.PollenAPIResponse, now time.T
 	}
 
 	// Map plants
-	for _, p := range today.PlantInfo {
-		snapshot.Plants = append(snapshot.Plants, repository.StoredPollenPlant{
+	for _, p := range info.PlantInfo {
+		day.Plants = append(day.Plants, repository.StoredPollenPlant{
 			Code:        p.Code,
 			DisplayName: p.DisplayName,
 			Index:       p.IndexInfo.Value,
@@ -73,13 +140,13 @@ func MapToSnapshot(locationID string, apiResp *api.PollenAPIResponse, now time.T
 	}
 
 	// Compute overall summary: find the highest UPI across the 3 types
-	for _, t := range snapshot.Types {
-		if t.Index > snapshot.OverallIndex {
-			snapshot.OverallIndex = t.Index
-			snapshot.OverallCategory = t.Category
-			snapshot.DominantType = t.Code
+	for _, t := range day.Types {
+		if t.Index > day.OverallIndex {
+			day.OverallIndex = t.Index
+			day.OverallCategory = t.Category
+			day.DominantType = t.Code
 		}
 	}
 
-	return snapshot
+	return day
 }
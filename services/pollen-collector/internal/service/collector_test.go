@@ -10,6 +10,7 @@ import (
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/testutil"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
 var fixedTime = time.Now()
@@ -230,6 +231,44 @@ func TestMapToSnapshot_TiedTypes(t *testing.T) {
 	}
 }
 
+func TestMapToForecast_AllDays(t *testing.T) {
+	apiResp := &api.PollenAPIResponse{
+		DailyInfo: []api.DailyInfo{
+			{
+				Date: api.APIDate{Year: 2026, Month: 4, Day: 2},
+				PollenTypeInfo: []api.PollenTypeInfo{
+					{Code: "TREE", InSeason: true, IndexInfo: api.IndexInfo{Value: 3, Category: "Moderate"}},
+				},
+			},
+			{
+				Date: api.APIDate{Year: 2026, Month: 4, Day: 3},
+				PollenTypeInfo: []api.PollenTypeInfo{
+					{Code: "GRASS", InSeason: true, IndexInfo: api.IndexInfo{Value: 2, Category: "Low"}},
+					{Code: "TREE", InSeason: true, IndexInfo: api.IndexInfo{Value: 5, Category: "Very High"}},
+				},
+				PlantInfo: []api.PlantInfo{
+					{Code: "OAK", DisplayName: "Oak", InSeason: true, IndexInfo: api.IndexInfo{Value: 5, Category: "Very High"}},
+				},
+			},
+		},
+	}
+
+	days := MapToForecast(apiResp)
+
+	if len(days) != 2 {
+		t.Fatalf("len(days) = %d, want 2 — every returned day, today included", len(days))
+	}
+	if days[0].Date != "2026-04-02" || days[1].Date != "2026-04-03" {
+		t.Errorf("dates = %q, %q, want 2026-04-02, 2026-04-03", days[0].Date, days[1].Date)
+	}
+	if days[1].OverallIndex != 5 || days[1].OverallCategory != "Very High" || days[1].DominantType != "TREE" {
+		t.Errorf("day 2 summary = %d/%s/%s, want 5/Very High/TREE", days[1].OverallIndex, days[1].OverallCategory, days[1].DominantType)
+	}
+	if len(days[1].Plants) != 1 || days[1].Plants[0].Code != "OAK" {
+		t.Errorf("day 2 plants = %+v, want [OAK]", days[1].Plants)
+	}
+}
+
 // --- Orchestration tests (new) ---
 
 func TestCollect_Success(t *testing.T) {
@@ -253,13 +292,13 @@ func TestCollect_Success(t *testing.T) {
 			savedSnapshot = snapshot
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
 			cachedLocationID = locationID
-			return nil
+			return nil, nil
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, &testutil.MockSender{}, Config{Detection: DefaultDetectionConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 	if err != nil {
@@ -274,6 +313,41 @@ func TestCollect_Success(t *testing.T) {
 	}
 }
 
+func TestCollect_StoresForecast(t *testing.T) {
+	var cachedForecast []repository.PollenForecastDay
+
+	fetcher := &testutil.MockFetcher{
+		FetchFn: func(apiKey string, location shared.Location) (*api.PollenAPIResponse, error) {
+			days := make([]api.DailyInfo, api.ForecastDays)
+			for i := range days {
+				days[i].Date = api.APIDate{Year: 2026, Month: 4, Day: 2 + i}
+			}
+			return &api.PollenAPIResponse{DailyInfo: days}, nil
+		},
+	}
+	writer := &testutil.MockWriter{
+		SaveRawFn: func(ctx context.Context, snapshot repository.PollenSnapshot) error {
+			return nil
+		},
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
+			cachedForecast = forecast
+			return nil, nil
+		},
+	}
+
+	svc := NewCollectorService(fetcher, writer, &testutil.MockSender{}, Config{Detection: DefaultDetectionConfig()})
+	if err := svc.Collect(context.Background(), "test-key", shared.Location{ID: "house-nick"}); err != nil {
+		t.Fatalf("Collect() returned error: %v", err)
+	}
+
+	if len(cachedForecast) != api.ForecastDays {
+		t.Fatalf("UpdateCache forecast has %d days, want %d", len(cachedForecast), api.ForecastDays)
+	}
+	if cachedForecast[4].Date != "2026-04-06" {
+		t.Errorf("last day = %q, want 2026-04-06", cachedForecast[4].Date)
+	}
+}
+
 func TestCollect_FetchError(t *testing.T) {
 	writerCalled := false
 
@@ -288,13 +362,13 @@ func TestCollect_FetchError(t *testing.T) {
 			writerCalled = true
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
 			writerCalled = true
-			return nil
+			return nil, nil
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, &testutil.MockSender{}, Config{Detection: DefaultDetectionConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 
@@ -326,13 +400,13 @@ func TestCollect_SaveRawError(t *testing.T) {
 		SaveRawFn: func(ctx context.Context, snapshot repository.PollenSnapshot) error {
 			return fmt.Errorf("firestore write failed")
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
 			cacheCalled = true
-			return nil
+			return nil, nil
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, &testutil.MockSender{}, Config{Detection: DefaultDetectionConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 
@@ -361,12 +435,12 @@ func TestCollect_UpdateCacheError(t *testing.T) {
 		SaveRawFn: func(ctx context.Context, snapshot repository.PollenSnapshot) error {
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot) error {
-			return fmt.Errorf("cache update failed")
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
+			return nil, fmt.Errorf("cache update failed")
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, &testutil.MockSender{}, Config{Detection: DefaultDetectionConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 
@@ -374,3 +448,167 @@ func TestCollect_UpdateCacheError(t *testing.T) {
 		t.Fatal("Collect() should return error when UpdateCache fails")
 	}
 }
+
+// --- Alert detection and delivery ---
+
+var testLocation = shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
+
+func testConfig() Config {
+	return Config{Detection: DefaultDetectionConfig()}
+}
+
+// indexFetcher returns a fetcher reporting a single juniper reading.
+func indexFetcher(index int, category string) *testutil.MockFetcher {
+	return &testutil.MockFetcher{
+		FetchFn: func(apiKey string, location shared.Location) (*api.PollenAPIResponse, error) {
+			return &api.PollenAPIResponse{
+				DailyInfo: []api.DailyInfo{{
+					PlantInfo: []api.PlantInfo{
+						{Code: "JUNIPER", DisplayName: "Juniper", InSeason: true, IndexInfo: api.IndexInfo{Value: index, Category: category}},
+					},
+				}},
+			}, nil
+		},
+	}
+}
+
+// deliveryWriter returns a writer whose UpdateCache commits the given alert
+// set and whose MarkNotified records the IDs it was handed.
+func deliveryWriter(committed []shared.Alert, marked *[]string, markErr error) *testutil.MockWriter {
+	return &testutil.MockWriter{
+		SaveRawFn: func(ctx context.Context, snapshot repository.PollenSnapshot) error {
+			return nil
+		},
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
+			return committed, nil
+		},
+		MarkNotifiedFn: func(ctx context.Context, locationID string, alertIDs []string, at time.Time) error {
+			*marked = append(*marked, alertIDs...)
+			return markErr
+		},
+	}
+}
+
+func deliveredIDs(sent []notify.Notification) []string {
+	ids := make([]string, len(sent))
+	for i, n := range sent {
+		ids[i] = n.Alert.ID
+	}
+	return ids
+}
+
+func TestCollect_WiresDetectedAlertsIntoMerge(t *testing.T) {
+	var committed []shared.Alert
+	writer := &testutil.MockWriter{
+		SaveRawFn: func(ctx context.Context, snapshot repository.PollenSnapshot) error {
+			return nil
+		},
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
+			committed = merge(nil, nil)
+			return committed, nil
+		},
+	}
+	sender := &testutil.MockSender{}
+
+	svc := NewCollectorService(indexFetcher(4, "High"), writer, sender, testConfig())
+	if err := svc.Collect(context.Background(), "test-key", testLocation); err != nil {
+		t.Fatalf("Collect() returned error: %v", err)
+	}
+
+	if len(committed) != 1 || committed[0].RuleID != "pollen-high-juniper" {
+		t.Fatalf("committed %+v, want one pollen-high-juniper alert", committed)
+	}
+	if committed[0].Location != testLocation.ID {
+		t.Errorf("Location = %q, want %q", committed[0].Location, testLocation.ID)
+	}
+	if len(sender.Sent) != 1 {
+		t.Errorf("delivered %d alerts, want 1", len(sender.Sent))
+	}
+}
+
+func TestCollect_DeliversOnlyUndeliveredActiveAlerts(t *testing.T) {
+	past := time.Date(2026, 4, 2, 6, 0, 0, 0, time.UTC)
+	committed := []shared.Alert{
+		{ID: "undelivered", Status: shared.AlertStatusActive},
+		{ID: "already-delivered", Status: shared.AlertStatusActive, NotifiedAt: past},
+		{ID: "resolved", Status: shared.AlertStatusResolved, NotifiedAt: past},
+		{ID: "resolved-undelivered", Status: shared.AlertStatusResolved},
+	}
+	var marked []string
+	sender := &testutil.MockSender{}
+
+	svc := NewCollectorService(indexFetcher(1, "Very Low"), deliveryWriter(committed, &marked, nil), sender, testConfig())
+	if err := svc.Collect(context.Background(), "test-key", testLocation); err != nil {
+		t.Fatalf("Collect() returned error: %v", err)
+	}
+
+	if got := deliveredIDs(sender.Sent); len(got) != 1 || got[0] != "undelivered" {
+		t.Errorf("delivered %v, want only [undelivered]", got)
+	}
+	if len(marked) != 1 || marked[0] != "undelivered" {
+		t.Errorf("MarkNotified got %v, want [undelivered]", marked)
+	}
+}
+
+func TestCollect_PersistingHighIndexDoesNotRedeliver(t *testing.T) {
+	// Yesterday's delivered High alert is still inside its window; today's
+	// reading at the same level merges into it and keeps the delivery record.
+	now := time.Now()
+	stored := shared.Alert{
+		ID:          "episode-1",
+		Location:    testLocation.ID,
+		RuleID:      "pollen-high-juniper",
+		Severity:    shared.AlertSeverityWarning,
+		Value:       4,
+		WindowStart: now.Add(-12 * time.Hour),
+		WindowEnd:   now.Add(12 * time.Hour),
+		Status:      shared.AlertStatusActive,
+		NotifiedAt:  now.Add(-12 * time.Hour),
+	}
+	sender := &testutil.MockSender{}
+	writer := &testutil.MockWriter{
+		SaveRawFn: func(ctx context.Context, snapshot repository.PollenSnapshot) error {
+			return nil
+		},
+		UpdateCacheFn: func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
+			return merge(nil, []shared.Alert{stored}), nil
+		},
+	}
+
+	svc := NewCollectorService(indexFetcher(4, "High"), writer, sender, testConfig())
+	if err := svc.Collect(context.Background(), "test-key", testLocation); err != nil {
+		t.Fatalf("Collect() returned error: %v", err)
+	}
+	if len(sender.Sent) != 0 {
+		t.Errorf("delivered %v, want nothing for an unchanged episode", deliveredIDs(sender.Sent))
+	}
+}
+
+func TestCollect_SendFailureDoesNotFailRun(t *testing.T) {
+	committed := []shared.Alert{{ID: "undelivered", Status: shared.AlertStatusActive}}
+	var marked []string
+	sender := &testutil.MockSender{Err: fmt.Errorf("smtp unavailable")}
+
+	svc := NewCollectorService(indexFetcher(1, "Very Low"), deliveryWriter(committed, &marked, nil), sender, testConfig())
+	if err := svc.Collect(context.Background(), "test-key", testLocation); err != nil {
+		t.Fatalf("Collect() should not fail when delivery fails, got: %v", err)
+	}
+	if len(marked) != 0 {
+		t.Errorf("MarkNotified got %v, want nothing marked when the send failed", marked)
+	}
+}
+
+func TestCollect_MarkNotifiedFailureDoesNotFailRun(t *testing.T) {
+	committed := []shared.Alert{{ID: "undelivered", Status: shared.AlertStatusActive}}
+	var marked []string
+	sender := &testutil.MockSender{}
+
+	writer := deliveryWriter(committed, &marked, fmt.Errorf("firestore unavailable"))
+	svc := NewCollectorService(indexFetcher(1, "Very Low"), writer, sender, testConfig())
+	if err := svc.Collect(context.Background(), "test-key", testLocation); err != nil {
+		t.Fatalf("Collect() should not fail when marking fails, got: %v", err)
+	}
+	if len(sender.Sent) != 1 {
+		t.Errorf("delivered %d alerts, want 1 — the alert re-delivers next run", len(sender.Sent))
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// Universal Pollen Index levels, as returned in indexInfo.value.
+const (
+	UPIHigh     = 4
+	UPIVeryHigh = 5
+)
+
+// MetricPollenUPI is the metric every pollen alert watches: a plant or
+// pollen type's Universal Pollen Index.
+const MetricPollenUPI = "pollen_upi"
+
+// DetectionConfig holds the pollen alert thresholds, sourced from env so they
+// can be tuned without a code change.
+type DetectionConfig struct {
+	HighIndex   int // POLLEN_HIGH_INDEX: warning when a plant or type reaches this UPI
+	SevereIndex int // POLLEN_SEVERE_INDEX: severe when it reaches this UPI
+	JumpLevels  int // POLLEN_JUMP_LEVELS: warning when an index rises this many levels since the last reading
+	WindowHours int // POLLEN_WINDOW_HOURS: how long an alert covers from the reading that raised it
+}
+
+// DefaultDetectionConfig alerts at the API's "High" category and on a rise of
+// two levels between readings.
+func DefaultDetectionConfig() DetectionConfig {
+	return DetectionConfig{HighIndex: UPIHigh, SevereIndex: UPIVeryHigh, JumpLevels: 2, WindowHours: 24}
+}
+
+// pollenReading is one plant or pollen type in a snapshot, flattened so the
+// rules treat both the same way.
+type pollenReading struct {
+	code     string
+	name     string
+	index    int
+	category string
+}
+
+// readings flattens a snapshot's types and plants. Type codes (TREE, GRASS,
+// WEED) and plant codes (JUNIPER, OAK, ...) never collide, so the code alone
+// identifies a reading.
+func readings(s repository.PollenSnapshot) []pollenReading {
+	out := make([]pollenReading, 0, len(s.Types)+len(s.Plants))
+	for _, t := range s.Types {
+		out = append(out, pollenReading{code: t.Code, name: typeName(t.Code), index: t.Index, category: t.Category})
+	}
+	for _, p := range s.Plants {
+		name := p.DisplayName
+		if name == "" {
+			name = typeName(p.Code)
+		}
+		out = append(out, pollenReading{code: p.Code, name: name, index: p.Index, category: p.Category})
+	}
+	return out
+}
+
+// typeName turns an API code into a readable label: "TREE" -> "Tree".
+func typeName(code string) string {
+	if code == "" {
+		return code
+	}
+	lower := strings.ToLower(code)
+	return strings.ToUpper(lower[:1]) + lower[1:]
+}
+
+// DetectPollenAlerts evaluates one snapshot against two rules per plant and
+// pollen type:
+//
+//   - pollen-high-{code}: the index is at or above HighIndex,
+//   - pollen-jump-{code}: the index rose at least JumpLevels since the most
+//     recent stored reading.
+//
+// The code is part of the rule ID so that juniper and oak alerts never merge
+// into one another. Each alert's window runs WindowHours from the reading, so
+// a condition that persists across the twice-daily runs overlaps itself and
+// merges into one episode rather than notifying every run.
+func DetectPollenAlerts(locationID string, snapshot repository.PollenSnapshot, history []repository.PollenSnapshot, cfg DetectionConfig, now time.Time) []shared.Alert {
+	if cfg.WindowHours <= 0 {
+		return nil
+	}
+
+	previous := make(map[string]pollenReading)
+	if len(history) > 0 {
+		for _, r := range readings(history[len(history)-1]) {
+			previous[r.code] = r
+		}
+	}
+
+	var alerts []shared.Alert
+	for _, r := range readings(snapshot) {
+		if cfg.HighIndex > 0 && r.index >= cfg.HighIndex {
+			alerts = append(alerts, buildPollenAlert(locationID, "pollen-high-", "", " UPI", r, float64(r.index), float64(cfg.HighIndex),
+				fmt.Sprintf("%s %s (UPI %d)", r.name, r.category, r.index), cfg, now))
+		}
+		prev, ok := previous[r.code]
+		if !ok || cfg.JumpLevels <= 0 {
+			continue
+		}
+		if rise := r.index - prev.index; rise >= cfg.JumpLevels {
+			alerts = append(alerts, buildPollenAlert(locationID, "pollen-jump-", shared.AlertDirectionRise, " levels", r, float64(rise), float64(cfg.JumpLevels),
+				fmt.Sprintf("%s %s -> %s (%+d)", r.name, prev.category, r.category, rise), cfg, now))
+		}
+	}
+	return alerts
+}
+
+// buildPollenAlert fills in the fields every pollen rule shares; direction
+// and unit are the rule's. Severity follows the reading's absolute level for
+// both rules: a two-level jump to Very High deserves the same urgency as Very
+// High reached gradually.
+func buildPollenAlert(locationID, rulePrefix, direction, unit string, r pollenReading, value, threshold float64, message string, cfg DetectionConfig, now time.Time) shared.Alert {
+	severity := shared.AlertSeverityWarning
+	if cfg.SevereIndex > 0 && r.index >= cfg.SevereIndex {
+		severity = shared.AlertSeveritySevere
+	}
+	alert := shared.Alert{
+		Location:    locationID,
+		RuleID:      rulePrefix + strings.ToLower(r.code),
+		Metric:      MetricPollenUPI,
+		Direction:   direction,
+		Label:       strings.ToLower(r.name) + " pollen",
+		Unit:        unit,
+		Severity:    severity,
+		Value:       value,
+		Threshold:   threshold,
+		WindowStart: now,
+		WindowEnd:   now.Add(time.Duration(cfg.WindowHours) * time.Hour),
+		Message:     message,
+		Status:      shared.AlertStatusActive,
+		IssuedAt:    now,
+	}
+	alert.ID = alert.ComputeID()
+	return alert
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+var detectNow = time.Date(2026, 4, 2, 12, 0, 0, 0, time.UTC)
+
+// plantSnapshot builds a snapshot holding a single plant at the given index.
+func plantSnapshot(code, name string, index int, category string) repository.PollenSnapshot {
+	return repository.PollenSnapshot{
+		LocationID:  "house-nick",
+		CollectedAt: detectNow,
+		Plants:      []repository.StoredPollenPlant{{Code: code, DisplayName: name, Index: index, Category: category, InSeason: true}},
+	}
+}
+
+// typeSnapshot builds a snapshot holding a single pollen type at the given index.
+func typeSnapshot(code string, index int, category string) repository.PollenSnapshot {
+	return repository.PollenSnapshot{
+		LocationID:  "house-nick",
+		CollectedAt: detectNow,
+		Types:       []repository.StoredPollenType{{Code: code, Index: index, Category: category, InSeason: true}},
+	}
+}
+
+func detectPollen(snapshot repository.PollenSnapshot, history ...repository.PollenSnapshot) []shared.Alert {
+	return DetectPollenAlerts("house-nick", snapshot, history, DefaultDetectionConfig(), detectNow)
+}
+
+func alertsByRule(alerts []shared.Alert) map[string]shared.Alert {
+	out := make(map[string]shared.Alert, len(alerts))
+	for _, a := range alerts {
+		out[a.RuleID] = a
+	}
+	return out
+}
+
+func TestDetectPollen_HighIndexProducesWarning(t *testing.T) {
+	alerts := detectPollen(plantSnapshot("JUNIPER", "Juniper", 4, "High"))
+
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1: %+v", len(alerts), alerts)
+	}
+	a := alerts[0]
+	if a.RuleID != "pollen-high-juniper" {
+		t.Errorf("RuleID = %q, want pollen-high-juniper", a.RuleID)
+	}
+	if a.Severity != shared.AlertSeverityWarning {
+		t.Errorf("Severity = %q, want warning", a.Severity)
+	}
+	if a.Value != 4 || a.Threshold != 4 {
+		t.Errorf("Value/Threshold = %v/%v, want 4/4", a.Value, a.Threshold)
+	}
+	if a.Message != "Juniper High (UPI 4)" {
+		t.Errorf("Message = %q", a.Message)
+	}
+	if !a.WindowStart.Equal(detectNow) || !a.WindowEnd.Equal(detectNow.Add(24*time.Hour)) {
+		t.Errorf("window = %v..%v, want 24h from the reading", a.WindowStart, a.WindowEnd)
+	}
+	if a.Status != shared.AlertStatusActive || a.ID == "" {
+		t.Errorf("Status/ID = %q/%q, want an active alert with an ID", a.Status, a.ID)
+	}
+}
+
+func TestDetectPollen_BelowThresholdIgnored(t *testing.T) {
+	if alerts := detectPollen(plantSnapshot("OAK", "Oak", 3, "Moderate")); len(alerts) != 0 {
+		t.Errorf("got %d alerts, want 0 below the high index", len(alerts))
+	}
+}
+
+func TestDetectPollen_SevereIndex(t *testing.T) {
+	alerts := detectPollen(plantSnapshot("JUNIPER", "Juniper", 5, "Very High"))
+
+	if len(alerts) != 1 || alerts[0].Severity != shared.AlertSeveritySevere {
+		t.Fatalf("got %+v, want one severe alert", alerts)
+	}
+}
+
+func TestDetectPollen_JumpAgainstLatestHistory(t *testing.T) {
+	older := typeSnapshot("TREE", 4, "High")
+	latest := typeSnapshot("TREE", 1, "Very Low")
+	current := typeSnapshot("TREE", 3, "Moderate")
+
+	alerts := alertsByRule(detectPollen(current, older, latest))
+
+	jump, ok := alerts["pollen-jump-tree"]
+	if !ok {
+		t.Fatalf("no pollen-jump-tree alert in %v — the rise is measured against the most recent reading", alerts)
+	}
+	if jump.Value != 2 || jump.Threshold != 2 {
+		t.Errorf("Value/Threshold = %v/%v, want +2/2", jump.Value, jump.Threshold)
+	}
+	if jump.Message != "Tree Very Low -> Moderate (+2)" {
+		t.Errorf("Message = %q", jump.Message)
+	}
+	if _, ok := alerts["pollen-high-tree"]; ok {
+		t.Error("Moderate is below the high index and should not raise pollen-high-tree")
+	}
+}
+
+func TestDetectPollen_NoHistoryNoJump(t *testing.T) {
+	alerts := alertsByRule(detectPollen(plantSnapshot("JUNIPER", "Juniper", 5, "Very High")))
+
+	if _, ok := alerts["pollen-jump-juniper"]; ok {
+		t.Error("first reading has nothing to jump from")
+	}
+}
+
+func TestDetectPollen_DistinctRulesPerPlant(t *testing.T) {
+	snapshot := repository.PollenSnapshot{
+		CollectedAt: detectNow,
+		Plants: []repository.StoredPollenPlant{
+			{Code: "JUNIPER", DisplayName: "Juniper", Index: 4, Category: "High"},
+			{Code: "OAK", DisplayName: "Oak", Index: 4, Category: "High"},
+		},
+	}
+
+	alerts := detectPollen(snapshot)
+	merged := shared.MergeAlerts(nil, alerts, detectNow)
+
+	if len(merged) != 2 {
+		t.Fatalf("got %d merged alerts, want 2 — juniper and oak must not merge into one", len(merged))
+	}
+}
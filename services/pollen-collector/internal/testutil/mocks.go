@@ -2,10 +2,12 @@ package testutil
 
 import (
 	"context"
+	"time"
 
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/api"
 	"github.com/nickfang/personal-dashboard/services/pollen-collector/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/notify"
 )
 
 // MockFetcher implements api.Fetcher for testing.
@@ -17,16 +19,44 @@ func (m *MockFetcher) Fetch(apiKey string, location shared.Location) (*api.Polle
 	return m.FetchFn(apiKey, location)
 }
 
-// MockWriter implements repository.Writer for testing.
+// MockWriter implements repository.Writer for testing. UpdateCacheFn and
+// MarkNotifiedFn behave as successful no-ops when unset; an unset
+// UpdateCacheFn still runs the merge against empty state, as a first run would.
 type MockWriter struct {
-	SaveRawFn     func(ctx context.Context, snapshot repository.PollenSnapshot) error
-	UpdateCacheFn func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot) error
+	SaveRawFn      func(ctx context.Context, snapshot repository.PollenSnapshot) error
+	UpdateCacheFn  func(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error)
+	MarkNotifiedFn func(ctx context.Context, locationID string, alertIDs []string, at time.Time) error
 }
 
 func (m *MockWriter) SaveRaw(ctx context.Context, snapshot repository.PollenSnapshot) error {
 	return m.SaveRawFn(ctx, snapshot)
 }
 
-func (m *MockWriter) UpdateCache(ctx context.Context, locationID string, snapshot repository.PollenSnapshot) error {
-	return m.UpdateCacheFn(ctx, locationID, snapshot)
+func (m *MockWriter) UpdateCache(ctx context.Context, locationID string, snapshot repository.PollenSnapshot, forecast []repository.PollenForecastDay, merge repository.MergeFunc) ([]shared.Alert, error) {
+	if m.UpdateCacheFn == nil {
+		return merge(nil, nil), nil
+	}
+	return m.UpdateCacheFn(ctx, locationID, snapshot, forecast, merge)
+}
+
+func (m *MockWriter) MarkNotified(ctx context.Context, locationID string, alertIDs []string, at time.Time) error {
+	if m.MarkNotifiedFn == nil {
+		return nil
+	}
+	return m.MarkNotifiedFn(ctx, locationID, alertIDs, at)
+}
+
+// MockSender implements notify.Sender for testing: it records what it was
+// asked to deliver and can be made to fail.
+type MockSender struct {
+	Sent []notify.Notification
+	Err  error
+}
+
+func (m *MockSender) Send(ctx context.Context, n notify.Notification) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Sent = append(m.Sent, n)
+	return nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/nickfang/personal-dashboard/services/pollen-provider/internal/service"
 	"github.com/nickfang/personal-dashboard/services/pollen-provider/internal/transport"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -36,7 +37,12 @@ func main() {
 	if port == "" {
 		port = "50052"
 	}
-	if projectID == "" {
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
+	if projectID == "" && !useSQLite {
 		slog.Error("Missing required env var: GCP_PROJECT_ID")
 		os.Exit(1)
 	}
@@ -44,7 +50,7 @@ func main() {
 	ctx := context.Background()
 
 	// 3. Initialize Layers
-	repo, err := repository.NewFirestoreRepository(ctx, projectID)
+	repo, err := repository.OpenReader(ctx, projectID)
 	if err != nil {
 		slog.Error("Failed to initialize repository", "error", err)
 		os.Exit(1)
@@ -3,44 +3,22 @@ package repository
 import (
 	"context"
 	"log/slog"
-	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
 	"google.golang.org/api/iterator"
 )
 
-// Internal Firestore Models (Match pollen-collector)
-type StoredPollenType struct {
-	Code     string `firestore:"code"`
-	Index    int    `firestore:"index"`
-	Category string `firestore:"category"`
-	InSeason bool   `firestore:"in_season"`
-}
-
-type StoredPollenPlant struct {
-	Code        string `firestore:"code"`
-	DisplayName string `firestore:"display_name"`
-	Index       int    `firestore:"index"`
-	Category    string `firestore:"category"`
-	InSeason    bool   `firestore:"in_season"`
-}
-
-type PollenSnapshot struct {
-	LocationID      string              `firestore:"location_id"`
-	CollectedAt     time.Time           `firestore:"collected_at"`
-	OverallIndex    int                 `firestore:"overall_index"`
-	OverallCategory string              `firestore:"overall_category"`
-	DominantType    string              `firestore:"dominant_type"`
-	Types           []StoredPollenType  `firestore:"types"`
-	Plants          []StoredPollenPlant `firestore:"plants"`
-}
-
-type CacheDoc struct {
-	LocationID   string         `firestore:"-"` // Not in doc, but we use doc.ID
-	LastUpdated  time.Time      `firestore:"last_updated"`
-	CurrentValue PollenSnapshot `firestore:"current"`
-}
+// The stored documents are defined once, in shared/schema, for
+// pollen-collector and every service that reads them.
+type (
+	StoredPollenType  = schema.PollenType
+	StoredPollenPlant = schema.PollenPlant
+	PollenSnapshot    = schema.PollenSnapshot
+	PollenForecastDay = schema.PollenForecastDay
+	CacheDoc          = schema.PollenCacheDoc
+)
 
 type FirestoreRepository struct {
 	client *firestore.Client
@@ -79,7 +57,7 @@ func (r *FirestoreRepository) GetAll(ctx context.Context) ([]CacheDoc, error) {
 			slog.Warn("Skipping invalid document in GetAll", "doc_id", doc.Ref.ID, "error", err)
 			continue
 		}
-		cache.LocationID = doc.Ref.ID
+		schema.Read(doc.Ref.ID, &cache)
 		results = append(results, cache)
 	}
 
@@ -96,6 +74,6 @@ func (r *FirestoreRepository) GetByID(ctx context.Context, id string) (*CacheDoc
 	if err := doc.DataTo(&cache); err != nil {
 		return nil, err
 	}
-	cache.LocationID = doc.Ref.ID
+	schema.Read(doc.Ref.ID, &cache)
 	return &cache, nil
 }
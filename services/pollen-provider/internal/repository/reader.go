@@ -6,3 +6,9 @@ type PollenReader interface {
 	GetAll(ctx context.Context) ([]CacheDoc, error)
 	GetByID(ctx context.Context, id string) (*CacheDoc, error)
 }
+
+// ReadCloser is a PollenReader that holds a connection.
+type ReadCloser interface {
+	PollenReader
+	Close() error
+}
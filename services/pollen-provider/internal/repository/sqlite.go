@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteRepository reads pollen_cache from the docstore file. A missing
+// document returns docstore.ErrNotFound, which carries codes.NotFound, so the
+// transport maps it exactly as it maps Firestore's.
+type SQLiteRepository struct {
+	db *docstore.DB
+}
+
+func NewSQLiteRepository(db *docstore.DB) *SQLiteRepository {
+	return &SQLiteRepository{db: db}
+}
+
+// OpenReader returns the SQLite repository when docstore selects it, and
+// otherwise connects to pollen-log.
+func OpenReader(ctx context.Context, projectID string) (ReadCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteRepository(db), nil
+	}
+	fr, err := NewFirestoreRepository(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fr, nil
+}
+
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *SQLiteRepository) GetAll(ctx context.Context) ([]CacheDoc, error) {
+	docs, err := r.db.Documents(ctx, docstore.Query{Collection: shared.PollenCacheCollection, Limit: 100})
+	if err != nil {
+		return nil, err
+	}
+	var results []CacheDoc
+	for _, doc := range docs {
+		var cache CacheDoc
+		if err := doc.DataTo(&cache); err != nil {
+			slog.Warn("Skipping invalid document in GetAll", "doc_id", doc.ID, "error", err)
+			continue
+		}
+		schema.Read(doc.ID, &cache)
+		results = append(results, cache)
+	}
+	return results, nil
+}
+
+func (r *SQLiteRepository) GetByID(ctx context.Context, id string) (*CacheDoc, error) {
+	var cache CacheDoc
+	if err := r.db.Get(ctx, shared.PollenCacheCollection, id, &cache); err != nil {
+		return nil, err
+	}
+	schema.Read(id, &cache)
+	return &cache, nil
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/nickfang/personal-dashboard/services/pollen-provider/internal/repository"
 )
@@ -21,3 +23,70 @@ func (s *PollenService) GetAllReports(ctx context.Context) ([]repository.CacheDo
 func (s *PollenService) GetReportByID(ctx context.Context, id string) (*repository.CacheDoc, error) {
 	return s.repo.GetByID(ctx, id)
 }
+
+// GetForecastByID returns the location's cache document, whose Forecast holds
+// the latest multi-day outlook. The outlook lives in the same document as the
+// current report, so this is the same read.
+func (s *PollenService) GetForecastByID(ctx context.Context, id string) (*repository.CacheDoc, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// HistoryQuery narrows a location's stored history. Zero values mean "no
+// filter": an empty Code keeps every type and plant, and a zero Start or End
+// leaves that side of the range open. The range is [Start, End), as for the
+// Weather Provider's range queries.
+type HistoryQuery struct {
+	Code  string
+	Start time.Time
+	End   time.Time
+}
+
+// GetHistoryByID returns the location's stored snapshots, oldest first, that
+// fall inside the query's time range. When the query names a code,
+// each snapshot is narrowed to it, as filterSnapshot describes.
+func (s *PollenService) GetHistoryByID(ctx context.Context, id string, q HistoryQuery) ([]repository.PollenSnapshot, error) {
+	doc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []repository.PollenSnapshot
+	for _, snap := range doc.History {
+		if !q.Start.IsZero() && snap.CollectedAt.Before(q.Start) {
+			continue
+		}
+		if !q.End.IsZero() && !snap.CollectedAt.Before(q.End) {
+			continue
+		}
+		if q.Code != "" {
+			snap = filterSnapshot(snap, q.Code)
+		}
+		out = append(out, snap)
+	}
+	return out, nil
+}
+
+// filterSnapshot returns a copy of snap whose types and plants are limited to
+// the given code. The overall index, category and dominant type are cleared,
+// since they summarize every code rather than the one asked for. The stored
+// slices are not modified.
+func filterSnapshot(snap repository.PollenSnapshot, code string) repository.PollenSnapshot {
+	var types []repository.StoredPollenType
+	for _, t := range snap.Types {
+		if strings.EqualFold(t.Code, code) {
+			types = append(types, t)
+		}
+	}
+	var plants []repository.StoredPollenPlant
+	for _, p := range snap.Plants {
+		if strings.EqualFold(p.Code, code) {
+			plants = append(plants, p)
+		}
+	}
+	snap.Types = types
+	snap.Plants = plants
+	snap.OverallIndex = 0
+	snap.OverallCategory = ""
+	snap.DominantType = ""
+	return snap
+}
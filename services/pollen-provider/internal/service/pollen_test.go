@@ -100,3 +100,88 @@ func TestGetAllReports(t *testing.T) {
 		}
 	})
 }
+
+func historyRepo(history []repository.PollenSnapshot) *testutil.MockReader {
+	return &testutil.MockReader{
+		GetByIDFunc: func(ctx context.Context, id string) (*repository.CacheDoc, error) {
+			return &repository.CacheDoc{LocationID: id, History: history}, nil
+		},
+	}
+}
+
+func TestGetHistoryByID(t *testing.T) {
+	base := time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC)
+	history := []repository.PollenSnapshot{
+		{
+			CollectedAt:     base,
+			OverallIndex:    3,
+			OverallCategory: "Moderate",
+			DominantType:    "TREE",
+			Types:           []repository.StoredPollenType{{Code: "TREE", Index: 3}, {Code: "GRASS", Index: 1}},
+			Plants:          []repository.StoredPollenPlant{{Code: "JUNIPER", Index: 3}, {Code: "OAK", Index: 2}},
+		},
+		{CollectedAt: base.Add(8 * time.Hour), Types: []repository.StoredPollenType{{Code: "TREE", Index: 4}}},
+		{CollectedAt: base.Add(24 * time.Hour), Types: []repository.StoredPollenType{{Code: "TREE", Index: 5}}},
+	}
+
+	t.Run("NoFilter", func(t *testing.T) {
+		svc := NewPollenService(historyRepo(history))
+
+		got, err := svc.GetHistoryByID(context.Background(), "house-nick", HistoryQuery{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(got) != 3 {
+			t.Errorf("expected all 3 snapshots, got %d", len(got))
+		}
+	})
+
+	t.Run("TimeRangeHalfOpen", func(t *testing.T) {
+		svc := NewPollenService(historyRepo(history))
+
+		got, err := svc.GetHistoryByID(context.Background(), "house-nick", HistoryQuery{
+			Start: base.Add(8 * time.Hour),
+			End:   base.Add(24 * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(got) != 1 || !got[0].CollectedAt.Equal(base.Add(8*time.Hour)) {
+			t.Errorf("expected only the snapshot at start, not the one at end, got %+v", got)
+		}
+	})
+
+	t.Run("CodeFilter", func(t *testing.T) {
+		svc := NewPollenService(historyRepo(history))
+
+		got, err := svc.GetHistoryByID(context.Background(), "house-nick", HistoryQuery{Code: "juniper"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(got) != 3 {
+			t.Fatalf("expected every snapshot kept, got %d", len(got))
+		}
+		if len(got[0].Types) != 0 || len(got[0].Plants) != 1 || got[0].Plants[0].Code != "JUNIPER" {
+			t.Errorf("expected only JUNIPER in the first snapshot, got types %+v plants %+v", got[0].Types, got[0].Plants)
+		}
+		if got[0].OverallIndex != 0 || got[0].OverallCategory != "" || got[0].DominantType != "" {
+			t.Errorf("expected the all-code summary dropped, got index %d category %q dominant %q", got[0].OverallIndex, got[0].OverallCategory, got[0].DominantType)
+		}
+		if len(history[0].Plants) != 2 {
+			t.Error("filtering mutated the stored history")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockRepo := &testutil.MockReader{
+			GetByIDFunc: func(ctx context.Context, id string) (*repository.CacheDoc, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewPollenService(mockRepo)
+
+		if _, err := svc.GetHistoryByID(context.Background(), "house-nick", HistoryQuery{}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
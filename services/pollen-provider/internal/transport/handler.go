@@ -51,26 +51,108 @@ func (h *GrpcHandler) GetPollenReport(ctx context.Context, req *pb.GetPollenRepo
 	return &pb.GetPollenReportResponse{Report: mapToProto(doc)}, nil
 }
 
+func (h *GrpcHandler) GetPollenForecast(ctx context.Context, req *pb.GetPollenForecastRequest) (*pb.GetPollenForecastResponse, error) {
+	if req.LocationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "location_id is required")
+	}
+	doc, err := h.svc.GetForecastByID(ctx, req.LocationId)
+	if err != nil {
+		slog.Error("Failed to retrieve pollen forecast", "error", err, "location_id", req.LocationId)
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "pollen forecast not found for location: %s", req.LocationId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to retrieve pollen forecast: %v", err)
+	}
+	// Documents written before the collector stored an outlook have none.
+	if len(doc.Forecast) == 0 {
+		return nil, status.Errorf(codes.NotFound, "pollen forecast not found for location: %s", req.LocationId)
+	}
+
+	return &pb.GetPollenForecastResponse{Forecast: mapForecastToProto(doc)}, nil
+}
+
+func (h *GrpcHandler) GetPollenHistory(ctx context.Context, req *pb.GetPollenHistoryRequest) (*pb.GetPollenHistoryResponse, error) {
+	if req.LocationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "location_id is required")
+	}
+	q := service.HistoryQuery{Code: req.Code}
+	if req.StartTime != nil {
+		q.Start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		q.End = req.EndTime.AsTime()
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && !q.Start.Before(q.End) {
+		return nil, status.Errorf(codes.InvalidArgument, "start_time must be before end_time")
+	}
+
+	history, err := h.svc.GetHistoryByID(ctx, req.LocationId, q)
+	if err != nil {
+		slog.Error("Failed to retrieve pollen history", "error", err, "location_id", req.LocationId)
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "pollen history not found for location: %s", req.LocationId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to retrieve pollen history: %v", err)
+	}
+
+	resp := &pb.GetPollenHistoryResponse{}
+	for _, snap := range history {
+		resp.Snapshots = append(resp.Snapshots, mapSnapshotToProto(req.LocationId, snap))
+	}
+	return resp, nil
+}
+
 func mapToProto(doc *repository.CacheDoc) *pb.PollenReport {
-	report := &pb.PollenReport{
-		LocationId:      doc.LocationID,
-		CollectedAt:     timestamppb.New(doc.CurrentValue.CollectedAt),
-		OverallIndex:    int32(doc.CurrentValue.OverallIndex),
-		OverallCategory: doc.CurrentValue.OverallCategory,
-		DominantType:    doc.CurrentValue.DominantType,
+	return mapSnapshotToProto(doc.LocationID, doc.CurrentValue)
+}
+
+func mapSnapshotToProto(locationID string, snap repository.PollenSnapshot) *pb.PollenReport {
+	return &pb.PollenReport{
+		LocationId:      locationID,
+		CollectedAt:     timestamppb.New(snap.CollectedAt),
+		OverallIndex:    int32(snap.OverallIndex),
+		OverallCategory: snap.OverallCategory,
+		DominantType:    snap.DominantType,
+		Types:           mapTypes(snap.Types),
+		Plants:          mapPlants(snap.Plants),
 	}
+}
 
-	for _, t := range doc.CurrentValue.Types {
-		report.Types = append(report.Types, &pb.PollenType{
+func mapForecastToProto(doc *repository.CacheDoc) *pb.PollenForecast {
+	forecast := &pb.PollenForecast{
+		LocationId:  doc.LocationID,
+		CollectedAt: timestamppb.New(doc.CurrentValue.CollectedAt),
+	}
+	for _, d := range doc.Forecast {
+		forecast.Days = append(forecast.Days, &pb.PollenForecastDay{
+			Date:            d.Date,
+			OverallIndex:    int32(d.OverallIndex),
+			OverallCategory: d.OverallCategory,
+			DominantType:    d.DominantType,
+			Types:           mapTypes(d.Types),
+			Plants:          mapPlants(d.Plants),
+		})
+	}
+	return forecast
+}
+
+func mapTypes(types []repository.StoredPollenType) []*pb.PollenType {
+	var out []*pb.PollenType
+	for _, t := range types {
+		out = append(out, &pb.PollenType{
 			Code:     t.Code,
 			Index:    int32(t.Index),
 			Category: t.Category,
 			InSeason: t.InSeason,
 		})
 	}
+	return out
+}
 
-	for _, p := range doc.CurrentValue.Plants {
-		report.Plants = append(report.Plants, &pb.PollenPlant{
+func mapPlants(plants []repository.StoredPollenPlant) []*pb.PollenPlant {
+	var out []*pb.PollenPlant
+	for _, p := range plants {
+		out = append(out, &pb.PollenPlant{
 			Code:        p.Code,
 			DisplayName: p.DisplayName,
 			Index:       int32(p.Index),
@@ -78,6 +160,5 @@ func mapToProto(doc *repository.CacheDoc) *pb.PollenReport {
 			InSeason:    p.InSeason,
 		})
 	}
-
-	return report
+	return out
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/nickfang/personal-dashboard/services/pollen-provider/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/pollen-provider/internal/service"
 	"github.com/nickfang/personal-dashboard/services/pollen-provider/internal/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestGetPollenReport_Mapping(t *testing.T) {
@@ -169,3 +172,172 @@ func TestGetPollenReport_Error(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestGetPollenForecast_Mapping(t *testing.T) {
+	now := time.Now()
+	mockRepo := &testutil.MockReader{
+		GetByIDFunc: func(ctx context.Context, id string) (*repository.CacheDoc, error) {
+			return &repository.CacheDoc{
+				LocationID:   id,
+				LastUpdated:  now,
+				CurrentValue: repository.PollenSnapshot{CollectedAt: now},
+				Forecast: []repository.PollenForecastDay{
+					{Date: "2026-04-02", OverallIndex: 3, OverallCategory: "Moderate", DominantType: "TREE"},
+					{
+						Date:            "2026-04-03",
+						OverallIndex:    5,
+						OverallCategory: "Very High",
+						DominantType:    "TREE",
+						Types:           []repository.StoredPollenType{{Code: "TREE", Index: 5, Category: "Very High", InSeason: true}},
+						Plants:          []repository.StoredPollenPlant{{Code: "OAK", DisplayName: "Oak", Index: 5, Category: "Very High", InSeason: true}},
+					},
+				},
+			}, nil
+		},
+	}
+
+	handler := NewGrpcHandler(service.NewPollenService(mockRepo))
+	resp, err := handler.GetPollenForecast(context.Background(), &pb.GetPollenForecastRequest{LocationId: "house-nick"})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	f := resp.Forecast
+	if f.LocationId != "house-nick" {
+		t.Errorf("LocationId = %s, want house-nick", f.LocationId)
+	}
+	if !f.CollectedAt.AsTime().Equal(now) {
+		t.Errorf("CollectedAt = %v, want %v", f.CollectedAt.AsTime(), now)
+	}
+	if len(f.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(f.Days))
+	}
+	day := f.Days[1]
+	if day.Date != "2026-04-03" || day.OverallIndex != 5 || day.OverallCategory != "Very High" {
+		t.Errorf("Days[1] = %s/%d/%s, want 2026-04-03/5/Very High", day.Date, day.OverallIndex, day.OverallCategory)
+	}
+	if len(day.Types) != 1 || day.Types[0].Code != "TREE" {
+		t.Errorf("Days[1].Types = %v, want [TREE]", day.Types)
+	}
+	if len(day.Plants) != 1 || day.Plants[0].DisplayName != "Oak" {
+		t.Errorf("Days[1].Plants = %v, want [Oak]", day.Plants)
+	}
+}
+
+func TestGetPollenForecast_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		doc      *repository.CacheDoc
+		err      error
+		wantCode codes.Code
+	}{
+		{"missing location", "", nil, nil, codes.InvalidArgument},
+		{"no stored outlook", "house-nick", &repository.CacheDoc{LocationID: "house-nick"}, nil, codes.NotFound},
+		{"document not found", "house-nick", nil, status.Error(codes.NotFound, "no doc"), codes.NotFound},
+		{"read failure", "house-nick", nil, fmt.Errorf("firestore unavailable"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &testutil.MockReader{
+				GetByIDFunc: func(ctx context.Context, id string) (*repository.CacheDoc, error) {
+					return tt.doc, tt.err
+				},
+			}
+			handler := NewGrpcHandler(service.NewPollenService(mockRepo))
+
+			_, err := handler.GetPollenForecast(context.Background(), &pb.GetPollenForecastRequest{LocationId: tt.id})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestGetPollenHistory_Mapping(t *testing.T) {
+	base := time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC)
+	mockRepo := &testutil.MockReader{
+		GetByIDFunc: func(ctx context.Context, id string) (*repository.CacheDoc, error) {
+			return &repository.CacheDoc{
+				LocationID: id,
+				History: []repository.PollenSnapshot{
+					{CollectedAt: base, OverallIndex: 3, OverallCategory: "Moderate", DominantType: "TREE"},
+					{
+						CollectedAt:     base.Add(8 * time.Hour),
+						OverallIndex:    4,
+						OverallCategory: "High",
+						DominantType:    "TREE",
+						Types:           []repository.StoredPollenType{{Code: "TREE", Index: 4, Category: "High", InSeason: true}},
+					},
+				},
+			}, nil
+		},
+	}
+
+	handler := NewGrpcHandler(service.NewPollenService(mockRepo))
+	resp, err := handler.GetPollenHistory(context.Background(), &pb.GetPollenHistoryRequest{
+		LocationId: "house-nick",
+		StartTime:  timestamppb.New(base.Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	if len(resp.Snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot after start_time, got %d", len(resp.Snapshots))
+	}
+	snap := resp.Snapshots[0]
+	if snap.LocationId != "house-nick" || snap.OverallIndex != 4 {
+		t.Errorf("snapshot = %s/%d, want house-nick/4", snap.LocationId, snap.OverallIndex)
+	}
+	if !snap.CollectedAt.AsTime().Equal(base.Add(8 * time.Hour)) {
+		t.Errorf("CollectedAt = %v, want %v", snap.CollectedAt.AsTime(), base.Add(8*time.Hour))
+	}
+	if len(snap.Types) != 1 || snap.Types[0].Code != "TREE" {
+		t.Errorf("Types = %v, want [TREE]", snap.Types)
+	}
+}
+
+func TestGetPollenHistory_Errors(t *testing.T) {
+	start := time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		req      *pb.GetPollenHistoryRequest
+		err      error
+		wantCode codes.Code
+	}{
+		{"missing location", &pb.GetPollenHistoryRequest{}, nil, codes.InvalidArgument},
+		{"inverted range", &pb.GetPollenHistoryRequest{
+			LocationId: "house-nick",
+			StartTime:  timestamppb.New(start),
+			EndTime:    timestamppb.New(start.Add(-time.Hour)),
+		}, nil, codes.InvalidArgument},
+		{"empty range", &pb.GetPollenHistoryRequest{
+			LocationId: "house-nick",
+			StartTime:  timestamppb.New(start),
+			EndTime:    timestamppb.New(start),
+		}, nil, codes.InvalidArgument},
+		{"document not found", &pb.GetPollenHistoryRequest{LocationId: "house-nick"}, status.Error(codes.NotFound, "no doc"), codes.NotFound},
+		{"read failure", &pb.GetPollenHistoryRequest{LocationId: "house-nick"}, fmt.Errorf("firestore unavailable"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &testutil.MockReader{
+				GetByIDFunc: func(ctx context.Context, id string) (*repository.CacheDoc, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &repository.CacheDoc{LocationID: id}, nil
+				},
+			}
+			handler := NewGrpcHandler(service.NewPollenService(mockRepo))
+
+			_, err := handler.GetPollenHistory(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
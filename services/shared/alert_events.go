@@ -0,0 +1,98 @@
+package shared
+
+import "time"
+
+// Alert lifecycle event types, in the order AlertEvents reports them for a
+// single alert.
+const (
+	AlertEventCreated     = "created"     // first detected
+	AlertEventReactivated = "reactivated" // resolved, then detected again
+	AlertEventResolved    = "resolved"    // no longer in the forecast
+	AlertEventEscalated   = "escalated"   // worsened enough to clear NotifiedAt
+	AlertEventDelivered   = "delivered"   // NotifiedAt stamped
+	AlertEventUpdated     = "updated"     // re-detected with new numbers, nothing else changed
+	AlertEventPruned      = "pruned"      // window passed; dropped from the cache
+)
+
+// AlertEventCovered is recorded by the notifier rather than AlertEvents: an
+// observed alert stamped without being sent, because a delivered forecast
+// alert already told the user about the same drop.
+const AlertEventCovered = "covered"
+
+// AlertEventHeld is recorded by the notifier too, on each run its gate holds
+// a pending forecast alert back. Nothing about the alert changes, so Prev is
+// the alert as well; why it was held is on that run's notifier_observations
+// record.
+const AlertEventHeld = "held"
+
+// AlertEvent is one transition in an alert's life. Alert is the alert after
+// the transition and Prev before it; a created alert has no Prev, and a
+// pruned one is the same in both.
+type AlertEvent struct {
+	Type  string
+	At    time.Time
+	Alert Alert
+	Prev  Alert
+}
+
+// AlertEvents derives the lifecycle events between a stored alert set and the
+// set replacing it, matching alerts by ID — which MergeAlerts preserves for
+// as long as an episode lasts. Each writer of an alert set calls it inside
+// the transaction that commits next, so the events are recorded exactly when
+// the change is.
+//
+// An alert can have several events in one transition: re-detection after a
+// flap that also worsened is reactivated and escalated. Updated is reported
+// only when no other event explains a change to the alert's numbers.
+func AlertEvents(prev, next []Alert, at time.Time) []AlertEvent {
+	stored := make(map[string]Alert, len(prev))
+	for _, p := range prev {
+		stored[p.ID] = p
+	}
+
+	var events []AlertEvent
+	add := func(typ string, a, p Alert) {
+		events = append(events, AlertEvent{Type: typ, At: at, Alert: a, Prev: p})
+	}
+	kept := make(map[string]bool, len(next))
+	for _, a := range next {
+		kept[a.ID] = true
+		p, ok := stored[a.ID]
+		if !ok {
+			add(AlertEventCreated, a, Alert{})
+			if !a.NotifiedAt.IsZero() {
+				add(AlertEventDelivered, a, Alert{})
+			}
+			continue
+		}
+		n := len(events)
+		switch {
+		case p.Status != AlertStatusActive && a.Status == AlertStatusActive:
+			add(AlertEventReactivated, a, p)
+		case p.Status == AlertStatusActive && a.Status != AlertStatusActive:
+			add(AlertEventResolved, a, p)
+		}
+		if !p.NotifiedAt.IsZero() && a.NotifiedAt.IsZero() {
+			add(AlertEventEscalated, a, p)
+		}
+		if !a.NotifiedAt.IsZero() && !a.NotifiedAt.Equal(p.NotifiedAt) {
+			add(AlertEventDelivered, a, p)
+		}
+		if len(events) == n && numbersChanged(p, a) {
+			add(AlertEventUpdated, a, p)
+		}
+	}
+	for _, p := range prev {
+		if !kept[p.ID] {
+			add(AlertEventPruned, p, p)
+		}
+	}
+	return events
+}
+
+// numbersChanged reports whether a re-detection moved anything a reader of
+// the alert would see.
+func numbersChanged(p, a Alert) bool {
+	return p.Value != a.Value || p.Severity != a.Severity || p.Threshold != a.Threshold ||
+		!p.WindowStart.Equal(a.WindowStart) || !p.WindowEnd.Equal(a.WindowEnd)
+}
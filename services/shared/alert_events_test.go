@@ -0,0 +1,114 @@
+package shared
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// eventTypes lists the types of events, in order.
+func eventTypes(events []AlertEvent) []string {
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestAlertEvents_Lifecycle(t *testing.T) {
+	tests := []struct {
+		name string
+		prev []Alert
+		next func(prev []Alert) []Alert
+		want []string
+	}{
+		{
+			name: "new detection",
+			next: func([]Alert) []Alert {
+				return MergeAlerts(nil, []Alert{pressureAlert(2, 5, -6, AlertStatusActive)}, baseTime)
+			},
+			want: []string{AlertEventCreated},
+		},
+		{
+			name: "unchanged re-detection",
+			prev: []Alert{pressureAlert(2, 5, -6, AlertStatusActive)},
+			next: func(prev []Alert) []Alert {
+				return MergeAlerts(prev, []Alert{pressureAlert(2, 5, -6, AlertStatusActive)}, baseTime)
+			},
+		},
+		{
+			name: "improved re-detection",
+			prev: []Alert{notified(pressureAlert(2, 5, -7, AlertStatusActive))},
+			next: func(prev []Alert) []Alert {
+				return MergeAlerts(prev, []Alert{pressureAlert(2, 5, -6, AlertStatusActive)}, baseTime)
+			},
+			want: []string{AlertEventUpdated},
+		},
+		{
+			name: "worsened past the escalation step",
+			prev: []Alert{notified(pressureAlert(2, 5, -6, AlertStatusActive))},
+			next: func(prev []Alert) []Alert {
+				return MergeAlerts(prev, []Alert{pressureAlert(2, 5, -7.2, AlertStatusActive)}, baseTime)
+			},
+			want: []string{AlertEventEscalated},
+		},
+		{
+			name: "no longer detected",
+			prev: []Alert{pressureAlert(2, 5, -6, AlertStatusActive)},
+			next: func(prev []Alert) []Alert { return MergeAlerts(prev, nil, baseTime) },
+			want: []string{AlertEventResolved},
+		},
+		{
+			name: "flap back, worse",
+			prev: []Alert{notified(pressureAlert(2, 5, -6, AlertStatusResolved))},
+			next: func(prev []Alert) []Alert {
+				return MergeAlerts(prev, []Alert{pressureAlert(2, 5, -7.2, AlertStatusActive)}, baseTime)
+			},
+			want: []string{AlertEventReactivated, AlertEventEscalated},
+		},
+		{
+			name: "window passed",
+			prev: []Alert{pressureAlert(-6, -3, -6, AlertStatusResolved)},
+			next: func(prev []Alert) []Alert { return MergeAlerts(prev, nil, baseTime) },
+			want: []string{AlertEventPruned},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AlertEvents(tt.prev, tt.next(tt.prev), baseTime)
+			if !slices.Equal(eventTypes(got), tt.want) {
+				t.Errorf("AlertEvents = %v, want %v", eventTypes(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestAlertEvents_Delivered(t *testing.T) {
+	prev := pressureAlert(2, 5, -6, AlertStatusActive)
+	sent := prev
+	sent.NotifiedAt = baseTime.Add(time.Minute)
+
+	got := AlertEvents([]Alert{prev}, []Alert{sent}, sent.NotifiedAt)
+
+	if len(got) != 1 || got[0].Type != AlertEventDelivered {
+		t.Fatalf("AlertEvents = %v, want one delivered event", eventTypes(got))
+	}
+	if !got[0].Prev.NotifiedAt.IsZero() || !got[0].Alert.NotifiedAt.Equal(sent.NotifiedAt) || !got[0].At.Equal(sent.NotifiedAt) {
+		t.Errorf("event = %+v, want the delivery on either side", got[0])
+	}
+
+	// Stamping the same time again is not a second delivery.
+	if got := AlertEvents([]Alert{sent}, []Alert{sent}, sent.NotifiedAt); len(got) != 0 {
+		t.Errorf("AlertEvents for an unchanged delivery = %v, want none", eventTypes(got))
+	}
+}
+
+func TestAlertEvents_PrunedKeepsLastState(t *testing.T) {
+	past := notified(pressureAlert(-6, -3, -6, AlertStatusActive))
+
+	got := AlertEvents([]Alert{past}, nil, baseTime)
+
+	if len(got) != 1 || got[0].Alert.ID != past.ID || got[0].Alert.Status != AlertStatusActive || got[0].Alert.NotifiedAt.IsZero() {
+		t.Errorf("AlertEvents = %+v, want a pruned event carrying the alert as stored", got)
+	}
+}
@@ -3,6 +3,7 @@ package shared
 import (
 	"crypto/sha1"
 	"encoding/hex"
+	"math"
 	"sort"
 	"time"
 )
@@ -11,15 +12,40 @@ const (
 	AlertStatusActive   = "active"
 	AlertStatusResolved = "resolved"
 
+	// AlertStatusExpired is only ever archived: the status of an alert whose
+	// window passed while it was still active, as opposed to one that left
+	// the forecast first and was archived as resolved.
+	AlertStatusExpired = "expired"
+
 	AlertSeverityWarning = "warning"
 	AlertSeveritySevere  = "severe"
 
 	// AlertEscalationStepMb guards re-notification against forecast noise: an
 	// already-delivered alert is only re-armed for delivery when its predicted
-	// magnitude worsens by at least this much (or its severity upgrades).
+	// magnitude worsens by at least this much (or its severity upgrades). It is
+	// in the alert's own unit, so for a pollen alert the step is one UPI level.
 	AlertEscalationStepMb = 1.0
+
+	// AlertEscalationFraction widens the step for rules whose unit makes one
+	// a rounding error — gusts in kph, precipitation in percent. The step is
+	// the larger of AlertEscalationStepMb and this fraction of the alert's
+	// threshold, which leaves pressure (5 mb) and pollen (UPI 4) at one unit.
+	AlertEscalationFraction = 0.2
+)
+
+// Alert directions: which way a change rule's metric has to move. A drop
+// fires on a negative delta, a rise on a positive one, and any on either;
+// level rules have none.
+const (
+	AlertDirectionDrop = "drop"
+	AlertDirectionRise = "rise"
+	AlertDirectionAny  = "any"
 )
 
+// MetricPressureMb is the metric the forecast and observed pressure-drop
+// rules watch, named as the forecast collector's rules name it.
+const MetricPressureMb = "pressure_mb"
+
 // Alert is a source-agnostic record of a detected condition (pressure drop,
 // pollen spike, ...). Detectors create them at write time; providers and
 // clients only read them. Future sources reuse this struct unchanged.
@@ -36,7 +62,7 @@ type Alert struct {
 	RuleID      string    `firestore:"rule_id"`
 	Severity    string    `firestore:"severity"`
 	Value       float64   `firestore:"value"`
-	Threshold   float64   `firestore:"threshold"`
+	Threshold   float64   `firestore:"threshold"` // warning threshold in force for this location when detected
 	WindowStart time.Time `firestore:"window_start"`
 	WindowEnd   time.Time `firestore:"window_end"`
 	Message     string    `firestore:"message"`
@@ -47,6 +73,39 @@ type Alert struct {
 	// means it never was. Delivery is gated on Status == AlertStatusActive and
 	// a zero NotifiedAt, so clearing it re-arms delivery.
 	NotifiedAt time.Time `firestore:"notified_at"`
+
+	// PeakValue and PeakSeverity are the worst Value and Severity the episode
+	// has been detected at, kept by MergeAlerts so the record survives the
+	// forecast improving. Alerts stored before they existed read as zero;
+	// use Peak, which falls back to the current numbers.
+	PeakValue    float64 `firestore:"peak_value"`
+	PeakSeverity string  `firestore:"peak_severity"`
+
+	// Metric, Direction, Label and Unit come from the rule that raised the
+	// alert, so readers title and format it without parsing RuleID: the
+	// quantity watched, the direction of a change rule, a lower-case name
+	// for the quantity, and the suffix Value renders with, as in Message.
+	// Alerts stored before they existed read as empty until the next
+	// detection replaces them.
+	Metric    string `firestore:"metric"`
+	Direction string `firestore:"direction"`
+	Label     string `firestore:"label"`
+	Unit      string `firestore:"unit"`
+}
+
+// Peak returns the worst value and severity the alert has reached: the
+// stored peak, or the current numbers when they are worse or no peak was
+// recorded.
+func (a Alert) Peak() (float64, string) {
+	value, severity := a.PeakValue, a.PeakSeverity
+	// Like mergedNotifiedAt, "worse" means further from zero.
+	if math.Abs(a.Value) > math.Abs(value) {
+		value = a.Value
+	}
+	if severity == "" || a.Severity == AlertSeveritySevere {
+		severity = a.Severity
+	}
+	return value, severity
 }
 
 // ComputeID derives a stable identity for a new alert from its location,
@@ -88,7 +147,12 @@ func overlapDuration(a, b Alert) time.Duration {
 //   - every detected alert is "active", whether or not it was previously
 //     resolved,
 //   - stored alerts no longer detected are marked "resolved" but keep their
-//     delivery record, so forecast noise cannot cause a re-notification.
+//     delivery record, so forecast noise cannot cause a re-notification,
+//   - every alert carries the worst value and severity of its episode in
+//     PeakValue and PeakSeverity.
+//
+// The pruned alerts are the ones in prev missing from the result; see
+// PrunedAlerts.
 func MergeAlerts(prev, detected []Alert, now time.Time) []Alert {
 	var live []Alert
 	for _, p := range prev {
@@ -117,6 +181,7 @@ func MergeAlerts(prev, detected []Alert, now time.Time) []Alert {
 			}
 		}
 		if bestIdx == -1 {
+			d.PeakValue, d.PeakSeverity = d.Peak()
 			result = append(result, d)
 			continue
 		}
@@ -125,6 +190,10 @@ func MergeAlerts(prev, detected []Alert, now time.Time) []Alert {
 		merged.ID = live[bestIdx].ID
 		merged.Status = AlertStatusActive
 		merged.NotifiedAt = mergedNotifiedAt(live[bestIdx], d)
+		// Seed the peak from the stored episode; Peak then folds in the
+		// newly detected numbers.
+		merged.PeakValue, merged.PeakSeverity = live[bestIdx].Peak()
+		merged.PeakValue, merged.PeakSeverity = merged.Peak()
 		result = append(result, merged)
 	}
 
@@ -139,6 +208,23 @@ func MergeAlerts(prev, detected []Alert, now time.Time) []Alert {
 	return result
 }
 
+// PrunedAlerts returns the alerts in prev that merged no longer holds — for
+// MergeAlerts, those whose window has passed. Collectors archive them to
+// alert_history in the transaction that drops them from the cache.
+func PrunedAlerts(prev, merged []Alert) []Alert {
+	kept := make(map[string]bool, len(merged))
+	for _, a := range merged {
+		kept[a.ID] = true
+	}
+	var pruned []Alert
+	for _, p := range prev {
+		if !kept[p.ID] {
+			pruned = append(pruned, p)
+		}
+	}
+	return pruned
+}
+
 // mergedNotifiedAt carries the stored delivery record onto a re-detected
 // alert, clearing it — and so re-arming delivery — when the prediction has
 // meaningfully worsened.
@@ -151,8 +237,11 @@ func mergedNotifiedAt(prev, detected Alert) time.Time {
 	if prev.NotifiedAt.IsZero() {
 		return time.Time{}
 	}
-	// Values are negative deltas; "worse" means more negative.
-	worsened := detected.Value <= prev.Value-AlertEscalationStepMb
+	// "Worse" means further from zero. Pressure-drop values are negative
+	// deltas and pollen values are positive index levels, so comparing
+	// magnitudes serves both without the merge knowing which rule it has.
+	step := math.Max(AlertEscalationStepMb, AlertEscalationFraction*math.Abs(detected.Threshold))
+	worsened := math.Abs(detected.Value) >= math.Abs(prev.Value)+step
 	upgraded := prev.Severity != AlertSeveritySevere && detected.Severity == AlertSeveritySevere
 	if worsened || upgraded {
 		return time.Time{}
@@ -135,6 +135,55 @@ func TestMergeAlerts_WorseningRearmsDelivery(t *testing.T) {
 	}
 }
 
+func TestMergeAlerts_PositiveValueWorseningRearmsDelivery(t *testing.T) {
+	// Pollen alerts carry positive index levels; rising is worsening.
+	prev := notified(pressureAlert(2, 5, 4, AlertStatusActive))
+	prev.RuleID = "pollen-high-juniper"
+	detected := pressureAlert(2, 5, 5, AlertStatusActive)
+	detected.RuleID = "pollen-high-juniper"
+
+	got := MergeAlerts([]Alert{prev}, []Alert{detected}, baseTime)
+
+	if len(got) != 1 || !got[0].NotifiedAt.IsZero() {
+		t.Fatalf("NotifiedAt = %v, want cleared (index rose a full level)", got)
+	}
+}
+
+func TestMergeAlerts_PositiveValueImprovementKeepsDeliveryRecord(t *testing.T) {
+	prev := notified(pressureAlert(2, 5, 5, AlertStatusActive))
+	prev.RuleID = "pollen-high-juniper"
+	detected := pressureAlert(2, 5, 4, AlertStatusActive)
+	detected.RuleID = "pollen-high-juniper"
+
+	got := MergeAlerts([]Alert{prev}, []Alert{detected}, baseTime)
+
+	if len(got) != 1 || !got[0].NotifiedAt.Equal(prev.NotifiedAt) {
+		t.Fatalf("NotifiedAt = %v, want preserved — an easing alert is not news", got)
+	}
+}
+
+func TestMergeAlerts_EscalationStepScalesWithThreshold(t *testing.T) {
+	// A 60 kph gust rule: a 5 kph rise is noise, 12 kph (20% of threshold)
+	// is news.
+	gust := func(value float64) Alert {
+		a := pressureAlert(2, 5, value, AlertStatusActive)
+		a.RuleID = "wind-gust"
+		a.Threshold = 60
+		return a
+	}
+	prev := notified(gust(62))
+
+	got := MergeAlerts([]Alert{prev}, []Alert{gust(67)}, baseTime)
+	if len(got) != 1 || !got[0].NotifiedAt.Equal(prev.NotifiedAt) {
+		t.Fatalf("NotifiedAt = %v, want preserved for a rise below 20%% of threshold", got)
+	}
+
+	got = MergeAlerts([]Alert{prev}, []Alert{gust(74)}, baseTime)
+	if len(got) != 1 || !got[0].NotifiedAt.IsZero() {
+		t.Fatalf("NotifiedAt = %v, want cleared for a rise of 20%% of threshold", got)
+	}
+}
+
 func TestMergeAlerts_SeverityUpgradeRearmsDelivery(t *testing.T) {
 	prev := notified(pressureAlert(2, 5, -9.5, AlertStatusActive))
 	detected := pressureAlert(2, 5, -10.2, AlertStatusActive)
@@ -271,3 +320,46 @@ func TestMergeAlerts_DifferentRuleOrLocationNeverMatches(t *testing.T) {
 		t.Fatalf("len = %d, want 2 (no cross-location merge)", len(got))
 	}
 }
+
+func TestMergeAlerts_PeakSurvivesImprovement(t *testing.T) {
+	first := MergeAlerts(nil, []Alert{pressureAlert(2, 5, -6.0, AlertStatusActive)}, baseTime)
+	if first[0].PeakValue != -6.0 || first[0].PeakSeverity != AlertSeverityWarning {
+		t.Fatalf("new alert peak = %v/%q, want its own numbers", first[0].PeakValue, first[0].PeakSeverity)
+	}
+
+	worse := pressureAlert(2, 5, -9.0, AlertStatusActive)
+	worse.Severity = AlertSeveritySevere
+	second := MergeAlerts(first, []Alert{worse}, baseTime)
+	better := MergeAlerts(second, []Alert{pressureAlert(2, 5, -5.5, AlertStatusActive)}, baseTime)
+
+	got := better[0]
+	if got.Value != -5.5 || got.Severity != AlertSeverityWarning {
+		t.Errorf("current = %v/%q, want the latest detection", got.Value, got.Severity)
+	}
+	if got.PeakValue != -9.0 || got.PeakSeverity != AlertSeveritySevere {
+		t.Errorf("peak = %v/%q, want -9/severe", got.PeakValue, got.PeakSeverity)
+	}
+}
+
+func TestAlertPeak_FallsBackToCurrentNumbers(t *testing.T) {
+	// Stored before peaks were recorded.
+	a := pressureAlert(2, 5, -6.0, AlertStatusActive)
+	if v, sev := a.Peak(); v != -6.0 || sev != AlertSeverityWarning {
+		t.Errorf("Peak() = %v/%q, want the current numbers", v, sev)
+	}
+}
+
+func TestPrunedAlerts(t *testing.T) {
+	past := pressureAlert(-6, -3, -6.0, AlertStatusResolved)
+	current := pressureAlert(2, 5, -6.0, AlertStatusActive)
+	prev := []Alert{past, current}
+
+	got := PrunedAlerts(prev, MergeAlerts(prev, nil, baseTime))
+
+	if len(got) != 1 || got[0].ID != past.ID {
+		t.Errorf("PrunedAlerts = %+v, want only the past alert", got)
+	}
+	if got := PrunedAlerts(nil, []Alert{current}); len(got) != 0 {
+		t.Errorf("PrunedAlerts with no stored alerts = %+v, want none", got)
+	}
+}
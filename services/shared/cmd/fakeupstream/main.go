@@ -0,0 +1,58 @@
+// Command fakeupstream serves a scripted stand-in for the Google Weather and
+// Pollen APIs, so the collectors run without an API key or a network. Point
+// them at it with WEATHER_API_BASE_URL and POLLEN_API_BASE_URL; any
+// GOOGLE_MAPS_API_KEY will do.
+//
+//	go run ./cmd/fakeupstream -scenario pressure-drop
+//	curl -X PUT 'localhost:8090/scenario?name=burst'
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/fakeupstream"
+)
+
+func main() {
+	shared.InitLogging()
+
+	addr := flag.String("addr", ":8090", "address to listen on")
+	scenario := flag.String("scenario", fakeupstream.ScenarioSteady,
+		"scenario to play: "+strings.Join(fakeupstream.Scenarios, ", "))
+	flag.Parse()
+
+	srv, err := fakeupstream.New(*scenario)
+	if err != nil {
+		slog.Error("Invalid scenario", "error", err)
+		fmt.Fprintln(os.Stderr, "Run with -h for usage.")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	httpServer := &http.Server{Addr: *addr, Handler: srv}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("Fake upstream listening", "addr", *addr, "scenario", *scenario)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Server failed", "error", err)
+		os.Exit(1)
+	}
+}
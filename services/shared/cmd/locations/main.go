@@ -0,0 +1,81 @@
+// Command locations lists the location registry as JSON Lines, or seeds it
+// from a JSON file. Importing upserts each location by ID and never deletes,
+// so re-running it against a live registry is safe. With STORAGE_BACKEND=sqlite
+// it works on the SQLITE_PATH file instead of Firestore.
+//
+//	go run ./cmd/locations -import locations.json
+//	go run ./cmd/locations
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
+)
+
+func main() {
+	// Logs go to stderr so the records themselves can be piped.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	project := flag.String("project", os.Getenv("GCP_PROJECT_ID"), "GCP project ID (default $GCP_PROJECT_ID)")
+	importPath := flag.String("import", "", "JSON file of locations to upsert (see locations.json)")
+	flag.Parse()
+
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		fail("Invalid storage backend", "error", err)
+	}
+	if *project == "" && !useSQLite {
+		fail("Missing project: set -project or GCP_PROJECT_ID")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	src, err := registry.OpenWriter(ctx, *project)
+	if err != nil {
+		fail("Failed to open location registry", "error", err)
+	}
+	defer src.Close()
+
+	if *importPath != "" {
+		// Validated as a whole first, so a bad file writes nothing.
+		locations, err := shared.ReadLocationsFile(*importPath)
+		if err != nil {
+			fail("Invalid locations file", "error", err)
+		}
+		for _, l := range locations {
+			if err := src.Put(ctx, l); err != nil {
+				fail("Failed to write location", "location", l.ID, "error", err)
+			}
+		}
+		slog.Info("Import complete", "path", *importPath, "locations", len(locations))
+		return
+	}
+
+	locations, err := src.Locations(ctx)
+	if err != nil {
+		fail("Failed to read locations", "error", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, l := range locations {
+		if err := enc.Encode(l); err != nil {
+			fail("Failed to write location", "error", err)
+		}
+	}
+}
+
+func fail(msg string, args ...any) {
+	slog.Error(msg, args...)
+	fmt.Fprintln(os.Stderr, "Run with -h for usage.")
+	os.Exit(1)
+}
@@ -0,0 +1,152 @@
+// Command migrate brings one database's stored documents up to the current
+// schema version by running the migrations registered in package migrate.
+// Run it with -dry-run first: the report lists what would change without
+// writing anything. Progress is kept in the -state file, so an interrupted
+// run picks up where it stopped when started again with the same file. With
+// STORAGE_BACKEND=sqlite it works on the SQLITE_PATH file instead of
+// Firestore.
+//
+//	go run ./cmd/migrate -database weather-log -dry-run
+//	go run ./cmd/migrate -database weather-log -state weather-log.cursors.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/migrate"
+)
+
+func main() {
+	// Logs go to stderr so the report can be piped.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	project := flag.String("project", os.Getenv("GCP_PROJECT_ID"), "GCP project ID (default $GCP_PROJECT_ID)")
+	database := flag.String("database", "", "database to migrate: "+strings.Join(databaseNames(), " or "))
+	only := flag.String("collections", "", "comma-separated collections to migrate (default all of the database's)")
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	batch := flag.Int("batch", migrate.DefaultBatchSize, "documents per read and write")
+	state := flag.String("state", "", "file to keep progress in, so an interrupted run can resume")
+	flag.Parse()
+
+	collections, ok := migrate.Databases[*database]
+	if !ok {
+		fail("Unknown database", "database", *database, "want", databaseNames())
+	}
+	if *only != "" {
+		requested := strings.Split(*only, ",")
+		for _, c := range requested {
+			if !slices.Contains(collections, c) {
+				fail("Collection is not in the database", "collection", c, "database", *database)
+			}
+		}
+		collections = requested
+	}
+
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		fail("Invalid storage backend", "error", err)
+	}
+	if *project == "" && !useSQLite {
+		fail("Missing project: set -project or GCP_PROJECT_ID")
+	}
+
+	opts := migrate.Options{DryRun: *dryRun, BatchSize: *batch}
+	if *state != "" && !*dryRun {
+		if opts.Cursors, err = migrate.LoadCursors(*state, *database); err != nil {
+			fail("Failed to read progress", "error", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var store migrate.Store
+	if useSQLite {
+		db, err := docstore.OpenFromEnv()
+		if err != nil {
+			fail("Failed to open SQLite store", "error", err)
+		}
+		store = migrate.NewSQLiteStore(db)
+	} else {
+		store, err = migrate.NewFirestoreStore(ctx, *project, *database)
+		if err != nil {
+			fail("Failed to create Firestore client", "database", *database, "error", err)
+		}
+	}
+	defer store.Close()
+
+	report, runErr := migrate.Run(ctx, store, collections, opts)
+	printReport(os.Stdout, report)
+	if runErr != nil {
+		slog.Error("Migration stopped", "error", runErr)
+		if opts.Cursors != nil {
+			slog.Info("Run again with the same -state file to resume", "state", *state)
+		}
+		store.Close()
+		os.Exit(1)
+	}
+}
+
+func printReport(f io.Writer, r migrate.Report) {
+	verb := "Changed"
+	if r.DryRun {
+		verb = "Would change"
+	}
+	fmt.Fprintf(f, "Schema version %d\n\n", r.Version)
+	w := tabwriter.NewWriter(f, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "COLLECTION\tSCANNED\t%s\tCURRENT\tNEWER\tCONFLICTS\tNOTES\n", strings.ToUpper(verb))
+	for _, c := range r.Collections {
+		var notes []string
+		switch {
+		case c.Skipped:
+			notes = append(notes, "finished in an earlier run")
+		case c.ResumedAfter != "":
+			notes = append(notes, "resumed after "+c.ResumedAfter)
+		}
+		if c.Conflicts > 0 {
+			notes = append(notes, "conflicts left for the next run")
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			c.Collection, c.Scanned, c.Changed, c.Current, c.Newer, c.Conflicts, strings.Join(notes, "; "))
+	}
+	w.Flush()
+
+	for _, c := range r.Collections {
+		if c.Changed == 0 {
+			continue
+		}
+		fmt.Fprintf(f, "\n%s: %s %d, e.g. %s\n", c.Collection, strings.ToLower(verb), c.Changed, strings.Join(c.Sample, ", "))
+		for _, m := range migrate.Migrations() {
+			if n := c.ByMigration[m.Version]; n > 0 {
+				fmt.Fprintf(f, "  v%d %s: %d\n", m.Version, m.Description, n)
+			}
+		}
+	}
+}
+
+func databaseNames() []string {
+	names := make([]string, 0, len(migrate.Databases))
+	for name := range migrate.Databases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func fail(msg string, args ...any) {
+	slog.Error(msg, args...)
+	fmt.Fprintln(os.Stderr, "Run with -h for usage.")
+	os.Exit(1)
+}
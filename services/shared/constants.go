@@ -15,6 +15,30 @@ const (
 	ForecastCacheCollection = "forecast_cache"
 	ForecastRawCollection   = "forecast_raw"
 
+	// Downsampled archives, written by archive-retention as raw documents
+	// age out. Pollen's lives in the pollen database with pollen_raw.
+	WeatherRollupCollection  = "weather_rollup"
+	ForecastRollupCollection = "forecast_rollup"
+	PollenRollupCollection   = "pollen_rollup"
+
+	// Alerts pruned from a cache document once their window passes,
+	// archived by the collector that pruned them. Forecast alerts live in the
+	// weather database, pollen alerts in the pollen database.
+	AlertHistoryCollection = "alert_history"
+
+	// Alert lifecycle events, one document per transition, written in the
+	// same transaction as the change they record. Same split as alert_history.
+	AlertEventsCollection = "alert_events"
+
+	// Forecast accuracy scores, written by forecast-verifier.
+	ForecastAccuracyCollection = "forecast_accuracy"
+
+	// Per-run observation records, written by notifier.
+	NotifierObservationsCollection = "notifier_observations"
+
+	// Location registry, read by every job and dashboard-api.
+	LocationsCollection = "locations"
+
 	// RPC timeout
 	RPCClientTimeout = 2 * time.Second
 )
@@ -0,0 +1,285 @@
+package docstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// timeLayout is fixed-width UTC, so stored timestamps compare and sort as
+// strings the way Firestore timestamps do.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// marshal encodes v as a JSON object using the firestore struct tags the
+// models already carry, so one struct serves both backends.
+func marshal(v any) ([]byte, error) {
+	enc, err := encodeValue(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := enc.(map[string]any); !ok {
+		return nil, fmt.Errorf("docstore: cannot store %T as a document", v)
+	}
+	return json.Marshal(enc)
+}
+
+// unmarshal decodes a stored document into dst, which must be a pointer.
+// Fields the struct does not have are ignored.
+func unmarshal(data []byte, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("docstore: decode target must be a non-nil pointer, got %T", dst)
+	}
+	var raw any
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return fmt.Errorf("docstore: %w", err)
+	}
+	return decodeValue(raw, rv.Elem())
+}
+
+// encodeValue turns v into the plain maps, slices and scalars json.Marshal
+// writes without consulting json tags.
+func encodeValue(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).UTC().Format(timeLayout), nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encodeValue(v.Elem())
+	case reflect.Struct:
+		out := make(map[string]any)
+		for _, f := range fields(v.Type()) {
+			fv := v.Field(f.index)
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			enc, err := encodeValue(fv)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			out[f.name] = enc
+		}
+		return out, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("docstore: map key type %s is not a string", v.Type().Key())
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			enc, err := encodeValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[iter.Key().String()] = enc
+		}
+		return out, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		fallthrough
+	case reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			enc, err := encodeValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = enc
+		}
+		return out, nil
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v.Interface(), nil
+	}
+	return nil, fmt.Errorf("docstore: cannot encode %s", v.Type())
+}
+
+func decodeValue(src any, dst reflect.Value) error {
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Type() == timeType {
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("docstore: cannot decode %T into time.Time", src)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return fmt.Errorf("docstore: %w", err)
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeValue(src, dst.Elem())
+	case reflect.Interface:
+		dst.Set(reflect.ValueOf(plain(src)))
+		return nil
+	case reflect.Struct:
+		m, ok := src.(map[string]any)
+		if !ok {
+			return fmt.Errorf("docstore: cannot decode %T into %s", src, dst.Type())
+		}
+		for _, f := range fields(dst.Type()) {
+			val, ok := m[f.name]
+			if !ok {
+				continue
+			}
+			if err := decodeValue(val, dst.Field(f.index)); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		m, ok := src.(map[string]any)
+		if !ok {
+			return fmt.Errorf("docstore: cannot decode %T into %s", src, dst.Type())
+		}
+		out := reflect.MakeMapWithSize(dst.Type(), len(m))
+		for k, val := range m {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeValue(val, elem); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(out)
+		return nil
+	case reflect.Slice, reflect.Array:
+		items, ok := src.([]any)
+		if !ok {
+			return fmt.Errorf("docstore: cannot decode %T into %s", src, dst.Type())
+		}
+		if dst.Kind() == reflect.Slice {
+			dst.Set(reflect.MakeSlice(dst.Type(), len(items), len(items)))
+		} else if len(items) > dst.Len() {
+			return fmt.Errorf("docstore: %d items do not fit %s", len(items), dst.Type())
+		}
+		for i, item := range items {
+			if err := decodeValue(item, dst.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return fmt.Errorf("docstore: cannot decode %T into bool", src)
+		}
+		dst.SetBool(b)
+		return nil
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("docstore: cannot decode %T into string", src)
+		}
+		dst.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := number(src).Int64()
+		if err != nil {
+			return fmt.Errorf("docstore: %w", err)
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := number(src).Int64()
+		if err != nil || n < 0 {
+			return fmt.Errorf("docstore: cannot decode %v into %s", src, dst.Type())
+		}
+		dst.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := number(src).Float64()
+		if err != nil {
+			return fmt.Errorf("docstore: %w", err)
+		}
+		dst.SetFloat(f)
+		return nil
+	}
+	return fmt.Errorf("docstore: cannot decode into %s", dst.Type())
+}
+
+// number returns src as a json.Number; a non-number yields one that fails
+// to parse, so the caller reports the mismatch.
+func number(src any) json.Number {
+	if n, ok := src.(json.Number); ok {
+		return n
+	}
+	return json.Number(fmt.Sprint(src))
+}
+
+// plain converts decoded numbers to int64 or float64 for interface{}
+// targets, matching what Firestore's DataTo produces for untyped fields.
+func plain(src any) any {
+	switch s := src.(type) {
+	case json.Number:
+		if n, err := s.Int64(); err == nil {
+			return n
+		}
+		f, _ := s.Float64()
+		return f
+	case map[string]any:
+		for k, v := range s {
+			s[k] = plain(v)
+		}
+	case []any:
+		for i, v := range s {
+			s[i] = plain(v)
+		}
+	}
+	return src
+}
+
+type field struct {
+	name      string
+	index     int
+	omitEmpty bool
+}
+
+// fields lists the stored fields of a struct type, named as Firestore would
+// name them: the firestore tag when present, the Go name otherwise, and
+// skipped for "-" or unexported fields.
+func fields(t reflect.Type) []field {
+	var out []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("firestore")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		out = append(out, field{name: name, index: i, omitEmpty: strings.Contains(opts, "omitempty")})
+	}
+	return out
+}
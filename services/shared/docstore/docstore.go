@@ -0,0 +1,343 @@
+// Package docstore is a single-file stand-in for Firestore, backed by SQLite,
+// so the pipeline runs offline. It keeps the parts of Firestore the
+// repositories rely on: documents addressed by collection and ID, structs
+// mapped through their firestore tags, serializable read-modify-write
+// transactions, and ordered range queries on a field.
+//
+// Every service opens the same file (SQLITE_PATH), so in docker-compose the
+// collectors, notifier and providers share one volume the way they share a
+// Firestore project in the cloud. Missing documents report
+// codes.NotFound, exactly as Firestore does, so callers keep one check.
+package docstore
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// Env vars that select the storage backend.
+const (
+	EnvBackend = "STORAGE_BACKEND"
+	EnvPath    = "SQLITE_PATH"
+)
+
+// Backends accepted by STORAGE_BACKEND.
+const (
+	BackendFirestore = "firestore"
+	BackendSQLite    = "sqlite"
+)
+
+// DefaultPath is used when SQLITE_PATH is unset.
+const DefaultPath = "personal-dashboard.db"
+
+// Errors carry gRPC codes so status.Code works on them as it does on
+// Firestore errors.
+var (
+	ErrNotFound = status.Error(codes.NotFound, "docstore: document not found")
+	ErrExists   = status.Error(codes.AlreadyExists, "docstore: document already exists")
+)
+
+// Backend returns the backend STORAGE_BACKEND selects, Firestore when unset.
+func Backend() (string, error) {
+	switch b := os.Getenv(EnvBackend); b {
+	case "", BackendFirestore:
+		return BackendFirestore, nil
+	case BackendSQLite:
+		return BackendSQLite, nil
+	default:
+		return "", fmt.Errorf("unknown %s %q (want %s or %s)", EnvBackend, b, BackendFirestore, BackendSQLite)
+	}
+}
+
+// UseSQLite reports whether STORAGE_BACKEND selects SQLite. An unknown value
+// is an error, so a typo cannot silently write to production Firestore.
+func UseSQLite() (bool, error) {
+	b, err := Backend()
+	return b == BackendSQLite, err
+}
+
+// OpenSelected opens the file SQLITE_PATH names when STORAGE_BACKEND selects
+// SQLite, and returns nil when it selects Firestore, so each package's Open
+// function builds only its own type:
+//
+//	db, err := docstore.OpenSelected()
+//	if err != nil {
+//		return nil, err
+//	}
+//	if db != nil {
+//		return NewSQLiteStore(db), nil
+//	}
+//	// connect to Firestore
+func OpenSelected() (*DB, error) {
+	useSQLite, err := UseSQLite()
+	if err != nil || !useSQLite {
+		return nil, err
+	}
+	return OpenFromEnv()
+}
+
+// OpenFromEnv opens the file SQLITE_PATH names.
+func OpenFromEnv() (*DB, error) {
+	path := os.Getenv(EnvPath)
+	if path == "" {
+		path = DefaultPath
+	}
+	return Open(path)
+}
+
+const schema = `CREATE TABLE IF NOT EXISTS documents (
+	collection TEXT NOT NULL,
+	id         TEXT NOT NULL,
+	data       TEXT NOT NULL,
+	PRIMARY KEY (collection, id)
+) WITHOUT ROWID`
+
+// DB is a document database in one SQLite file.
+type DB struct {
+	db *sql.DB
+}
+
+// Open creates the file and schema if needed. Transactions take the write
+// lock when they begin, and other processes wait for it rather than failing,
+// which is what makes RunTransaction serializable across services.
+func Open(path string) (*DB, error) {
+	dsn := "file:" + path + "?_txlock=immediate" +
+		"&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	// One connection per process: SQLite has one writer anyway, and it keeps
+	// ":memory:", which tests use, a single database. Inside RunTransaction,
+	// read through the Tx; the DB methods would wait for the connection.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating schema in %s: %w", path, err)
+	}
+	return &DB{db: db}, nil
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// execer is what reads and writes need from either a *sql.DB or a *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Get decodes the document into dst, or returns ErrNotFound.
+func (d *DB) Get(ctx context.Context, collection, id string, dst any) error {
+	return get(ctx, d.db, collection, id, dst)
+}
+
+// Set creates or replaces the document.
+func (d *DB) Set(ctx context.Context, collection, id string, v any) error {
+	return set(ctx, d.db, collection, id, v)
+}
+
+// Create writes the document, or returns ErrExists if the ID is taken.
+func (d *DB) Create(ctx context.Context, collection, id string, v any) error {
+	return create(ctx, d.db, collection, id, v)
+}
+
+// Add stores v under a new random ID, like CollectionRef.Add, and returns it.
+func (d *DB) Add(ctx context.Context, collection string, v any) (string, error) {
+	id := newID()
+	return id, create(ctx, d.db, collection, id, v)
+}
+
+// Delete removes the document. Deleting a missing document succeeds, as it
+// does in Firestore, so a retried cleanup is not an error; use
+// Tx.DeleteExisting where a missing document matters.
+func (d *DB) Delete(ctx context.Context, collection, id string) error {
+	return del(ctx, d.db, collection, id)
+}
+
+// Documents runs q and returns the matching documents.
+func (d *DB) Documents(ctx context.Context, q Query) ([]Document, error) {
+	return documents(ctx, d.db, q)
+}
+
+// Tx is a transaction. Unlike Firestore, reads and writes may interleave:
+// the write lock is held from the start, so nothing can change underneath.
+type Tx struct {
+	ctx context.Context
+	tx  *sql.Tx
+}
+
+func (t *Tx) Get(collection, id string, dst any) error {
+	return get(t.ctx, t.tx, collection, id, dst)
+}
+
+func (t *Tx) Set(collection, id string, v any) error {
+	return set(t.ctx, t.tx, collection, id, v)
+}
+
+func (t *Tx) Create(collection, id string, v any) error {
+	return create(t.ctx, t.tx, collection, id, v)
+}
+
+// Delete removes the document, succeeding if it is missing, as DB.Delete
+// does.
+func (t *Tx) Delete(collection, id string) error {
+	return del(t.ctx, t.tx, collection, id)
+}
+
+// DeleteExisting removes the document, or returns ErrNotFound if it is
+// missing: Firestore's Delete with the firestore.Exists precondition.
+func (t *Tx) DeleteExisting(collection, id string) error {
+	var exists struct{} // decodes no fields, so any document will do
+	if err := t.Get(collection, id, &exists); err != nil {
+		return err
+	}
+	return t.Delete(collection, id)
+}
+
+func (t *Tx) Documents(q Query) ([]Document, error) {
+	return documents(t.ctx, t.tx, q)
+}
+
+// Update replaces one top-level field and leaves the rest of the stored
+// document byte for byte, like Firestore's Update with a single path. It
+// returns ErrNotFound if the document is missing.
+func (t *Tx) Update(collection, id, field string, value any) error {
+	if !fieldPattern.MatchString(field) {
+		return fmt.Errorf("docstore: invalid field %q", field)
+	}
+	enc, err := encodeValue(reflect.ValueOf(value))
+	if err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(enc)
+	if err != nil {
+		return err
+	}
+	data, err := read(t.ctx, t.tx, collection, id)
+	if err != nil {
+		return err
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("decoding %s/%s: %w", collection, id, err)
+	}
+	doc[field] = encoded
+	if data, err = json.Marshal(doc); err != nil {
+		return err
+	}
+	return write(t.ctx, t.tx, collection, id, data)
+}
+
+// RunTransaction runs fn in a transaction and commits it if fn returns nil.
+// The write lock is taken up front, so fn runs exactly once; there is no
+// contention retry to guard against.
+func (d *DB) RunTransaction(ctx context.Context, fn func(ctx context.Context, tx *Tx) error) error {
+	sqlTx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("docstore: beginning transaction: %w", err)
+	}
+	if err := fn(ctx, &Tx{ctx: ctx, tx: sqlTx}); err != nil {
+		sqlTx.Rollback()
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("docstore: committing: %w", err)
+	}
+	return nil
+}
+
+func get(ctx context.Context, e execer, collection, id string, dst any) error {
+	data, err := read(ctx, e, collection, id)
+	if err != nil {
+		return err
+	}
+	if err := unmarshal(data, dst); err != nil {
+		return fmt.Errorf("decoding %s/%s: %w", collection, id, err)
+	}
+	return nil
+}
+
+func read(ctx context.Context, e execer, collection, id string) ([]byte, error) {
+	var data []byte
+	err := e.QueryRowContext(ctx, `SELECT data FROM documents WHERE collection = ? AND id = ?`, collection, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("docstore: reading %s/%s: %w", collection, id, err)
+	}
+	return data, nil
+}
+
+func set(ctx context.Context, e execer, collection, id string, v any) error {
+	data, err := marshal(v)
+	if err != nil {
+		return err
+	}
+	return write(ctx, e, collection, id, data)
+}
+
+func write(ctx context.Context, e execer, collection, id string, data []byte) error {
+	_, err := e.ExecContext(ctx,
+		`INSERT INTO documents (collection, id, data) VALUES (?, ?, ?)
+		 ON CONFLICT (collection, id) DO UPDATE SET data = excluded.data`,
+		collection, id, string(data))
+	if err != nil {
+		return fmt.Errorf("docstore: writing %s/%s: %w", collection, id, err)
+	}
+	return nil
+}
+
+func create(ctx context.Context, e execer, collection, id string, v any) error {
+	data, err := marshal(v)
+	if err != nil {
+		return err
+	}
+	res, err := e.ExecContext(ctx,
+		`INSERT INTO documents (collection, id, data) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`,
+		collection, id, string(data))
+	if err != nil {
+		return fmt.Errorf("docstore: creating %s/%s: %w", collection, id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrExists
+	}
+	return nil
+}
+
+func del(ctx context.Context, e execer, collection, id string) error {
+	if _, err := e.ExecContext(ctx, `DELETE FROM documents WHERE collection = ? AND id = ?`, collection, id); err != nil {
+		return fmt.Errorf("docstore: deleting %s/%s: %w", collection, id, err)
+	}
+	return nil
+}
+
+// idChars matches Firestore's auto-ID alphabet.
+const idChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+func newID() string {
+	b := make([]byte, 20)
+	rand.Read(b)
+	for i := range b {
+		b[i] = idChars[int(b[i])%len(idChars)]
+	}
+	return string(b)
+}
+
+// fieldPattern bounds the field names a query or update may name; they end
+// up in a JSON path.
+var fieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
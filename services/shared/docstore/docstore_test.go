@@ -0,0 +1,252 @@
+package docstore
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type reading struct {
+	LocationID string          `firestore:"location_id"`
+	Pressure   float64         `firestore:"pressure"`
+	Delta      *float64        `firestore:"delta"`
+	Count      int             `firestore:"count"`
+	At         time.Time       `firestore:"at"`
+	Tags       []string        `firestore:"tags"`
+	Sources    map[string]bool `firestore:"sources,omitempty"`
+	Nested     struct {
+		Note string `firestore:"note"`
+	} `firestore:"nested"`
+	Untagged string
+	Skipped  string `firestore:"-"`
+}
+
+func openTest(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	delta := -1.5
+	in := reading{
+		LocationID: "house-nick",
+		Pressure:   1013.25,
+		Delta:      &delta,
+		Count:      3,
+		At:         time.Date(2026, 3, 1, 6, 30, 0, 0, time.FixedZone("CST", -6*3600)),
+		Tags:       []string{"a", "b"},
+		Sources:    map[string]bool{"pollen": false},
+		Untagged:   "kept",
+		Skipped:    "dropped",
+	}
+	in.Nested.Note = "hi"
+
+	data, err := marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var out reading
+	if err := unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !out.At.Equal(in.At) {
+		t.Errorf("At = %v, want %v", out.At, in.At)
+	}
+	out.At, in.At = time.Time{}, time.Time{}
+	in.Skipped = ""
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", out, in)
+	}
+}
+
+func TestDB_NotFoundAndExistsCarryGrpcCodes(t *testing.T) {
+	db := openTest(t)
+	ctx := context.Background()
+
+	var r reading
+	if err := db.Get(ctx, "readings", "missing", &r); status.Code(err) != codes.NotFound {
+		t.Errorf("Get missing: got %v, want NotFound", err)
+	}
+	if err := db.Delete(ctx, "readings", "missing"); err != nil {
+		t.Errorf("Delete missing: got %v, want success as in Firestore", err)
+	}
+	err := db.RunTransaction(ctx, func(ctx context.Context, tx *Tx) error {
+		return tx.DeleteExisting("readings", "missing")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteExisting missing: got %v, want NotFound", err)
+	}
+	if err := db.Create(ctx, "readings", "a", reading{Count: 1}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := db.Create(ctx, "readings", "a", reading{Count: 2}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("second Create: got %v, want AlreadyExists", err)
+	}
+	if err := db.Get(ctx, "readings", "a", &r); err != nil || r.Count != 1 {
+		t.Errorf("Get after failed Create: count %d, err %v", r.Count, err)
+	}
+}
+
+func TestDB_RunTransaction(t *testing.T) {
+	db := openTest(t)
+	ctx := context.Background()
+	if err := db.Set(ctx, "readings", "a", reading{Count: 1, Tags: []string{"x"}}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	t.Run("an error rolls back every write", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := db.RunTransaction(ctx, func(ctx context.Context, tx *Tx) error {
+			if err := tx.Set("readings", "a", reading{Count: 99}); err != nil {
+				return err
+			}
+			return boom
+		})
+		if !errors.Is(err, boom) {
+			t.Fatalf("got %v, want boom", err)
+		}
+		var r reading
+		db.Get(ctx, "readings", "a", &r)
+		if r.Count != 1 {
+			t.Errorf("count = %d after rollback, want 1", r.Count)
+		}
+	})
+
+	t.Run("Update writes one field and keeps the rest", func(t *testing.T) {
+		err := db.RunTransaction(ctx, func(ctx context.Context, tx *Tx) error {
+			return tx.Update("readings", "a", "count", 5)
+		})
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		var r reading
+		db.Get(ctx, "readings", "a", &r)
+		if r.Count != 5 || len(r.Tags) != 1 {
+			t.Errorf("after Update got %+v", r)
+		}
+	})
+
+	t.Run("concurrent read-modify-write loses nothing", func(t *testing.T) {
+		const n = 20
+		errs := make(chan error, n)
+		for i := 0; i < n; i++ {
+			go func() {
+				errs <- db.RunTransaction(ctx, func(ctx context.Context, tx *Tx) error {
+					var r reading
+					if err := tx.Get("readings", "a", &r); err != nil {
+						return err
+					}
+					r.Count++
+					return tx.Set("readings", "a", r)
+				})
+			}()
+		}
+		for i := 0; i < n; i++ {
+			if err := <-errs; err != nil {
+				t.Fatalf("transaction: %v", err)
+			}
+		}
+		var r reading
+		db.Get(ctx, "readings", "a", &r)
+		if r.Count != 5+n {
+			t.Errorf("count = %d, want %d", r.Count, 5+n)
+		}
+	})
+}
+
+func TestDB_Documents(t *testing.T) {
+	db := openTest(t)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, loc := range []string{"house-nick", "distribution-hall", "house-nick", "house-nick"} {
+		if _, err := db.Add(ctx, "raw", reading{LocationID: loc, Count: i, At: base.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	docs, err := db.Documents(ctx, Query{
+		Collection: "raw",
+		Where: []Filter{
+			{Field: "location_id", Op: "==", Value: "house-nick"},
+			{Field: "at", Op: ">=", Value: base.Add(2 * time.Hour).In(time.FixedZone("CST", -6*3600))},
+		},
+		OrderBy: "at",
+		Desc:    true,
+	})
+	if err != nil {
+		t.Fatalf("Documents: %v", err)
+	}
+	var counts []int
+	for _, doc := range docs {
+		var r reading
+		if err := doc.DataTo(&r); err != nil {
+			t.Fatal(err)
+		}
+		counts = append(counts, r.Count)
+	}
+	if !reflect.DeepEqual(counts, []int{3, 2}) {
+		t.Errorf("counts = %v, want [3 2]", counts)
+	}
+
+	docs, err = db.Documents(ctx, Query{Collection: "raw", OrderBy: "at", Offset: 1, Limit: 2})
+	if err != nil || len(docs) != 2 {
+		t.Fatalf("paged query: %d docs, err %v", len(docs), err)
+	}
+
+	var ids []string
+	for after := ""; ; {
+		docs, err := db.Documents(ctx, Query{Collection: "raw", AfterID: after, Limit: 3})
+		if err != nil {
+			t.Fatalf("cursor query: %v", err)
+		}
+		if len(docs) == 0 {
+			break
+		}
+		for _, doc := range docs {
+			ids = append(ids, doc.ID)
+		}
+		after = docs[len(docs)-1].ID
+	}
+	if len(ids) != 4 || !sort.StringsAreSorted(ids) {
+		t.Errorf("paging by ID returned %v, want all four in ID order", ids)
+	}
+	if _, err := db.Documents(ctx, Query{Collection: "raw", OrderBy: "at", AfterID: ids[0]}); err == nil {
+		t.Error("AfterID with OrderBy should be rejected")
+	}
+
+	if _, err := db.Documents(ctx, Query{Collection: "raw", Where: []Filter{{Field: "at') OR 1=1 --", Op: "=="}}}); err == nil {
+		t.Error("a field outside the allowed pattern should be rejected")
+	}
+}
+
+func TestBackend(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", BackendFirestore, false},
+		{"firestore", BackendFirestore, false},
+		{"sqlite", BackendSQLite, false},
+		{"sqllite", "", true},
+	}
+	for _, tt := range tests {
+		t.Setenv(EnvBackend, tt.value)
+		got, err := Backend()
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("Backend() with %q = %q, %v", tt.value, got, err)
+		}
+	}
+}
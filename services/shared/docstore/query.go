@@ -0,0 +1,140 @@
+package docstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Filter compares a field with a value. Op is one of ==, <, <=, > or >=.
+// Times compare chronologically, as they do in Firestore.
+type Filter struct {
+	Field string
+	Op    string
+	Value any
+}
+
+// Query selects documents from one collection. Fields may be dotted paths
+// into nested maps. With no OrderBy, documents come back in ID order.
+type Query struct {
+	Collection string
+	Where      []Filter
+	OrderBy    string
+	Desc       bool
+	Offset     int
+	Limit      int    // 0 means no limit
+	AfterID    string // with no OrderBy, start after this document ID, like StartAfter on firestore.DocumentID
+}
+
+// Document is one query result.
+type Document struct {
+	ID   string
+	data []byte
+}
+
+// DataTo decodes the document into dst, like DocumentSnapshot.DataTo.
+func (d Document) DataTo(dst any) error {
+	if err := unmarshal(d.data, dst); err != nil {
+		return fmt.Errorf("decoding %s: %w", d.ID, err)
+	}
+	return nil
+}
+
+// DecodeAll decodes every document into a T, in order.
+func DecodeAll[T any](docs []Document) ([]T, error) {
+	out := make([]T, 0, len(docs))
+	for _, doc := range docs {
+		var item T
+		if err := doc.DataTo(&item); err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+var ops = map[string]bool{"==": true, "<": true, "<=": true, ">": true, ">=": true}
+
+func documents(ctx context.Context, e execer, q Query) ([]Document, error) {
+	var sb strings.Builder
+	args := []any{q.Collection}
+	sb.WriteString(`SELECT id, data FROM documents WHERE collection = ?`)
+	for _, f := range q.Where {
+		if !fieldPattern.MatchString(f.Field) || !ops[f.Op] {
+			return nil, fmt.Errorf("docstore: invalid filter %s %s", f.Field, f.Op)
+		}
+		op := f.Op
+		if op == "==" {
+			op = "="
+		}
+		fmt.Fprintf(&sb, ` AND json_extract(data, ?) %s ?`, op)
+		args = append(args, "$."+f.Field, queryValue(f.Value))
+	}
+	if q.AfterID != "" {
+		if q.OrderBy != "" {
+			return nil, fmt.Errorf("docstore: AfterID needs ID order, not %q", q.OrderBy)
+		}
+		sb.WriteString(` AND id > ?`)
+		args = append(args, q.AfterID)
+	}
+	if q.OrderBy != "" {
+		if !fieldPattern.MatchString(q.OrderBy) {
+			return nil, fmt.Errorf("docstore: invalid order field %q", q.OrderBy)
+		}
+		dir := "ASC"
+		if q.Desc {
+			dir = "DESC"
+		}
+		fmt.Fprintf(&sb, ` ORDER BY json_extract(data, ?) %s, id %s`, dir, dir)
+		args = append(args, "$."+q.OrderBy)
+	} else {
+		sb.WriteString(` ORDER BY id`)
+	}
+	if q.Limit > 0 || q.Offset > 0 {
+		limit := q.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+		sb.WriteString(` LIMIT ? OFFSET ?`)
+		args = append(args, limit, q.Offset)
+	}
+
+	rows, err := e.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("docstore: querying %s: %w", q.Collection, err)
+	}
+	defer rows.Close()
+	var docs []Document
+	for rows.Next() {
+		var doc Document
+		if err := rows.Scan(&doc.ID, &doc.data); err != nil {
+			return nil, fmt.Errorf("docstore: querying %s: %w", q.Collection, err)
+		}
+		docs = append(docs, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("docstore: querying %s: %w", q.Collection, err)
+	}
+	return docs, nil
+}
+
+// queryValue converts a filter value to what json_extract returns for the
+// stored field: times become their stored string, bools become 0 or 1.
+func queryValue(v any) any {
+	switch val := v.(type) {
+	case time.Time:
+		return val.UTC().Format(timeLayout)
+	case bool:
+		if val {
+			return 1
+		}
+		return 0
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	return v
+}
@@ -0,0 +1,298 @@
+// Package fakeupstream stands in for the Google Weather and Pollen APIs, so
+// the collectors run end to end without GOOGLE_MAPS_API_KEY or a network. It
+// serves the three endpoints they call, in the response shape the collectors
+// decode, from a scripted scenario:
+//
+//   - steady: flat pressure, mild weather, moderate pollen
+//   - pressure-drop: pressure falls sharply enough to trip pressure-drop-3h
+//   - missing-hours: the hourly forecast has gaps and pollen covers fewer days
+//   - burst: every call fails with 429, then 503, then succeeds, so each fetch
+//     only gets through on its retries
+//
+// Responses depend only on the scenario, the request and the clock, except
+// that current conditions step forward one reading per call for each
+// location, so repeated collector runs see the pressure fall.
+//
+// Point a collector at it with WEATHER_API_BASE_URL or POLLEN_API_BASE_URL.
+// The API key header is accepted but not checked.
+package fakeupstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scenarios the server can play.
+const (
+	ScenarioSteady       = "steady"
+	ScenarioPressureDrop = "pressure-drop"
+	ScenarioMissingHours = "missing-hours"
+	ScenarioBurst        = "burst"
+)
+
+// Scenarios lists every scenario name, for flag help and validation.
+var Scenarios = []string{ScenarioSteady, ScenarioPressureDrop, ScenarioMissingHours, ScenarioBurst}
+
+// Paths the collectors request, relative to their base URL.
+const (
+	CurrentConditionsPath = "/v1/currentConditions:lookup"
+	ForecastHoursPath     = "/v1/forecast/hours:lookup"
+	PollenForecastPath    = "/v1/forecast:lookup"
+)
+
+// Server is an http.Handler serving the fake upstream APIs.
+type Server struct {
+	mux *http.ServeMux
+	now func() time.Time
+
+	mu       sync.Mutex
+	scenario string
+	readings map[string]int // current-conditions calls so far, per location
+	calls    map[string]int // calls so far, per path, for burst failures
+}
+
+// New returns a server playing scenario, which must be one of Scenarios.
+func New(scenario string) (*Server, error) {
+	return NewWithClock(scenario, time.Now)
+}
+
+// NewWithClock is New with the clock forecasts start from, for tests.
+func NewWithClock(scenario string, now func() time.Time) (*Server, error) {
+	if !slices.Contains(Scenarios, scenario) {
+		return nil, unknownScenario(scenario)
+	}
+	s := &Server{
+		mux:      http.NewServeMux(),
+		now:      now,
+		scenario: scenario,
+		readings: make(map[string]int),
+		calls:    make(map[string]int),
+	}
+	s.mux.HandleFunc("GET "+CurrentConditionsPath, s.handleCurrentConditions)
+	s.mux.HandleFunc("GET "+ForecastHoursPath, s.handleForecastHours)
+	s.mux.HandleFunc("GET "+PollenForecastPath, s.handlePollenForecast)
+	s.mux.HandleFunc("GET /scenario", s.handleGetScenario)
+	s.mux.HandleFunc("PUT /scenario", s.handlePutScenario)
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Scenario returns the scenario being played.
+func (s *Server) Scenario() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scenario
+}
+
+// SetScenario switches scenario and restarts its script: reading and burst
+// counters go back to zero.
+func (s *Server) SetScenario(scenario string) error {
+	if !slices.Contains(Scenarios, scenario) {
+		return unknownScenario(scenario)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scenario = scenario
+	clear(s.readings)
+	clear(s.calls)
+	return nil
+}
+
+func unknownScenario(name string) error {
+	return fmt.Errorf("unknown scenario %q (want one of %s)", name, strings.Join(Scenarios, ", "))
+}
+
+// burstStatuses is what the burst scenario answers, in turn, before letting a
+// call through. The collectors retry three times, so each fetch succeeds.
+var burstStatuses = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+
+// begin records a call and returns the scenario to answer it with, or writes
+// a burst failure and returns false.
+func (s *Server) begin(w http.ResponseWriter, r *http.Request) (string, bool) {
+	s.mu.Lock()
+	scenario := s.scenario
+	n := s.calls[r.URL.Path]
+	s.calls[r.URL.Path] = n + 1
+	s.mu.Unlock()
+
+	if scenario == ScenarioBurst {
+		if i := n % (len(burstStatuses) + 1); i < len(burstStatuses) {
+			code := burstStatuses[i]
+			slog.Debug("Failing request", "path", r.URL.Path, "status", code)
+			writeError(w, code, http.StatusText(code))
+			return "", false
+		}
+	}
+	return scenario, true
+}
+
+func (s *Server) handleCurrentConditions(w http.ResponseWriter, r *http.Request) {
+	lat, long, ok := coordinates(w, r)
+	if !ok {
+		return
+	}
+	scenario, ok := s.begin(w, r)
+	if !ok {
+		return
+	}
+	key := locationKey(lat, long)
+	s.mu.Lock()
+	n := s.readings[key]
+	s.readings[key] = n + 1
+	s.mu.Unlock()
+
+	writeJSON(w, currentConditions(scenario, lat, long, s.now(), n))
+}
+
+func (s *Server) handleForecastHours(w http.ResponseWriter, r *http.Request) {
+	lat, long, ok := coordinates(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	hours, err := intParam(q.Get("hours"), defaultForecastHours, maxForecastHours)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid hours: "+err.Error())
+		return
+	}
+	pageSize, err := intParam(q.Get("pageSize"), defaultPageSize, maxPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid pageSize: "+err.Error())
+		return
+	}
+	offset := 0
+	if token := q.Get("pageToken"); token != "" {
+		if offset, err = parsePageToken(token); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid pageToken")
+			return
+		}
+	}
+	scenario, ok := s.begin(w, r)
+	if !ok {
+		return
+	}
+
+	all := forecastHours(scenario, lat, long, s.now(), hours)
+	page := forecastHoursResponse{ForecastHours: []forecastHour{}}
+	if offset < len(all) {
+		end := min(offset+pageSize, len(all))
+		page.ForecastHours = all[offset:end]
+		if end < len(all) {
+			page.NextPageToken = pageToken(end)
+		}
+	}
+	writeJSON(w, page)
+}
+
+func (s *Server) handlePollenForecast(w http.ResponseWriter, r *http.Request) {
+	lat, long, ok := coordinates(w, r)
+	if !ok {
+		return
+	}
+	days, err := intParam(r.URL.Query().Get("days"), 1, maxPollenDays)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid days: "+err.Error())
+		return
+	}
+	scenario, ok := s.begin(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, pollenForecast(scenario, lat, long, s.now(), days))
+}
+
+func (s *Server) handleGetScenario(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{"scenario": s.Scenario()})
+}
+
+// handlePutScenario switches scenario from ?name=, so a running stack can be
+// moved on to the next script without a restart.
+func (s *Server) handlePutScenario(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if err := s.SetScenario(name); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	slog.Info("Scenario changed", "scenario", name)
+	writeJSON(w, map[string]string{"scenario": name})
+}
+
+// coordinates reads the location the collectors send, failing the request
+// with 400 as the real API does when it is missing.
+func coordinates(w http.ResponseWriter, r *http.Request) (float64, float64, bool) {
+	q := r.URL.Query()
+	lat, err := strconv.ParseFloat(q.Get("location.latitude"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		writeError(w, http.StatusBadRequest, "invalid location.latitude")
+		return 0, 0, false
+	}
+	long, err := strconv.ParseFloat(q.Get("location.longitude"), 64)
+	if err != nil || long < -180 || long > 180 {
+		writeError(w, http.StatusBadRequest, "invalid location.longitude")
+		return 0, 0, false
+	}
+	return lat, long, true
+}
+
+func locationKey(lat, long float64) string {
+	return fmt.Sprintf("%.4f,%.4f", lat, long)
+}
+
+// intParam parses an optional positive integer, capped at max.
+func intParam(raw string, def, max int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("must be positive, got %d", n)
+	}
+	return min(n, max), nil
+}
+
+const pageTokenPrefix = "fake-offset-"
+
+func pageToken(offset int) string {
+	return pageTokenPrefix + strconv.Itoa(offset)
+}
+
+func parsePageToken(token string) (int, error) {
+	raw, ok := strings.CutPrefix(token, pageTokenPrefix)
+	if !ok {
+		return 0, fmt.Errorf("unknown page token %q", token)
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("unknown page token %q", token)
+	}
+	return n, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Failed to write response", "error", err)
+	}
+}
+
+// writeError answers in the google.rpc.Status envelope the real APIs use.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]any{"code": code, "message": message},
+	})
+}
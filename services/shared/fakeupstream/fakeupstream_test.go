@@ -0,0 +1,218 @@
+package fakeupstream
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 6, 12, 5, 30, 0, 0, time.UTC)
+
+func newTestServer(t *testing.T, scenario string) *httptest.Server {
+	t.Helper()
+	srv, err := NewWithClock(scenario, func() time.Time { return testNow })
+	if err != nil {
+		t.Fatalf("NewWithClock: %v", err)
+	}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func get(t *testing.T, ts *httptest.Server, path string, params url.Values, dst any) int {
+	t.Helper()
+	params.Set("location.latitude", "30.267200")
+	params.Set("location.longitude", "-97.743100")
+	resp, err := http.Get(ts.URL + path + "?" + params.Encode())
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK && dst != nil {
+		if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+			t.Fatalf("decoding %s: %v", path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// forecast follows pageToken the way the forecast collector does.
+func forecast(t *testing.T, ts *httptest.Server, hours int) []forecastHour {
+	t.Helper()
+	var all []forecastHour
+	token := ""
+	for pages := 0; ; pages++ {
+		if pages > 20 {
+			t.Fatal("pagination did not end")
+		}
+		params := url.Values{"hours": {strconv.Itoa(hours)}, "pageSize": {"24"}}
+		if token != "" {
+			params.Set("pageToken", token)
+		}
+		var page forecastHoursResponse
+		if code := get(t, ts, ForecastHoursPath, params, &page); code != http.StatusOK {
+			t.Fatalf("forecast page %d: status %d", pages, code)
+		}
+		all = append(all, page.ForecastHours...)
+		if token = page.NextPageToken; token == "" {
+			return all
+		}
+	}
+}
+
+func TestForecastHours_PagesThroughTheHorizon(t *testing.T) {
+	ts := newTestServer(t, ScenarioSteady)
+
+	hours := forecast(t, ts, 72)
+	if len(hours) != 72 {
+		t.Fatalf("got %d hours, want 72", len(hours))
+	}
+	if want := testNow.Truncate(time.Hour); !hours[0].Interval.StartTime.Equal(want) {
+		t.Errorf("first hour starts %v, want %v", hours[0].Interval.StartTime, want)
+	}
+	for i := 1; i < len(hours); i++ {
+		if d := hours[i].Interval.StartTime.Sub(hours[i-1].Interval.StartTime); d != time.Hour {
+			t.Fatalf("hour %d is %v after the previous one", i, d)
+		}
+		if hours[i].AirPressure != hours[0].AirPressure {
+			t.Fatalf("steady pressure changed at hour %d", i)
+		}
+	}
+}
+
+func TestForecastHours_PressureDropTripsTheDefaultRule(t *testing.T) {
+	ts := newTestServer(t, ScenarioPressureDrop)
+
+	hours := forecast(t, ts, 72)
+	worst := 0.0
+	for i := 3; i < len(hours); i++ {
+		drop := hours[i-3].AirPressure.MeanSeaLevelMillibars - hours[i].AirPressure.MeanSeaLevelMillibars
+		worst = max(worst, drop)
+	}
+	// pressure-drop-3h: warning at 5 mb, severe at 10.
+	if worst < 10 {
+		t.Errorf("largest 3h drop = %.1f mb, want at least 10", worst)
+	}
+}
+
+func TestForecastHours_MissingHoursLeavesGaps(t *testing.T) {
+	ts := newTestServer(t, ScenarioMissingHours)
+
+	hours := forecast(t, ts, 72)
+	if len(hours) != 72-72/missingHourEvery {
+		t.Errorf("got %d hours, want %d", len(hours), 72-72/missingHourEvery)
+	}
+	gaps := 0
+	for i := 1; i < len(hours); i++ {
+		if hours[i].Interval.StartTime.Sub(hours[i-1].Interval.StartTime) > time.Hour {
+			gaps++
+		}
+	}
+	if gaps == 0 {
+		t.Error("expected gaps between forecast hours")
+	}
+}
+
+func TestCurrentConditions_PressureFallsPerReading(t *testing.T) {
+	ts := newTestServer(t, ScenarioPressureDrop)
+
+	var readings []float64
+	for range 3 {
+		var resp currentConditionsResponse
+		if code := get(t, ts, CurrentConditionsPath, url.Values{}, &resp); code != http.StatusOK {
+			t.Fatalf("status %d", code)
+		}
+		readings = append(readings, resp.AirPressure.MeanSeaLevelMillibars)
+	}
+	if !(readings[0] > readings[1] && readings[1] > readings[2]) {
+		t.Errorf("pressure readings %v, want strictly falling", readings)
+	}
+}
+
+func TestPollenForecast(t *testing.T) {
+	tests := []struct {
+		scenario string
+		wantDays int
+	}{
+		{ScenarioSteady, 5},
+		{ScenarioMissingHours, missingPollenDays},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			ts := newTestServer(t, tt.scenario)
+			var resp pollenForecastResponse
+			if code := get(t, ts, PollenForecastPath, url.Values{"days": {"5"}}, &resp); code != http.StatusOK {
+				t.Fatalf("status %d", code)
+			}
+			if len(resp.DailyInfo) != tt.wantDays {
+				t.Fatalf("got %d days, want %d", len(resp.DailyInfo), tt.wantDays)
+			}
+			today := resp.DailyInfo[0]
+			if today.Date != (date{Year: 2026, Month: 6, Day: 12}) {
+				t.Errorf("first day = %+v, want 2026-06-12", today.Date)
+			}
+			if len(today.PollenTypeInfo) != 3 || today.PollenTypeInfo[0].IndexInfo.Category == "" {
+				t.Errorf("pollen types = %+v", today.PollenTypeInfo)
+			}
+		})
+	}
+}
+
+func TestBurst_FailsTwiceThenSucceeds(t *testing.T) {
+	ts := newTestServer(t, ScenarioBurst)
+
+	var codes []int
+	for range 6 {
+		codes = append(codes, get(t, ts, CurrentConditionsPath, url.Values{}, nil))
+	}
+	want := []int{429, 503, 200, 429, 503, 200}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("statuses = %v, want %v", codes, want)
+		}
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	ts := newTestServer(t, ScenarioSteady)
+
+	if code := get(t, ts, ForecastHoursPath, url.Values{"pageToken": {"bogus"}}, nil); code != http.StatusBadRequest {
+		t.Errorf("bogus pageToken: status %d, want 400", code)
+	}
+	resp, err := http.Get(ts.URL + CurrentConditionsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("missing location: status %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestSetScenario(t *testing.T) {
+	srv, err := New(ScenarioSteady)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New("hurricane"); err == nil {
+		t.Error("New should reject an unknown scenario")
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/scenario?name=burst", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || srv.Scenario() != ScenarioBurst {
+		t.Errorf("PUT /scenario: status %d, scenario %q", rec.Code, srv.Scenario())
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/scenario?name=hurricane", nil)
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || srv.Scenario() != ScenarioBurst {
+		t.Errorf("PUT unknown scenario: status %d, scenario %q", rec.Code, srv.Scenario())
+	}
+}
@@ -0,0 +1,319 @@
+package fakeupstream
+
+import (
+	"math"
+	"time"
+)
+
+// Forecast and pollen limits, matching the real APIs.
+const (
+	defaultForecastHours = 24
+	maxForecastHours     = 240
+	defaultPageSize      = 24
+	maxPageSize          = 24
+	maxPollenDays        = 5
+)
+
+// The pressure-drop script: steady for dropStartHour hours, then falling
+// dropPerHourMb each hour for dropHours hours, then recovering slowly. Any
+// three hours of the fall lose 10.5 mb, past pressure-drop-3h's severe
+// threshold of 10.
+const (
+	dropStartHour  = 6
+	dropHours      = 6
+	dropPerHourMb  = 3.5
+	recoverPerHour = 0.5
+)
+
+// missingHourEvery drops one forecast hour in this many under missing-hours,
+// and missingPollenDays caps the pollen outlook.
+const (
+	missingHourEvery  = 6
+	missingPollenDays = 2
+)
+
+// The response types carry only what the collectors decode, plus the unit
+// fields that keep a response recognisable next to a real one.
+
+type degrees struct {
+	Degrees float64 `json:"degrees"`
+	Unit    string  `json:"unit"`
+}
+
+type airPressure struct {
+	MeanSeaLevelMillibars float64 `json:"meanSeaLevelMillibars"`
+}
+
+type wind struct {
+	Direction struct {
+		Degrees  int    `json:"degrees"`
+		Cardinal string `json:"cardinal"`
+	} `json:"direction"`
+	Speed struct {
+		Value float64 `json:"value"`
+		Unit  string  `json:"unit"`
+	} `json:"speed"`
+	Gust struct {
+		Value float64 `json:"value"`
+		Unit  string  `json:"unit"`
+	} `json:"gust"`
+}
+
+type interval struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// currentConditionsResponse names the precipitation chance
+// probability.probability, as currentConditions:lookup does; hourly
+// forecasts call it probability.percent.
+type currentConditionsResponse struct {
+	CurrentTime          time.Time   `json:"currentTime"`
+	Temperature          degrees     `json:"temperature"`
+	FeelsLikeTemperature degrees     `json:"feelsLikeTemperature"`
+	DewPoint             degrees     `json:"dewPoint"`
+	RelativeHumidity     int         `json:"relativeHumidity"`
+	UVIndex              int         `json:"uvIndex"`
+	AirPressure          airPressure `json:"airPressure"`
+	Wind                 wind        `json:"wind"`
+	Visibility           struct {
+		Distance float64 `json:"distance"`
+		Unit     string  `json:"unit"`
+	} `json:"visibility"`
+	Precipitation struct {
+		Probability struct {
+			Probability int    `json:"probability"`
+			Type        string `json:"type"`
+		} `json:"probability"`
+	} `json:"precipitation"`
+}
+
+type forecastHour struct {
+	Interval             interval    `json:"interval"`
+	Temperature          degrees     `json:"temperature"`
+	FeelsLikeTemperature degrees     `json:"feelsLikeTemperature"`
+	DewPoint             degrees     `json:"dewPoint"`
+	RelativeHumidity     int         `json:"relativeHumidity"`
+	UVIndex              int         `json:"uvIndex"`
+	AirPressure          airPressure `json:"airPressure"`
+	Wind                 wind        `json:"wind"`
+	Precipitation        struct {
+		Probability struct {
+			Percent int    `json:"percent"`
+			Type    string `json:"type"`
+		} `json:"probability"`
+	} `json:"precipitation"`
+}
+
+type forecastHoursResponse struct {
+	ForecastHours []forecastHour `json:"forecastHours"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+type indexInfo struct {
+	Code        string `json:"code"`
+	DisplayName string `json:"displayName"`
+	Value       int    `json:"value"`
+	Category    string `json:"category"`
+}
+
+type pollenInfo struct {
+	Code        string    `json:"code"`
+	DisplayName string    `json:"displayName"`
+	InSeason    bool      `json:"inSeason"`
+	IndexInfo   indexInfo `json:"indexInfo"`
+}
+
+type date struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+type dailyInfo struct {
+	Date           date         `json:"date"`
+	PollenTypeInfo []pollenInfo `json:"pollenTypeInfo"`
+	PlantInfo      []pollenInfo `json:"plantInfo"`
+}
+
+type pollenForecastResponse struct {
+	RegionCode string      `json:"regionCode"`
+	DailyInfo  []dailyInfo `json:"dailyInfo"`
+}
+
+// weatherAt is the shared weather model: everything but pressure follows a
+// daily cycle in the location's approximate solar time.
+type weatherAt struct {
+	tempC, dewPointC float64
+	humidity, uv     int
+	windKph, gustKph float64
+	windDir          int
+	precipPercent    int
+}
+
+func weather(lat, long float64, t time.Time) weatherAt {
+	solarHour := math.Mod(float64(t.UTC().Hour())+long/15+24, 24)
+	day := math.Sin(2 * math.Pi * (solarHour - 9) / 24) // peaks mid-afternoon
+	temp := 24 - math.Abs(lat-30)/3 + 6*day
+	uv := 0
+	if solarHour >= 7 && solarHour <= 19 {
+		uv = int(math.Round(8 * math.Sin(math.Pi*(solarHour-7)/12)))
+	}
+	return weatherAt{
+		tempC:         round1(temp),
+		dewPointC:     round1(temp - 8 + 2*day),
+		humidity:      int(math.Round(60 - 15*day)),
+		uv:            uv,
+		windKph:       round1(10 + 4*day),
+		gustKph:       round1(18 + 6*day),
+		windDir:       180,
+		precipPercent: 10,
+	}
+}
+
+// basePressure is the location's steady sea-level pressure, offset a little
+// per location so several locations do not look identical.
+func basePressure(lat, long float64) float64 {
+	return round1(1015 + math.Mod(math.Abs(lat*7+long*3), 4) - 2)
+}
+
+// forecastPressure is the pressure hour i of the forecast predicts.
+func forecastPressure(scenario string, base float64, i int) float64 {
+	if scenario != ScenarioPressureDrop || i <= dropStartHour {
+		return base
+	}
+	bottom := base - dropPerHourMb*dropHours
+	if i <= dropStartHour+dropHours {
+		return round1(base - dropPerHourMb*float64(i-dropStartHour))
+	}
+	return round1(math.Min(base, bottom+recoverPerHour*float64(i-dropStartHour-dropHours)))
+}
+
+// readingPressure is the pressure the nth current-conditions call for a
+// location reports: under pressure-drop it falls one step per call, as if
+// each collector run were an hour apart.
+func readingPressure(scenario string, base float64, n int) float64 {
+	if scenario != ScenarioPressureDrop {
+		return base
+	}
+	return round1(base - dropPerHourMb*float64(min(n, dropHours)))
+}
+
+func currentConditions(scenario string, lat, long float64, now time.Time, n int) currentConditionsResponse {
+	w := weather(lat, long, now)
+	var resp currentConditionsResponse
+	resp.CurrentTime = now.UTC().Truncate(time.Second)
+	resp.Temperature = celsius(w.tempC)
+	resp.FeelsLikeTemperature = celsius(w.tempC)
+	resp.DewPoint = celsius(w.dewPointC)
+	resp.RelativeHumidity = w.humidity
+	resp.UVIndex = w.uv
+	resp.AirPressure.MeanSeaLevelMillibars = readingPressure(scenario, basePressure(lat, long), n)
+	resp.Wind = windOf(w)
+	resp.Visibility.Distance = 16
+	resp.Visibility.Unit = "KILOMETERS"
+	resp.Precipitation.Probability.Probability = w.precipPercent
+	resp.Precipitation.Probability.Type = "RAIN"
+	return resp
+}
+
+// forecastHours returns the whole forecast from the current hour; the
+// handler pages through it.
+func forecastHours(scenario string, lat, long float64, now time.Time, hours int) []forecastHour {
+	start := now.UTC().Truncate(time.Hour)
+	base := basePressure(lat, long)
+	out := make([]forecastHour, 0, hours)
+	for i := range hours {
+		if scenario == ScenarioMissingHours && i%missingHourEvery == missingHourEvery-1 {
+			continue
+		}
+		t := start.Add(time.Duration(i) * time.Hour)
+		w := weather(lat, long, t)
+		var h forecastHour
+		h.Interval = interval{StartTime: t, EndTime: t.Add(time.Hour)}
+		h.Temperature = celsius(w.tempC)
+		h.FeelsLikeTemperature = celsius(w.tempC)
+		h.DewPoint = celsius(w.dewPointC)
+		h.RelativeHumidity = w.humidity
+		h.UVIndex = w.uv
+		h.AirPressure.MeanSeaLevelMillibars = forecastPressure(scenario, base, i)
+		h.Wind = windOf(w)
+		h.Precipitation.Probability.Percent = w.precipPercent
+		h.Precipitation.Probability.Type = "RAIN"
+		out = append(out, h)
+	}
+	return out
+}
+
+// pollenLevels is the steady outlook: moderate tree pollen from oak, low
+// grass, very low weed.
+var pollenLevels = []struct {
+	code, name string
+	index      int
+	plants     []pollenPlant
+}{
+	{"GRASS", "Grass", 2, []pollenPlant{{"GRAMINALES", "Grasses", 2}}},
+	{"TREE", "Tree", 3, []pollenPlant{{"OAK", "Oak", 3}, {"JUNIPER", "Juniper", 1}}},
+	{"WEED", "Weed", 1, []pollenPlant{{"RAGWEED", "Ragweed", 1}}},
+}
+
+type pollenPlant struct {
+	code, name string
+	index      int
+}
+
+func pollenForecast(scenario string, lat, long float64, now time.Time, days int) pollenForecastResponse {
+	if scenario == ScenarioMissingHours {
+		days = min(days, missingPollenDays)
+	}
+	today := now.UTC()
+	resp := pollenForecastResponse{RegionCode: "US", DailyInfo: make([]dailyInfo, 0, days)}
+	for d := range days {
+		t := today.AddDate(0, 0, d)
+		day := dailyInfo{Date: date{Year: t.Year(), Month: int(t.Month()), Day: t.Day()}}
+		for _, level := range pollenLevels {
+			day.PollenTypeInfo = append(day.PollenTypeInfo, pollenInfoOf(level.code, level.name, level.index))
+			for _, p := range level.plants {
+				day.PlantInfo = append(day.PlantInfo, pollenInfoOf(p.code, p.name, p.index))
+			}
+		}
+		resp.DailyInfo = append(resp.DailyInfo, day)
+	}
+	return resp
+}
+
+func pollenInfoOf(code, name string, index int) pollenInfo {
+	return pollenInfo{
+		Code:        code,
+		DisplayName: name,
+		InSeason:    index > 0,
+		IndexInfo: indexInfo{
+			Code:        "UPI",
+			DisplayName: "Universal Pollen Index",
+			Value:       index,
+			Category:    upiCategories[index],
+		},
+	}
+}
+
+// upiCategories names each Universal Pollen Index level.
+var upiCategories = []string{"None", "Very Low", "Low", "Moderate", "High", "Very High"}
+
+func celsius(v float64) degrees {
+	return degrees{Degrees: v, Unit: "CELSIUS"}
+}
+
+func windOf(w weatherAt) wind {
+	var out wind
+	out.Direction.Degrees = w.windDir
+	out.Direction.Cardinal = "SOUTH"
+	out.Speed.Value = w.windKph
+	out.Speed.Unit = "KILOMETERS_PER_HOUR"
+	out.Gust.Value = w.gustKph
+	out.Gust.Unit = "KILOMETERS_PER_HOUR"
+	return out
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
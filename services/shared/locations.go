@@ -1,15 +1,166 @@
 package shared
 
-// Location represents a monitored geographic point.
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	// Every binary that reads the registry loads location timezones, and
+	// the alpine and distroless images do not all ship zoneinfo. Embedding
+	// the database makes a zone that validates here load everywhere.
+	_ "time/tzdata"
+)
+
+// Data sources a location can be switched on or off for. Each names the job
+// or jobs that act on the flag.
+const (
+	SourceWeather  = "weather"  // weather-collector
+	SourceForecast = "forecast" // forecast-collector, forecast-verifier
+	SourcePollen   = "pollen"   // pollen-collector
+	SourceNotify   = "notify"   // notifier
+)
+
+var knownSources = map[string]bool{
+	SourceWeather:  true,
+	SourceForecast: true,
+	SourcePollen:   true,
+	SourceNotify:   true,
+}
+
+// locationIDPattern keeps IDs safe as Firestore document IDs and URL path
+// segments: lowercase words joined by single hyphens.
+var locationIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// maxLocationIDLen bounds IDs well under Firestore's 1500-byte document ID
+// limit; an ID is also embedded in every alert's hash key.
+const maxLocationIDLen = 64
+
+// Location represents a monitored geographic point. The registry — the
+// locations collection, or a file named by LOCATIONS_FILE — is the source of
+// truth; see the registry package.
+//
+// The ID is the key every collection is written under, so it must stay
+// stable once data exists for it. Name is display-only and may change.
 type Location struct {
-	ID   string
-	Lat  float64
-	Long float64
+	ID       string  `firestore:"id" json:"id"`
+	Name     string  `firestore:"name" json:"name,omitempty"`
+	Lat      float64 `firestore:"lat" json:"lat"`
+	Long     float64 `firestore:"long" json:"long"`
+	Timezone string  `firestore:"timezone" json:"timezone"` // IANA zone, e.g. America/Chicago
+
+	// Disabled switches the location off for every source at once.
+	Disabled bool `firestore:"disabled" json:"disabled,omitempty"`
+
+	// Sources switches individual data sources off. A source absent from
+	// the map is enabled, so a new source starts collecting everywhere.
+	Sources map[string]bool `firestore:"sources" json:"sources,omitempty"`
+}
+
+// Enabled reports whether source should run for this location.
+func (l Location) Enabled(source string) bool {
+	if l.Disabled {
+		return false
+	}
+	on, ok := l.Sources[source]
+	return !ok || on
+}
+
+// TimeZone is the location's zone, for rendering any time a person reads:
+// alert messages, notification bodies and text output. Validate guarantees
+// it loads; UTC covers a Location built without validation.
+func (l Location) TimeZone() *time.Location {
+	zone, err := time.LoadLocation(l.Timezone) // "" loads UTC
+	if err != nil {
+		return time.UTC
+	}
+	return zone
+}
+
+// DisplayName is Name, or the ID when no name is set.
+func (l Location) DisplayName() string {
+	if l.Name != "" {
+		return l.Name
+	}
+	return l.ID
+}
+
+// Validate checks one location in isolation: ID rules, coordinate bounds, a
+// present and loadable timezone, and known source names.
+func (l Location) Validate() error {
+	if l.ID == "" {
+		return fmt.Errorf("location: missing id")
+	}
+	if len(l.ID) > maxLocationIDLen || !locationIDPattern.MatchString(l.ID) {
+		return fmt.Errorf("location %q: id must be lowercase letters, digits and single hyphens, at most %d characters", l.ID, maxLocationIDLen)
+	}
+	if l.Lat < -90 || l.Lat > 90 {
+		return fmt.Errorf("location %s: latitude %v out of range [-90, 90]", l.ID, l.Lat)
+	}
+	if l.Long < -180 || l.Long > 180 {
+		return fmt.Errorf("location %s: longitude %v out of range [-180, 180]", l.ID, l.Long)
+	}
+	// Required rather than defaulted: every deployed process runs in UTC, so
+	// a missing zone would quietly render local times hours off.
+	if l.Timezone == "" {
+		return fmt.Errorf("location %s: missing timezone", l.ID)
+	}
+	if _, err := time.LoadLocation(l.Timezone); err != nil {
+		return fmt.Errorf("location %s: timezone %q: %w", l.ID, l.Timezone, err)
+	}
+	for source := range l.Sources {
+		if !knownSources[source] {
+			return fmt.Errorf("location %s: unknown source %q", l.ID, source)
+		}
+	}
+	return nil
+}
+
+// ValidateLocations checks every location in a locations file and rejects
+// duplicate IDs. An empty list is an error: a file with nothing in it almost
+// always means the wrong file was named. The registry collection is checked
+// per document instead (see registry.Source).
+func ValidateLocations(locations []Location) error {
+	if len(locations) == 0 {
+		return fmt.Errorf("no locations configured")
+	}
+	seen := make(map[string]bool, len(locations))
+	for _, l := range locations {
+		if err := l.Validate(); err != nil {
+			return err
+		}
+		if seen[l.ID] {
+			return fmt.Errorf("location %s: duplicate id", l.ID)
+		}
+		seen[l.ID] = true
+	}
+	return nil
+}
+
+// EnabledFor returns the locations with source enabled, in registry order.
+func EnabledFor(locations []Location, source string) []Location {
+	var out []Location
+	for _, l := range locations {
+		if l.Enabled(source) {
+			out = append(out, l)
+		}
+	}
+	return out
 }
 
-// Locations is the canonical list used by all collector services.
-var Locations = []Location{
-	{ID: "house-nick", Lat: 30.260543381977474, Long: -97.66768538740229},
-	{ID: "house-nita", Lat: 30.29420179895202, Long: -97.6958691874014},
-	{ID: "distribution-hall", Lat: 30.261932944618565, Long: -97.72816923158192},
+// ReadLocationsFile reads and validates a JSON array of locations.
+func ReadLocationsFile(path string) ([]Location, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading locations file: %w", err)
+	}
+	var locations []Location
+	if err := json.Unmarshal(raw, &locations); err != nil {
+		return nil, fmt.Errorf("parsing locations file %s: %w", path, err)
+	}
+	if err := ValidateLocations(locations); err != nil {
+		return nil, fmt.Errorf("locations file %s: %w", path, err)
+	}
+	return locations, nil
 }
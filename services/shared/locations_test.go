@@ -1,38 +1,158 @@
 package shared
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
 
-func TestLocationsExist(t *testing.T) {
-	if len(Locations) != 3 {
-		t.Fatalf("expected 3 locations, got %d", len(Locations))
-	}
+func validLocation() Location {
+	return Location{ID: "house-nick", Lat: 30.26, Long: -97.67, Timezone: "America/Chicago"}
 }
 
-func TestLocationIDs(t *testing.T) {
-	expectedIDs := []string{"house-nick", "house-nita", "distribution-hall"}
+func TestShippedLocationsFile(t *testing.T) {
+	locations, err := ReadLocationsFile("locations.json")
+	if err != nil {
+		t.Fatalf("ReadLocationsFile() error: %v", err)
+	}
 
+	expectedIDs := []string{"house-nick", "house-nita", "distribution-hall"}
+	if len(locations) != len(expectedIDs) {
+		t.Fatalf("expected %d locations, got %d", len(expectedIDs), len(locations))
+	}
 	for i, expected := range expectedIDs {
-		if Locations[i].ID != expected {
-			t.Errorf("Locations[%d].ID = %q, want %q", i, Locations[i].ID, expected)
+		if locations[i].ID != expected {
+			t.Errorf("locations[%d].ID = %q, want %q", i, locations[i].ID, expected)
+		}
+		if locations[i].Name == "" || locations[i].Timezone == "" {
+			t.Errorf("location %q should carry a name and timezone", expected)
 		}
 	}
 }
 
-func TestLocationCoordinatesAreValid(t *testing.T) {
-	for _, loc := range Locations {
-		if loc.Lat < -90 || loc.Lat > 90 {
-			t.Errorf("location %q has invalid latitude: %f", loc.ID, loc.Lat)
-		}
-		if loc.Long < -180 || loc.Long > 180 {
-			t.Errorf("location %q has invalid longitude: %f", loc.ID, loc.Long)
-		}
+func TestLocation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Location)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(l *Location) {}},
+		{name: "no timezone", mutate: func(l *Location) { l.Timezone = "" }, wantErr: "missing timezone"},
+		{name: "UTC", mutate: func(l *Location) { l.Timezone = "UTC" }},
+		{name: "missing id", mutate: func(l *Location) { l.ID = "" }, wantErr: "missing id"},
+		{name: "uppercase id", mutate: func(l *Location) { l.ID = "House-Nick" }, wantErr: "lowercase"},
+		{name: "slash in id", mutate: func(l *Location) { l.ID = "house/nick" }, wantErr: "lowercase"},
+		{name: "double hyphen", mutate: func(l *Location) { l.ID = "house--nick" }, wantErr: "single hyphens"},
+		{name: "id too long", mutate: func(l *Location) { l.ID = strings.Repeat("a", 65) }, wantErr: "at most 64"},
+		{name: "latitude too high", mutate: func(l *Location) { l.Lat = 90.1 }, wantErr: "latitude"},
+		{name: "latitude too low", mutate: func(l *Location) { l.Lat = -91 }, wantErr: "latitude"},
+		{name: "longitude too high", mutate: func(l *Location) { l.Long = 180.5 }, wantErr: "longitude"},
+		{name: "longitude too low", mutate: func(l *Location) { l.Long = -181 }, wantErr: "longitude"},
+		{name: "unknown timezone", mutate: func(l *Location) { l.Timezone = "America/Austin" }, wantErr: "timezone"},
+		{name: "unknown source", mutate: func(l *Location) { l.Sources = map[string]bool{"radar": false} }, wantErr: "unknown source"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := validLocation()
+			tt.mutate(&l)
+			err := l.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLocations(t *testing.T) {
+	if err := ValidateLocations(nil); err == nil {
+		t.Error("an empty registry should be an error")
+	}
+	l := validLocation()
+	if err := ValidateLocations([]Location{l, l}); err == nil || !strings.Contains(err.Error(), "duplicate id") {
+		t.Errorf("err = %v, want duplicate id", err)
+	}
+	other := validLocation()
+	other.ID = "house-nita"
+	if err := ValidateLocations([]Location{l, other}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLocation_Enabled(t *testing.T) {
+	l := validLocation()
+	if !l.Enabled(SourcePollen) {
+		t.Error("a source absent from Sources should be enabled")
+	}
+	l.Sources = map[string]bool{SourcePollen: false, SourceWeather: true}
+	if l.Enabled(SourcePollen) || !l.Enabled(SourceWeather) {
+		t.Error("Sources should switch individual sources")
+	}
+	l.Disabled = true
+	if l.Enabled(SourceWeather) {
+		t.Error("Disabled should switch every source off")
 	}
 }
 
-func TestLocationFieldsExported(t *testing.T) {
-	// Verify all fields are accessible (exported) from the shared package.
-	loc := Locations[0]
-	_ = loc.ID
-	_ = loc.Lat
-	_ = loc.Long
+func TestEnabledFor(t *testing.T) {
+	on := validLocation()
+	off := validLocation()
+	off.ID = "house-nita"
+	off.Sources = map[string]bool{SourcePollen: false}
+
+	got := EnabledFor([]Location{on, off}, SourcePollen)
+	if len(got) != 1 || got[0].ID != "house-nick" {
+		t.Errorf("EnabledFor(pollen) = %v, want house-nick only", got)
+	}
+	if got := EnabledFor([]Location{on, off}, SourceWeather); len(got) != 2 {
+		t.Errorf("EnabledFor(weather) = %d locations, want 2", len(got))
+	}
+}
+
+func TestLocation_DisplayName(t *testing.T) {
+	l := validLocation()
+	if l.DisplayName() != "house-nick" {
+		t.Errorf("DisplayName() = %q, want the ID when unnamed", l.DisplayName())
+	}
+	l.Name = "Nick's house"
+	if l.DisplayName() != "Nick's house" {
+		t.Errorf("DisplayName() = %q, want the name", l.DisplayName())
+	}
+}
+
+func TestLocation_TimeZone(t *testing.T) {
+	l := validLocation()
+	at := time.Date(2026, 7, 1, 18, 0, 0, 0, time.UTC)
+	if got := at.In(l.TimeZone()).Format("15:04 MST"); got != "13:00 CDT" {
+		t.Errorf("America/Chicago rendered %q, want 13:00 CDT", got)
+	}
+	l.Timezone = "Europe/London"
+	if got := at.In(l.TimeZone()).Format("15:04 MST"); got != "19:00 BST" {
+		t.Errorf("Europe/London rendered %q, want 19:00 BST", got)
+	}
+	if got := (Location{ID: "bare"}).TimeZone(); got != time.UTC {
+		t.Errorf("an unvalidated location without a zone should fall back to UTC, got %v", got)
+	}
+}
+
+func TestReadLocationsFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadLocationsFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing file should fail")
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`[{"id": "x", "lat": 95, "long": 0}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadLocationsFile(bad); err == nil || !strings.Contains(err.Error(), "latitude") {
+		t.Errorf("err = %v, want the out-of-range latitude reported", err)
+	}
 }
@@ -0,0 +1,74 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// Cursors is a run's progress, kept in a JSON file so an interrupted run can
+// resume. It is only valid for the database and schema version it was
+// written for; a file from another of either starts over. The database
+// matters because collection names repeat across them: alert_history and
+// alert_events are in both weather-log and pollen-log.
+type Cursors struct {
+	path        string
+	Database    string            `json:"database"`
+	Version     int               `json:"version"`
+	Collections map[string]Cursor `json:"collections"`
+}
+
+// Cursor is how far one collection got.
+type Cursor struct {
+	After string `json:"after"` // last document ID written
+	Done  bool   `json:"done"`
+}
+
+// LoadCursors reads the cursor file at path for a run over database, or
+// starts empty if there is none.
+func LoadCursors(path, database string) (*Cursors, error) {
+	c := &Cursors{path: path, Database: database, Version: schema.Version, Collections: map[string]Cursor{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored Cursors
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("reading cursors from %s: %w", path, err)
+	}
+	if stored.Database == database && stored.Version == schema.Version && stored.Collections != nil {
+		c.Collections = stored.Collections
+	}
+	return c, nil
+}
+
+// save records a collection's cursor and rewrites the file. The write goes
+// through a temporary file, so a crash leaves the previous cursors intact.
+func (c *Cursors) save(collection string, cur Cursor) error {
+	c.Collections[collection] = cur
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".migrate-cursors-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
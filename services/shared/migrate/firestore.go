@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreStore migrates one Firestore database.
+type FirestoreStore struct {
+	client *firestore.Client
+}
+
+func NewFirestoreStore(ctx context.Context, projectID, databaseID string) (*FirestoreStore, error) {
+	client, err := firestore.NewClientWithDatabase(ctx, projectID, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	return &FirestoreStore{client: client}, nil
+}
+
+func (s *FirestoreStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *FirestoreStore) Page(ctx context.Context, collection, after string, limit int) ([]Doc, error) {
+	query := s.client.Collection(collection).OrderBy(firestore.DocumentID, firestore.Asc).Limit(limit)
+	if after != "" {
+		query = query.StartAfter(after)
+	}
+	snaps, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]Doc, len(snaps))
+	for i, snap := range snaps {
+		docs[i] = Doc{ID: snap.Ref.ID, Data: snap.Data(), token: snap.UpdateTime}
+	}
+	return docs, nil
+}
+
+// Write sends the page through a BulkWriter, each update conditioned on the
+// document's update time at read. A failed precondition is a conflict; any
+// other failure is returned once the rest of the page has been attempted.
+func (s *FirestoreStore) Write(ctx context.Context, collection string, changes []Change) ([]string, error) {
+	bw := s.client.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(changes))
+	var firstErr error
+	for _, ch := range changes {
+		updates := make([]firestore.Update, 0, len(ch.Fields))
+		for _, k := range sortedKeys(ch.Fields) {
+			updates = append(updates, firestore.Update{Path: k, Value: ch.Fields[k]})
+		}
+		ref := s.client.Collection(collection).Doc(ch.Doc.ID)
+		job, err := bw.Update(ref, updates, firestore.LastUpdateTime(ch.Doc.token.(time.Time)))
+		if err != nil {
+			firstErr = err
+			break
+		}
+		jobs = append(jobs, job)
+	}
+	bw.End()
+
+	var conflicts []string
+	for i, job := range jobs {
+		_, err := job.Results()
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.FailedPrecondition, codes.NotFound:
+			conflicts = append(conflicts, changes[i].Doc.ID)
+		default:
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return conflicts, firstErr
+}
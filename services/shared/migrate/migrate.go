@@ -0,0 +1,309 @@
+// Package migrate writes schema upgrades back to stored documents. Readers
+// already upgrade older documents in memory (schema.Read), so a new field
+// with a usable zero value needs nothing here. A change that does, such as a
+// renamed key or a field derived from others, registers a Migration for the
+// schema.Version that introduces it, and Run brings every document in the
+// listed collections up to date:
+//
+//   - Documents are read a page at a time in ID order. Each one below
+//     schema.Version gets every registered migration above its version, then
+//     schema_version is set to schema.Version.
+//   - Only the fields a migration sets are written, and only if the document
+//     has not changed since it was read; one that has is counted as a
+//     conflict and left for the next run.
+//   - With Cursors, the last ID of each written page is saved, so a run that
+//     is interrupted resumes where it stopped instead of rescanning. A
+//     collection that had conflicts is not marked done, and the next run
+//     scans it again from the start.
+//   - DryRun reads and plans but writes nothing, cursors included.
+//
+// Documents from a newer schema are counted and left alone.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// Migration upgrades one schema version's documents to the next.
+type Migration struct {
+	// Version is the schema.Version this migration brings documents to.
+	Version     int
+	Description string
+	Collections []string
+	// Apply returns the top-level fields to set on a document of one of
+	// Collections, or nil if it needs none beyond the version stamp. doc
+	// holds what is stored plus whatever earlier migrations set, and must
+	// not be modified. Values are as the backend decodes them: a timestamp
+	// is a time.Time from Firestore but a string from SQLite.
+	Apply func(collection, id string, doc map[string]any) map[string]any
+}
+
+var migrations []Migration
+
+// Register adds a migration. Migrations must be registered in version order,
+// from an init function, and none may target a version beyond
+// schema.Version.
+func Register(m Migration) {
+	if m.Version < 1 || m.Version > schema.Version {
+		panic(fmt.Sprintf("migrate: migration to version %d, schema is at %d", m.Version, schema.Version))
+	}
+	if n := len(migrations); n > 0 && migrations[n-1].Version >= m.Version {
+		panic(fmt.Sprintf("migrate: migration to version %d registered after %d", m.Version, migrations[n-1].Version))
+	}
+	migrations = append(migrations, m)
+}
+
+// Migrations returns the registered migrations in version order.
+func Migrations() []Migration {
+	return slices.Clone(migrations)
+}
+
+// Databases lists the versioned collections in each Firestore database.
+// With STORAGE_BACKEND=sqlite both live in the one file.
+var Databases = map[string][]string{
+	shared.WeatherDatabaseID: {
+		shared.WeatherRawCollection,
+		shared.WeatherCacheCollection,
+		shared.ForecastRawCollection,
+		shared.ForecastCacheCollection,
+		shared.WeatherRollupCollection,
+		shared.ForecastRollupCollection,
+		shared.AlertHistoryCollection,
+		shared.AlertEventsCollection,
+	},
+	shared.PollenDatabaseID: {
+		shared.PollenRawCollection,
+		shared.PollenCacheCollection,
+		shared.PollenRollupCollection,
+		shared.AlertHistoryCollection,
+		shared.AlertEventsCollection,
+	},
+}
+
+// Doc is one stored document as a Store reads it.
+type Doc struct {
+	ID   string
+	Data map[string]any
+
+	// token is what the Store checks on write to detect a concurrent
+	// change: the update time in Firestore.
+	token any
+}
+
+// Change is the set of fields to write to one document.
+type Change struct {
+	Doc    Doc
+	Fields map[string]any
+}
+
+// Store reads and writes one database's documents.
+type Store interface {
+	// Page returns up to limit documents of a collection whose IDs sort
+	// after the given one, in ID order.
+	Page(ctx context.Context, collection, after string, limit int) ([]Doc, error)
+	// Write sets each change's fields and leaves the rest of the document.
+	// A document changed or deleted since it was read is not written; its
+	// ID is returned among the conflicts.
+	Write(ctx context.Context, collection string, changes []Change) (conflicts []string, err error)
+	Close() error
+}
+
+// Options control a Run.
+type Options struct {
+	DryRun    bool
+	BatchSize int      // documents per page, and per write
+	Cursors   *Cursors // optional; ignored in a dry run
+}
+
+// DefaultBatchSize stays well under Firestore's limit of 500 writes per
+// batch.
+const DefaultBatchSize = 200
+
+// sampleSize caps CollectionReport.Sample.
+const sampleSize = 5
+
+// Report is what a Run did, or in a dry run would have done.
+type Report struct {
+	DryRun      bool
+	Version     int
+	Collections []CollectionReport
+}
+
+// CollectionReport counts one collection's documents.
+type CollectionReport struct {
+	Collection   string
+	ResumedAfter string // cursor the run started from, if any
+	Skipped      bool   // finished by an earlier run, per the cursors
+	Scanned      int
+	Changed      int // written, or would be in a dry run
+	Current      int // already at Version
+	Newer        int // from a newer schema; left alone
+	Conflicts    int // changed underneath the run; left for the next one
+	// ByMigration counts the changed documents each migration set fields
+	// on, by target version.
+	ByMigration map[int]int
+	Sample      []string // the first few changed IDs
+}
+
+// Run migrates each collection in turn and stops at the first error. The
+// report covers everything done up to that point.
+func Run(ctx context.Context, store Store, collections []string, opts Options) (Report, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.DryRun {
+		opts.Cursors = nil
+	}
+	report := Report{DryRun: opts.DryRun, Version: schema.Version}
+	for _, coll := range collections {
+		cr, err := runCollection(ctx, store, coll, opts)
+		report.Collections = append(report.Collections, cr)
+		if err != nil {
+			return report, fmt.Errorf("migrating %s: %w", coll, err)
+		}
+	}
+	return report, nil
+}
+
+func runCollection(ctx context.Context, store Store, coll string, opts Options) (CollectionReport, error) {
+	cr := CollectionReport{Collection: coll, ByMigration: map[int]int{}}
+	var after string
+	if opts.Cursors != nil {
+		cur := opts.Cursors.Collections[coll]
+		if cur.Done {
+			cr.Skipped = true
+			return cr, nil
+		}
+		after, cr.ResumedAfter = cur.After, cur.After
+	}
+
+	for {
+		docs, err := store.Page(ctx, coll, after, opts.BatchSize)
+		if err != nil {
+			return cr, err
+		}
+		if len(docs) == 0 {
+			break
+		}
+
+		var changes []Change
+		applied := map[string][]int{}
+		for _, doc := range docs {
+			cr.Scanned++
+			v := Version(doc.Data)
+			switch {
+			case v > schema.Version:
+				cr.Newer++
+			case v == schema.Version:
+				cr.Current++
+			default:
+				fields, versions := plan(coll, doc, v)
+				changes = append(changes, Change{Doc: doc, Fields: fields})
+				applied[doc.ID] = versions
+			}
+		}
+
+		conflicts := map[string]bool{}
+		if !opts.DryRun && len(changes) > 0 {
+			ids, err := store.Write(ctx, coll, changes)
+			if err != nil {
+				return cr, err
+			}
+			for _, id := range ids {
+				conflicts[id] = true
+			}
+		}
+		for _, ch := range changes {
+			if conflicts[ch.Doc.ID] {
+				cr.Conflicts++
+				continue
+			}
+			cr.Changed++
+			for _, v := range applied[ch.Doc.ID] {
+				cr.ByMigration[v]++
+			}
+			if len(cr.Sample) < sampleSize {
+				cr.Sample = append(cr.Sample, ch.Doc.ID)
+			}
+		}
+
+		after = docs[len(docs)-1].ID
+		if opts.Cursors != nil {
+			if err := opts.Cursors.save(coll, Cursor{After: after}); err != nil {
+				return cr, err
+			}
+		}
+		if len(docs) < opts.BatchSize {
+			break
+		}
+	}
+
+	if opts.Cursors != nil {
+		done := Cursor{After: after, Done: true}
+		if cr.Conflicts > 0 {
+			done = Cursor{}
+		}
+		if err := opts.Cursors.save(coll, done); err != nil {
+			return cr, err
+		}
+	}
+	return cr, nil
+}
+
+// plan returns the fields that bring a document from version v to
+// schema.Version, and the versions of the migrations that contributed.
+func plan(coll string, doc Doc, v int) (map[string]any, []int) {
+	working := make(map[string]any, len(doc.Data))
+	for k, val := range doc.Data {
+		working[k] = val
+	}
+	fields := map[string]any{}
+	var applied []int
+	for _, m := range migrations {
+		if m.Version <= v || !slices.Contains(m.Collections, coll) {
+			continue
+		}
+		set := m.Apply(coll, doc.ID, working)
+		if len(set) == 0 {
+			continue
+		}
+		applied = append(applied, m.Version)
+		for k, val := range set {
+			fields[k] = val
+			working[k] = val
+		}
+	}
+	fields["schema_version"] = schema.Version
+	return fields, applied
+}
+
+// Version reads a stored document's schema_version; a document without one
+// is version 0.
+func Version(doc map[string]any) int {
+	switch v := doc["schema_version"].(type) {
+	case int64:
+		return int(v)
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// sortedKeys returns a change's fields in a stable order, for writes that
+// list them.
+func sortedKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,254 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+var testTime = time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+
+func openTestStore(t *testing.T) (*SQLiteStore, *docstore.DB) {
+	t.Helper()
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewSQLiteStore(db), db
+}
+
+// seedWeather stores version 0 cache documents for each ID, a current one
+// and one from a newer schema.
+func seedWeather(t *testing.T, db *docstore.DB, ids ...string) {
+	t.Helper()
+	ctx := context.Background()
+	for _, id := range ids {
+		v0 := map[string]any{"last_updated": testTime, "history": []map[string]any{{"pressure_mb": 1012.5}}}
+		if err := db.Set(ctx, shared.WeatherCacheCollection, id, v0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	current := schema.WeatherCacheDoc{SchemaVersion: schema.Version, Location: "zz-current", LastUpdated: testTime}
+	if err := db.Set(ctx, shared.WeatherCacheCollection, "zz-current", current); err != nil {
+		t.Fatal(err)
+	}
+	newer := map[string]any{"schema_version": schema.Version + 1, "added_later": "keep"}
+	if err := db.Set(ctx, shared.WeatherCacheCollection, "zz-newer", newer); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readCache(t *testing.T, db *docstore.DB, id string) schema.WeatherCacheDoc {
+	t.Helper()
+	var doc schema.WeatherCacheDoc
+	if err := db.Get(context.Background(), shared.WeatherCacheCollection, id, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestRun_DryRunWritesNothing(t *testing.T) {
+	store, db := openTestStore(t)
+	seedWeather(t, db, "house-nick", "house-nita")
+
+	report, err := Run(context.Background(), store, []string{shared.WeatherCacheCollection}, Options{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := report.Collections[0]
+	if !report.DryRun || cr.Scanned != 4 || cr.Changed != 2 || cr.Current != 1 || cr.Newer != 1 || cr.ByMigration[1] != 2 {
+		t.Errorf("report = %+v", cr)
+	}
+	if doc := readCache(t, db, "house-nick"); doc.SchemaVersion != 0 || doc.Location != "" {
+		t.Errorf("dry run wrote %+v", doc)
+	}
+}
+
+func TestRun_MigratesToCurrentVersion(t *testing.T) {
+	store, db := openTestStore(t)
+	seedWeather(t, db, "house-nick", "house-nita")
+	ctx := context.Background()
+	raw := map[string]any{"location": "house-nick", "timestamp": testTime, "pressure_mb": 1012.5}
+	if err := db.Set(ctx, shared.WeatherRawCollection, "r1", raw); err != nil {
+		t.Fatal(err)
+	}
+
+	collections := []string{shared.WeatherRawCollection, shared.WeatherCacheCollection}
+	report, err := Run(ctx, store, collections, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cr := report.Collections[0]; cr.Changed != 1 || cr.ByMigration[1] != 0 {
+		t.Errorf("raw report = %+v, want the version stamp alone", cr)
+	}
+	if cr := report.Collections[1]; cr.Changed != 2 || cr.Sample[0] != "house-nick" {
+		t.Errorf("cache report = %+v", cr)
+	}
+
+	doc := readCache(t, db, "house-nick")
+	if doc.SchemaVersion != schema.Version || doc.Location != "house-nick" {
+		t.Errorf("migrated doc at version %d for %q", doc.SchemaVersion, doc.Location)
+	}
+	if !doc.LastUpdated.Equal(testTime) || len(doc.History) != 1 || doc.History[0].PressureMb != 1012.5 {
+		t.Errorf("migration lost fields: %+v", doc)
+	}
+	var newer map[string]any
+	if err := db.Get(ctx, shared.WeatherCacheCollection, "zz-newer", &newer); err != nil || newer["added_later"] != "keep" || Version(newer) != schema.Version+1 {
+		t.Errorf("newer doc = %v, %v; want it untouched", newer, err)
+	}
+	var migratedRaw schema.WeatherPoint
+	if err := db.Get(ctx, shared.WeatherRawCollection, "r1", &migratedRaw); err != nil || migratedRaw.SchemaVersion != schema.Version || migratedRaw.PressureMb != 1012.5 {
+		t.Errorf("raw doc = %+v, %v", migratedRaw, err)
+	}
+
+	again, err := Run(ctx, store, collections, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cr := again.Collections[1]; cr.Changed != 0 || cr.Current != 3 {
+		t.Errorf("second run = %+v, want nothing left to change", cr)
+	}
+}
+
+// failingStore fails the write of the given page, counting from 1.
+type failingStore struct {
+	Store
+	failPage, pages int
+}
+
+func (s *failingStore) Write(ctx context.Context, collection string, changes []Change) ([]string, error) {
+	if s.pages++; s.pages == s.failPage {
+		return nil, errors.New("connection reset")
+	}
+	return s.Store.Write(ctx, collection, changes)
+}
+
+func TestRun_ResumesFromCursors(t *testing.T) {
+	store, db := openTestStore(t)
+	seedWeather(t, db, "a", "b", "c", "d", "e")
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	collections := []string{shared.WeatherCacheCollection}
+
+	cursors, err := LoadCursors(path, "weather-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Run(ctx, &failingStore{Store: store, failPage: 2}, collections, Options{BatchSize: 2, Cursors: cursors})
+	if err == nil {
+		t.Fatal("Run should report the failed write")
+	}
+
+	cursors, err = LoadCursors(path, "weather-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cursors.Collections[shared.WeatherCacheCollection]; got.After != "b" || got.Done {
+		t.Fatalf("cursor after the failure = %+v, want after b", got)
+	}
+	report, err := Run(ctx, store, collections, Options{BatchSize: 2, Cursors: cursors})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := report.Collections[0]
+	if cr.ResumedAfter != "b" || cr.Scanned != 5 || cr.Changed != 3 {
+		t.Errorf("resumed report = %+v, want c, d and e changed after b", cr)
+	}
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		if doc := readCache(t, db, id); doc.Location != id {
+			t.Errorf("%s not migrated: %+v", id, doc)
+		}
+	}
+
+	cursors, _ = LoadCursors(path, "weather-log")
+	report, err = Run(ctx, store, collections, Options{Cursors: cursors})
+	if err != nil || !report.Collections[0].Skipped {
+		t.Errorf("finished collection should be skipped: %+v, %v", report.Collections[0], err)
+	}
+}
+
+func TestLoadCursors_StartsOverForAnotherDatabase(t *testing.T) {
+	store, db := openTestStore(t)
+	seedWeather(t, db, "a")
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	collections := []string{shared.AlertHistoryCollection}
+
+	cursors, err := LoadCursors(path, "weather-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Run(ctx, store, collections, Options{Cursors: cursors}); err != nil {
+		t.Fatal(err)
+	}
+	if cursors, _ = LoadCursors(path, "weather-log"); !cursors.Collections[shared.AlertHistoryCollection].Done {
+		t.Fatalf("cursors = %+v, want alert_history done for weather-log", cursors.Collections)
+	}
+	// The same file reused for pollen-log, whose alert_history is another
+	// collection of the same name.
+	cursors, err = LoadCursors(path, "pollen-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cursors.Collections) != 0 {
+		t.Errorf("cursors = %+v, want a fresh start for another database", cursors.Collections)
+	}
+}
+
+// racingStore rewrites a document between the read and the write, the way a
+// collector run would.
+type racingStore struct {
+	*SQLiteStore
+	db *docstore.DB
+	id string
+}
+
+func (s *racingStore) Write(ctx context.Context, collection string, changes []Change) ([]string, error) {
+	doc := schema.WeatherCacheDoc{SchemaVersion: schema.Version, Location: s.id, LastUpdated: testTime.Add(time.Hour)}
+	if err := s.db.Set(ctx, collection, s.id, doc); err != nil {
+		return nil, err
+	}
+	return s.SQLiteStore.Write(ctx, collection, changes)
+}
+
+func TestRun_ConcurrentChangeIsAConflict(t *testing.T) {
+	store, db := openTestStore(t)
+	seedWeather(t, db, "house-nick", "house-nita")
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	cursors, err := LoadCursors(path, "weather-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Run(ctx, &racingStore{store, db, "house-nick"}, []string{shared.WeatherCacheCollection}, Options{Cursors: cursors})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cr := report.Collections[0]; cr.Conflicts != 1 || cr.Changed != 1 {
+		t.Errorf("report = %+v, want one conflict and one change", cr)
+	}
+	if doc := readCache(t, db, "house-nick"); !doc.LastUpdated.Equal(testTime.Add(time.Hour)) {
+		t.Errorf("the concurrent write was overwritten: %+v", doc)
+	}
+	cursors, _ = LoadCursors(path, "weather-log")
+	if cur := cursors.Collections[shared.WeatherCacheCollection]; cur.Done {
+		t.Error("a collection with conflicts should be scanned again")
+	}
+}
+
+func TestRegister_RejectsOutOfOrder(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Register should panic on a version already registered")
+		}
+	}()
+	Register(Migration{Version: 1})
+}
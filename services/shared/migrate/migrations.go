@@ -0,0 +1,36 @@
+package migrate
+
+import "github.com/nickfang/personal-dashboard/services/shared"
+
+func init() {
+	// Version 1 stamped every document with schema_version and stored each
+	// cache document's location in the document, where version 0 left it to
+	// the ID. Raw documents always carried theirs.
+	cacheLocation := map[string]string{
+		shared.WeatherCacheCollection:  "location",
+		shared.ForecastCacheCollection: "location",
+		shared.PollenCacheCollection:   "location_id",
+	}
+	Register(Migration{
+		Version:     1,
+		Description: "store the location in cache documents",
+		Collections: []string{
+			shared.WeatherRawCollection,
+			shared.WeatherCacheCollection,
+			shared.ForecastRawCollection,
+			shared.ForecastCacheCollection,
+			shared.PollenRawCollection,
+			shared.PollenCacheCollection,
+		},
+		Apply: func(collection, id string, doc map[string]any) map[string]any {
+			field, ok := cacheLocation[collection]
+			if !ok {
+				return nil
+			}
+			if loc, _ := doc[field].(string); loc != "" {
+				return nil
+			}
+			return map[string]any{field: id}
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+// SQLiteStore migrates the shared docstore file, which holds both
+// databases' collections.
+type SQLiteStore struct {
+	db *docstore.DB
+}
+
+func NewSQLiteStore(db *docstore.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) Page(ctx context.Context, collection, after string, limit int) ([]Doc, error) {
+	found, err := s.db.Documents(ctx, docstore.Query{Collection: collection, AfterID: after, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]Doc, len(found))
+	for i, d := range found {
+		docs[i].ID = d.ID
+		if err := d.DataTo(&docs[i].Data); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// Write applies the page in one transaction. docstore has no update times,
+// so each document is read again under the write lock and compared with what
+// the page held.
+func (s *SQLiteStore) Write(ctx context.Context, collection string, changes []Change) ([]string, error) {
+	var conflicts []string
+	err := s.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		conflicts = nil
+		for _, ch := range changes {
+			var current map[string]any
+			err := tx.Get(collection, ch.Doc.ID, &current)
+			if errors.Is(err, docstore.ErrNotFound) || err == nil && !reflect.DeepEqual(current, ch.Doc.Data) {
+				conflicts = append(conflicts, ch.Doc.ID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			for _, k := range sortedKeys(ch.Fields) {
+				if err := tx.Update(collection, ch.Doc.ID, k, ch.Fields[k]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
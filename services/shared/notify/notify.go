@@ -9,6 +9,8 @@ package notify
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,44 +41,69 @@ type NopSender struct{}
 
 func (NopSender) Send(context.Context, Notification) error { return nil }
 
-// FromAlert renders an alert as a notification.
+// FromAlert renders an alert as a notification, with body times in zone —
+// the alerting location's (shared.Location.TimeZone). A nil zone is UTC.
 //
 // The title stays ASCII: a non-ASCII subject would need RFC 2047 encoded-word
 // wrapping, and Alert.Message is already pure ASCII (see the forecast
 // collector's buildMessage), so honoring this costs nothing.
-func FromAlert(a shared.Alert) Notification {
-	title := fmt.Sprintf("%s (%s) - %s: %s", ruleTitle(a.RuleID), a.Severity, a.Location, a.Message)
+func FromAlert(a shared.Alert, zone *time.Location) Notification {
+	if zone == nil {
+		zone = time.UTC
+	}
+	title := fmt.Sprintf("%s (%s) - %s: %s", title(a), a.Severity, a.Location, a.Message)
 
 	var b strings.Builder
 	fmt.Fprintf(&b, "Location:  %s\n", a.Location)
 	fmt.Fprintf(&b, "Rule:      %s\n", a.RuleID)
 	fmt.Fprintf(&b, "Severity:  %s\n", a.Severity)
-	fmt.Fprintf(&b, "Value:     %+.1f mb (threshold %.1f)\n", a.Value, a.Threshold)
-	fmt.Fprintf(&b, "Window:    %s to %s\n", formatUTC(a.WindowStart), formatUTC(a.WindowEnd))
-	fmt.Fprintf(&b, "Issued at: %s\n", formatUTC(a.IssuedAt))
+	fmt.Fprintf(&b, "Value:     %s\n", formatValue(a))
+	fmt.Fprintf(&b, "Window:    %s to %s\n", formatTime(a.WindowStart, zone), formatTime(a.WindowEnd, zone))
+	fmt.Fprintf(&b, "Issued at: %s\n", formatTime(a.IssuedAt, zone))
 
 	return Notification{Title: title, Body: b.String(), Alert: a}
 }
 
-// ruleTitle turns a rule ID into a human label. Alert is source-agnostic, so
-// this is a lookup rather than a fixed string; an unknown rule falls back to
-// its own ID, which is still readable in a subject line.
-func ruleTitle(ruleID string) string {
-	switch {
-	case strings.HasPrefix(ruleID, "pressure-drop"):
-		return "Pressure drop"
+// title names the alert from its rule: the label with what the rule looks
+// for, as in "Pressure drop" or "High gusts". An alert stored without a
+// label falls back to its rule ID, which is still readable in a subject line.
+func title(a shared.Alert) string {
+	if a.Label == "" {
+		return a.RuleID
+	}
+	var t string
+	switch a.Direction {
+	case "":
+		t = "high " + a.Label
+	case shared.AlertDirectionAny:
+		t = a.Label + " swing"
 	default:
-		return ruleID
+		t = a.Label + " " + a.Direction
 	}
+	return strings.ToUpper(t[:1]) + t[1:]
+}
+
+// formatValue renders an alert's value and threshold in its unit. A change
+// is signed and a level is not. Both are rounded to a tenth and drop a
+// trailing zero, so whole UPI levels and kph do not read as decimals.
+func formatValue(a shared.Alert) string {
+	value := formatNumber(a.Value)
+	if a.Direction != "" && a.Value >= 0 {
+		value = "+" + value
+	}
+	return fmt.Sprintf("%s%s (threshold %s)", value, a.Unit, formatNumber(a.Threshold))
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
 }
 
-// formatUTC renders a timestamp for the body. The body is the precise record;
-// the local-time anchor a reader acts on is already in the title, carried
-// over from Alert.Message, so this does not duplicate the collector's
-// display-zone handling.
-func formatUTC(t time.Time) string {
+// formatTime renders a body timestamp in the location's zone, the same zone
+// the title's anchor (from Alert.Message) is in. The abbreviation stays, so
+// the body remains a precise record across a DST change.
+func formatTime(t time.Time, zone *time.Location) string {
 	if t.IsZero() {
 		return "-"
 	}
-	return t.UTC().Format("2006-01-02 15:04 MST")
+	return t.In(zone).Format("2006-01-02 15:04 MST")
 }
@@ -13,6 +13,10 @@ func testAlert() shared.Alert {
 		ID:          "abc123",
 		Location:    "house-nick",
 		RuleID:      "pressure-drop-3h",
+		Metric:      shared.MetricPressureMb,
+		Direction:   shared.AlertDirectionDrop,
+		Label:       "pressure",
+		Unit:        " mb",
 		Severity:    shared.AlertSeveritySevere,
 		Value:       -6.2,
 		Threshold:   5,
@@ -27,17 +31,28 @@ func testAlert() shared.Alert {
 func TestFromAlert_TitleCarriesTheNotification(t *testing.T) {
 	// The subject is all a phone lock screen shows, so it has to be
 	// actionable on its own.
-	got := FromAlert(testAlert()).Title
+	got := FromAlert(testAlert(), nil).Title
 	want := "Pressure drop (severe) - house-nick: Thu 2 PM  -6.2 mb/3h  -8.1/6h"
 	if got != want {
 		t.Errorf("Title = %q, want %q", got, want)
 	}
 }
 
+func TestFromAlert_ObservedDropIsTitledApart(t *testing.T) {
+	// An observed drop has already happened; the subject should not read
+	// like another forecast.
+	a := testAlert()
+	a.RuleID = "pressure-drop-observed-3h"
+	a.Label = "observed pressure"
+	if got := FromAlert(a, nil).Title; !strings.HasPrefix(got, "Observed pressure drop (severe)") {
+		t.Errorf("Title = %q, want it titled as an observed drop", got)
+	}
+}
+
 func TestFromAlert_TitleIsASCII(t *testing.T) {
 	// A non-ASCII subject would need RFC 2047 encoded-word wrapping, which
 	// buildMessage does not do.
-	title := FromAlert(testAlert()).Title
+	title := FromAlert(testAlert(), nil).Title
 	for i, r := range title {
 		if r > 127 {
 			t.Fatalf("Title has non-ASCII %q at %d: %q", r, i, title)
@@ -45,21 +60,23 @@ func TestFromAlert_TitleIsASCII(t *testing.T) {
 	}
 }
 
-func TestFromAlert_UnknownRuleFallsBackToItsID(t *testing.T) {
+func TestFromAlert_UnlabeledAlertFallsBackToItsRuleID(t *testing.T) {
+	// Alerts stored before labels existed carry none.
 	a := testAlert()
 	a.RuleID = "pollen-spike-1d"
-	if got := FromAlert(a).Title; !strings.HasPrefix(got, "pollen-spike-1d (severe)") {
+	a.Label = ""
+	if got := FromAlert(a, nil).Title; !strings.HasPrefix(got, "pollen-spike-1d (severe)") {
 		t.Errorf("Title = %q, want it to lead with the raw rule ID", got)
 	}
 }
 
 func TestFromAlert_BodyCarriesTheFullRecord(t *testing.T) {
-	body := FromAlert(testAlert()).Body
+	body := FromAlert(testAlert(), nil).Body
 	for _, want := range []string{
 		"house-nick",
 		"pressure-drop-3h",
 		"severe",
-		"-6.2 mb (threshold 5.0)",
+		"-6.2 mb (threshold 5)",
 		"2026-06-12 19:00 UTC to 2026-06-12 22:00 UTC",
 		"2026-06-12 06:00 UTC",
 	} {
@@ -69,9 +86,80 @@ func TestFromAlert_BodyCarriesTheFullRecord(t *testing.T) {
 	}
 }
 
+func TestFromAlert_BodyUsesTheLocationZone(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := FromAlert(testAlert(), chicago).Body
+	for _, want := range []string{
+		"2026-06-12 14:00 CDT to 2026-06-12 17:00 CDT",
+		"Issued at: 2026-06-12 01:00 CDT",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestFromAlert_PollenRulesUseIndexUnits(t *testing.T) {
+	a := testAlert()
+	a.RuleID = "pollen-high-juniper"
+	a.Metric, a.Direction, a.Label, a.Unit = "pollen_upi", "", "juniper pollen", " UPI"
+	a.Value, a.Threshold = 5, 4
+	n := FromAlert(a, nil)
+	if !strings.HasPrefix(n.Title, "High juniper pollen (severe)") {
+		t.Errorf("Title = %q, want the pollen rule label", n.Title)
+	}
+	if !strings.Contains(n.Body, "5 UPI (threshold 4)") {
+		t.Errorf("Body should render the index without a pressure unit:\n%s", n.Body)
+	}
+
+	a.RuleID = "pollen-jump-tree"
+	a.Direction, a.Label, a.Unit = shared.AlertDirectionRise, "tree pollen", " levels"
+	a.Value, a.Threshold = 3, 2
+	n = FromAlert(a, nil)
+	if !strings.HasPrefix(n.Title, "Tree pollen rise (severe)") {
+		t.Errorf("Title = %q, want the pollen rise label", n.Title)
+	}
+	if !strings.Contains(n.Body, "+3 levels (threshold 2)") {
+		t.Errorf("Body should render the rise in levels:\n%s", n.Body)
+	}
+}
+
+func TestFromAlert_WeatherRulesUseTheirUnits(t *testing.T) {
+	tests := []struct {
+		ruleID            string
+		metric, direction string
+		label, unit       string
+		value, threshold  float64
+		title, body       string
+	}{
+		{"pressure-rise-3h", "pressure_mb", "rise", "pressure", " mb", 5.4, 5, "Pressure rise (severe)", "+5.4 mb (threshold 5)"},
+		{"temp-swing-3h", "temp_c", "any", "temp", " C", -9.2, 8, "Temp swing (severe)", "-9.2 C (threshold 8)"},
+		{"wind-gust", "wind_gust_kph", "", "gusts", " kph", 72, 60, "High gusts (severe)", "72 kph (threshold 60)"},
+		{"precip-high", "precipitation_pct", "", "precip", "%", 85, 70, "High precip (severe)", "85% (threshold 70)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ruleID, func(t *testing.T) {
+			a := testAlert()
+			a.RuleID = tt.ruleID
+			a.Metric, a.Direction, a.Label, a.Unit = tt.metric, tt.direction, tt.label, tt.unit
+			a.Value, a.Threshold = tt.value, tt.threshold
+			n := FromAlert(a, nil)
+			if !strings.HasPrefix(n.Title, tt.title) {
+				t.Errorf("Title = %q, want prefix %q", n.Title, tt.title)
+			}
+			if !strings.Contains(n.Body, tt.body) {
+				t.Errorf("Body should contain %q:\n%s", tt.body, n.Body)
+			}
+		})
+	}
+}
+
 func TestBuildMessage_HeadersAndSeparator(t *testing.T) {
 	date := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
-	n := FromAlert(testAlert())
+	n := FromAlert(testAlert(), nil)
 	raw := string(buildMessage("me@gmail.com", "you@gmail.com", n, date))
 
 	headers, body, found := strings.Cut(raw, "\r\n\r\n")
@@ -112,7 +200,7 @@ func TestBuildMessage_SubjectCannotInjectHeaders(t *testing.T) {
 }
 
 func TestNopSender_Succeeds(t *testing.T) {
-	if err := (NopSender{}).Send(t.Context(), FromAlert(testAlert())); err != nil {
+	if err := (NopSender{}).Send(t.Context(), FromAlert(testAlert(), nil)); err != nil {
 		t.Errorf("NopSender.Send() = %v, want nil", err)
 	}
 }
@@ -36,7 +36,7 @@ func TestSend_ContextDeadlineBoundsAHungServer(t *testing.T) {
 	defer cancel()
 
 	done := make(chan error, 1)
-	go func() { done <- s.Send(ctx, FromAlert(testAlert())) }()
+	go func() { done <- s.Send(ctx, FromAlert(testAlert(), nil)) }()
 
 	select {
 	case err := <-done:
@@ -63,7 +63,7 @@ func TestSend_DialThatNeverCompletesReturns(t *testing.T) {
 	defer cancel()
 
 	done := make(chan error, 1)
-	go func() { done <- s.Send(ctx, FromAlert(testAlert())) }()
+	go func() { done <- s.Send(ctx, FromAlert(testAlert(), nil)) }()
 
 	select {
 	case err := <-done:
@@ -85,7 +85,7 @@ func TestSend_DialsTheSubmissionEndpoint(t *testing.T) {
 		},
 	}
 
-	if err := s.Send(t.Context(), FromAlert(testAlert())); err == nil {
+	if err := s.Send(t.Context(), FromAlert(testAlert(), nil)); err == nil {
 		t.Fatal("Send() = nil, want the dial error")
 	}
 	if want := "smtp.gmail.com:587"; got != want {
@@ -103,7 +103,7 @@ func TestSend_ErrorNeverCarriesTheAppPassword(t *testing.T) {
 		},
 	}
 
-	err := s.Send(t.Context(), FromAlert(testAlert()))
+	err := s.Send(t.Context(), FromAlert(testAlert(), nil))
 	if err == nil {
 		t.Fatal("Send() = nil, want an error")
 	}
@@ -0,0 +1,305 @@
+// Package registry loads the monitored locations. In deployed environments
+// they live in the locations collection of the weather database, one document
+// per location keyed by its ID, so adding or disabling a location takes
+// effect on each job's next run without a redeploy. Setting LOCATIONS_FILE
+// reads a JSON file instead, and STORAGE_BACKEND=sqlite keeps the collection
+// in the shared SQLite file, for local runs without Firestore.
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"cloud.google.com/go/firestore"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnvFile names the env var that switches the registry to a JSON file.
+const EnvFile = "LOCATIONS_FILE"
+
+// Errors returned by Store's single-location operations. Validation
+// failures wrap ErrInvalid, with the reason in the message.
+var (
+	ErrNotFound = errors.New("location not found")
+	ErrExists   = errors.New("location already exists")
+	ErrInvalid  = errors.New("invalid location")
+	ErrReadOnly = errors.New("location registry is read-only")
+)
+
+// UpdateFunc edits a location in place. It runs inside the store's
+// transaction and may run more than once on contention, so it must not have
+// side effects.
+type UpdateFunc func(l *shared.Location) error
+
+// Source reads the full registry, disabled locations included. The
+// collection-backed implementations validate each document on its own and
+// skip, with a warning, any that fail, so one malformed location cannot stop
+// every other from running. An empty collection is an empty result, not an
+// error: the last location can be deleted through the API, and each job logs
+// and exits when it has nothing to run. FileSource still rejects a bad or
+// empty file outright, as a local file is fixed by hand before a run.
+type Source interface {
+	Locations(ctx context.Context) ([]shared.Location, error)
+	Close() error
+}
+
+// Store manages individual locations. Writes validate the location first;
+// changes reach each job on its next run, since jobs read the registry at
+// startup.
+type Store interface {
+	Source
+	Get(ctx context.Context, id string) (shared.Location, error)
+	Create(ctx context.Context, l shared.Location) error
+	// Update applies fn to an existing location and returns the result.
+	// The ID cannot change.
+	Update(ctx context.Context, id string, fn UpdateFunc) (shared.Location, error)
+	// Delete removes the registry entry only. Data already collected for
+	// the location stays where it is.
+	Delete(ctx context.Context, id string) error
+}
+
+// Writer is a Store that can also upsert, which is what seeding needs.
+type Writer interface {
+	Store
+	Put(ctx context.Context, l shared.Location) error
+}
+
+// Open returns the file store when LOCATIONS_FILE is set, the SQLite store
+// when STORAGE_BACKEND=sqlite, and the Firestore store otherwise.
+func Open(ctx context.Context, projectID string) (Store, error) {
+	if path := os.Getenv(EnvFile); path != "" {
+		return FileSource{Path: path}, nil
+	}
+	return OpenWriter(ctx, projectID)
+}
+
+// OpenWriter is Open without the read-only file store.
+func OpenWriter(ctx context.Context, projectID string) (Writer, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteSource(db), nil
+	}
+	return NewFirestoreSource(ctx, projectID)
+}
+
+// Load reads the registry once and returns the locations with source
+// enabled. It is what a job calls at startup; a long-running server keeps a
+// Source open instead and reads it per request.
+//
+// An empty result is not an error: every location may legitimately have a
+// source switched off, and the caller decides whether that is worth a run.
+func Load(ctx context.Context, projectID, source string) ([]shared.Location, error) {
+	src, err := Open(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("opening location registry: %w", err)
+	}
+	defer src.Close()
+	all, err := src.Locations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return shared.EnabledFor(all, source), nil
+}
+
+// FileSource reads a JSON array of locations; see services/shared/locations.json.
+// It is read-only: the file is a local stand-in, and the jobs reading it
+// would not see a write made by another process anyway.
+type FileSource struct {
+	Path string
+}
+
+func (s FileSource) Locations(ctx context.Context) ([]shared.Location, error) {
+	return shared.ReadLocationsFile(s.Path)
+}
+
+func (s FileSource) Get(ctx context.Context, id string) (shared.Location, error) {
+	locations, err := s.Locations(ctx)
+	if err != nil {
+		return shared.Location{}, err
+	}
+	for _, l := range locations {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return shared.Location{}, ErrNotFound
+}
+
+func (s FileSource) Create(ctx context.Context, l shared.Location) error { return ErrReadOnly }
+func (s FileSource) Delete(ctx context.Context, id string) error         { return ErrReadOnly }
+
+func (s FileSource) Update(ctx context.Context, id string, fn UpdateFunc) (shared.Location, error) {
+	return shared.Location{}, ErrReadOnly
+}
+
+func (s FileSource) Close() error { return nil }
+
+// FirestoreSource reads the locations collection.
+type FirestoreSource struct {
+	client *firestore.Client
+}
+
+// NewFirestoreSource connects to the weather database, which holds the
+// registry alongside the weather and forecast collections.
+func NewFirestoreSource(ctx context.Context, projectID string) (*FirestoreSource, error) {
+	client, err := firestore.NewClientWithDatabase(ctx, projectID, shared.WeatherDatabaseID)
+	if err != nil {
+		return nil, err
+	}
+	return &FirestoreSource{client: client}, nil
+}
+
+func (s *FirestoreSource) Close() error {
+	return s.client.Close()
+}
+
+// Locations returns every valid document in ID order, or none for an empty
+// collection; invalid documents are skipped as checkDocument describes.
+func (s *FirestoreSource) Locations(ctx context.Context) ([]shared.Location, error) {
+	docs, err := s.client.Collection(shared.LocationsCollection).OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading locations: %w", err)
+	}
+	locations := make([]shared.Location, 0, len(docs))
+	for _, doc := range docs {
+		var l shared.Location
+		err := doc.DataTo(&l)
+		if err == nil {
+			err = checkDocument(doc.Ref.ID, &l)
+		}
+		if err != nil {
+			skipDocument(doc.Ref.ID, err)
+			continue
+		}
+		locations = append(locations, l)
+	}
+	return locations, nil
+}
+
+// checkDocument validates one decoded registry document. A document's ID is
+// the location's ID; an id field that disagrees with it is rejected rather
+// than guessed at, since the ID keys every other collection. Duplicate IDs
+// cannot occur, as document IDs are unique.
+func checkDocument(docID string, l *shared.Location) error {
+	if l.ID == "" {
+		l.ID = docID
+	}
+	if l.ID != docID {
+		return fmt.Errorf("id field %q does not match document id", l.ID)
+	}
+	return l.Validate()
+}
+
+// skipDocument logs a registry document Locations leaves out. It is a
+// warning rather than an error: the other locations still run, and the
+// document can be fixed through the API.
+func skipDocument(docID string, err error) {
+	slog.Warn("Skipping invalid location document", "location", docID, "error", err)
+}
+
+// Put creates or replaces one location's document after validating it.
+func (s *FirestoreSource) Put(ctx context.Context, l shared.Location) error {
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if _, err := s.client.Collection(shared.LocationsCollection).Doc(l.ID).Set(ctx, l); err != nil {
+		return fmt.Errorf("writing location %s: %w", l.ID, err)
+	}
+	return nil
+}
+
+func (s *FirestoreSource) Get(ctx context.Context, id string) (shared.Location, error) {
+	doc, err := s.client.Collection(shared.LocationsCollection).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return shared.Location{}, ErrNotFound
+	}
+	if err != nil {
+		return shared.Location{}, fmt.Errorf("reading location %s: %w", id, err)
+	}
+	var l shared.Location
+	if err := doc.DataTo(&l); err != nil {
+		return shared.Location{}, fmt.Errorf("decoding location %s: %w", id, err)
+	}
+	if l.ID == "" {
+		l.ID = id
+	}
+	return l, nil
+}
+
+// Create fails with ErrExists rather than overwriting, so two clients
+// choosing the same ID cannot silently merge their locations.
+func (s *FirestoreSource) Create(ctx context.Context, l shared.Location) error {
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	_, err := s.client.Collection(shared.LocationsCollection).Doc(l.ID).Create(ctx, l)
+	if status.Code(err) == codes.AlreadyExists {
+		return ErrExists
+	}
+	if err != nil {
+		return fmt.Errorf("creating location %s: %w", l.ID, err)
+	}
+	return nil
+}
+
+// Update reads, edits and writes the location in one transaction, so two
+// concurrent edits to different fields both land, and an update racing a
+// delete does not resurrect the location.
+func (s *FirestoreSource) Update(ctx context.Context, id string, fn UpdateFunc) (shared.Location, error) {
+	ref := s.client.Collection(shared.LocationsCollection).Doc(id)
+	var updated shared.Location
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var l shared.Location
+		if err := doc.DataTo(&l); err != nil {
+			return fmt.Errorf("decoding: %w", err)
+		}
+		if l.ID == "" {
+			l.ID = id
+		}
+		if err := fn(&l); err != nil {
+			return err
+		}
+		if l.ID != id {
+			return fmt.Errorf("%w: id cannot change", ErrInvalid)
+		}
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		updated = l
+		return tx.Set(ref, l)
+	})
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalid) {
+		return shared.Location{}, err
+	}
+	if err != nil {
+		return shared.Location{}, fmt.Errorf("updating location %s: %w", id, err)
+	}
+	return updated, nil
+}
+
+func (s *FirestoreSource) Delete(ctx context.Context, id string) error {
+	_, err := s.client.Collection(shared.LocationsCollection).Doc(id).Delete(ctx, firestore.Exists)
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting location %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+func TestOpen_FileWhenConfigured(t *testing.T) {
+	t.Setenv(EnvFile, "../locations.json")
+	src, err := Open(context.Background(), "unused")
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer src.Close()
+	if _, ok := src.(FileSource); !ok {
+		t.Fatalf("Open() = %T, want FileSource", src)
+	}
+}
+
+func TestLoad_FiltersBySource(t *testing.T) {
+	t.Setenv(EnvFile, "../locations.json")
+	locations, err := Load(context.Background(), "unused", shared.SourceWeather)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(locations) != 3 {
+		t.Errorf("Load() = %d locations, want all 3 enabled", len(locations))
+	}
+}
+
+func TestLoad_InvalidFileFails(t *testing.T) {
+	t.Setenv(EnvFile, "does-not-exist.json")
+	if _, err := Load(context.Background(), "unused", shared.SourceWeather); err == nil {
+		t.Error("Load() should fail when the named file is missing")
+	}
+}
+
+func TestFileSource_GetAndReadOnly(t *testing.T) {
+	src := FileSource{Path: "../locations.json"}
+	ctx := context.Background()
+
+	l, err := src.Get(ctx, "distribution-hall")
+	if err != nil || l.Name == "" {
+		t.Fatalf("Get() = %+v, %v, want the seeded location", l, err)
+	}
+	if _, err := src.Get(ctx, "house-bob"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(unknown) error = %v, want ErrNotFound", err)
+	}
+
+	if err := src.Create(ctx, l); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Create() error = %v, want ErrReadOnly", err)
+	}
+	if _, err := src.Update(ctx, l.ID, func(*shared.Location) error { return nil }); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Update() error = %v, want ErrReadOnly", err)
+	}
+	if err := src.Delete(ctx, l.ID); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Delete() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestOpen_SQLiteWhenSelected(t *testing.T) {
+	t.Setenv(docstore.EnvBackend, docstore.BackendSQLite)
+	t.Setenv(docstore.EnvPath, filepath.Join(t.TempDir(), "registry.db"))
+	ctx := context.Background()
+
+	store, err := Open(ctx, "")
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer store.Close()
+	if _, ok := store.(*SQLiteSource); !ok {
+		t.Fatalf("Open() = %T, want *SQLiteSource", store)
+	}
+
+	seed := shared.Location{ID: "cabin", Lat: 35.6, Long: -83.5, Timezone: "America/New_York"}
+	if err := store.Create(ctx, seed); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if err := store.Create(ctx, seed); !errors.Is(err, ErrExists) {
+		t.Errorf("second Create() error = %v, want ErrExists", err)
+	}
+	if err := store.Create(ctx, shared.Location{ID: "Bad ID"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Create(invalid) error = %v, want ErrInvalid", err)
+	}
+
+	updated, err := store.Update(ctx, "cabin", func(l *shared.Location) error {
+		l.Name = "Cabin"
+		return nil
+	})
+	if err != nil || updated.Name != "Cabin" {
+		t.Fatalf("Update() = %+v, %v", updated, err)
+	}
+	if _, err := store.Update(ctx, "cabin", func(l *shared.Location) error {
+		l.Lat = 95
+		return nil
+	}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("invalid Update() error = %v, want ErrInvalid", err)
+	}
+
+	// A job loading the registry from the same file sees the change.
+	locations, err := Load(ctx, "", shared.SourceWeather)
+	if err != nil || len(locations) != 1 || locations[0].Name != "Cabin" || locations[0].Lat != 35.6 {
+		t.Errorf("Load() = %+v, %v", locations, err)
+	}
+
+	if err := store.Delete(ctx, "cabin"); err != nil {
+		t.Errorf("Delete() error: %v", err)
+	}
+	if _, err := store.Get(ctx, "cabin"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLiteSource_SkipsInvalidDocuments(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "registry.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	src := NewSQLiteSource(db)
+	defer src.Close()
+	ctx := context.Background()
+
+	// Written straight to the collection, as a hand edit in the console
+	// would be, so they skip the validation Create does.
+	docs := map[string]any{
+		"cabin":      shared.Location{ID: "cabin", Lat: 35.6, Long: -83.5, Timezone: "America/New_York"},
+		"house-nick": shared.Location{ID: "house-nick", Lat: 30.26, Long: -97.67, Timezone: "America/Chicago"},
+		"no-zone":    shared.Location{ID: "no-zone", Lat: 30, Long: -97},
+		"renamed":    shared.Location{ID: "other", Lat: 30, Long: -97, Timezone: "America/Chicago"},
+		"garbled":    map[string]any{"lat": "north"},
+	}
+	for id, doc := range docs {
+		if err := db.Set(ctx, shared.LocationsCollection, id, doc); err != nil {
+			t.Fatalf("seeding %s: %v", id, err)
+		}
+	}
+
+	locations, err := src.Locations(ctx)
+	if err != nil {
+		t.Fatalf("Locations() error: %v", err)
+	}
+	if len(locations) != 2 || locations[0].ID != "cabin" || locations[1].ID != "house-nick" {
+		t.Errorf("Locations() = %+v, want only cabin and house-nick", locations)
+	}
+	// The garbled document can still be removed, and a missing one is
+	// reported as such rather than silently succeeding.
+	if err := src.Delete(ctx, "garbled"); err != nil {
+		t.Errorf("Delete(garbled) error: %v", err)
+	}
+	if err := src.Delete(ctx, "garbled"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete(garbled) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,136 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+// SQLiteSource keeps the locations collection in the docstore file, for
+// offline runs with STORAGE_BACKEND=sqlite. Unlike FileSource it is writable,
+// and every service sharing the file sees a change on its next read.
+type SQLiteSource struct {
+	db *docstore.DB
+}
+
+func NewSQLiteSource(db *docstore.DB) *SQLiteSource {
+	return &SQLiteSource{db: db}
+}
+
+func (s *SQLiteSource) Close() error {
+	return s.db.Close()
+}
+
+// Locations returns every valid location in ID order, skipping invalid
+// documents the way FirestoreSource does.
+func (s *SQLiteSource) Locations(ctx context.Context) ([]shared.Location, error) {
+	docs, err := s.db.Documents(ctx, docstore.Query{Collection: shared.LocationsCollection})
+	if err != nil {
+		return nil, fmt.Errorf("reading locations: %w", err)
+	}
+	locations := make([]shared.Location, 0, len(docs))
+	for _, doc := range docs {
+		var l shared.Location
+		err := doc.DataTo(&l)
+		if err == nil {
+			err = checkDocument(doc.ID, &l)
+		}
+		if err != nil {
+			skipDocument(doc.ID, err)
+			continue
+		}
+		locations = append(locations, l)
+	}
+	return locations, nil
+}
+
+// Put creates or replaces one location after validating it.
+func (s *SQLiteSource) Put(ctx context.Context, l shared.Location) error {
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if err := s.db.Set(ctx, shared.LocationsCollection, l.ID, l); err != nil {
+		return fmt.Errorf("writing location %s: %w", l.ID, err)
+	}
+	return nil
+}
+
+func (s *SQLiteSource) Get(ctx context.Context, id string) (shared.Location, error) {
+	var l shared.Location
+	err := s.db.Get(ctx, shared.LocationsCollection, id, &l)
+	if errors.Is(err, docstore.ErrNotFound) {
+		return shared.Location{}, ErrNotFound
+	}
+	if err != nil {
+		return shared.Location{}, fmt.Errorf("reading location %s: %w", id, err)
+	}
+	if l.ID == "" {
+		l.ID = id
+	}
+	return l, nil
+}
+
+func (s *SQLiteSource) Create(ctx context.Context, l shared.Location) error {
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	err := s.db.Create(ctx, shared.LocationsCollection, l.ID, l)
+	if errors.Is(err, docstore.ErrExists) {
+		return ErrExists
+	}
+	if err != nil {
+		return fmt.Errorf("creating location %s: %w", l.ID, err)
+	}
+	return nil
+}
+
+func (s *SQLiteSource) Update(ctx context.Context, id string, fn UpdateFunc) (shared.Location, error) {
+	var updated shared.Location
+	err := s.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		var l shared.Location
+		err := tx.Get(shared.LocationsCollection, id, &l)
+		if errors.Is(err, docstore.ErrNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if l.ID == "" {
+			l.ID = id
+		}
+		if err := fn(&l); err != nil {
+			return err
+		}
+		if l.ID != id {
+			return fmt.Errorf("%w: id cannot change", ErrInvalid)
+		}
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		updated = l
+		return tx.Set(shared.LocationsCollection, id, l)
+	})
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalid) {
+		return shared.Location{}, err
+	}
+	if err != nil {
+		return shared.Location{}, fmt.Errorf("updating location %s: %w", id, err)
+	}
+	return updated, nil
+}
+
+func (s *SQLiteSource) Delete(ctx context.Context, id string) error {
+	err := s.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		return tx.DeleteExisting(shared.LocationsCollection, id)
+	})
+	if errors.Is(err, docstore.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting location %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// AlertRecord is one alert_history document: an alert as it stood when
+// MergeAlerts pruned it from a cache document, stored under the alert's ID.
+// The cache keeps only live episodes, so this is the only record that an
+// episode was raised, how bad it got, and whether anyone was told.
+type AlertRecord struct {
+	SchemaVersion int    `firestore:"schema_version"`
+	ID            string `firestore:"id"`
+	Location      string `firestore:"location"`
+	RuleID        string `firestore:"rule_id"`
+
+	// Status is final: resolved if the condition had left the forecast,
+	// expired if it was still active when its window passed.
+	Status string `firestore:"status"`
+
+	// Severity and Value are from the last detection; the peaks are the
+	// worst of the episode.
+	Severity     string  `firestore:"severity"`
+	Value        float64 `firestore:"value"`
+	PeakSeverity string  `firestore:"peak_severity"`
+	PeakValue    float64 `firestore:"peak_value"`
+	Threshold    float64 `firestore:"threshold"`
+
+	WindowStart time.Time `firestore:"window_start"`
+	WindowEnd   time.Time `firestore:"window_end"`
+	Message     string    `firestore:"message"`
+	IssuedAt    time.Time `firestore:"issued_at"`
+
+	// NotifiedAt is the last delivery; zero if the alert never went out.
+	NotifiedAt time.Time `firestore:"notified_at"`
+	ArchivedAt time.Time `firestore:"archived_at"`
+}
+
+// NewAlertRecord archives a pruned alert at the time of the run that pruned
+// it.
+func NewAlertRecord(a shared.Alert, archivedAt time.Time) AlertRecord {
+	status := a.Status
+	if status == shared.AlertStatusActive {
+		status = shared.AlertStatusExpired
+	}
+	peakValue, peakSeverity := a.Peak()
+	return AlertRecord{
+		SchemaVersion: Version,
+		ID:            a.ID,
+		Location:      a.Location,
+		RuleID:        a.RuleID,
+		Status:        status,
+		Severity:      a.Severity,
+		Value:         a.Value,
+		PeakSeverity:  peakSeverity,
+		PeakValue:     peakValue,
+		Threshold:     a.Threshold,
+		WindowStart:   a.WindowStart,
+		WindowEnd:     a.WindowEnd,
+		Message:       a.Message,
+		IssuedAt:      a.IssuedAt,
+		NotifiedAt:    a.NotifiedAt,
+		ArchivedAt:    archivedAt,
+	}
+}
+
+func (r *AlertRecord) version() int { return r.SchemaVersion }
+func (r *AlertRecord) kind() string { return "alert record" }
+
+func (r *AlertRecord) upgrade(string) {
+	r.SchemaVersion = Version
+}
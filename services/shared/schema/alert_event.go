@@ -0,0 +1,101 @@
+package schema
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// AlertEventRecord is one alert_events document: a single lifecycle
+// transition of one alert, with the alert's state on either side of it.
+// Listing an alert ID's events by At replays why it was, or wasn't, emailed.
+type AlertEventRecord struct {
+	SchemaVersion int       `firestore:"schema_version"`
+	AlertID       string    `firestore:"alert_id"`
+	Type          string    `firestore:"type"`
+	At            time.Time `firestore:"at"`
+	Location      string    `firestore:"location"`
+	RuleID        string    `firestore:"rule_id"`
+
+	// The alert after the transition.
+	Status      string    `firestore:"status"`
+	Severity    string    `firestore:"severity"`
+	Value       float64   `firestore:"value"`
+	Threshold   float64   `firestore:"threshold"`
+	WindowStart time.Time `firestore:"window_start"`
+	WindowEnd   time.Time `firestore:"window_end"`
+	NotifiedAt  time.Time `firestore:"notified_at"`
+
+	// The alert before it; empty for a created event.
+	PrevStatus     string    `firestore:"prev_status"`
+	PrevSeverity   string    `firestore:"prev_severity"`
+	PrevValue      float64   `firestore:"prev_value"`
+	PrevNotifiedAt time.Time `firestore:"prev_notified_at"`
+}
+
+// NewAlertEventRecord stores one event from shared.AlertEvents.
+func NewAlertEventRecord(e shared.AlertEvent) AlertEventRecord {
+	return AlertEventRecord{
+		SchemaVersion:  Version,
+		AlertID:        e.Alert.ID,
+		Type:           e.Type,
+		At:             e.At,
+		Location:       e.Alert.Location,
+		RuleID:         e.Alert.RuleID,
+		Status:         e.Alert.Status,
+		Severity:       e.Alert.Severity,
+		Value:          e.Alert.Value,
+		Threshold:      e.Alert.Threshold,
+		WindowStart:    e.Alert.WindowStart,
+		WindowEnd:      e.Alert.WindowEnd,
+		NotifiedAt:     e.Alert.NotifiedAt,
+		PrevStatus:     e.Prev.Status,
+		PrevSeverity:   e.Prev.Severity,
+		PrevValue:      e.Prev.Value,
+		PrevNotifiedAt: e.Prev.NotifiedAt,
+	}
+}
+
+// DocID is the record's document ID: the alert, the time and the event
+// type. An alert has at most one event of a type per run, so a retried
+// transaction overwrites its own events instead of duplicating them.
+func (r AlertEventRecord) DocID() string {
+	return r.AlertID + "_" + r.At.UTC().Format("20060102T150405.000Z") + "_" + r.Type
+}
+
+func (r *AlertEventRecord) version() int { return r.SchemaVersion }
+func (r *AlertEventRecord) kind() string { return "alert event" }
+
+func (r *AlertEventRecord) upgrade(string) {
+	r.SchemaVersion = Version
+}
+
+// SetFunc writes one document inside the caller's transaction. A docstore
+// Tx's Set is one; a Firestore writer wraps tx.Set on the collection's Doc.
+type SetFunc func(collection, id string, v any) error
+
+// RecordAlertChanges archives the alerts the merge from prev to next pruned
+// to alert_history, and appends every transition between them to
+// alert_events, all through set, so they commit with next. at is the run's
+// time, both the archive time and each event's.
+func RecordAlertChanges(set SetFunc, prev, next []shared.Alert, at time.Time) error {
+	for _, a := range shared.PrunedAlerts(prev, next) {
+		if err := set(shared.AlertHistoryCollection, a.ID, NewAlertRecord(a, at)); err != nil {
+			return fmt.Errorf("archiving alert %s: %w", a.ID, err)
+		}
+	}
+	return RecordEvents(set, shared.AlertEvents(prev, next, at))
+}
+
+// RecordEvents appends events to alert_events through set. Writers that only
+// stamp delivery, and so prune nothing, call it directly.
+func RecordEvents(set SetFunc, events []shared.AlertEvent) error {
+	for _, e := range events {
+		rec := NewAlertEventRecord(e)
+		if err := set(shared.AlertEventsCollection, rec.DocID(), rec); err != nil {
+			return fmt.Errorf("recording %s event for alert %s: %w", e.Type, e.Alert.ID, err)
+		}
+	}
+	return nil
+}
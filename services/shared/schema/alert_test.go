@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+func TestNewAlertRecord(t *testing.T) {
+	at := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	alert := shared.Alert{
+		ID:           "alert-1",
+		Location:     "house-nick",
+		RuleID:       "pressure-drop-3h",
+		Severity:     shared.AlertSeverityWarning,
+		Value:        -5.5,
+		PeakValue:    -8,
+		PeakSeverity: shared.AlertSeveritySevere,
+		Status:       shared.AlertStatusActive,
+		NotifiedAt:   at.Add(-6 * time.Hour),
+	}
+
+	r := NewAlertRecord(alert, at)
+	if r.Status != shared.AlertStatusExpired {
+		t.Errorf("Status = %q, want an active alert archived as expired", r.Status)
+	}
+	if r.PeakValue != -8 || r.PeakSeverity != shared.AlertSeveritySevere || r.Value != -5.5 {
+		t.Errorf("numbers = %v (peak %v/%q), want the last detection and the episode's peak", r.Value, r.PeakValue, r.PeakSeverity)
+	}
+	if r.SchemaVersion != Version || r.ID != "alert-1" || !r.ArchivedAt.Equal(at) || !r.NotifiedAt.Equal(alert.NotifiedAt) {
+		t.Errorf("record = %+v", r)
+	}
+
+	alert.Status = shared.AlertStatusResolved
+	if r := NewAlertRecord(alert, at); r.Status != shared.AlertStatusResolved {
+		t.Errorf("Status = %q, want a resolved alert to stay resolved", r.Status)
+	}
+}
+
+func TestNewAlertEventRecord(t *testing.T) {
+	at := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	prev := shared.Alert{ID: "alert-1", Location: "house-nick", RuleID: "pressure-drop-3h", Status: shared.AlertStatusActive, Value: -6, Severity: shared.AlertSeverityWarning, NotifiedAt: at.Add(-6 * time.Hour)}
+	next := prev
+	next.Value, next.Severity, next.NotifiedAt = -9, shared.AlertSeveritySevere, time.Time{}
+
+	r := NewAlertEventRecord(shared.AlertEvent{Type: shared.AlertEventEscalated, At: at, Alert: next, Prev: prev})
+	if r.SchemaVersion != Version || r.AlertID != "alert-1" || r.Type != shared.AlertEventEscalated || !r.At.Equal(at) {
+		t.Errorf("record = %+v", r)
+	}
+	if r.Value != -9 || r.PrevValue != -6 || r.PrevSeverity != shared.AlertSeverityWarning || !r.NotifiedAt.IsZero() || !r.PrevNotifiedAt.Equal(prev.NotifiedAt) {
+		t.Errorf("record = %+v, want the alert on both sides of the escalation", r)
+	}
+	if got, want := r.DocID(), "alert-1_20260612T060000.000Z_escalated"; got != want {
+		t.Errorf("DocID = %q, want %q", got, want)
+	}
+}
+
+func TestRecordAlertChanges(t *testing.T) {
+	at := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	gone := shared.Alert{ID: "gone", Status: shared.AlertStatusActive, WindowEnd: at.Add(-time.Hour)}
+	kept := shared.Alert{ID: "kept", Status: shared.AlertStatusActive}
+	written := map[string][]string{}
+	set := func(collection, id string, v any) error {
+		written[collection] = append(written[collection], id)
+		return nil
+	}
+
+	if err := RecordAlertChanges(set, []shared.Alert{gone, kept}, []shared.Alert{kept}, at); err != nil {
+		t.Fatal(err)
+	}
+	if h := written[shared.AlertHistoryCollection]; len(h) != 1 || h[0] != "gone" {
+		t.Errorf("alert_history writes = %v, want the pruned alert", h)
+	}
+	pruned := NewAlertEventRecord(shared.AlertEvent{Type: shared.AlertEventPruned, At: at, Alert: gone, Prev: gone})
+	if e := written[shared.AlertEventsCollection]; len(e) != 1 || e[0] != pruned.DocID() {
+		t.Errorf("alert_events writes = %v, want its pruned event", e)
+	}
+}
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// ForecastPoint is one forecast hour.
+type ForecastPoint struct {
+	ValidTime time.Time `firestore:"valid_time"`
+
+	HumidityPercent      int     `firestore:"humidity_pct"`
+	PrecipitationPercent int     `firestore:"precipitation_pct"`
+	UVIndex              int     `firestore:"uv_index"`
+	PressureMb           float64 `firestore:"pressure_mb"`
+	WindDirDeg           int     `firestore:"wind_dir_deg"`
+
+	TempC        float64 `firestore:"temp_c"`
+	TempFeelC    float64 `firestore:"temp_feel_c"`
+	DewpointC    float64 `firestore:"dewpoint_c"`
+	WindSpeedKph float64 `firestore:"wind_speed_kph"`
+	WindGustKph  float64 `firestore:"wind_gust_kph"`
+
+	TempF     float64 `firestore:"temp_f"`
+	TempFeelF float64 `firestore:"temp_feel_f"`
+	DewpointF float64 `firestore:"dewpoint_f"`
+}
+
+// ForecastRun is one append-only audit record in forecast_raw: the full
+// forecast snapshot produced by a single collector run for one location.
+type ForecastRun struct {
+	SchemaVersion int             `firestore:"schema_version"`
+	Location      string          `firestore:"location"`
+	IssuedAt      time.Time       `firestore:"issued_at"`
+	Points        []ForecastPoint `firestore:"points"`
+}
+
+func (r *ForecastRun) version() int { return r.SchemaVersion }
+func (r *ForecastRun) kind() string { return "forecast run" }
+
+func (r *ForecastRun) upgrade(string) {
+	r.SchemaVersion = Version
+}
+
+// ForecastCacheDoc is the latest forecast for a location, stored at
+// forecast_cache/{locationID} and fully replaced on each run. The notifier
+// updates Alerts alone, to record delivery.
+type ForecastCacheDoc struct {
+	SchemaVersion int             `firestore:"schema_version"`
+	Location      string          `firestore:"location"`
+	IssuedAt      time.Time       `firestore:"issued_at"`
+	Points        []ForecastPoint `firestore:"points"`
+	Alerts        []shared.Alert  `firestore:"alerts"`
+}
+
+func (d *ForecastCacheDoc) version() int { return d.SchemaVersion }
+func (d *ForecastCacheDoc) kind() string { return "forecast cache" }
+
+func (d *ForecastCacheDoc) upgrade(id string) {
+	if d.Location == "" {
+		d.Location = id
+	}
+	d.SchemaVersion = Version
+}
@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// MaxPollenHistory caps PollenCacheDoc.History: 14 days × 2 readings/day.
+const MaxPollenHistory = 28
+
+type PollenType struct {
+	Code     string `firestore:"code"`
+	Index    int    `firestore:"index"`
+	Category string `firestore:"category"`
+	InSeason bool   `firestore:"in_season"`
+}
+
+type PollenPlant struct {
+	Code        string `firestore:"code"`
+	DisplayName string `firestore:"display_name"`
+	Index       int    `firestore:"index"`
+	Category    string `firestore:"category"`
+	InSeason    bool   `firestore:"in_season"`
+}
+
+// PollenSnapshot is one reading: a pollen_raw document, and the current
+// reading and history entries in pollen_cache. Written by pollen-collector.
+type PollenSnapshot struct {
+	SchemaVersion   int           `firestore:"schema_version"`
+	LocationID      string        `firestore:"location_id"`
+	CollectedAt     time.Time     `firestore:"collected_at"`
+	OverallIndex    int           `firestore:"overall_index"`
+	OverallCategory string        `firestore:"overall_category"`
+	DominantType    string        `firestore:"dominant_type"`
+	Types           []PollenType  `firestore:"types"`
+	Plants          []PollenPlant `firestore:"plants"`
+}
+
+func (s *PollenSnapshot) version() int { return s.SchemaVersion }
+func (s *PollenSnapshot) kind() string { return "pollen snapshot" }
+
+func (s *PollenSnapshot) upgrade(string) {
+	s.SchemaVersion = Version
+}
+
+// PollenForecastDay is one day of the API's outlook. Date is the calendar
+// date at the location as the API reports it ("2026-04-02"), kept as a string
+// because a timestamp would pin it to a zone the API never specified.
+type PollenForecastDay struct {
+	Date            string        `firestore:"date"`
+	OverallIndex    int           `firestore:"overall_index"`
+	OverallCategory string        `firestore:"overall_category"`
+	DominantType    string        `firestore:"dominant_type"`
+	Types           []PollenType  `firestore:"types"`
+	Plants          []PollenPlant `firestore:"plants"`
+}
+
+// PollenCacheDoc is pollen_cache/{locationID}, rewritten by pollen-collector
+// on every run. Documents written before the collector stored an outlook
+// have no Forecast.
+type PollenCacheDoc struct {
+	SchemaVersion int                 `firestore:"schema_version"`
+	LocationID    string              `firestore:"location_id"` // since version 1
+	LastUpdated   time.Time           `firestore:"last_updated"`
+	CurrentValue  PollenSnapshot      `firestore:"current"`
+	History       []PollenSnapshot    `firestore:"history"`
+	Forecast      []PollenForecastDay `firestore:"forecast"` // Latest outlook, today first; replaced every run
+	Alerts        []shared.Alert      `firestore:"alerts"`
+}
+
+func (d *PollenCacheDoc) version() int { return d.SchemaVersion }
+func (d *PollenCacheDoc) kind() string { return "pollen cache" }
+
+func (d *PollenCacheDoc) upgrade(id string) {
+	if d.LocationID == "" {
+		d.LocationID = id
+	}
+	d.SchemaVersion = Version
+}
@@ -0,0 +1,202 @@
+package schema
+
+import (
+	"sort"
+	"time"
+)
+
+// Stats summarises Count samples of one field. Two summaries merge exactly,
+// which is what lets a rollup absorb originals over more than one run.
+type Stats struct {
+	Count int     `firestore:"count"`
+	Min   float64 `firestore:"min"`
+	Max   float64 `firestore:"max"`
+	Mean  float64 `firestore:"mean"`
+}
+
+// Add folds in one sample.
+func (s *Stats) Add(v float64) {
+	s.Merge(sample(v))
+}
+
+func sample(v float64) Stats {
+	return Stats{Count: 1, Min: v, Max: v, Mean: v}
+}
+
+// Merge folds in another summary.
+func (s *Stats) Merge(o Stats) {
+	if o.Count == 0 {
+		return
+	}
+	if s.Count == 0 {
+		*s = o
+		return
+	}
+	n := s.Count + o.Count
+	s.Mean = (s.Mean*float64(s.Count) + o.Mean*float64(o.Count)) / float64(n)
+	s.Min = min(s.Min, o.Min)
+	s.Max = max(s.Max, o.Max)
+	s.Count = n
+}
+
+// WeatherRollup is one location's observations for one calendar day at the
+// location, stored at weather_rollup/{location}_{date} once the weather_raw
+// documents it summarises are deleted. Written by archive-retention.
+type WeatherRollup struct {
+	SchemaVersion int       `firestore:"schema_version"`
+	Location      string    `firestore:"location"`
+	Date          string    `firestore:"date"`  // "2026-06-12" at the location
+	Start         time.Time `firestore:"start"` // local midnight starting Date
+	First         time.Time `firestore:"first"` // earliest observation included
+	Last          time.Time `firestore:"last"`  // latest observation included
+	Observations  int       `firestore:"observations"`
+
+	HumidityPercent      Stats `firestore:"humidity_pct"`
+	PrecipitationPercent Stats `firestore:"precipitation_pct"`
+	UVIndex              Stats `firestore:"uv_index"`
+	PressureMb           Stats `firestore:"pressure_mb"`
+
+	TempC        Stats `firestore:"temp_c"`
+	TempFeelC    Stats `firestore:"temp_feel_c"`
+	DewpointC    Stats `firestore:"dewpoint_c"`
+	WindSpeedKph Stats `firestore:"wind_speed_kph"`
+	WindGustKph  Stats `firestore:"wind_gust_kph"`
+	VisibilityKm Stats `firestore:"visibility_km"`
+
+	TempF        Stats `firestore:"temp_f"`
+	TempFeelF    Stats `firestore:"temp_feel_f"`
+	WindSpeedMph Stats `firestore:"wind_speed_mph"`
+	WindGustMph  Stats `firestore:"wind_gust_mph"`
+	VisibilityM  Stats `firestore:"visibility_miles"`
+	DewpointF    Stats `firestore:"dewpoint_f"`
+}
+
+func (r *WeatherRollup) version() int { return r.SchemaVersion }
+func (r *WeatherRollup) kind() string { return "weather rollup" }
+
+func (r *WeatherRollup) upgrade(string) {
+	r.SchemaVersion = Version
+}
+
+// stats lists the summarised fields in the order values returns them.
+func (r *WeatherRollup) stats() []*Stats {
+	return []*Stats{
+		&r.HumidityPercent, &r.PrecipitationPercent, &r.UVIndex, &r.PressureMb,
+		&r.TempC, &r.TempFeelC, &r.DewpointC, &r.WindSpeedKph, &r.WindGustKph, &r.VisibilityKm,
+		&r.TempF, &r.TempFeelF, &r.WindSpeedMph, &r.WindGustMph, &r.VisibilityM, &r.DewpointF,
+	}
+}
+
+func (p WeatherPoint) values() []float64 {
+	return []float64{
+		float64(p.HumidityPercent), float64(p.PrecipitationPercent), float64(p.UVIndex), p.PressureMb,
+		p.TempC, p.TempFeelC, p.DewpointC, p.WindSpeedKph, p.WindGustKph, p.VisibilityKm,
+		p.TempF, p.TempFeelF, p.WindSpeedMph, p.WindGustMph, p.VisibilityM, p.DewpointF,
+	}
+}
+
+// Add folds in one observation. The caller sets the identifying fields.
+func (r *WeatherRollup) Add(p WeatherPoint) {
+	mine := r.stats()
+	for i, v := range p.values() {
+		mine[i].Add(v)
+	}
+	r.Observations++
+	r.First, r.Last = widen(r.First, r.Last, p.Timestamp, p.Timestamp)
+}
+
+// Merge folds in another rollup of the same location and day.
+func (r *WeatherRollup) Merge(o WeatherRollup) {
+	theirs := o.stats()
+	for i, s := range r.stats() {
+		s.Merge(*theirs[i])
+	}
+	r.Observations += o.Observations
+	r.First, r.Last = widen(r.First, r.Last, o.First, o.Last)
+}
+
+// IndexStats summarises one pollen type's or plant's index.
+type IndexStats struct {
+	Code  string `firestore:"code"`
+	Index Stats  `firestore:"index"`
+}
+
+// PollenRollup is one location's pollen readings for one calendar day at the
+// location, stored at pollen_rollup/{location}_{date} once the pollen_raw
+// documents it summarises are deleted. Written by archive-retention.
+type PollenRollup struct {
+	SchemaVersion int          `firestore:"schema_version"`
+	LocationID    string       `firestore:"location_id"`
+	Date          string       `firestore:"date"`  // "2026-06-12" at the location
+	Start         time.Time    `firestore:"start"` // local midnight starting Date
+	First         time.Time    `firestore:"first"` // earliest snapshot included
+	Last          time.Time    `firestore:"last"`  // latest snapshot included
+	Snapshots     int          `firestore:"snapshots"`
+	OverallIndex  Stats        `firestore:"overall_index"`
+	Types         []IndexStats `firestore:"types"`  // Sorted by Code
+	Plants        []IndexStats `firestore:"plants"` // Sorted by Code
+}
+
+func (r *PollenRollup) version() int { return r.SchemaVersion }
+func (r *PollenRollup) kind() string { return "pollen rollup" }
+
+func (r *PollenRollup) upgrade(string) {
+	r.SchemaVersion = Version
+}
+
+// Add folds in one snapshot. The caller sets the identifying fields.
+func (r *PollenRollup) Add(s PollenSnapshot) {
+	o := PollenRollup{
+		Snapshots:    1,
+		First:        s.CollectedAt,
+		Last:         s.CollectedAt,
+		OverallIndex: sample(float64(s.OverallIndex)),
+	}
+	for _, t := range s.Types {
+		o.Types = append(o.Types, IndexStats{Code: t.Code, Index: sample(float64(t.Index))})
+	}
+	for _, p := range s.Plants {
+		o.Plants = append(o.Plants, IndexStats{Code: p.Code, Index: sample(float64(p.Index))})
+	}
+	r.Merge(o)
+}
+
+// Merge folds in another rollup of the same location and day.
+func (r *PollenRollup) Merge(o PollenRollup) {
+	r.OverallIndex.Merge(o.OverallIndex)
+	r.Types = mergeIndexes(r.Types, o.Types)
+	r.Plants = mergeIndexes(r.Plants, o.Plants)
+	r.Snapshots += o.Snapshots
+	r.First, r.Last = widen(r.First, r.Last, o.First, o.Last)
+}
+
+func mergeIndexes(a, b []IndexStats) []IndexStats {
+	byCode := make(map[string]*Stats, len(a)+len(b))
+	for _, list := range [][]IndexStats{a, b} {
+		for _, is := range list {
+			s, ok := byCode[is.Code]
+			if !ok {
+				s = &Stats{}
+				byCode[is.Code] = s
+			}
+			s.Merge(is.Index)
+		}
+	}
+	out := make([]IndexStats, 0, len(byCode))
+	for code, s := range byCode {
+		out = append(out, IndexStats{Code: code, Index: *s})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// widen extends [first, last] to cover [from, to]; a zero bound is unset.
+func widen(first, last, from, to time.Time) (time.Time, time.Time) {
+	if first.IsZero() || (!from.IsZero() && from.Before(first)) {
+		first = from
+	}
+	if to.After(last) {
+		last = to
+	}
+	return first, last
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStats_MergeMatchesAddingEverySample(t *testing.T) {
+	samples := []float64{1012, 1009.5, 1015, 1011, 1008}
+	var all, a, b Stats
+	for i, v := range samples {
+		all.Add(v)
+		if i < 2 {
+			a.Add(v)
+		} else {
+			b.Add(v)
+		}
+	}
+	a.Merge(b)
+	if a.Count != all.Count || a.Min != 1008 || a.Max != 1015 || math.Abs(a.Mean-all.Mean) > 1e-9 {
+		t.Errorf("merged = %+v, want %+v", a, all)
+	}
+	var empty Stats
+	empty.Merge(Stats{})
+	if empty.Count != 0 {
+		t.Errorf("merging two empty summaries = %+v", empty)
+	}
+}
+
+func TestWeatherRollup_AddAndMerge(t *testing.T) {
+	start := time.Date(2026, 6, 12, 5, 0, 0, 0, time.UTC)
+	var whole, early, late WeatherRollup
+	for h, p := range []float64{1012, 1010, 1008, 1011} {
+		point := WeatherPoint{Timestamp: start.Add(time.Duration(h) * time.Hour), PressureMb: p, TempC: float64(20 + h), HumidityPercent: 50 + h}
+		whole.Add(point)
+		if h < 2 {
+			early.Add(point)
+		} else {
+			late.Add(point)
+		}
+	}
+	late.Merge(early)
+	if late.Observations != 4 || !late.First.Equal(start) || !late.Last.Equal(start.Add(3*time.Hour)) {
+		t.Errorf("merged bounds = %d observations, %v..%v", late.Observations, late.First, late.Last)
+	}
+	if late.PressureMb != whole.PressureMb || late.TempC != whole.TempC || late.HumidityPercent != whole.HumidityPercent {
+		t.Errorf("merged stats differ:\n%+v\n%+v", late, whole)
+	}
+	if whole.PressureMb.Min != 1008 || whole.PressureMb.Max != 1012 || whole.TempC.Mean != 21.5 {
+		t.Errorf("pressure = %+v, temp = %+v", whole.PressureMb, whole.TempC)
+	}
+}
+
+func TestPollenRollup_MergesByCode(t *testing.T) {
+	at := time.Date(2026, 4, 2, 11, 0, 0, 0, time.UTC)
+	var r PollenRollup
+	r.Add(PollenSnapshot{CollectedAt: at, OverallIndex: 3, Types: []PollenType{{Code: "TREE", Index: 3}, {Code: "GRASS", Index: 1}}})
+	r.Add(PollenSnapshot{CollectedAt: at.Add(8 * time.Hour), OverallIndex: 4, Types: []PollenType{{Code: "TREE", Index: 4}}})
+
+	if r.Snapshots != 2 || r.OverallIndex.Max != 4 || r.OverallIndex.Mean != 3.5 {
+		t.Errorf("overall = %+v over %d snapshots", r.OverallIndex, r.Snapshots)
+	}
+	if len(r.Types) != 2 || r.Types[0].Code != "GRASS" || r.Types[0].Index.Count != 1 || r.Types[1].Index.Mean != 3.5 {
+		t.Errorf("types = %+v, want GRASS once and TREE twice, sorted", r.Types)
+	}
+}
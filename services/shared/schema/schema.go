@@ -0,0 +1,75 @@
+// Package schema is the one definition of every document a collector writes
+// and another service reads: weather_raw and weather_cache, forecast_raw and
+// forecast_cache, pollen_raw and pollen_cache, the rollups archive-retention
+// replaces aged raw documents with, the alert_history records collectors
+// archive pruned alerts to, and the alert_events lifecycle log. Writers and
+// readers share these structs, so a field a collector adds is a field every
+// reader decodes.
+//
+// Each top-level document records the Version it was written at in
+// schema_version. Documents from before the field existed read as version 0.
+// Decoding a document is not enough on its own; pass it through Read (or
+// Rewrite, before writing it back), which brings older documents up to the
+// current shape and flags newer ones:
+//
+//   - Older: upgraded in memory. Version 1 added the location to each cache
+//     document, which version 0 documents leave to the document ID.
+//   - Newer: written by a build that knows fields this one does not. Read
+//     logs a warning and serves what it can decode; Rewrite refuses, because
+//     writing the document back would drop those fields.
+//
+// Bump Version whenever a change needs more than a new field with a usable
+// zero value, and teach upgrade to fill the gap for the versions before it.
+// To write the upgrade back to stored documents as well, register a
+// migration for the new version in package migrate.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Version is the schema this build writes.
+const Version = 1
+
+// ErrNewerVersion reports a document written by a newer schema than this
+// build knows.
+var ErrNewerVersion = errors.New("document has a newer schema version")
+
+// Document is a top-level stored document. Only this package's types
+// implement it.
+type Document interface {
+	version() int
+	kind() string
+	// upgrade fills what an older version left out. id is the document ID.
+	upgrade(id string)
+}
+
+// Read prepares a decoded document for reading: an older version is upgraded
+// in place, and a newer one is logged once per kind and otherwise read as is.
+func Read(id string, doc Document) {
+	if v := doc.version(); v > Version {
+		if _, seen := warned.LoadOrStore(doc.kind(), true); !seen {
+			slog.Warn("Reading a document from a newer schema; fields it added are ignored",
+				"kind", doc.kind(), "id", id, "version", v, "supported", Version)
+		}
+		return
+	}
+	doc.upgrade(id)
+}
+
+// Rewrite prepares a decoded document to be modified and written back. It
+// upgrades an older version like Read, and returns ErrNewerVersion for a
+// newer one, whose unknown fields the write would drop.
+func Rewrite(id string, doc Document) error {
+	if v := doc.version(); v > Version {
+		return fmt.Errorf("%w: %s %s is version %d, this build writes %d", ErrNewerVersion, doc.kind(), id, v, Version)
+	}
+	doc.upgrade(id)
+	return nil
+}
+
+// warned holds the kinds Read has already logged a newer version for.
+var warned sync.Map
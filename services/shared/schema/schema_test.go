@@ -0,0 +1,183 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+)
+
+// documents lists every top-level document type.
+var documents = []Document{
+	&WeatherPoint{},
+	&WeatherCacheDoc{},
+	&ForecastRun{},
+	&ForecastCacheDoc{},
+	&PollenSnapshot{},
+	&PollenCacheDoc{},
+	&WeatherRollup{},
+	&PollenRollup{},
+	&AlertRecord{},
+	&AlertEventRecord{},
+}
+
+func TestRead_UpgradesVersionZero(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      Document
+		location func(Document) string
+	}{
+		{"weather cache", &WeatherCacheDoc{}, func(d Document) string { return d.(*WeatherCacheDoc).Location }},
+		{"forecast cache", &ForecastCacheDoc{}, func(d Document) string { return d.(*ForecastCacheDoc).Location }},
+		{"pollen cache", &PollenCacheDoc{}, func(d Document) string { return d.(*PollenCacheDoc).LocationID }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Read("house-nick", tt.doc)
+			if got := tt.location(tt.doc); got != "house-nick" {
+				t.Errorf("location = %q, want the document ID", got)
+			}
+			if v := tt.doc.version(); v != Version {
+				t.Errorf("version = %d after upgrade, want %d", v, Version)
+			}
+		})
+	}
+}
+
+func TestRead_KeepsStoredLocation(t *testing.T) {
+	doc := &ForecastCacheDoc{SchemaVersion: Version, Location: "house-nita"}
+	Read("house-nick", doc)
+	if doc.Location != "house-nita" {
+		t.Errorf("Location = %q, want the stored value", doc.Location)
+	}
+}
+
+func TestNewerVersion(t *testing.T) {
+	for _, doc := range []Document{
+		&WeatherCacheDoc{SchemaVersion: Version + 1},
+		&ForecastCacheDoc{SchemaVersion: Version + 1},
+		&PollenCacheDoc{SchemaVersion: Version + 1},
+	} {
+		Read("house-nick", doc)
+		if doc.version() != Version+1 {
+			t.Errorf("Read changed the version of a newer %s to %d", doc.kind(), doc.version())
+		}
+		if err := Rewrite("house-nick", doc); !errors.Is(err, ErrNewerVersion) {
+			t.Errorf("Rewrite(newer %s) = %v, want ErrNewerVersion", doc.kind(), err)
+		}
+	}
+	if err := Rewrite("house-nick", &WeatherCacheDoc{}); err != nil {
+		t.Errorf("Rewrite(version 0) = %v, want nil", err)
+	}
+}
+
+// TestRoundTrip stores each cache document the way the collectors do and
+// reads it back the way the providers do: every field has to survive.
+func TestRoundTrip(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "schema.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	at := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+	delta := -1.5
+
+	tests := []struct {
+		name  string
+		write Document
+		read  Document
+	}{
+		{
+			"weather cache",
+			&WeatherCacheDoc{
+				SchemaVersion: Version,
+				Location:      "house-nick",
+				LastUpdated:   at,
+				CurrentValue:  WeatherPoint{SchemaVersion: Version, Location: "house-nick", Timestamp: at, PressureMb: 1012.5, VisibilityM: 6.2},
+				Analysis:      PressureStats{Timestamp: at, Delta3h: &delta, Trend: "falling"},
+				History:       []PressurePoint{{Timestamp: at, PressureMb: 1012.5, DewpointF: 55}},
+			},
+			&WeatherCacheDoc{},
+		},
+		{
+			"forecast cache",
+			&ForecastCacheDoc{
+				SchemaVersion: Version,
+				Location:      "house-nick",
+				IssuedAt:      at,
+				Points:        []ForecastPoint{{ValidTime: at, PressureMb: 1011, WindGustKph: 30}},
+				Alerts:        []shared.Alert{{ID: "a1", Location: "house-nick", RuleID: "pressure-drop-3h", IssuedAt: at}},
+			},
+			&ForecastCacheDoc{},
+		},
+		{
+			"pollen cache",
+			&PollenCacheDoc{
+				SchemaVersion: Version,
+				LocationID:    "house-nick",
+				LastUpdated:   at,
+				CurrentValue:  PollenSnapshot{SchemaVersion: Version, LocationID: "house-nick", CollectedAt: at, Types: []PollenType{{Code: "TREE", Index: 3}}},
+				Forecast:      []PollenForecastDay{{Date: "2026-06-12", Plants: []PollenPlant{{Code: "OAK", InSeason: true}}}},
+				Alerts:        []shared.Alert{{ID: "a2", Location: "house-nick"}},
+			},
+			&PollenCacheDoc{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := db.Set(ctx, "roundtrip", tt.name, tt.write); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Get(ctx, "roundtrip", tt.name, tt.read); err != nil {
+				t.Fatal(err)
+			}
+			Read(tt.name, tt.read)
+			if !reflect.DeepEqual(tt.write, tt.read) {
+				t.Errorf("read back\n%+v\nwant\n%+v", tt.read, tt.write)
+			}
+		})
+	}
+}
+
+// TestFirestoreTags holds every stored field to an explicit, unique name:
+// renaming a Go field must not rename what is stored.
+func TestFirestoreTags(t *testing.T) {
+	pkg := reflect.TypeOf(WeatherPoint{}).PkgPath()
+	seen := map[reflect.Type]bool{}
+	var check func(reflect.Type)
+	check = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || typ.PkgPath() != pkg || seen[typ] {
+			return
+		}
+		seen[typ] = true
+		names := map[string]string{}
+		for i := range typ.NumField() {
+			f := typ.Field(i)
+			tag := f.Tag.Get("firestore")
+			if tag == "" || tag == "-" {
+				t.Errorf("%s.%s has no firestore name", typ.Name(), f.Name)
+				continue
+			}
+			if other, ok := names[tag]; ok {
+				t.Errorf("%s.%s and %s are both stored as %q", typ.Name(), f.Name, other, tag)
+			}
+			names[tag] = f.Name
+			check(f.Type)
+		}
+	}
+	for _, doc := range documents {
+		check(reflect.TypeOf(doc))
+	}
+	if len(seen) < len(documents) {
+		t.Errorf("checked %d types, want at least the %d documents", len(seen), len(documents))
+	}
+}
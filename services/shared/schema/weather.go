@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+)
+
+// WeatherPoint is one observation: a weather_raw document, and the current
+// reading in weather_cache. Written by weather-collector.
+type WeatherPoint struct {
+	SchemaVersion int       `firestore:"schema_version"`
+	Location      string    `firestore:"location"`
+	Timestamp     time.Time `firestore:"timestamp"`
+
+	HumidityPercent      int     `firestore:"humidity_pct"`
+	PrecipitationPercent int     `firestore:"precipitation_pct"`
+	UVIndex              int     `firestore:"uv_index"`
+	PressureMb           float64 `firestore:"pressure_mb"`
+	WindDirDeg           int     `firestore:"wind_dir_deg"`
+
+	TempC        float64 `firestore:"temp_c"`
+	TempFeelC    float64 `firestore:"temp_feel_c"`
+	DewpointC    float64 `firestore:"dewpoint_c"`
+	WindSpeedKph float64 `firestore:"wind_speed_kph"`
+	WindGustKph  float64 `firestore:"wind_gust_kph"`
+	VisibilityKm float64 `firestore:"visibility_km"`
+
+	TempF        float64 `firestore:"temp_f"`
+	TempFeelF    float64 `firestore:"temp_feel_f"`
+	WindSpeedMph float64 `firestore:"wind_speed_mph"`
+	WindGustMph  float64 `firestore:"wind_gust_mph"`
+	VisibilityM  float64 `firestore:"visibility_miles"`
+	DewpointF    float64 `firestore:"dewpoint_f"`
+}
+
+func (p *WeatherPoint) version() int { return p.SchemaVersion }
+func (p *WeatherPoint) kind() string { return "weather point" }
+
+func (p *WeatherPoint) upgrade(string) {
+	p.SchemaVersion = Version
+}
+
+// PressurePoint is one entry of weather_cache's rolling history.
+type PressurePoint struct {
+	Timestamp       time.Time `firestore:"timestamp"`
+	HumidityPercent int       `firestore:"humidity_pct"`
+	PressureMb      float64   `firestore:"pressure_mb"`
+
+	TempC     float64 `firestore:"temp_c"`
+	TempFeelC float64 `firestore:"temp_feel_c"`
+	DewpointC float64 `firestore:"dewpoint_c"`
+
+	TempF     float64 `firestore:"temp_f"`
+	TempFeelF float64 `firestore:"temp_feel_f"`
+	DewpointF float64 `firestore:"dewpoint_f"`
+}
+
+// PressureStats is weather_cache's pressure analysis.
+type PressureStats struct {
+	// Pointers are used for Delta fields to support a true "N/A" (nil) state.
+	// This allows the dashboard to distinguish between a 0.0 change and missing data.
+	Timestamp time.Time `firestore:"timestamp"`
+	Delta1h   *float64  `firestore:"delta_01h"`
+	Delta3h   *float64  `firestore:"delta_03h"`
+	Delta6h   *float64  `firestore:"delta_06h"`
+	Delta12h  *float64  `firestore:"delta_12h"`
+	Delta24h  *float64  `firestore:"delta_24h"`
+	Trend     string    `firestore:"trend"`
+}
+
+// WeatherCacheDoc is weather_cache/{locationID}, rewritten by weather-collector
+// on every run.
+type WeatherCacheDoc struct {
+	SchemaVersion int             `firestore:"schema_version"`
+	Location      string          `firestore:"location"` // since version 1
+	LastUpdated   time.Time       `firestore:"last_updated"`
+	CurrentValue  WeatherPoint    `firestore:"current"`
+	Analysis      PressureStats   `firestore:"analysis"`
+	History       []PressurePoint `firestore:"history"` // Oldest first, capped at MaxWeatherHistory
+
+	// Alerts are the observed-pressure alerts, merged on every run as the
+	// forecast and pollen collectors merge theirs.
+	Alerts []shared.Alert `firestore:"alerts"`
+}
+
+// MaxWeatherHistory caps WeatherCacheDoc.History: 48 hourly readings.
+const MaxWeatherHistory = 48
+
+func (d *WeatherCacheDoc) version() int { return d.SchemaVersion }
+func (d *WeatherCacheDoc) kind() string { return "weather cache" }
+
+func (d *WeatherCacheDoc) upgrade(id string) {
+	if d.Location == "" {
+		d.Location = id
+	}
+	d.SchemaVersion = Version
+}
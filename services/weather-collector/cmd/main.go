@@ -6,10 +6,13 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/registry"
 	"github.com/nickfang/personal-dashboard/services/weather-collector/internal/api"
 	"github.com/nickfang/personal-dashboard/services/weather-collector/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/weather-collector/internal/service"
@@ -21,30 +24,88 @@ func main() {
 	if err := godotenv.Load(); err != nil {
 		slog.Debug("No .env file found, using system environment variables", "error", err)
 	}
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
 	apiKey := os.Getenv("GOOGLE_MAPS_API_KEY")
 	projectID := os.Getenv("GCP_PROJECT_ID")
-	if apiKey == "" || projectID == "" {
+	if apiKey == "" || (projectID == "" && !useSQLite) {
 		slog.Error("Missing required env vars", "vars", "GOOGLE_MAPS_API_KEY, GCP_PROJECT_ID")
 		os.Exit(1)
 	}
 
+	nowcast := service.DefaultNowcastConfig()
+	nowcast.WindowHours = envInt("NOWCAST_WINDOW_HOURS", nowcast.WindowHours)
+	nowcast.WarningMb = envFloat("NOWCAST_WARNING_MB", nowcast.WarningMb)
+	nowcast.SevereMb = envFloat("NOWCAST_SEVERE_MB", nowcast.SevereMb)
+	if os.Getenv("NOWCAST_ENABLED") == "false" {
+		slog.Info("Observed-pressure alerts disabled", "reason", "NOWCAST_ENABLED is false")
+		nowcast.WarningMb = 0
+	}
+	if err := nowcast.Validate(); err != nil {
+		slog.Error("Invalid nowcast config", "error", err)
+		os.Exit(1)
+	}
+	cfg := service.Config{Nowcast: nowcast}
+
 	ctx := context.Background()
-	writer, err := repository.NewFirestoreWriter(ctx, projectID)
+	locations, err := registry.Load(ctx, projectID, shared.SourceWeather)
 	if err != nil {
-		slog.Error("Failed to create firestore writer", "error", err)
+		slog.Error("Failed to load locations", "error", err)
+		os.Exit(1)
+	}
+	if len(locations) == 0 {
+		slog.Warn("No locations enabled, nothing to collect", "source", shared.SourceWeather)
+		return
+	}
+
+	writer, err := repository.OpenWriter(ctx, projectID)
+	if err != nil {
+		slog.Error("Failed to create writer", "error", err)
 		os.Exit(1)
 	}
 	defer writer.Close()
 
 	httpClient := &http.Client{Timeout: 15 * time.Second}
-	fetcher := api.New(httpClient)
-	collector := service.NewCollectorService(fetcher, writer)
-	if err := collectAll(ctx, apiKey, collector, shared.Locations); err != nil {
+	fetcher := api.NewWithBaseURL(httpClient, os.Getenv("WEATHER_API_BASE_URL"))
+	collector := service.NewCollectorService(fetcher, writer, cfg)
+	if err := collectAll(ctx, apiKey, collector, locations); err != nil {
 		slog.Error("Collection failed", "error", err)
 		os.Exit(1)
 	}
 }
 
+// envInt reads an integer env var, falling back to a default when unset or invalid.
+func envInt(name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+		return fallback
+	}
+	return v
+}
+
+// envFloat reads a float env var, falling back to a default when unset or
+// invalid. Zero is kept: for the nowcast thresholds it means off.
+func envFloat(name string, fallback float64) float64 {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v < 0 {
+		slog.Warn("Invalid env var, using default", "name", name, "value", raw, "default", fallback)
+		return fallback
+	}
+	return v
+}
+
 func collectAll(ctx context.Context, apiKey string, collector *service.CollectorService, locations []shared.Location) error {
 	if len(locations) == 0 {
 		return fmt.Errorf("no locations provided")
@@ -28,7 +28,7 @@ func happyWriter() *testutil.MockWriter {
 		SaveRawFn: func(ctx context.Context, wp repository.WeatherPoint) error {
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc, merge repository.MergeFunc) error {
 			return nil
 		},
 	}
@@ -48,7 +48,7 @@ var testLocations = []shared.Location{
 }
 
 func TestCollectAll_AllLocationsSucceed(t *testing.T) {
-	collector := service.NewCollectorService(happyFetcher(), happyWriter())
+	collector := service.NewCollectorService(happyFetcher(), happyWriter(), service.Config{Nowcast: service.DefaultNowcastConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err != nil {
@@ -71,7 +71,7 @@ func TestCollectAll_PartialFailure(t *testing.T) {
 		},
 	}
 
-	collector := service.NewCollectorService(fetcher, happyWriter())
+	collector := service.NewCollectorService(fetcher, happyWriter(), service.Config{Nowcast: service.DefaultNowcastConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err != nil {
@@ -80,7 +80,7 @@ func TestCollectAll_PartialFailure(t *testing.T) {
 }
 
 func TestCollectAll_AllLocationsFail(t *testing.T) {
-	collector := service.NewCollectorService(failingFetcher(), happyWriter())
+	collector := service.NewCollectorService(failingFetcher(), happyWriter(), service.Config{Nowcast: service.DefaultNowcastConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, testLocations)
 	if err == nil {
@@ -89,7 +89,7 @@ func TestCollectAll_AllLocationsFail(t *testing.T) {
 }
 
 func TestCollectAll_EmptyLocations(t *testing.T) {
-	collector := service.NewCollectorService(happyFetcher(), happyWriter())
+	collector := service.NewCollectorService(happyFetcher(), happyWriter(), service.Config{Nowcast: service.DefaultNowcastConfig()})
 
 	err := collectAll(context.Background(), "test-key", collector, []shared.Location{})
 	if err == nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/nickfang/personal-dashboard/services/shared"
@@ -22,21 +23,34 @@ type nonRetryable struct{ error }
 // Client fetches weather data from the Google Weather API.
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
 }
 
-// New creates a new weather API .
+// DefaultBaseURL is the real API. WEATHER_API_BASE_URL overrides it, e.g.
+// to point at the fake upstream (services/shared/cmd/fakeupstream).
+const DefaultBaseURL = "https://weather.googleapis.com"
+
+// New creates a new weather API client against DefaultBaseURL.
 func New(httpApi *http.Client) *Client {
-	return &Client{httpClient: httpApi}
+	return NewWithBaseURL(httpApi, "")
+}
+
+// NewWithBaseURL creates a client against baseURL, or DefaultBaseURL when it
+// is empty.
+func NewWithBaseURL(httpApi *http.Client, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpApi, baseURL: strings.TrimSuffix(baseURL, "/")}
 }
 
 func (c *Client) fetchWeather(apiKey string, location shared.Location) (*WeatherAPIResponse, error) {
-	baseUrl := "https://weather.googleapis.com/v1/currentConditions:lookup"
 	queryParams := url.Values{
 		"location.latitude":  {fmt.Sprintf("%f", location.Lat)},
 		"location.longitude": {fmt.Sprintf("%f", location.Long)},
 		// "unitsSystem":        {"imperial"},
 	}
-	url := baseUrl + "?" + queryParams.Encode()
+	url := c.baseURL + "/v1/currentConditions:lookup?" + queryParams.Encode()
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/fakeupstream"
 )
 
 // roundTripFunc adapts a plain function into an http.RoundTripper.
@@ -133,3 +135,21 @@ func TestFetch_NonRetryableStatusCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestFetch_AgainstFakeUpstream(t *testing.T) {
+	srv, err := fakeupstream.New(fakeupstream.ScenarioSteady)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	c := NewWithBaseURL(ts.Client(), ts.URL+"/")
+	data, err := c.Fetch("fake-key", shared.Location{ID: "test-loc", Lat: 30.0, Long: -97.0})
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	if data.AirPressure.MeanSeaLevelMillibars == 0 || data.Precipitation.Probability.Percent == 0 {
+		t.Errorf("fake response decoded with zero fields: %+v", data)
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteWriter writes observations, the weather cache and its observed
+// alerts to the docstore file, as FirestoreWriter does.
+type SQLiteWriter struct {
+	db *docstore.DB
+}
+
+func NewSQLiteWriter(db *docstore.DB) *SQLiteWriter {
+	return &SQLiteWriter{db: db}
+}
+
+// OpenWriter returns the SQLite writer when docstore selects it, and
+// otherwise connects to weather-log.
+func OpenWriter(ctx context.Context, projectID string) (WriteCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteWriter(db), nil
+	}
+	fw, err := NewFirestoreWriter(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+func (sw *SQLiteWriter) Close() error {
+	return sw.db.Close()
+}
+
+func (sw *SQLiteWriter) SaveRaw(ctx context.Context, wp WeatherPoint) error {
+	wp.SchemaVersion = schema.Version
+	_, err := sw.db.Add(ctx, shared.WeatherRawCollection, wp)
+	return err
+}
+
+func (sw *SQLiteWriter) UpdateCache(ctx context.Context, locationID string, wp WeatherPoint, analyze AnalyzeFunc, merge MergeFunc) error {
+	return sw.db.RunTransaction(ctx, func(ctx context.Context, tx *docstore.Tx) error {
+		cache := CacheDoc{History: []PressurePoint{}}
+		err := tx.Get(shared.WeatherCacheCollection, locationID, &cache)
+		if err != nil && !errors.Is(err, docstore.ErrNotFound) {
+			return fmt.Errorf("reading cache doc: %w", err)
+		}
+		if err == nil {
+			if err := schema.Rewrite(locationID, &cache); err != nil {
+				return err
+			}
+		}
+		prev := cache.Alerts
+		cache = appendToCache(cache, &wp, analyze, merge)
+		if err := tx.Set(shared.WeatherCacheCollection, locationID, cache); err != nil {
+			return err
+		}
+		return schema.RecordAlertChanges(tx.Set, prev, cache.Alerts, wp.Timestamp)
+	})
+}
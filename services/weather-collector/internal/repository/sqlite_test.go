@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// noAlerts is a MergeFunc for tests that are not about alerts.
+func noAlerts([]PressurePoint, []shared.Alert) []shared.Alert { return nil }
+
+func TestSQLiteWriter_UpdateCache(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "weather.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var seen []int
+	analyze := func(history []PressurePoint) PressureStats {
+		seen = append(seen, len(history))
+		return PressureStats{Timestamp: history[len(history)-1].Timestamp, Trend: "steady"}
+	}
+	for i := 0; i < MaxHistoryPoints+2; i++ {
+		wp := WeatherPoint{Location: "house-nick", Timestamp: base.Add(time.Duration(i) * time.Hour), PressureMb: 1000 + float64(i)}
+		if err := writer.UpdateCache(ctx, "house-nick", wp, analyze, noAlerts); err != nil {
+			t.Fatalf("UpdateCache #%d: %v", i, err)
+		}
+	}
+
+	var cache CacheDoc
+	if err := db.Get(ctx, shared.WeatherCacheCollection, "house-nick", &cache); err != nil {
+		t.Fatalf("reading cache: %v", err)
+	}
+	if len(cache.History) != MaxHistoryPoints {
+		t.Errorf("history has %d points, want it capped at %d", len(cache.History), MaxHistoryPoints)
+	}
+	last := base.Add(time.Duration(MaxHistoryPoints+1) * time.Hour)
+	if !cache.LastUpdated.Equal(last) || cache.CurrentValue.PressureMb != 1000+float64(MaxHistoryPoints+1) {
+		t.Errorf("cache current = %v at %v, want the last point", cache.CurrentValue.PressureMb, cache.LastUpdated)
+	}
+	if cache.Analysis.Trend != "steady" || !cache.Analysis.Timestamp.Equal(last) {
+		t.Errorf("analysis = %+v, want the result of the last analyze call", cache.Analysis)
+	}
+	if seen[0] != 1 || seen[len(seen)-1] != MaxHistoryPoints {
+		t.Errorf("analyze saw history lengths %v, want 1 first and the cap last", seen)
+	}
+	if cache.SchemaVersion != schema.Version || cache.Location != "house-nick" {
+		t.Errorf("cache version %d for %q, want version %d for house-nick", cache.SchemaVersion, cache.Location, schema.Version)
+	}
+}
+
+func TestSQLiteWriter_UpdateCache_SchemaVersions(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "weather.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	analyze := func([]PressurePoint) PressureStats { return PressureStats{} }
+
+	// A version 0 document keeps its history through the upgrade.
+	old := map[string]any{"history": []PressurePoint{{Timestamp: base, PressureMb: 1010}}}
+	if err := db.Set(ctx, shared.WeatherCacheCollection, "house-nick", old); err != nil {
+		t.Fatal(err)
+	}
+	wp := WeatherPoint{Location: "house-nick", Timestamp: base.Add(time.Hour), PressureMb: 1011}
+	if err := writer.UpdateCache(ctx, "house-nick", wp, analyze, noAlerts); err != nil {
+		t.Fatalf("UpdateCache over version 0: %v", err)
+	}
+	var cache CacheDoc
+	if err := db.Get(ctx, shared.WeatherCacheCollection, "house-nick", &cache); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.History) != 2 || cache.SchemaVersion != schema.Version {
+		t.Errorf("upgraded cache has %d points at version %d, want 2 at %d", len(cache.History), cache.SchemaVersion, schema.Version)
+	}
+
+	// A newer document is left alone rather than stripped of what it added.
+	newer := map[string]any{"schema_version": schema.Version + 1, "added_later": true}
+	if err := db.Set(ctx, shared.WeatherCacheCollection, "house-nick", newer); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.UpdateCache(ctx, "house-nick", wp, analyze, noAlerts); !errors.Is(err, schema.ErrNewerVersion) {
+		t.Errorf("UpdateCache over a newer version: err = %v, want ErrNewerVersion", err)
+	}
+	var raw map[string]any
+	if err := db.Get(ctx, shared.WeatherCacheCollection, "house-nick", &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["added_later"] != true {
+		t.Errorf("newer document was overwritten: %v", raw)
+	}
+}
+
+func TestSQLiteWriter_UpdateCacheArchivesPrunedAlerts(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "weather.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writer := NewSQLiteWriter(db)
+	defer writer.Close()
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	analyze := func([]PressurePoint) PressureStats { return PressureStats{} }
+	drop := shared.Alert{Location: "house-nick", RuleID: "pressure-drop-observed-3h", Value: -6, Threshold: 5, WindowStart: base.Add(-3 * time.Hour), WindowEnd: base.Add(3 * time.Hour)}
+	drop.ID = drop.ComputeID()
+	merge := func(detected []shared.Alert) MergeFunc {
+		return func(history []PressurePoint, prev []shared.Alert) []shared.Alert {
+			return shared.MergeAlerts(prev, detected, history[len(history)-1].Timestamp)
+		}
+	}
+
+	wp := WeatherPoint{Location: "house-nick", Timestamp: base, PressureMb: 1004}
+	if err := writer.UpdateCache(ctx, "house-nick", wp, analyze, merge([]shared.Alert{drop})); err != nil {
+		t.Fatal(err)
+	}
+	var cache CacheDoc
+	if err := db.Get(ctx, shared.WeatherCacheCollection, "house-nick", &cache); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.Alerts) != 1 || cache.Alerts[0].ID != drop.ID {
+		t.Fatalf("cached alerts = %+v, want the detected drop", cache.Alerts)
+	}
+
+	// Past the window, the merge prunes the alert into alert_history.
+	wp.Timestamp = base.Add(4 * time.Hour)
+	if err := writer.UpdateCache(ctx, "house-nick", wp, analyze, merge(nil)); err != nil {
+		t.Fatal(err)
+	}
+	var archived AlertRecord
+	if err := db.Get(ctx, shared.AlertHistoryCollection, drop.ID, &archived); err != nil {
+		t.Fatalf("pruned alert not archived: %v", err)
+	}
+	if archived.RuleID != "pressure-drop-observed-3h" || !archived.ArchivedAt.Equal(wp.Timestamp) {
+		t.Errorf("archived = %+v", archived)
+	}
+	events, err := db.Documents(ctx, docstore.Query{Collection: shared.AlertEventsCollection, OrderBy: "at"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Errorf("%d alert events, want created then pruned", len(events))
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"cloud.google.com/go/firestore"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -14,10 +15,21 @@ import (
 // This allows the service layer's business logic to run inside the repository's transaction.
 type AnalyzeFunc func(history []PressurePoint) PressureStats
 
+// MergeFunc detects observed-pressure alerts and reconciles them with the
+// previously stored set. Like AnalyzeFunc it runs inside the transaction,
+// against the history with the incoming reading already appended.
+type MergeFunc func(history []PressurePoint, prev []shared.Alert) []shared.Alert
+
 // Writer defines the interface for writing weather data to storage.
 type Writer interface {
 	SaveRaw(ctx context.Context, wp WeatherPoint) error
-	UpdateCache(ctx context.Context, locationID string, wp WeatherPoint, analyze AnalyzeFunc) error
+	UpdateCache(ctx context.Context, locationID string, wp WeatherPoint, analyze AnalyzeFunc, merge MergeFunc) error
+}
+
+// WriteCloser is a Writer that holds a connection.
+type WriteCloser interface {
+	Writer
+	Close() error
 }
 
 type FirestoreWriter struct {
@@ -37,35 +49,54 @@ func (fw *FirestoreWriter) Close() error {
 }
 
 func (fw *FirestoreWriter) SaveRaw(ctx context.Context, wp WeatherPoint) error {
+	wp.SchemaVersion = schema.Version
 	_, _, err := fw.client.Collection(shared.WeatherRawCollection).Add(ctx, wp)
 	return err
 }
 
-func (fw *FirestoreWriter) UpdateCache(ctx context.Context, locationID string, wp WeatherPoint, analyze AnalyzeFunc) error {
+// UpdateCache appends wp to the location's cache and recomputes its analysis
+// and alerts. Alerts the merge prunes are archived to alert_history, and the
+// merge's lifecycle events recorded to alert_events, in the same transaction.
+func (fw *FirestoreWriter) UpdateCache(ctx context.Context, locationID string, wp WeatherPoint, analyze AnalyzeFunc, merge MergeFunc) error {
 	cacheRef := fw.client.Collection(shared.WeatherCacheCollection).Doc(locationID)
 	return fw.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
-		cache, err := getUpdatedCacheDoc(cacheRef, &wp, tx, analyze)
+		prev, cache, err := getUpdatedCacheDoc(cacheRef, locationID, &wp, tx, analyze, merge)
 		if err != nil {
 			return err
 		}
-		return tx.Set(cacheRef, cache)
+		if err := tx.Set(cacheRef, cache); err != nil {
+			return err
+		}
+		return schema.RecordAlertChanges(fw.setter(tx), prev, cache.Alerts, wp.Timestamp)
 	})
 }
 
-func getUpdatedCacheDoc(cacheRef *firestore.DocumentRef, wp *WeatherPoint, tx *firestore.Transaction, analyze AnalyzeFunc) (CacheDoc, error) {
+// getUpdatedCacheDoc returns the stored alerts alongside the updated cache,
+// so the caller can archive what the merge pruned.
+func getUpdatedCacheDoc(cacheRef *firestore.DocumentRef, locationID string, wp *WeatherPoint, tx *firestore.Transaction, analyze AnalyzeFunc, merge MergeFunc) ([]shared.Alert, CacheDoc, error) {
 	doc, err := tx.Get(cacheRef)
 	var cache CacheDoc
 	if status.Code(err) == codes.NotFound {
 		cache = CacheDoc{History: []PressurePoint{}}
 	} else if err != nil {
-		return cache, fmt.Errorf("reading cache doc: %w", err)
+		return nil, cache, fmt.Errorf("reading cache doc: %w", err)
 	} else {
 		if err := doc.DataTo(&cache); err != nil {
-			return cache, err
+			return nil, cache, err
+		}
+		if err := schema.Rewrite(locationID, &cache); err != nil {
+			return nil, cache, err
 		}
 	}
+	return cache.Alerts, appendToCache(cache, wp, analyze, merge), nil
+}
+
+// appendToCache adds wp to the cache's history and recomputes the analysis
+// and alerts. Every backend calls it between its transactional read and
+// write.
+func appendToCache(cache CacheDoc, wp *WeatherPoint, analyze AnalyzeFunc, merge MergeFunc) CacheDoc {
 	newPoint := PressurePoint{
-		TimeStamp:       wp.Timestamp,
+		Timestamp:       wp.Timestamp,
 		TempC:           wp.TempC,
 		TempF:           wp.TempF,
 		HumidityPercent: wp.HumidityPercent,
@@ -80,9 +111,20 @@ func getUpdatedCacheDoc(cacheRef *firestore.DocumentRef, wp *WeatherPoint, tx *f
 		cache.History = cache.History[len(cache.History)-MaxHistoryPoints:]
 	}
 
+	cache.SchemaVersion = schema.Version
+	cache.Location = wp.Location
 	cache.LastUpdated = wp.Timestamp
 	cache.CurrentValue = *wp
 	cache.Analysis = analyze(cache.History)
+	cache.Alerts = merge(cache.History, cache.Alerts)
 
-	return cache, nil
+	return cache
+}
+
+// setter writes documents of this database within tx, for the schema
+// package's alert records.
+func (fw *FirestoreWriter) setter(tx *firestore.Transaction) schema.SetFunc {
+	return func(collection, id string, v any) error {
+		return tx.Set(fw.client.Collection(collection).Doc(id), v)
+	}
 }
@@ -16,15 +16,22 @@ const (
 	DeltaNoiseThreshold = 0.5 // mb
 )
 
+// Config holds the collector's tuning, kept separate from the interfaces it
+// depends on, as in the forecast and pollen collectors.
+type Config struct {
+	Nowcast NowcastConfig // Observed-pressure alert thresholds
+}
+
 // CollectorService orchestrates the weather collection flow.
 type CollectorService struct {
 	fetcher api.Fetcher
 	writer  repository.Writer
+	cfg     Config
 }
 
 // NewCollectorService creates a new CollectorService with injected dependencies.
-func NewCollectorService(fetcher api.Fetcher, writer repository.Writer) *CollectorService {
-	return &CollectorService{fetcher: fetcher, writer: writer}
+func NewCollectorService(fetcher api.Fetcher, writer repository.Writer, cfg Config) *CollectorService {
+	return &CollectorService{fetcher: fetcher, writer: writer, cfg: cfg}
 }
 
 // Collect fetches weather data for a location, maps it, and writes to storage.
@@ -40,7 +47,13 @@ func (s *CollectorService) Collect(ctx context.Context, apiKey string, location
 	if err := s.writer.SaveRaw(ctx, *wp); err != nil {
 		return fmt.Errorf("saving weather data for %s: %w", location.ID, err)
 	}
-	if err := s.writer.UpdateCache(ctx, location.ID, *wp, CalculatePressureStats); err != nil {
+	// Delivery is the Notifier's, as for forecast alerts: it reads these
+	// from the cache on its hourly run.
+	merge := func(history []repository.PressurePoint, prev []shared.Alert) []shared.Alert {
+		detected := DetectObservedDrop(location, history, s.cfg.Nowcast, wp.Timestamp)
+		return shared.MergeAlerts(prev, detected, wp.Timestamp)
+	}
+	if err := s.writer.UpdateCache(ctx, location.ID, *wp, CalculatePressureStats, merge); err != nil {
 		return fmt.Errorf("updating weather cache for %s: %w", location.ID, err)
 	}
 	return nil
@@ -121,7 +134,7 @@ func CalculatePressureStats(history []repository.PressurePoint) repository.Press
 	// This decouples logic from the sampling rate and makes it resilient to
 	// missing data points or job scheduling jitter.
 	getDelta := func(hoursAgo int) *float64 {
-		targetTime := current.TimeStamp.Add(time.Duration(-hoursAgo) * time.Hour)
+		targetTime := current.Timestamp.Add(time.Duration(-hoursAgo) * time.Hour)
 		// 45 minute tolerance allows us to find the closest point even if
 		// some cycles were missed or delayed.
 		tolerance := DeltaTolerance
@@ -132,7 +145,7 @@ func CalculatePressureStats(history []repository.PressurePoint) repository.Press
 		for i := len(history) - 2; i >= 0; i-- {
 			p := &history[i]
 
-			diff := p.TimeStamp.Sub(targetTime)
+			diff := p.Timestamp.Sub(targetTime)
 			if diff < 0 {
 				diff = -diff
 			}
@@ -146,7 +159,7 @@ func CalculatePressureStats(history []repository.PressurePoint) repository.Press
 
 			// Optimization: History is sorted ascending; if we are way before
 			// the target window, we can safely stop searching.
-			if targetTime.Sub(p.TimeStamp) > tolerance {
+			if targetTime.Sub(p.Timestamp) > tolerance {
 				break
 			}
 		}
@@ -155,7 +168,7 @@ func CalculatePressureStats(history []repository.PressurePoint) repository.Press
 		entry := deltaAudit{Target: targetTime.Format(time.RFC3339)}
 
 		if bestMatch != nil {
-			entry.Found = bestMatch.TimeStamp.Format(time.RFC3339)
+			entry.Found = bestMatch.Timestamp.Format(time.RFC3339)
 			res := current.PressureMb - bestMatch.PressureMb
 			entry.Delta = &res
 			audit[key] = entry
@@ -174,7 +187,7 @@ func CalculatePressureStats(history []repository.PressurePoint) repository.Press
 
 	// Log the timestamp and value audit info at INFO level
 	slog.Info("Pressure Analysis Diagnostics",
-		"current_time", current.TimeStamp.Format(time.RFC3339),
+		"current_time", current.Timestamp.Format(time.RFC3339),
 		"analysis", audit,
 	)
 
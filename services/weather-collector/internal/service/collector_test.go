@@ -21,7 +21,7 @@ func TestCalculatePressureStats(t *testing.T) {
 
 	mkPoint := func(hoursAgo int, pressure float64) repository.PressurePoint {
 		return repository.PressurePoint{
-			TimeStamp:  now.Add(time.Duration(-hoursAgo) * time.Hour),
+			Timestamp:  now.Add(time.Duration(-hoursAgo) * time.Hour),
 			PressureMb: pressure,
 		}
 	}
@@ -138,13 +138,13 @@ func TestCollect_Success(t *testing.T) {
 			savedWP = wp
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc, merge repository.MergeFunc) error {
 			cachedLocationID = locationID
 			return nil
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, Config{Nowcast: DefaultNowcastConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 	if err != nil {
@@ -174,13 +174,13 @@ func TestCollect_FetchError(t *testing.T) {
 			writerCalled = true
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc, merge repository.MergeFunc) error {
 			writerCalled = true
 			return nil
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, Config{Nowcast: DefaultNowcastConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 
@@ -208,13 +208,13 @@ func TestCollect_SaveRawError(t *testing.T) {
 		SaveRawFn: func(ctx context.Context, wp repository.WeatherPoint) error {
 			return fmt.Errorf("firestore write failed")
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc, merge repository.MergeFunc) error {
 			cacheCalled = true
 			return nil
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, Config{Nowcast: DefaultNowcastConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 
@@ -239,12 +239,12 @@ func TestCollect_UpdateCacheError(t *testing.T) {
 		SaveRawFn: func(ctx context.Context, wp repository.WeatherPoint) error {
 			return nil
 		},
-		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc) error {
+		UpdateCacheFn: func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc, merge repository.MergeFunc) error {
 			return fmt.Errorf("cache update failed")
 		},
 	}
 
-	svc := NewCollectorService(fetcher, writer)
+	svc := NewCollectorService(fetcher, writer, Config{Nowcast: DefaultNowcastConfig()})
 	loc := shared.Location{ID: "house-nick", Lat: 30.0, Long: -97.0}
 	err := svc.Collect(context.Background(), "test-key", loc)
 
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/weather-collector/internal/repository"
+)
+
+// NowcastConfig holds the observed-pressure alert thresholds, sourced from env
+// so they can be tuned without a code change. A WarningMb of 0 turns
+// nowcasting off, which NOWCAST_ENABLED=false sets.
+type NowcastConfig struct {
+	WindowHours int     // NOWCAST_WINDOW_HOURS: how far back the observed drop is measured
+	WarningMb   float64 // NOWCAST_WARNING_MB: warning on an observed fall of this much
+	SevereMb    float64 // NOWCAST_SEVERE_MB: severe on a fall of this much; 0 disables severe
+}
+
+// DefaultNowcastConfig matches the forecast collector's default
+// pressure-drop-3h rule, so an observed alert fires on the drop the forecast
+// would have had to predict.
+func DefaultNowcastConfig() NowcastConfig {
+	return NowcastConfig{WindowHours: 3, WarningMb: 5, SevereMb: 10}
+}
+
+// Validate rejects thresholds that would raise the wrong severity: a severe
+// threshold below the warning one would mark every alert severe. Zero for
+// either is allowed, since it turns nowcasting or severe off.
+func (c NowcastConfig) Validate() error {
+	if c.WindowHours <= 0 {
+		return fmt.Errorf("nowcast window_hours must be positive, got %d", c.WindowHours)
+	}
+	if c.WarningMb < 0 || c.SevereMb < 0 {
+		return fmt.Errorf("nowcast thresholds must not be negative, got warning %v and severe %v", c.WarningMb, c.SevereMb)
+	}
+	if c.SevereMb != 0 && c.SevereMb < c.WarningMb {
+		return fmt.Errorf("nowcast severe %v is below warning %v", c.SevereMb, c.WarningMb)
+	}
+	return nil
+}
+
+// RuleID names the observed rule after its window, as the forecast rules are
+// named.
+func (c NowcastConfig) RuleID() string {
+	return fmt.Sprintf("pressure-drop-observed-%dh", c.WindowHours)
+}
+
+// DetectObservedDrop raises an alert when the newest reading in history is at
+// least WarningMb below the reading WindowHours earlier, matched within
+// DeltaTolerance as CalculatePressureStats matches its deltas. history is
+// oldest first and ends with the reading just collected.
+//
+// The window runs from the earlier reading to WindowHours past the newest.
+// The drop has already happened, so the forward half stands in for the hours
+// its weather is still arriving: it keeps the alert live until the next run,
+// where a continuing drop merges into the same episode rather than raising a
+// new alert every hour.
+func DetectObservedDrop(location shared.Location, history []repository.PressurePoint, cfg NowcastConfig, now time.Time) []shared.Alert {
+	if cfg.WarningMb <= 0 || cfg.WindowHours <= 0 || len(history) < 2 {
+		return nil
+	}
+	current := history[len(history)-1]
+	window := time.Duration(cfg.WindowHours) * time.Hour
+	i := shared.NearestIndex(history[:len(history)-1], func(p repository.PressurePoint) time.Time { return p.Timestamp }, current.Timestamp.Add(-window), DeltaTolerance)
+	if i < 0 {
+		return nil
+	}
+	delta := current.PressureMb - history[i].PressureMb
+	if -delta < cfg.WarningMb {
+		return nil
+	}
+
+	severity := shared.AlertSeverityWarning
+	if cfg.SevereMb > 0 && -delta >= cfg.SevereMb {
+		severity = shared.AlertSeveritySevere
+	}
+	since := history[i].Timestamp.In(location.TimeZone()).Format("Mon 3 PM")
+	alert := shared.Alert{
+		Location:    location.ID,
+		RuleID:      cfg.RuleID(),
+		Metric:      shared.MetricPressureMb,
+		Direction:   shared.AlertDirectionDrop,
+		Label:       "observed pressure",
+		Unit:        " mb",
+		Severity:    severity,
+		Value:       delta,
+		Threshold:   cfg.WarningMb,
+		WindowStart: history[i].Timestamp,
+		WindowEnd:   current.Timestamp.Add(window),
+		Message:     fmt.Sprintf("%s  %+.1f mb/%dh observed", since, delta, cfg.WindowHours),
+		Status:      shared.AlertStatusActive,
+		IssuedAt:    now,
+	}
+	alert.ID = alert.ComputeID()
+	return []shared.Alert{alert}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/weather-collector/internal/repository"
+)
+
+var nowcastLocation = shared.Location{ID: "house-nick", Timezone: "America/Chicago"}
+
+// readings builds one reading an hour, the last at end.
+func readings(end time.Time, pressures ...float64) []repository.PressurePoint {
+	history := make([]repository.PressurePoint, len(pressures))
+	for i, p := range pressures {
+		history[i] = repository.PressurePoint{Timestamp: end.Add(time.Duration(i-len(pressures)+1) * time.Hour), PressureMb: p}
+	}
+	return history
+}
+
+func TestDetectObservedDrop(t *testing.T) {
+	end := time.Date(2026, 4, 2, 20, 2, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		pressures []float64
+		severity  string // "" for no alert
+	}{
+		{"steady", []float64{1013, 1013, 1013, 1013}, ""},
+		{"small drop", []float64{1013, 1012, 1011, 1010}, ""},
+		{"warning drop", []float64{1013, 1011, 1009, 1007.5}, shared.AlertSeverityWarning},
+		{"severe drop", []float64{1013, 1009, 1006, 1002}, shared.AlertSeveritySevere},
+		{"rise", []float64{1007, 1009, 1011, 1013}, ""},
+		{"too little history", []float64{1013, 1007}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := DetectObservedDrop(nowcastLocation, readings(end, tt.pressures...), DefaultNowcastConfig(), end)
+			if tt.severity == "" {
+				if len(alerts) != 0 {
+					t.Errorf("got %+v, want no alert", alerts)
+				}
+				return
+			}
+			if len(alerts) != 1 || alerts[0].Severity != tt.severity {
+				t.Fatalf("got %+v, want one %s alert", alerts, tt.severity)
+			}
+		})
+	}
+}
+
+func TestDetectObservedDrop_Alert(t *testing.T) {
+	end := time.Date(2026, 4, 2, 20, 2, 0, 0, time.UTC)
+	alerts := DetectObservedDrop(nowcastLocation, readings(end, 1013, 1011, 1009, 1007), DefaultNowcastConfig(), end)
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1", len(alerts))
+	}
+	a := alerts[0]
+	if a.RuleID != "pressure-drop-observed-3h" || a.Value != -6 || a.Threshold != 5 || a.Status != shared.AlertStatusActive {
+		t.Errorf("alert = %+v", a)
+	}
+	if !a.WindowStart.Equal(end.Add(-3*time.Hour)) || !a.WindowEnd.Equal(end.Add(3*time.Hour)) {
+		t.Errorf("window = %v..%v, want from the reading 3h back to 3h ahead", a.WindowStart, a.WindowEnd)
+	}
+	if a.Message != "Thu 12 PM  -6.0 mb/3h observed" {
+		t.Errorf("message = %q", a.Message)
+	}
+	if a.ID != a.ComputeID() {
+		t.Error("alert ID is not its computed ID")
+	}
+}
+
+func TestDetectObservedDrop_ContinuingDropIsOneEpisode(t *testing.T) {
+	end := time.Date(2026, 4, 2, 20, 2, 0, 0, time.UTC)
+	cfg := DefaultNowcastConfig()
+	first := DetectObservedDrop(nowcastLocation, readings(end, 1013, 1011, 1009, 1007), cfg, end)
+	next := end.Add(time.Hour)
+	second := DetectObservedDrop(nowcastLocation, readings(next, 1013, 1011, 1009, 1007, 1005), cfg, next)
+
+	merged := shared.MergeAlerts(shared.MergeAlerts(nil, first, end), second, next)
+	if len(merged) != 1 || merged[0].ID != first[0].ID {
+		t.Errorf("merged = %+v, want the next hour's detection merged into the first", merged)
+	}
+}
+
+func TestDetectObservedDrop_Disabled(t *testing.T) {
+	end := time.Date(2026, 4, 2, 20, 2, 0, 0, time.UTC)
+	cfg := DefaultNowcastConfig()
+	cfg.WarningMb = 0
+	if alerts := DetectObservedDrop(nowcastLocation, readings(end, 1013, 1009, 1006, 1002), cfg, end); len(alerts) != 0 {
+		t.Errorf("got %+v with nowcasting off", alerts)
+	}
+}
+
+func TestNowcastConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     NowcastConfig
+		wantErr bool
+	}{
+		{"default", DefaultNowcastConfig(), false},
+		{"severe off", NowcastConfig{WindowHours: 3, WarningMb: 5, SevereMb: 0}, false},
+		{"nowcast off", NowcastConfig{WindowHours: 3, WarningMb: 0, SevereMb: 10}, false},
+		{"severe equals warning", NowcastConfig{WindowHours: 3, WarningMb: 5, SevereMb: 5}, false},
+		{"severe below warning", NowcastConfig{WindowHours: 3, WarningMb: 5, SevereMb: 4}, true},
+		{"negative warning", NowcastConfig{WindowHours: 3, WarningMb: -1, SevereMb: 10}, true},
+		{"no window", NowcastConfig{WindowHours: 0, WarningMb: 5, SevereMb: 10}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,13 +20,13 @@ func (m *MockFetcher) Fetch(apiKey string, location shared.Location) (*api.Weath
 // MockWriter implements repository.Writer for testing.
 type MockWriter struct {
 	SaveRawFn     func(ctx context.Context, wp repository.WeatherPoint) error
-	UpdateCacheFn func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc) error
+	UpdateCacheFn func(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc, merge repository.MergeFunc) error
 }
 
 func (m *MockWriter) SaveRaw(ctx context.Context, wp repository.WeatherPoint) error {
 	return m.SaveRawFn(ctx, wp)
 }
 
-func (m *MockWriter) UpdateCache(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc) error {
-	return m.UpdateCacheFn(ctx, locationID, wp, analyze)
+func (m *MockWriter) UpdateCache(ctx context.Context, locationID string, wp repository.WeatherPoint, analyze repository.AnalyzeFunc, merge repository.MergeFunc) error {
+	return m.UpdateCacheFn(ctx, locationID, wp, analyze, merge)
 }
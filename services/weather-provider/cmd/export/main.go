@@ -1,124 +1,138 @@
+// Command export writes one Firestore archive to a CSV, JSON Lines or Parquet
+// file for offline analysis. Rows are streamed page by page, so exporting
+// months of data does not need the whole collection in memory. With
+// STORAGE_BACKEND=sqlite it reads the SQLITE_PATH file instead.
+//
+//	go run ./cmd/export -dataset weather_raw -location house-nick \
+//	    -start 2026-01-01 -end 2026-04-01 -format parquet
 package main
 
 import (
 	"context"
-	"encoding/csv"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
-	"strconv"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
 	"time"
 
-	// "github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/joho/godotenv"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/export"
 	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/service"
 )
 
-type WeatherPoint struct {
-	Location             string    `firestore:"location"`
-	Timestamp            time.Time `firestore:"timestamp"`
-	HumidityPercent      int       `firestore:"humidity_pct"`
-	PrecipitationPercent int       `firestore:"precipitation_pct"`
-	UVIndex              int       `firestore:"uv_index"`
-	PressureMb           float64   `firestore:"pressure_mb"`
-	WindDirDeg           int       `firestore:"wind_dir_deg"`
-	TempC                float64   `firestore:"temp_c"`
-	TempFeelC            float64   `firestore:"temp_feel_c"`
-	DewpointC            float64   `firestore:"dewpoint_c"`
-	WindSpeedKph         float64   `firestore:"wind_speed_kph"`
-	WindGustKph          float64   `firestore:"wind_gust_kph"`
-	VisibilityKm         float64   `firestore:"visibility_km"`
-	TempF                float64   `firestore:"temp_f"`
-	TempFeelF            float64   `firestore:"temp_feel_f"`
-	WindSpeedMph         float64   `firestore:"wind_speed_mph"`
-	WindGustMph          float64   `firestore:"wind_gust_mph"`
-	VisibilityM          float64   `firestore:"visibility_miles"`
-	DewpointF            float64   `firestore:"dewpoint_f"`
-}
+func main() {
+	// Logs go to stderr so "-out -" can stream the export itself to stdout.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
 
-type WeatherPoints []WeatherPoint
+	if err := godotenv.Load(); err != nil {
+		slog.Debug("No .env file found, using system environment variables", "error", err)
+	}
 
-func main() {
-	// get data
-	projectID := "fang-gcp"
-	ctx := context.Background()
+	project := flag.String("project", os.Getenv("GCP_PROJECT_ID"), "GCP project ID (default $GCP_PROJECT_ID)")
+	dataset := flag.String("dataset", string(export.DatasetWeatherRaw), "dataset to export: "+joinNames(export.Datasets))
+	location := flag.String("location", "", "only export this location ID (default all)")
+	start := flag.String("start", "", "inclusive start, RFC 3339 or YYYY-MM-DD in UTC (default no limit)")
+	end := flag.String("end", "", "exclusive end, RFC 3339 or YYYY-MM-DD in UTC (default no limit)")
+	format := flag.String("format", string(export.FormatCSV), "output format: "+joinNames(export.Formats))
+	out := flag.String("out", "", `output file, or "-" for stdout (default <dataset>.<format>)`)
+	pageSize := flag.Int("page-size", service.DefaultRawPageSize, "documents per Firestore read")
+	flag.Parse()
 
-	repo, err := repository.NewFirestoreRepository(ctx, projectID)
+	useSQLite, err := docstore.UseSQLite()
 	if err != nil {
-		os.Exit(1)
+		fail("Invalid storage backend", "error", err)
+	}
+	if *project == "" && !useSQLite {
+		fail("Missing project: set -project or GCP_PROJECT_ID")
+	}
+	if !slices.Contains(export.Datasets, export.Dataset(*dataset)) {
+		fail("Unknown -dataset", "dataset", *dataset)
 	}
-	defer repo.Close()
+	if !slices.Contains(export.Formats, export.Format(*format)) {
+		fail("Unknown -format", "format", *format)
+	}
+	if *pageSize <= 0 {
+		fail("-page-size must be positive")
+	}
+	q := repository.RawQuery{LocationID: *location, PageSize: *pageSize}
+	if q.Start, err = parseTime(*start); err != nil {
+		fail("Invalid -start", "error", err)
+	}
+	if q.End, err = parseTime(*end); err != nil {
+		fail("Invalid -end", "error", err)
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && !q.Start.Before(q.End) {
+		fail("-start must be before -end")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	file, err := os.Create("weather_raw.csv")
+	src, err := export.OpenSource(ctx, *project)
 	if err != nil {
-		log.Fatalf("failed to create file: %s", err)
+		fail("Failed to open storage", "error", err)
 	}
-	defer file.Close()
+	defer src.Close()
 
-	svc := service.NewWeatherService(repo)
-	data, err := svc.GetAllRaw(ctx)
+	path := *out
+	if path == "" {
+		path = *dataset + "." + *format
+	}
+	w, closeOut, err := openOutput(path)
 	if err != nil {
-		os.Exit(1)
+		fail("Failed to open output", "path", path, "error", err)
 	}
 
-	writer := csv.NewWriter(file)
+	rows, err := export.Export(ctx, src, export.Dataset(*dataset), q, export.Format(*format), w)
+	if cerr := closeOut(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fail("Export failed", "dataset", *dataset, "rows_written", rows, "error", err)
+	}
+	slog.Info("Export complete", "dataset", *dataset, "format", *format, "rows", rows, "out", path)
+}
 
-	headers := []string{
-		"Id",
-		"Location",
-		"Timestamp",
-		"HumidityPercent",
-		"PrecipitationPercent",
-		"UVIndex",
-		"PressureMb",
-		"WindDirDeg",
-		"TempC",
-		"TempFeelC",
-		"DewpointC",
-		"WindSpeedKph",
-		"WindGustKph",
-		"VisibilityKm",
-		"TempF",
-		"TempFeelF",
-		"WindSpeedMph",
-		"WindGustMph",
-		"VisibilityM",
-		"DewpointF",
-	}
-	writer.Write(headers)
-	for i, d := range data {
-		record := []string{
-			strconv.Itoa(i),
-			d.LocationID,
-			d.Timestamp.Format(time.RFC3339),
-			strconv.Itoa(d.HumidityPercent),
-			strconv.Itoa(d.PrecipitationPercent),
-			strconv.Itoa(d.UVIndex),
-			strconv.FormatFloat(d.PressureMb, 'f', 2, 64),
-			strconv.Itoa(d.WindDirDeg),
-			strconv.FormatFloat(d.TempC, 'f', 2, 64),
-			strconv.FormatFloat(d.TempFeelC, 'f', 2, 64),
-			strconv.FormatFloat(d.DewpointC, 'f', 2, 64),
-			strconv.FormatFloat(d.WindSpeedKph, 'f', 2, 64),
-			strconv.FormatFloat(d.WindGustKph, 'f', 2, 64),
-			strconv.FormatFloat(d.VisibilityKm, 'f', 2, 64),
-			strconv.FormatFloat(d.TempF, 'f', 2, 64),
-			strconv.FormatFloat(d.TempFeelF, 'f', 2, 64),
-			strconv.FormatFloat(d.WindSpeedMph, 'f', 2, 64),
-			strconv.FormatFloat(d.WindGustMph, 'f', 2, 64),
-			strconv.FormatFloat(d.VisibilityM, 'f', 2, 64),
-			strconv.FormatFloat(d.DewpointF, 'f', 2, 64),
-		}
-		if err := writer.Write(record); err != nil {
-			log.Fatalf("error writing data to csv: %s", err)
-		}
+// parseTime accepts RFC 3339 or a bare date, read as midnight UTC. Empty
+// means unbounded.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
 	}
+	return time.Parse(time.RFC3339, s)
+}
 
-	writer.Flush()
+func openOutput(path string) (io.Writer, func() error, error) {
+	if path == "-" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
 
-	if err := writer.Error(); err != nil {
-		log.Fatalf("error flushing writer: %s", err)
+func joinNames[T ~string](names []T) string {
+	s := make([]string, len(names))
+	for i, n := range names {
+		s[i] = string(n)
 	}
+	return strings.Join(s, ", ")
+}
 
-	fmt.Println("Success!")
+func fail(msg string, args ...any) {
+	slog.Error(msg, args...)
+	fmt.Fprintln(os.Stderr, "Run with -h for usage.")
+	os.Exit(1)
 }
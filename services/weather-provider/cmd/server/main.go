@@ -10,6 +10,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
 	pb "github.com/nickfang/personal-dashboard/services/weather-provider/internal/gen/go/weather-provider/v1"
 	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
 	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/service"
@@ -37,7 +38,12 @@ func main() {
 	if port == "" {
 		port = "50051"
 	}
-	if projectID == "" {
+	useSQLite, err := docstore.UseSQLite()
+	if err != nil {
+		slog.Error("Invalid storage backend", "error", err)
+		os.Exit(1)
+	}
+	if projectID == "" && !useSQLite {
 		slog.Error("Missing required env var: GCP_PROJECT_ID", "env", os.Environ())
 		os.Exit(1)
 	}
@@ -45,7 +51,7 @@ func main() {
 	ctx := context.Background()
 
 	// 3. Initialize Layers
-	repo, err := repository.NewFirestoreRepository(ctx, projectID)
+	repo, err := repository.OpenReader(ctx, projectID)
 	if err != nil {
 		slog.Error("Failed to initialize repository", "error", err)
 		os.Exit(1)
@@ -67,6 +73,7 @@ func main() {
 	// Register Services
 	pb.RegisterPressureStatsServiceServer(grpcServer, handler)
 	pb.RegisterForecastServiceServer(grpcServer, handler)
+	pb.RegisterRawWeatherServiceServer(grpcServer, handler)
 
 	// Register Standard Health Check
 	healthServer := health.NewServer()
@@ -0,0 +1,82 @@
+// Package export streams the append-only Firestore archives to flat files for
+// offline analysis. Rows are written page by page as Firestore returns them,
+// so an export never holds more than one page of a collection in memory.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+)
+
+// Dataset names the collection an export reads.
+type Dataset string
+
+const (
+	DatasetWeatherRaw  Dataset = "weather_raw"
+	DatasetForecastRaw Dataset = "forecast_raw"
+	DatasetPollenRaw   Dataset = "pollen_raw"
+	DatasetAlerts      Dataset = "alerts"
+)
+
+// Datasets lists every dataset Export understands, in the order the CLI shows
+// them.
+var Datasets = []Dataset{DatasetWeatherRaw, DatasetForecastRaw, DatasetPollenRaw, DatasetAlerts}
+
+// Export reads dataset from src, narrowed by q, and writes it to w in format.
+// It returns the number of rows written. On a read error the rows already
+// written are flushed so the partial output is still readable.
+func Export(ctx context.Context, src Source, dataset Dataset, q repository.RawQuery, format Format, w io.Writer) (int, error) {
+	switch dataset {
+	case DatasetWeatherRaw:
+		return run(format, w, func(emit func([]WeatherRow) error) error {
+			return src.WeatherRaw(ctx, q, func(points []repository.WeatherPoint) error {
+				return emit(weatherRows(points))
+			})
+		})
+	case DatasetForecastRaw:
+		return run(format, w, func(emit func([]ForecastRow) error) error {
+			return src.ForecastRaw(ctx, q, func(runs []repository.ForecastRun) error {
+				return emit(forecastRows(runs))
+			})
+		})
+	case DatasetPollenRaw:
+		return run(format, w, func(emit func([]PollenRow) error) error {
+			return src.PollenRaw(ctx, q, func(snapshots []PollenSnapshot) error {
+				return emit(pollenRows(snapshots))
+			})
+		})
+	case DatasetAlerts:
+		return run(format, w, func(emit func([]AlertRow) error) error {
+			return src.Alerts(ctx, q, func(alerts []SourcedAlert) error {
+				return emit(alertRows(alerts))
+			})
+		})
+	default:
+		return 0, fmt.Errorf("unknown dataset %q", dataset)
+	}
+}
+
+// run wires a dataset's paged reader to a row writer for its row type.
+func run[T any](format Format, w io.Writer, read func(emit func([]T) error) error) (int, error) {
+	rw, err := newRowWriter[T](format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	readErr := read(func(rows []T) error {
+		if err := rw.Write(rows); err != nil {
+			return err
+		}
+		count += len(rows)
+		return nil
+	})
+	closeErr := rw.Close()
+	if readErr != nil {
+		return count, readErr
+	}
+	return count, closeErr
+}
@@ -0,0 +1,342 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+	"github.com/parquet-go/parquet-go"
+)
+
+var t0 = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeSource serves fixed pages for each dataset and records the query.
+type fakeSource struct {
+	weather  [][]repository.WeatherPoint
+	forecast [][]repository.ForecastRun
+	pollen   [][]PollenSnapshot
+	alerts   [][]SourcedAlert
+	err      error
+	query    repository.RawQuery
+}
+
+func servePages[T any](pages [][]T, err error, fn func([]T) error) error {
+	for _, p := range pages {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (f *fakeSource) WeatherRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+	f.query = q
+	return servePages(f.weather, f.err, fn)
+}
+
+func (f *fakeSource) ForecastRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.ForecastRun) error) error {
+	f.query = q
+	return servePages(f.forecast, f.err, fn)
+}
+
+func (f *fakeSource) PollenRaw(ctx context.Context, q repository.RawQuery, fn func([]PollenSnapshot) error) error {
+	f.query = q
+	return servePages(f.pollen, f.err, fn)
+}
+
+func (f *fakeSource) Alerts(ctx context.Context, q repository.RawQuery, fn func([]SourcedAlert) error) error {
+	f.query = q
+	return servePages(f.alerts, f.err, fn)
+}
+
+func weatherSource() *fakeSource {
+	return &fakeSource{weather: [][]repository.WeatherPoint{
+		{
+			{Location: "house-nick", Timestamp: t0, PressureMb: 1013.25, HumidityPercent: 70, VisibilityM: 6.2},
+			{Location: "house-nick", Timestamp: t0.Add(time.Hour), PressureMb: 1012.5},
+		},
+		{
+			{Location: "house-nick", Timestamp: t0.Add(2 * time.Hour), PressureMb: 1011},
+		},
+	}}
+}
+
+func readCSV(t *testing.T, buf *bytes.Buffer) []map[string]string {
+	t.Helper()
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) == 0 {
+		t.Fatal("CSV has no header")
+	}
+	var rows []map[string]string
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(rec))
+		for i, col := range records[0] {
+			row[col] = rec[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestExport_WeatherCSV(t *testing.T) {
+	src := weatherSource()
+	q := repository.RawQuery{LocationID: "house-nick", Start: t0, PageSize: 2}
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), src, DatasetWeatherRaw, q, FormatCSV, &buf)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("rows = %d, want 3 across both pages", n)
+	}
+	if src.query != q {
+		t.Errorf("source got query %+v, want %+v", src.query, q)
+	}
+
+	rows := readCSV(t, &buf)
+	if len(rows) != 3 {
+		t.Fatalf("got %d CSV rows, want 3", len(rows))
+	}
+	first := rows[0]
+	if first["location"] != "house-nick" || first["timestamp"] != "2026-03-01T12:00:00Z" {
+		t.Errorf("first row location/timestamp = %q/%q", first["location"], first["timestamp"])
+	}
+	if first["pressure_mb"] != "1013.25" || first["humidity_pct"] != "70" || first["visibility_miles"] != "6.2" {
+		t.Errorf("first row pressure/humidity/visibility = %q/%q/%q", first["pressure_mb"], first["humidity_pct"], first["visibility_miles"])
+	}
+}
+
+func TestExport_EmptyCSVHasHeader(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), &fakeSource{}, DatasetAlerts, repository.RawQuery{}, FormatCSV, &buf)
+	if err != nil || n != 0 {
+		t.Fatalf("Export = %d, %v; want 0, nil", n, err)
+	}
+	if !strings.HasPrefix(buf.String(), "source,id,location,rule_id,") {
+		t.Errorf("empty export = %q, want just the header", buf.String())
+	}
+}
+
+func TestExport_ForecastJSONL(t *testing.T) {
+	src := &fakeSource{forecast: [][]repository.ForecastRun{{
+		{Location: "house-nick", IssuedAt: t0, Points: []repository.ForecastPoint{
+			{ValidTime: t0.Add(time.Hour), PressureMb: 1010},
+			{ValidTime: t0.Add(6 * time.Hour), PressureMb: 1006},
+		}},
+	}}}
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), src, DatasetForecastRaw, repository.RawQuery{}, FormatJSONL, &buf)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("rows = %d, want one per forecast hour (2)", n)
+	}
+
+	var rows []ForecastRow
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var row ForecastRow
+		if err := json.Unmarshal(sc.Bytes(), &row); err != nil {
+			t.Fatalf("line %q is not JSON: %v", sc.Text(), err)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d lines, want 2", len(rows))
+	}
+	if rows[1].LeadHours != 6 || !rows[1].IssuedAt.Equal(t0) || rows[1].PressureMb != 1006 {
+		t.Errorf("second row = %+v, want lead 6h from the run", rows[1])
+	}
+}
+
+func TestExport_PollenParquet(t *testing.T) {
+	src := &fakeSource{pollen: [][]PollenSnapshot{{
+		{
+			LocationID: "house-nick", CollectedAt: t0, OverallIndex: 4, OverallCategory: "High", DominantType: "TREE",
+			Types:  []PollenType{{Code: "TREE", Index: 4, Category: "High", InSeason: true}},
+			Plants: []PollenPlant{{Code: "OAK", DisplayName: "Oak", Index: 3, Category: "Moderate", InSeason: true}},
+		},
+		{LocationID: "house-nick", CollectedAt: t0.Add(12 * time.Hour)},
+	}}}
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), src, DatasetPollenRaw, repository.RawQuery{}, FormatParquet, &buf)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("rows = %d, want a type row, a plant row and one for the empty snapshot", n)
+	}
+
+	rows, err := parquet.Read[PollenRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid Parquet: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("read %d rows back, want 3", len(rows))
+	}
+	if rows[0].Kind != "type" || rows[0].Code != "TREE" || rows[0].OverallCategory != "High" {
+		t.Errorf("rows[0] = %+v, want the TREE type row", rows[0])
+	}
+	if rows[1].Kind != "plant" || rows[1].DisplayName != "Oak" || rows[1].Index != 3 {
+		t.Errorf("rows[1] = %+v, want the OAK plant row", rows[1])
+	}
+	if rows[2].Kind != "" || !rows[2].CollectedAt.Equal(t0.Add(12*time.Hour)) {
+		t.Errorf("rows[2] = %+v, want the empty snapshot's overall row", rows[2])
+	}
+}
+
+func TestExport_AlertNotifiedAt(t *testing.T) {
+	notified := t0.Add(time.Minute)
+	src := &fakeSource{alerts: [][]SourcedAlert{{
+		{Source: AlertSourceForecast, Alert: shared.Alert{ID: "a1", Location: "house-nick", IssuedAt: t0, NotifiedAt: notified}},
+		{Source: AlertSourcePollen, Alert: shared.Alert{ID: "a2", Location: "house-nick", IssuedAt: t0}},
+	}}}
+	var buf bytes.Buffer
+
+	if _, err := Export(context.Background(), src, DatasetAlerts, repository.RawQuery{}, FormatCSV, &buf); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	rows := readCSV(t, &buf)
+	if rows[0]["notified_at"] != "2026-03-01T12:01:00Z" || rows[0]["source"] != "forecast" {
+		t.Errorf("rows[0] notified_at/source = %q/%q", rows[0]["notified_at"], rows[0]["source"])
+	}
+	if rows[1]["notified_at"] != "" {
+		t.Errorf("undelivered alert notified_at = %q, want empty", rows[1]["notified_at"])
+	}
+}
+
+func TestExport_ReadErrorKeepsWrittenRows(t *testing.T) {
+	src := weatherSource()
+	src.err = errors.New("firestore down")
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), src, DatasetWeatherRaw, repository.RawQuery{}, FormatCSV, &buf)
+	if err == nil {
+		t.Fatal("expected the read error")
+	}
+	if n != 3 {
+		t.Errorf("rows = %d, want the 3 read before the failure", n)
+	}
+	if rows := readCSV(t, &buf); len(rows) != 3 {
+		t.Errorf("flushed %d rows, want 3", len(rows))
+	}
+}
+
+func TestExport_UnknownDatasetAndFormat(t *testing.T) {
+	if _, err := Export(context.Background(), &fakeSource{}, "bogus", repository.RawQuery{}, FormatCSV, &bytes.Buffer{}); err == nil {
+		t.Error("unknown dataset: expected an error")
+	}
+	if _, err := Export(context.Background(), &fakeSource{}, DatasetWeatherRaw, repository.RawQuery{}, "xml", &bytes.Buffer{}); err == nil {
+		t.Error("unknown format: expected an error")
+	}
+}
+
+func TestFilterAlerts(t *testing.T) {
+	alerts := []shared.Alert{
+		{ID: "late", Location: "house-nick", IssuedAt: t0.Add(2 * time.Hour)},
+		{ID: "early", Location: "house-nick", IssuedAt: t0},
+		{ID: "other", Location: "house-nita", IssuedAt: t0},
+		{ID: "at-end", Location: "house-nick", IssuedAt: t0.Add(3 * time.Hour)},
+	}
+	q := repository.RawQuery{LocationID: "house-nick", Start: t0, End: t0.Add(3 * time.Hour)}
+
+	got := filterAlerts(AlertSourcePollen, alerts, q)
+
+	var ids []string
+	for _, a := range got {
+		ids = append(ids, a.Alert.ID)
+		if a.Source != AlertSourcePollen {
+			t.Errorf("%s source = %q, want pollen", a.Alert.ID, a.Source)
+		}
+	}
+	if strings.Join(ids, ",") != "early,late" {
+		t.Errorf("ids = %v, want [early late]: location filtered, end exclusive, oldest first", ids)
+	}
+}
+
+func TestSQLiteSource_AlertsIncludeHistory(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "export.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	src := NewSQLiteSource(db)
+	defer src.Close()
+	ctx := context.Background()
+
+	if err := db.Set(ctx, shared.ForecastCacheCollection, "house-nick", alertsDoc{Alerts: []shared.Alert{
+		{ID: "live", Location: "house-nick", IssuedAt: t0},
+	}}); err != nil {
+		t.Fatalf("seeding cache: %v", err)
+	}
+	for _, rec := range []repository.AlertRecord{
+		{ID: "pollen-archived", Location: "house-nick", RuleID: "pollen-high-tree", IssuedAt: t0.Add(-time.Hour), Status: shared.AlertStatusExpired},
+		{ID: "elsewhere", Location: "house-nita", IssuedAt: t0},
+	} {
+		if err := db.Set(ctx, shared.AlertHistoryCollection, rec.ID, rec); err != nil {
+			t.Fatalf("seeding history: %v", err)
+		}
+	}
+
+	var got []SourcedAlert
+	q := repository.RawQuery{LocationID: "house-nick", PageSize: 10}
+	if err := src.Alerts(ctx, q, func(page []SourcedAlert) error {
+		got = append(got, page...)
+		return nil
+	}); err != nil {
+		t.Fatalf("Alerts: %v", err)
+	}
+	if len(got) != 2 || got[0].Alert.ID != "live" || got[1].Alert.ID != "pollen-archived" || got[1].Source != AlertSourceHistory || got[1].Alert.Status != shared.AlertStatusExpired {
+		t.Errorf("alerts = %+v, want the cached alert then house-nick's archived one", got)
+	}
+}
+
+func TestSQLiteSource_AlertsReadEveryCacheDocument(t *testing.T) {
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "export.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	src := NewSQLiteSource(db)
+	defer src.Close()
+	ctx := context.Background()
+
+	// More locations than the provider's capped GetAll reads return.
+	const locations = 120
+	for i := 0; i < locations; i++ {
+		id := fmt.Sprintf("loc-%03d", i)
+		if err := db.Set(ctx, shared.ForecastCacheCollection, id, alertsDoc{Alerts: []shared.Alert{
+			{ID: id, Location: id, IssuedAt: t0},
+		}}); err != nil {
+			t.Fatalf("seeding cache: %v", err)
+		}
+	}
+
+	count := 0
+	if err := src.Alerts(ctx, repository.RawQuery{PageSize: 10}, func(page []SourcedAlert) error {
+		count += len(page)
+		return nil
+	}); err != nil {
+		t.Fatalf("Alerts: %v", err)
+	}
+	if count != locations {
+		t.Errorf("exported %d alerts, want one per cache document (%d)", count, locations)
+	}
+}
@@ -0,0 +1,163 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Format is an output encoding.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatJSONL   Format = "jsonl"
+	FormatParquet Format = "parquet"
+)
+
+// Formats lists every supported output format.
+var Formats = []Format{FormatCSV, FormatJSONL, FormatParquet}
+
+// parquetRowGroupSize bounds how many rows the Parquet writer buffers before
+// flushing a row group, keeping memory flat on long exports.
+const parquetRowGroupSize = 10000
+
+// rowWriter encodes rows of one type. Close flushes buffered output but does
+// not close the underlying io.Writer.
+type rowWriter[T any] interface {
+	Write(rows []T) error
+	Close() error
+}
+
+func newRowWriter[T any](format Format, w io.Writer) (rowWriter[T], error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter[T](w), nil
+	case FormatJSONL:
+		return &jsonlWriter[T]{enc: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return &parquetWriter[T]{w: parquet.NewGenericWriter[T](w, parquet.MaxRowsPerRowGroup(parquetRowGroupSize))}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// csvWriter writes one column per struct field, named by its json tag so CSV
+// and JSON Lines exports share column names. The header goes out with the
+// first write, or on Close for an empty export.
+type csvWriter[T any] struct {
+	w             *csv.Writer
+	header        []string
+	headerWritten bool
+}
+
+func newCSVWriter[T any](w io.Writer) *csvWriter[T] {
+	t := reflect.TypeFor[T]()
+	header := make([]string, t.NumField())
+	for i := range header {
+		header[i] = columnName(t.Field(i))
+	}
+	return &csvWriter[T]{w: csv.NewWriter(w), header: header}
+}
+
+func (c *csvWriter[T]) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(c.header)
+}
+
+func (c *csvWriter[T]) Write(rows []T) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	record := make([]string, len(c.header))
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i := range record {
+			record[i] = formatCSVValue(v.Field(i))
+		}
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter[T]) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// columnName returns the json tag name of a row field.
+func columnName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// formatCSVValue renders a row field. Times are RFC 3339 in UTC; nil pointers
+// become empty cells.
+func formatCSVValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+type jsonlWriter[T any] struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlWriter[T]) Write(rows []T) error {
+	for _, row := range rows {
+		if err := j.enc.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonlWriter[T]) Close() error { return nil }
+
+type parquetWriter[T any] struct {
+	w *parquet.GenericWriter[T]
+}
+
+func (p *parquetWriter[T]) Write(rows []T) error {
+	_, err := p.w.Write(rows)
+	return err
+}
+
+func (p *parquetWriter[T]) Close() error { return p.w.Close() }
@@ -0,0 +1,221 @@
+package export
+
+import (
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+)
+
+// Row types are the flat output schema for each dataset. Column names follow
+// the Firestore field names so exports line up with the stored documents; the
+// json tag names the CSV and JSON Lines column and the parquet tag the Parquet
+// one.
+
+// WeatherRow is one weather_raw observation.
+type WeatherRow struct {
+	Location             string    `json:"location" parquet:"location"`
+	Timestamp            time.Time `json:"timestamp" parquet:"timestamp,timestamp(millisecond)"`
+	HumidityPercent      int       `json:"humidity_pct" parquet:"humidity_pct"`
+	PrecipitationPercent int       `json:"precipitation_pct" parquet:"precipitation_pct"`
+	UVIndex              int       `json:"uv_index" parquet:"uv_index"`
+	PressureMb           float64   `json:"pressure_mb" parquet:"pressure_mb"`
+	WindDirDeg           int       `json:"wind_dir_deg" parquet:"wind_dir_deg"`
+	TempC                float64   `json:"temp_c" parquet:"temp_c"`
+	TempFeelC            float64   `json:"temp_feel_c" parquet:"temp_feel_c"`
+	DewpointC            float64   `json:"dewpoint_c" parquet:"dewpoint_c"`
+	WindSpeedKph         float64   `json:"wind_speed_kph" parquet:"wind_speed_kph"`
+	WindGustKph          float64   `json:"wind_gust_kph" parquet:"wind_gust_kph"`
+	VisibilityKm         float64   `json:"visibility_km" parquet:"visibility_km"`
+	TempF                float64   `json:"temp_f" parquet:"temp_f"`
+	TempFeelF            float64   `json:"temp_feel_f" parquet:"temp_feel_f"`
+	WindSpeedMph         float64   `json:"wind_speed_mph" parquet:"wind_speed_mph"`
+	WindGustMph          float64   `json:"wind_gust_mph" parquet:"wind_gust_mph"`
+	VisibilityMiles      float64   `json:"visibility_miles" parquet:"visibility_miles"`
+	DewpointF            float64   `json:"dewpoint_f" parquet:"dewpoint_f"`
+}
+
+// ForecastRow is one forecast hour from one forecast_raw run. LeadHours is
+// how far ahead of the run the hour was forecast.
+type ForecastRow struct {
+	Location             string    `json:"location" parquet:"location"`
+	IssuedAt             time.Time `json:"issued_at" parquet:"issued_at,timestamp(millisecond)"`
+	ValidTime            time.Time `json:"valid_time" parquet:"valid_time,timestamp(millisecond)"`
+	LeadHours            float64   `json:"lead_hours" parquet:"lead_hours"`
+	HumidityPercent      int       `json:"humidity_pct" parquet:"humidity_pct"`
+	PrecipitationPercent int       `json:"precipitation_pct" parquet:"precipitation_pct"`
+	UVIndex              int       `json:"uv_index" parquet:"uv_index"`
+	PressureMb           float64   `json:"pressure_mb" parquet:"pressure_mb"`
+	WindDirDeg           int       `json:"wind_dir_deg" parquet:"wind_dir_deg"`
+	TempC                float64   `json:"temp_c" parquet:"temp_c"`
+	TempFeelC            float64   `json:"temp_feel_c" parquet:"temp_feel_c"`
+	DewpointC            float64   `json:"dewpoint_c" parquet:"dewpoint_c"`
+	WindSpeedKph         float64   `json:"wind_speed_kph" parquet:"wind_speed_kph"`
+	WindGustKph          float64   `json:"wind_gust_kph" parquet:"wind_gust_kph"`
+	TempF                float64   `json:"temp_f" parquet:"temp_f"`
+	TempFeelF            float64   `json:"temp_feel_f" parquet:"temp_feel_f"`
+	DewpointF            float64   `json:"dewpoint_f" parquet:"dewpoint_f"`
+}
+
+// PollenRow is one pollen type or plant from one pollen_raw snapshot, in long
+// form: Kind is "type" or "plant". The snapshot-level overall fields repeat on
+// every row so each row stands alone.
+type PollenRow struct {
+	Location        string    `json:"location" parquet:"location"`
+	CollectedAt     time.Time `json:"collected_at" parquet:"collected_at,timestamp(millisecond)"`
+	OverallIndex    int       `json:"overall_index" parquet:"overall_index"`
+	OverallCategory string    `json:"overall_category" parquet:"overall_category"`
+	DominantType    string    `json:"dominant_type" parquet:"dominant_type"`
+	Kind            string    `json:"kind" parquet:"kind"`
+	Code            string    `json:"code" parquet:"code"`
+	DisplayName     string    `json:"display_name" parquet:"display_name"`
+	Index           int       `json:"index" parquet:"index"`
+	Category        string    `json:"category" parquet:"category"`
+	InSeason        bool      `json:"in_season" parquet:"in_season"`
+}
+
+// AlertRow is one alert from a forecast or pollen cache document, or from
+// alert_history. Source says which. NotifiedAt is nil for alerts that were
+// never delivered.
+type AlertRow struct {
+	Source      string     `json:"source" parquet:"source"`
+	ID          string     `json:"id" parquet:"id"`
+	Location    string     `json:"location" parquet:"location"`
+	RuleID      string     `json:"rule_id" parquet:"rule_id"`
+	Severity    string     `json:"severity" parquet:"severity"`
+	Value       float64    `json:"value" parquet:"value"`
+	Threshold   float64    `json:"threshold" parquet:"threshold"`
+	WindowStart time.Time  `json:"window_start" parquet:"window_start,timestamp(millisecond)"`
+	WindowEnd   time.Time  `json:"window_end" parquet:"window_end,timestamp(millisecond)"`
+	Message     string     `json:"message" parquet:"message"`
+	Status      string     `json:"status" parquet:"status"`
+	IssuedAt    time.Time  `json:"issued_at" parquet:"issued_at,timestamp(millisecond)"`
+	NotifiedAt  *time.Time `json:"notified_at" parquet:"notified_at,timestamp(millisecond),optional"`
+}
+
+const (
+	pollenKindType  = "type"
+	pollenKindPlant = "plant"
+)
+
+func weatherRows(points []repository.WeatherPoint) []WeatherRow {
+	rows := make([]WeatherRow, 0, len(points))
+	for _, p := range points {
+		rows = append(rows, WeatherRow{
+			Location:             p.Location,
+			Timestamp:            p.Timestamp,
+			HumidityPercent:      p.HumidityPercent,
+			PrecipitationPercent: p.PrecipitationPercent,
+			UVIndex:              p.UVIndex,
+			PressureMb:           p.PressureMb,
+			WindDirDeg:           p.WindDirDeg,
+			TempC:                p.TempC,
+			TempFeelC:            p.TempFeelC,
+			DewpointC:            p.DewpointC,
+			WindSpeedKph:         p.WindSpeedKph,
+			WindGustKph:          p.WindGustKph,
+			VisibilityKm:         p.VisibilityKm,
+			TempF:                p.TempF,
+			TempFeelF:            p.TempFeelF,
+			WindSpeedMph:         p.WindSpeedMph,
+			WindGustMph:          p.WindGustMph,
+			VisibilityMiles:      p.VisibilityM,
+			DewpointF:            p.DewpointF,
+		})
+	}
+	return rows
+}
+
+func forecastRows(runs []repository.ForecastRun) []ForecastRow {
+	var rows []ForecastRow
+	for _, run := range runs {
+		for _, p := range run.Points {
+			rows = append(rows, ForecastRow{
+				Location:             run.Location,
+				IssuedAt:             run.IssuedAt,
+				ValidTime:            p.ValidTime,
+				LeadHours:            p.ValidTime.Sub(run.IssuedAt).Hours(),
+				HumidityPercent:      p.HumidityPercent,
+				PrecipitationPercent: p.PrecipitationPercent,
+				UVIndex:              p.UVIndex,
+				PressureMb:           p.PressureMb,
+				WindDirDeg:           p.WindDirDeg,
+				TempC:                p.TempC,
+				TempFeelC:            p.TempFeelC,
+				DewpointC:            p.DewpointC,
+				WindSpeedKph:         p.WindSpeedKph,
+				WindGustKph:          p.WindGustKph,
+				TempF:                p.TempF,
+				TempFeelF:            p.TempFeelF,
+				DewpointF:            p.DewpointF,
+			})
+		}
+	}
+	return rows
+}
+
+// pollenRows flattens each snapshot to one row per type and plant. A snapshot
+// with neither still yields one row carrying its overall fields, so readings
+// are never silently dropped.
+func pollenRows(snapshots []PollenSnapshot) []PollenRow {
+	var rows []PollenRow
+	for _, s := range snapshots {
+		base := PollenRow{
+			Location:        s.LocationID,
+			CollectedAt:     s.CollectedAt,
+			OverallIndex:    s.OverallIndex,
+			OverallCategory: s.OverallCategory,
+			DominantType:    s.DominantType,
+		}
+		if len(s.Types) == 0 && len(s.Plants) == 0 {
+			rows = append(rows, base)
+			continue
+		}
+		for _, t := range s.Types {
+			row := base
+			row.Kind = pollenKindType
+			row.Code = t.Code
+			row.Index = t.Index
+			row.Category = t.Category
+			row.InSeason = t.InSeason
+			rows = append(rows, row)
+		}
+		for _, p := range s.Plants {
+			row := base
+			row.Kind = pollenKindPlant
+			row.Code = p.Code
+			row.DisplayName = p.DisplayName
+			row.Index = p.Index
+			row.Category = p.Category
+			row.InSeason = p.InSeason
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func alertRows(alerts []SourcedAlert) []AlertRow {
+	rows := make([]AlertRow, 0, len(alerts))
+	for _, sa := range alerts {
+		a := sa.Alert
+		row := AlertRow{
+			Source:      sa.Source,
+			ID:          a.ID,
+			Location:    a.Location,
+			RuleID:      a.RuleID,
+			Severity:    a.Severity,
+			Value:       a.Value,
+			Threshold:   a.Threshold,
+			WindowStart: a.WindowStart,
+			WindowEnd:   a.WindowEnd,
+			Message:     a.Message,
+			Status:      a.Status,
+			IssuedAt:    a.IssuedAt,
+		}
+		if !a.NotifiedAt.IsZero() {
+			notified := a.NotifiedAt
+			row.NotifiedAt = &notified
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,204 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"cloud.google.com/go/firestore"
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+	"google.golang.org/api/iterator"
+)
+
+// Alert sources, as reported in AlertRow.Source. History is alert_history,
+// every collector's archived alerts; the rule ID tells them apart.
+const (
+	AlertSourceForecast = "forecast"
+	AlertSourcePollen   = "pollen"
+	AlertSourceHistory  = "history"
+)
+
+// Source reads each dataset a page at a time, oldest first, narrowed by q.
+// Each implementation hands pages to fn as they arrive and stops on the
+// first error fn returns.
+type Source interface {
+	WeatherRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error
+	ForecastRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.ForecastRun) error) error
+	PollenRaw(ctx context.Context, q repository.RawQuery, fn func([]PollenSnapshot) error) error
+	Alerts(ctx context.Context, q repository.RawQuery, fn func([]SourcedAlert) error) error
+}
+
+// SourceCloser is a Source that holds a connection.
+type SourceCloser interface {
+	Source
+	Close() error
+}
+
+// The pollen archive's documents, as defined in shared/schema.
+type (
+	PollenSnapshot = schema.PollenSnapshot
+	PollenType     = schema.PollenType
+	PollenPlant    = schema.PollenPlant
+)
+
+// SourcedAlert is an alert tagged with the cache, or archive, it was read
+// from.
+type SourcedAlert struct {
+	Source string
+	Alert  shared.Alert
+}
+
+// alertsDoc reads only the alerts of a forecast or pollen cache document.
+type alertsDoc struct {
+	Alerts []shared.Alert `firestore:"alerts"`
+}
+
+// FirestoreSource reads the weather archives through the provider's
+// repository and the pollen archive from the pollen database directly.
+type FirestoreSource struct {
+	weather *repository.FirestoreRepository
+	pollen  *firestore.Client
+}
+
+func NewFirestoreSource(ctx context.Context, projectID string) (*FirestoreSource, error) {
+	weather, err := repository.NewFirestoreRepository(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("open weather database: %w", err)
+	}
+	pollen, err := firestore.NewClientWithDatabase(ctx, projectID, shared.PollenDatabaseID)
+	if err != nil {
+		weather.Close()
+		return nil, fmt.Errorf("open pollen database: %w", err)
+	}
+	return &FirestoreSource{weather: weather, pollen: pollen}, nil
+}
+
+func (s *FirestoreSource) Close() error {
+	werr := s.weather.Close()
+	if perr := s.pollen.Close(); perr != nil {
+		return perr
+	}
+	return werr
+}
+
+func (s *FirestoreSource) WeatherRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+	return s.weather.QueryRaw(ctx, q, fn)
+}
+
+func (s *FirestoreSource) ForecastRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.ForecastRun) error) error {
+	return s.weather.QueryForecastRuns(ctx, q, fn)
+}
+
+func (s *FirestoreSource) PollenRaw(ctx context.Context, q repository.RawQuery, fn func([]PollenSnapshot) error) error {
+	query := repository.RangeQuery(s.pollen.Collection(shared.PollenRawCollection).Query, "location_id", "collected_at", q)
+	return repository.Pages(ctx, query, q.PageSize, fn)
+}
+
+// Alerts reads the alerts held in the forecast and pollen cache documents,
+// one page per cache, then pages through alert_history. Each cache keeps one
+// small document per location, so the page size applies only to the archive.
+// A pruned alert is in the archive and no longer in its cache, so no alert
+// appears twice.
+func (s *FirestoreSource) Alerts(ctx context.Context, q repository.RawQuery, fn func([]SourcedAlert) error) error {
+	forecastAlerts, err := cacheAlerts(ctx, s.weather.Client().Collection(shared.ForecastCacheCollection))
+	if err != nil {
+		return fmt.Errorf("read forecast alerts: %w", err)
+	}
+
+	pollenAlerts, err := cacheAlerts(ctx, s.pollen.Collection(shared.PollenCacheCollection))
+	if err != nil {
+		return fmt.Errorf("read pollen alerts: %w", err)
+	}
+
+	for _, page := range [][]SourcedAlert{
+		filterAlerts(AlertSourceForecast, forecastAlerts, q),
+		filterAlerts(AlertSourcePollen, pollenAlerts, q),
+	} {
+		if len(page) == 0 {
+			continue
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return s.weather.QueryAlertHistory(ctx, q, func(records []repository.AlertRecord) error {
+		return fn(historyAlerts(records))
+	})
+}
+
+// cacheAlerts reads every document of a cache collection. It is not capped
+// like the provider's GetAll reads: the collection holds one document per
+// location, locations can be added at runtime, and an export that silently
+// dropped some would look complete.
+func cacheAlerts(ctx context.Context, coll *firestore.CollectionRef) ([]shared.Alert, error) {
+	var alerts []shared.Alert
+	iter := coll.Select("alerts").Documents(ctx)
+	defer iter.Stop()
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var cache alertsDoc
+		if err := doc.DataTo(&cache); err != nil {
+			slog.Warn("Skipping invalid document in cacheAlerts", "doc_id", doc.Ref.Path, "error", err)
+			continue
+		}
+		alerts = append(alerts, cache.Alerts...)
+	}
+	return alerts, nil
+}
+
+// historyAlerts tags archived alerts for export. The archive's peak and
+// archived_at have no AlertRow column, so the row carries what a cached
+// alert's would.
+func historyAlerts(records []repository.AlertRecord) []SourcedAlert {
+	out := make([]SourcedAlert, len(records))
+	for i, rec := range records {
+		out[i] = SourcedAlert{Source: AlertSourceHistory, Alert: shared.Alert{
+			ID:          rec.ID,
+			Location:    rec.Location,
+			RuleID:      rec.RuleID,
+			Severity:    rec.Severity,
+			Value:       rec.Value,
+			Threshold:   rec.Threshold,
+			WindowStart: rec.WindowStart,
+			WindowEnd:   rec.WindowEnd,
+			Message:     rec.Message,
+			Status:      rec.Status,
+			IssuedAt:    rec.IssuedAt,
+			NotifiedAt:  rec.NotifiedAt,
+		}}
+	}
+	return out
+}
+
+// filterAlerts keeps the alerts matching q's location and whose IssuedAt is
+// in [Start, End), oldest first.
+func filterAlerts(source string, alerts []shared.Alert, q repository.RawQuery) []SourcedAlert {
+	var out []SourcedAlert
+	for _, a := range alerts {
+		if q.LocationID != "" && a.Location != q.LocationID {
+			continue
+		}
+		if !q.Start.IsZero() && a.IssuedAt.Before(q.Start) {
+			continue
+		}
+		if !q.End.IsZero() && !a.IssuedAt.Before(q.End) {
+			continue
+		}
+		out = append(out, SourcedAlert{Source: source, Alert: a})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Alert.IssuedAt.Before(out[j].Alert.IssuedAt)
+	})
+	return out
+}
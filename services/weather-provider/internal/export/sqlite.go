@@ -0,0 +1,90 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+)
+
+// SQLiteSource reads every archive from the shared docstore file, which
+// holds the weather and pollen collections side by side.
+type SQLiteSource struct {
+	weather *repository.SQLiteRepository
+}
+
+func NewSQLiteSource(db *docstore.DB) *SQLiteSource {
+	return &SQLiteSource{weather: repository.NewSQLiteRepository(db)}
+}
+
+// OpenSource returns the SQLite source when docstore selects it, where every
+// archive shares the one file, and otherwise reads the weather archives
+// through the repository and the pollen archive from pollen-log.
+func OpenSource(ctx context.Context, projectID string) (SourceCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteSource(db), nil
+	}
+	fs, err := NewFirestoreSource(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (s *SQLiteSource) Close() error {
+	return s.weather.Close()
+}
+
+func (s *SQLiteSource) WeatherRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+	return s.weather.QueryRaw(ctx, q, fn)
+}
+
+func (s *SQLiteSource) ForecastRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.ForecastRun) error) error {
+	return s.weather.QueryForecastRuns(ctx, q, fn)
+}
+
+func (s *SQLiteSource) PollenRaw(ctx context.Context, q repository.RawQuery, fn func([]PollenSnapshot) error) error {
+	query := repository.SQLiteRangeQuery(shared.PollenRawCollection, "location_id", "collected_at", q)
+	return repository.SQLitePages(ctx, s.weather.DB(), query, q.PageSize, fn)
+}
+
+// Alerts reads the forecast and pollen cache alerts, one page per cache, then
+// alert_history, as FirestoreSource does.
+func (s *SQLiteSource) Alerts(ctx context.Context, q repository.RawQuery, fn func([]SourcedAlert) error) error {
+	var pages [][]SourcedAlert
+	for _, c := range []struct{ source, collection string }{
+		{AlertSourceForecast, shared.ForecastCacheCollection},
+		{AlertSourcePollen, shared.PollenCacheCollection},
+	} {
+		docs, err := s.weather.DB().Documents(ctx, docstore.Query{Collection: c.collection})
+		if err != nil {
+			return fmt.Errorf("read %s alerts: %w", c.source, err)
+		}
+		caches, err := docstore.DecodeAll[alertsDoc](docs)
+		if err != nil {
+			return fmt.Errorf("read %s alerts: %w", c.source, err)
+		}
+		var alerts []shared.Alert
+		for _, cache := range caches {
+			alerts = append(alerts, cache.Alerts...)
+		}
+		pages = append(pages, filterAlerts(c.source, alerts, q))
+	}
+	for _, page := range pages {
+		if len(page) == 0 {
+			continue
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return s.weather.QueryAlertHistory(ctx, q, func(records []repository.AlertRecord) error {
+		return fn(historyAlerts(records))
+	})
+}
@@ -531,6 +531,471 @@ func (x *GetForecastResponse) GetForecast() *Forecast {
 	return nil
 }
 
+// ArchivedAlert is a forecast alert pruned from the cache once its window
+// passed. alert.status is final: "resolved" if the condition left the
+// forecast first, "expired" if it was still active.
+type ArchivedAlert struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Alert *Alert                 `protobuf:"bytes,1,opt,name=alert,proto3" json:"alert,omitempty"`
+	// The worst value and severity the episode was detected at; alert.value
+	// and alert.severity are from the last detection.
+	PeakValue    float64 `protobuf:"fixed64,2,opt,name=peak_value,json=peakValue,proto3" json:"peak_value,omitempty"`
+	PeakSeverity string  `protobuf:"bytes,3,opt,name=peak_severity,json=peakSeverity,proto3" json:"peak_severity,omitempty"`
+	// Last delivery; unset if the alert was never sent.
+	NotifiedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=notified_at,json=notifiedAt,proto3" json:"notified_at,omitempty"`
+	ArchivedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchivedAlert) Reset() {
+	*x = ArchivedAlert{}
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchivedAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchivedAlert) ProtoMessage() {}
+
+func (x *ArchivedAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchivedAlert.ProtoReflect.Descriptor instead.
+func (*ArchivedAlert) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_forecast_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ArchivedAlert) GetAlert() *Alert {
+	if x != nil {
+		return x.Alert
+	}
+	return nil
+}
+
+func (x *ArchivedAlert) GetPeakValue() float64 {
+	if x != nil {
+		return x.PeakValue
+	}
+	return 0
+}
+
+func (x *ArchivedAlert) GetPeakSeverity() string {
+	if x != nil {
+		return x.PeakSeverity
+	}
+	return ""
+}
+
+func (x *ArchivedAlert) GetNotifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotifiedAt
+	}
+	return nil
+}
+
+func (x *ArchivedAlert) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+type ListAlertHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional filters; empty matches every location or rule.
+	LocationId string `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	RuleId     string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Optional bounds on window_start, start inclusive and end exclusive.
+	// Unset bounds are open.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Maximum alerts returned. 0 uses the server default (100); values above
+	// 1000 are capped.
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertHistoryRequest) Reset() {
+	*x = ListAlertHistoryRequest{}
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertHistoryRequest) ProtoMessage() {}
+
+func (x *ListAlertHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_forecast_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAlertHistoryRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListAlertHistoryRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *ListAlertHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListAlertHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListAlertHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAlertHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest window_start first.
+	Alerts        []*ArchivedAlert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertHistoryResponse) Reset() {
+	*x = ListAlertHistoryResponse{}
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertHistoryResponse) ProtoMessage() {}
+
+func (x *ListAlertHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_forecast_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListAlertHistoryResponse) GetAlerts() []*ArchivedAlert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+// AlertEvent is one transition in a forecast alert's life: created, updated,
+// escalated, resolved, reactivated, delivered, covered, held or pruned. The
+// unprefixed fields are the alert after it, the prev_ fields before it.
+type AlertEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AlertId     string                 `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	Type        string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	At          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	LocationId  string                 `protobuf:"bytes,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	RuleId      string                 `protobuf:"bytes,5,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Status      string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Severity    string                 `protobuf:"bytes,7,opt,name=severity,proto3" json:"severity,omitempty"`
+	Value       float64                `protobuf:"fixed64,8,opt,name=value,proto3" json:"value,omitempty"`
+	Threshold   float64                `protobuf:"fixed64,9,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	WindowStart *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	// Unset when the alert has not been sent, or was re-armed by escalation.
+	NotifiedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=notified_at,json=notifiedAt,proto3" json:"notified_at,omitempty"`
+	PrevStatus     string                 `protobuf:"bytes,13,opt,name=prev_status,json=prevStatus,proto3" json:"prev_status,omitempty"`
+	PrevSeverity   string                 `protobuf:"bytes,14,opt,name=prev_severity,json=prevSeverity,proto3" json:"prev_severity,omitempty"`
+	PrevValue      float64                `protobuf:"fixed64,15,opt,name=prev_value,json=prevValue,proto3" json:"prev_value,omitempty"`
+	PrevNotifiedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=prev_notified_at,json=prevNotifiedAt,proto3" json:"prev_notified_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AlertEvent) Reset() {
+	*x = AlertEvent{}
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertEvent) ProtoMessage() {}
+
+func (x *AlertEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertEvent.ProtoReflect.Descriptor instead.
+func (*AlertEvent) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_forecast_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AlertEvent) GetAlertId() string {
+	if x != nil {
+		return x.AlertId
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AlertEvent) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *AlertEvent) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetNotifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotifiedAt
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetPrevStatus() string {
+	if x != nil {
+		return x.PrevStatus
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetPrevSeverity() string {
+	if x != nil {
+		return x.PrevSeverity
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetPrevValue() float64 {
+	if x != nil {
+		return x.PrevValue
+	}
+	return 0
+}
+
+func (x *AlertEvent) GetPrevNotifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PrevNotifiedAt
+	}
+	return nil
+}
+
+type ListAlertEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlertId       string                 `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertEventsRequest) Reset() {
+	*x = ListAlertEventsRequest{}
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertEventsRequest) ProtoMessage() {}
+
+func (x *ListAlertEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertEventsRequest) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_forecast_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListAlertEventsRequest) GetAlertId() string {
+	if x != nil {
+		return x.AlertId
+	}
+	return ""
+}
+
+type ListAlertEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Events        []*AlertEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertEventsResponse) Reset() {
+	*x = ListAlertEventsResponse{}
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertEventsResponse) ProtoMessage() {}
+
+func (x *ListAlertEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_forecast_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertEventsResponse) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_forecast_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListAlertEventsResponse) GetEvents() []*AlertEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
 var File_weather_provider_v1_forecast_proto protoreflect.FileDescriptor
 
 const file_weather_provider_v1_forecast_proto_rawDesc = "" +
@@ -585,10 +1050,59 @@ const file_weather_provider_v1_forecast_proto_rawDesc = "" +
 	"\vlocation_id\x18\x01 \x01(\tR\n" +
 	"locationId\"P\n" +
 	"\x13GetForecastResponse\x129\n" +
-	"\bforecast\x18\x01 \x01(\v2\x1d.weather_provider.v1.ForecastR\bforecast2\xe1\x01\n" +
+	"\bforecast\x18\x01 \x01(\v2\x1d.weather_provider.v1.ForecastR\bforecast\"\xff\x01\n" +
+	"\rArchivedAlert\x120\n" +
+	"\x05alert\x18\x01 \x01(\v2\x1a.weather_provider.v1.AlertR\x05alert\x12\x1d\n" +
+	"\n" +
+	"peak_value\x18\x02 \x01(\x01R\tpeakValue\x12#\n" +
+	"\rpeak_severity\x18\x03 \x01(\tR\fpeakSeverity\x12;\n" +
+	"\vnotified_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"notifiedAt\x12;\n" +
+	"\varchived_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\"\xdb\x01\n" +
+	"\x17ListAlertHistoryRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\tR\x06ruleId\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"V\n" +
+	"\x18ListAlertHistoryResponse\x12:\n" +
+	"\x06alerts\x18\x01 \x03(\v2\".weather_provider.v1.ArchivedAlertR\x06alerts\"\xeb\x04\n" +
+	"\n" +
+	"AlertEvent\x12\x19\n" +
+	"\balert_id\x18\x01 \x01(\tR\aalertId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12*\n" +
+	"\x02at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\tR\n" +
+	"locationId\x12\x17\n" +
+	"\arule_id\x18\x05 \x01(\tR\x06ruleId\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\bseverity\x18\a \x01(\tR\bseverity\x12\x14\n" +
+	"\x05value\x18\b \x01(\x01R\x05value\x12\x1c\n" +
+	"\tthreshold\x18\t \x01(\x01R\tthreshold\x12=\n" +
+	"\fwindow_start\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vwindowStart\x129\n" +
+	"\n" +
+	"window_end\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\twindowEnd\x12;\n" +
+	"\vnotified_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"notifiedAt\x12\x1f\n" +
+	"\vprev_status\x18\r \x01(\tR\n" +
+	"prevStatus\x12#\n" +
+	"\rprev_severity\x18\x0e \x01(\tR\fprevSeverity\x12\x1d\n" +
+	"\n" +
+	"prev_value\x18\x0f \x01(\x01R\tprevValue\x12D\n" +
+	"\x10prev_notified_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x0eprevNotifiedAt\"3\n" +
+	"\x16ListAlertEventsRequest\x12\x19\n" +
+	"\balert_id\x18\x01 \x01(\tR\aalertId\"R\n" +
+	"\x17ListAlertEventsResponse\x127\n" +
+	"\x06events\x18\x01 \x03(\v2\x1f.weather_provider.v1.AlertEventR\x06events2\xc0\x03\n" +
 	"\x0fForecastService\x12l\n" +
 	"\x0fGetAllForecasts\x12+.weather_provider.v1.GetAllForecastsRequest\x1a,.weather_provider.v1.GetAllForecastsResponse\x12`\n" +
-	"\vGetForecast\x12'.weather_provider.v1.GetForecastRequest\x1a(.weather_provider.v1.GetForecastResponseB\xf7\x01\n" +
+	"\vGetForecast\x12'.weather_provider.v1.GetForecastRequest\x1a(.weather_provider.v1.GetForecastResponse\x12o\n" +
+	"\x10ListAlertHistory\x12,.weather_provider.v1.ListAlertHistoryRequest\x1a-.weather_provider.v1.ListAlertHistoryResponse\x12l\n" +
+	"\x0fListAlertEvents\x12+.weather_provider.v1.ListAlertEventsRequest\x1a,.weather_provider.v1.ListAlertEventsResponseB\xf7\x01\n" +
 	"\x17com.weather_provider.v1B\rForecastProtoP\x01Zdgithub.com/nickfang/personal-dashboard/services/weather-provider/internal/gen/go/weather-provider/v1\xa2\x02\x03WXX\xaa\x02\x12WeatherProvider.V1\xca\x02\x12WeatherProvider\\V1\xe2\x02\x1eWeatherProvider\\V1\\GPBMetadata\xea\x02\x13WeatherProvider::V1b\x06proto3"
 
 var (
@@ -603,36 +1117,58 @@ func file_weather_provider_v1_forecast_proto_rawDescGZIP() []byte {
 	return file_weather_provider_v1_forecast_proto_rawDescData
 }
 
-var file_weather_provider_v1_forecast_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_weather_provider_v1_forecast_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_weather_provider_v1_forecast_proto_goTypes = []any{
-	(*ForecastPoint)(nil),           // 0: weather_provider.v1.ForecastPoint
-	(*Alert)(nil),                   // 1: weather_provider.v1.Alert
-	(*Forecast)(nil),                // 2: weather_provider.v1.Forecast
-	(*GetAllForecastsRequest)(nil),  // 3: weather_provider.v1.GetAllForecastsRequest
-	(*GetAllForecastsResponse)(nil), // 4: weather_provider.v1.GetAllForecastsResponse
-	(*GetForecastRequest)(nil),      // 5: weather_provider.v1.GetForecastRequest
-	(*GetForecastResponse)(nil),     // 6: weather_provider.v1.GetForecastResponse
-	(*timestamppb.Timestamp)(nil),   // 7: google.protobuf.Timestamp
+	(*ForecastPoint)(nil),            // 0: weather_provider.v1.ForecastPoint
+	(*Alert)(nil),                    // 1: weather_provider.v1.Alert
+	(*Forecast)(nil),                 // 2: weather_provider.v1.Forecast
+	(*GetAllForecastsRequest)(nil),   // 3: weather_provider.v1.GetAllForecastsRequest
+	(*GetAllForecastsResponse)(nil),  // 4: weather_provider.v1.GetAllForecastsResponse
+	(*GetForecastRequest)(nil),       // 5: weather_provider.v1.GetForecastRequest
+	(*GetForecastResponse)(nil),      // 6: weather_provider.v1.GetForecastResponse
+	(*ArchivedAlert)(nil),            // 7: weather_provider.v1.ArchivedAlert
+	(*ListAlertHistoryRequest)(nil),  // 8: weather_provider.v1.ListAlertHistoryRequest
+	(*ListAlertHistoryResponse)(nil), // 9: weather_provider.v1.ListAlertHistoryResponse
+	(*AlertEvent)(nil),               // 10: weather_provider.v1.AlertEvent
+	(*ListAlertEventsRequest)(nil),   // 11: weather_provider.v1.ListAlertEventsRequest
+	(*ListAlertEventsResponse)(nil),  // 12: weather_provider.v1.ListAlertEventsResponse
+	(*timestamppb.Timestamp)(nil),    // 13: google.protobuf.Timestamp
 }
 var file_weather_provider_v1_forecast_proto_depIdxs = []int32{
-	7,  // 0: weather_provider.v1.ForecastPoint.valid_time:type_name -> google.protobuf.Timestamp
-	7,  // 1: weather_provider.v1.Alert.window_start:type_name -> google.protobuf.Timestamp
-	7,  // 2: weather_provider.v1.Alert.window_end:type_name -> google.protobuf.Timestamp
-	7,  // 3: weather_provider.v1.Alert.issued_at:type_name -> google.protobuf.Timestamp
-	7,  // 4: weather_provider.v1.Forecast.issued_at:type_name -> google.protobuf.Timestamp
+	13, // 0: weather_provider.v1.ForecastPoint.valid_time:type_name -> google.protobuf.Timestamp
+	13, // 1: weather_provider.v1.Alert.window_start:type_name -> google.protobuf.Timestamp
+	13, // 2: weather_provider.v1.Alert.window_end:type_name -> google.protobuf.Timestamp
+	13, // 3: weather_provider.v1.Alert.issued_at:type_name -> google.protobuf.Timestamp
+	13, // 4: weather_provider.v1.Forecast.issued_at:type_name -> google.protobuf.Timestamp
 	0,  // 5: weather_provider.v1.Forecast.points:type_name -> weather_provider.v1.ForecastPoint
 	1,  // 6: weather_provider.v1.Forecast.alerts:type_name -> weather_provider.v1.Alert
 	2,  // 7: weather_provider.v1.GetAllForecastsResponse.forecasts:type_name -> weather_provider.v1.Forecast
 	2,  // 8: weather_provider.v1.GetForecastResponse.forecast:type_name -> weather_provider.v1.Forecast
-	3,  // 9: weather_provider.v1.ForecastService.GetAllForecasts:input_type -> weather_provider.v1.GetAllForecastsRequest
-	5,  // 10: weather_provider.v1.ForecastService.GetForecast:input_type -> weather_provider.v1.GetForecastRequest
-	4,  // 11: weather_provider.v1.ForecastService.GetAllForecasts:output_type -> weather_provider.v1.GetAllForecastsResponse
-	6,  // 12: weather_provider.v1.ForecastService.GetForecast:output_type -> weather_provider.v1.GetForecastResponse
-	11, // [11:13] is the sub-list for method output_type
-	9,  // [9:11] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	1,  // 9: weather_provider.v1.ArchivedAlert.alert:type_name -> weather_provider.v1.Alert
+	13, // 10: weather_provider.v1.ArchivedAlert.notified_at:type_name -> google.protobuf.Timestamp
+	13, // 11: weather_provider.v1.ArchivedAlert.archived_at:type_name -> google.protobuf.Timestamp
+	13, // 12: weather_provider.v1.ListAlertHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	13, // 13: weather_provider.v1.ListAlertHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	7,  // 14: weather_provider.v1.ListAlertHistoryResponse.alerts:type_name -> weather_provider.v1.ArchivedAlert
+	13, // 15: weather_provider.v1.AlertEvent.at:type_name -> google.protobuf.Timestamp
+	13, // 16: weather_provider.v1.AlertEvent.window_start:type_name -> google.protobuf.Timestamp
+	13, // 17: weather_provider.v1.AlertEvent.window_end:type_name -> google.protobuf.Timestamp
+	13, // 18: weather_provider.v1.AlertEvent.notified_at:type_name -> google.protobuf.Timestamp
+	13, // 19: weather_provider.v1.AlertEvent.prev_notified_at:type_name -> google.protobuf.Timestamp
+	10, // 20: weather_provider.v1.ListAlertEventsResponse.events:type_name -> weather_provider.v1.AlertEvent
+	3,  // 21: weather_provider.v1.ForecastService.GetAllForecasts:input_type -> weather_provider.v1.GetAllForecastsRequest
+	5,  // 22: weather_provider.v1.ForecastService.GetForecast:input_type -> weather_provider.v1.GetForecastRequest
+	8,  // 23: weather_provider.v1.ForecastService.ListAlertHistory:input_type -> weather_provider.v1.ListAlertHistoryRequest
+	11, // 24: weather_provider.v1.ForecastService.ListAlertEvents:input_type -> weather_provider.v1.ListAlertEventsRequest
+	4,  // 25: weather_provider.v1.ForecastService.GetAllForecasts:output_type -> weather_provider.v1.GetAllForecastsResponse
+	6,  // 26: weather_provider.v1.ForecastService.GetForecast:output_type -> weather_provider.v1.GetForecastResponse
+	9,  // 27: weather_provider.v1.ForecastService.ListAlertHistory:output_type -> weather_provider.v1.ListAlertHistoryResponse
+	12, // 28: weather_provider.v1.ForecastService.ListAlertEvents:output_type -> weather_provider.v1.ListAlertEventsResponse
+	25, // [25:29] is the sub-list for method output_type
+	21, // [21:25] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_weather_provider_v1_forecast_proto_init() }
@@ -646,7 +1182,7 @@ func file_weather_provider_v1_forecast_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_weather_provider_v1_forecast_proto_rawDesc), len(file_weather_provider_v1_forecast_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ForecastService_GetAllForecasts_FullMethodName = "/weather_provider.v1.ForecastService/GetAllForecasts"
-	ForecastService_GetForecast_FullMethodName     = "/weather_provider.v1.ForecastService/GetForecast"
+	ForecastService_GetAllForecasts_FullMethodName  = "/weather_provider.v1.ForecastService/GetAllForecasts"
+	ForecastService_GetForecast_FullMethodName      = "/weather_provider.v1.ForecastService/GetForecast"
+	ForecastService_ListAlertHistory_FullMethodName = "/weather_provider.v1.ForecastService/ListAlertHistory"
+	ForecastService_ListAlertEvents_FullMethodName  = "/weather_provider.v1.ForecastService/ListAlertEvents"
 )
 
 // ForecastServiceClient is the client API for ForecastService service.
@@ -33,6 +35,8 @@ const (
 type ForecastServiceClient interface {
 	GetAllForecasts(ctx context.Context, in *GetAllForecastsRequest, opts ...grpc.CallOption) (*GetAllForecastsResponse, error)
 	GetForecast(ctx context.Context, in *GetForecastRequest, opts ...grpc.CallOption) (*GetForecastResponse, error)
+	ListAlertHistory(ctx context.Context, in *ListAlertHistoryRequest, opts ...grpc.CallOption) (*ListAlertHistoryResponse, error)
+	ListAlertEvents(ctx context.Context, in *ListAlertEventsRequest, opts ...grpc.CallOption) (*ListAlertEventsResponse, error)
 }
 
 type forecastServiceClient struct {
@@ -63,6 +67,26 @@ func (c *forecastServiceClient) GetForecast(ctx context.Context, in *GetForecast
 	return out, nil
 }
 
+func (c *forecastServiceClient) ListAlertHistory(ctx context.Context, in *ListAlertHistoryRequest, opts ...grpc.CallOption) (*ListAlertHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertHistoryResponse)
+	err := c.cc.Invoke(ctx, ForecastService_ListAlertHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forecastServiceClient) ListAlertEvents(ctx context.Context, in *ListAlertEventsRequest, opts ...grpc.CallOption) (*ListAlertEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertEventsResponse)
+	err := c.cc.Invoke(ctx, ForecastService_ListAlertEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ForecastServiceServer is the server API for ForecastService service.
 // All implementations must embed UnimplementedForecastServiceServer
 // for forward compatibility.
@@ -73,6 +97,8 @@ func (c *forecastServiceClient) GetForecast(ctx context.Context, in *GetForecast
 type ForecastServiceServer interface {
 	GetAllForecasts(context.Context, *GetAllForecastsRequest) (*GetAllForecastsResponse, error)
 	GetForecast(context.Context, *GetForecastRequest) (*GetForecastResponse, error)
+	ListAlertHistory(context.Context, *ListAlertHistoryRequest) (*ListAlertHistoryResponse, error)
+	ListAlertEvents(context.Context, *ListAlertEventsRequest) (*ListAlertEventsResponse, error)
 	mustEmbedUnimplementedForecastServiceServer()
 }
 
@@ -89,6 +115,12 @@ func (UnimplementedForecastServiceServer) GetAllForecasts(context.Context, *GetA
 func (UnimplementedForecastServiceServer) GetForecast(context.Context, *GetForecastRequest) (*GetForecastResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetForecast not implemented")
 }
+func (UnimplementedForecastServiceServer) ListAlertHistory(context.Context, *ListAlertHistoryRequest) (*ListAlertHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAlertHistory not implemented")
+}
+func (UnimplementedForecastServiceServer) ListAlertEvents(context.Context, *ListAlertEventsRequest) (*ListAlertEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAlertEvents not implemented")
+}
 func (UnimplementedForecastServiceServer) mustEmbedUnimplementedForecastServiceServer() {}
 func (UnimplementedForecastServiceServer) testEmbeddedByValue()                         {}
 
@@ -146,6 +178,42 @@ func _ForecastService_GetForecast_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ForecastService_ListAlertHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForecastServiceServer).ListAlertHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForecastService_ListAlertHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForecastServiceServer).ListAlertHistory(ctx, req.(*ListAlertHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForecastService_ListAlertEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForecastServiceServer).ListAlertEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForecastService_ListAlertEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForecastServiceServer).ListAlertEvents(ctx, req.(*ListAlertEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ForecastService_ServiceDesc is the grpc.ServiceDesc for ForecastService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +229,14 @@ var ForecastService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetForecast",
 			Handler:    _ForecastService_GetForecast_Handler,
 		},
+		{
+			MethodName: "ListAlertHistory",
+			Handler:    _ForecastService_ListAlertHistory_Handler,
+		},
+		{
+			MethodName: "ListAlertEvents",
+			Handler:    _ForecastService_ListAlertEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "weather-provider/v1/forecast.proto",
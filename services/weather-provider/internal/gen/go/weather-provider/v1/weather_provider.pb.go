@@ -566,6 +566,522 @@ func (x *GetLastWeatherResponse) GetWeather() *Weather {
 	return nil
 }
 
+// PressurePoint is one observed reading from the weather cache's rolling
+// history (hourly, up to 48 points).
+type PressurePoint struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	PressureMb      float64                `protobuf:"fixed64,2,opt,name=pressure_mb,json=pressureMb,proto3" json:"pressure_mb,omitempty"`
+	HumidityPercent int32                  `protobuf:"varint,3,opt,name=humidity_percent,json=humidityPercent,proto3" json:"humidity_percent,omitempty"`
+	TempC           float64                `protobuf:"fixed64,4,opt,name=temp_c,json=tempC,proto3" json:"temp_c,omitempty"`
+	TempF           float64                `protobuf:"fixed64,5,opt,name=temp_f,json=tempF,proto3" json:"temp_f,omitempty"`
+	TempFeelC       float64                `protobuf:"fixed64,6,opt,name=temp_feel_c,json=tempFeelC,proto3" json:"temp_feel_c,omitempty"`
+	TempFeelF       float64                `protobuf:"fixed64,7,opt,name=temp_feel_f,json=tempFeelF,proto3" json:"temp_feel_f,omitempty"`
+	DewpointC       float64                `protobuf:"fixed64,8,opt,name=dewpoint_c,json=dewpointC,proto3" json:"dewpoint_c,omitempty"`
+	DewpointF       float64                `protobuf:"fixed64,9,opt,name=dewpoint_f,json=dewpointF,proto3" json:"dewpoint_f,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PressurePoint) Reset() {
+	*x = PressurePoint{}
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressurePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressurePoint) ProtoMessage() {}
+
+func (x *PressurePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressurePoint.ProtoReflect.Descriptor instead.
+func (*PressurePoint) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_weather_provider_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PressurePoint) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *PressurePoint) GetPressureMb() float64 {
+	if x != nil {
+		return x.PressureMb
+	}
+	return 0
+}
+
+func (x *PressurePoint) GetHumidityPercent() int32 {
+	if x != nil {
+		return x.HumidityPercent
+	}
+	return 0
+}
+
+func (x *PressurePoint) GetTempC() float64 {
+	if x != nil {
+		return x.TempC
+	}
+	return 0
+}
+
+func (x *PressurePoint) GetTempF() float64 {
+	if x != nil {
+		return x.TempF
+	}
+	return 0
+}
+
+func (x *PressurePoint) GetTempFeelC() float64 {
+	if x != nil {
+		return x.TempFeelC
+	}
+	return 0
+}
+
+func (x *PressurePoint) GetTempFeelF() float64 {
+	if x != nil {
+		return x.TempFeelF
+	}
+	return 0
+}
+
+func (x *PressurePoint) GetDewpointC() float64 {
+	if x != nil {
+		return x.DewpointC
+	}
+	return 0
+}
+
+func (x *PressurePoint) GetDewpointF() float64 {
+	if x != nil {
+		return x.DewpointF
+	}
+	return 0
+}
+
+type GetPressureHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPressureHistoryRequest) Reset() {
+	*x = GetPressureHistoryRequest{}
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPressureHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPressureHistoryRequest) ProtoMessage() {}
+
+func (x *GetPressureHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPressureHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPressureHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_weather_provider_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPressureHistoryRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type GetPressureHistoryResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	LocationId string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// Oldest first.
+	Points        []*PressurePoint `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPressureHistoryResponse) Reset() {
+	*x = GetPressureHistoryResponse{}
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPressureHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPressureHistoryResponse) ProtoMessage() {}
+
+func (x *GetPressureHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPressureHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPressureHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_weather_provider_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPressureHistoryResponse) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *GetPressureHistoryResponse) GetPoints() []*PressurePoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// WeatherPoint is one raw observation as weather-collector stored it.
+type WeatherPoint struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	LocationId           string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Timestamp            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	HumidityPercent      int32                  `protobuf:"varint,3,opt,name=humidity_percent,json=humidityPercent,proto3" json:"humidity_percent,omitempty"`
+	PrecipitationPercent int32                  `protobuf:"varint,4,opt,name=precipitation_percent,json=precipitationPercent,proto3" json:"precipitation_percent,omitempty"`
+	UvIndex              int32                  `protobuf:"varint,5,opt,name=uv_index,json=uvIndex,proto3" json:"uv_index,omitempty"`
+	PressureMb           float64                `protobuf:"fixed64,6,opt,name=pressure_mb,json=pressureMb,proto3" json:"pressure_mb,omitempty"`
+	WindDirDeg           int32                  `protobuf:"varint,7,opt,name=wind_dir_deg,json=windDirDeg,proto3" json:"wind_dir_deg,omitempty"`
+	TempC                float64                `protobuf:"fixed64,8,opt,name=temp_c,json=tempC,proto3" json:"temp_c,omitempty"`
+	TempFeelC            float64                `protobuf:"fixed64,9,opt,name=temp_feel_c,json=tempFeelC,proto3" json:"temp_feel_c,omitempty"`
+	DewpointC            float64                `protobuf:"fixed64,10,opt,name=dewpoint_c,json=dewpointC,proto3" json:"dewpoint_c,omitempty"`
+	WindSpeedKph         float64                `protobuf:"fixed64,11,opt,name=wind_speed_kph,json=windSpeedKph,proto3" json:"wind_speed_kph,omitempty"`
+	WindGustKph          float64                `protobuf:"fixed64,12,opt,name=wind_gust_kph,json=windGustKph,proto3" json:"wind_gust_kph,omitempty"`
+	VisibilityKm         float64                `protobuf:"fixed64,13,opt,name=visibility_km,json=visibilityKm,proto3" json:"visibility_km,omitempty"`
+	TempF                float64                `protobuf:"fixed64,14,opt,name=temp_f,json=tempF,proto3" json:"temp_f,omitempty"`
+	TempFeelF            float64                `protobuf:"fixed64,15,opt,name=temp_feel_f,json=tempFeelF,proto3" json:"temp_feel_f,omitempty"`
+	WindSpeedMph         float64                `protobuf:"fixed64,16,opt,name=wind_speed_mph,json=windSpeedMph,proto3" json:"wind_speed_mph,omitempty"`
+	WindGustMph          float64                `protobuf:"fixed64,17,opt,name=wind_gust_mph,json=windGustMph,proto3" json:"wind_gust_mph,omitempty"`
+	VisibilityMiles      float64                `protobuf:"fixed64,18,opt,name=visibility_miles,json=visibilityMiles,proto3" json:"visibility_miles,omitempty"`
+	DewpointF            float64                `protobuf:"fixed64,19,opt,name=dewpoint_f,json=dewpointF,proto3" json:"dewpoint_f,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WeatherPoint) Reset() {
+	*x = WeatherPoint{}
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeatherPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherPoint) ProtoMessage() {}
+
+func (x *WeatherPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherPoint.ProtoReflect.Descriptor instead.
+func (*WeatherPoint) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_weather_provider_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WeatherPoint) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *WeatherPoint) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *WeatherPoint) GetHumidityPercent() int32 {
+	if x != nil {
+		return x.HumidityPercent
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetPrecipitationPercent() int32 {
+	if x != nil {
+		return x.PrecipitationPercent
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetUvIndex() int32 {
+	if x != nil {
+		return x.UvIndex
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetPressureMb() float64 {
+	if x != nil {
+		return x.PressureMb
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetWindDirDeg() int32 {
+	if x != nil {
+		return x.WindDirDeg
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetTempC() float64 {
+	if x != nil {
+		return x.TempC
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetTempFeelC() float64 {
+	if x != nil {
+		return x.TempFeelC
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetDewpointC() float64 {
+	if x != nil {
+		return x.DewpointC
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetWindSpeedKph() float64 {
+	if x != nil {
+		return x.WindSpeedKph
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetWindGustKph() float64 {
+	if x != nil {
+		return x.WindGustKph
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetVisibilityKm() float64 {
+	if x != nil {
+		return x.VisibilityKm
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetTempF() float64 {
+	if x != nil {
+		return x.TempF
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetTempFeelF() float64 {
+	if x != nil {
+		return x.TempFeelF
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetWindSpeedMph() float64 {
+	if x != nil {
+		return x.WindSpeedMph
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetWindGustMph() float64 {
+	if x != nil {
+		return x.WindGustMph
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetVisibilityMiles() float64 {
+	if x != nil {
+		return x.VisibilityMiles
+	}
+	return 0
+}
+
+func (x *WeatherPoint) GetDewpointF() float64 {
+	if x != nil {
+		return x.DewpointF
+	}
+	return 0
+}
+
+type QueryRawWeatherRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty matches every location.
+	LocationId string `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// Inclusive. Unset reads from the start of the archive.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Exclusive. Unset reads to the newest observation.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Observations per Firestore page and per streamed response. 0 uses the
+	// server default; larger values are capped.
+	PageSize      int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRawWeatherRequest) Reset() {
+	*x = QueryRawWeatherRequest{}
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRawWeatherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRawWeatherRequest) ProtoMessage() {}
+
+func (x *QueryRawWeatherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRawWeatherRequest.ProtoReflect.Descriptor instead.
+func (*QueryRawWeatherRequest) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_weather_provider_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QueryRawWeatherRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *QueryRawWeatherRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *QueryRawWeatherRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *QueryRawWeatherRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// QueryRawWeatherResponse carries one page of observations. Pages arrive
+// oldest first, so a client that loses the stream can resume from the last
+// timestamp it received.
+type QueryRawWeatherResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*WeatherPoint        `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRawWeatherResponse) Reset() {
+	*x = QueryRawWeatherResponse{}
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRawWeatherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRawWeatherResponse) ProtoMessage() {}
+
+func (x *QueryRawWeatherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_provider_v1_weather_provider_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRawWeatherResponse.ProtoReflect.Descriptor instead.
+func (*QueryRawWeatherResponse) Descriptor() ([]byte, []int) {
+	return file_weather_provider_v1_weather_provider_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *QueryRawWeatherResponse) GetPoints() []*WeatherPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
 var File_weather_provider_v1_weather_provider_proto protoreflect.FileDescriptor
 
 const file_weather_provider_v1_weather_provider_proto_rawDesc = "" +
@@ -608,12 +1124,70 @@ const file_weather_provider_v1_weather_provider_proto_rawDesc = "" +
 	"\vlocation_id\x18\x01 \x01(\tR\n" +
 	"locationId\"P\n" +
 	"\x16GetLastWeatherResponse\x126\n" +
-	"\aweather\x18\x01 \x01(\v2\x1c.weather_provider.v1.WeatherR\aweather2\xe0\x03\n" +
+	"\aweather\x18\x01 \x01(\v2\x1c.weather_provider.v1.WeatherR\aweather\"\xc1\x02\n" +
+	"\rPressurePoint\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1f\n" +
+	"\vpressure_mb\x18\x02 \x01(\x01R\n" +
+	"pressureMb\x12)\n" +
+	"\x10humidity_percent\x18\x03 \x01(\x05R\x0fhumidityPercent\x12\x15\n" +
+	"\x06temp_c\x18\x04 \x01(\x01R\x05tempC\x12\x15\n" +
+	"\x06temp_f\x18\x05 \x01(\x01R\x05tempF\x12\x1e\n" +
+	"\vtemp_feel_c\x18\x06 \x01(\x01R\ttempFeelC\x12\x1e\n" +
+	"\vtemp_feel_f\x18\a \x01(\x01R\ttempFeelF\x12\x1d\n" +
+	"\n" +
+	"dewpoint_c\x18\b \x01(\x01R\tdewpointC\x12\x1d\n" +
+	"\n" +
+	"dewpoint_f\x18\t \x01(\x01R\tdewpointF\"<\n" +
+	"\x19GetPressureHistoryRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\"y\n" +
+	"\x1aGetPressureHistoryResponse\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12:\n" +
+	"\x06points\x18\x02 \x03(\v2\".weather_provider.v1.PressurePointR\x06points\"\xb7\x05\n" +
+	"\fWeatherPoint\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12)\n" +
+	"\x10humidity_percent\x18\x03 \x01(\x05R\x0fhumidityPercent\x123\n" +
+	"\x15precipitation_percent\x18\x04 \x01(\x05R\x14precipitationPercent\x12\x19\n" +
+	"\buv_index\x18\x05 \x01(\x05R\auvIndex\x12\x1f\n" +
+	"\vpressure_mb\x18\x06 \x01(\x01R\n" +
+	"pressureMb\x12 \n" +
+	"\fwind_dir_deg\x18\a \x01(\x05R\n" +
+	"windDirDeg\x12\x15\n" +
+	"\x06temp_c\x18\b \x01(\x01R\x05tempC\x12\x1e\n" +
+	"\vtemp_feel_c\x18\t \x01(\x01R\ttempFeelC\x12\x1d\n" +
+	"\n" +
+	"dewpoint_c\x18\n" +
+	" \x01(\x01R\tdewpointC\x12$\n" +
+	"\x0ewind_speed_kph\x18\v \x01(\x01R\fwindSpeedKph\x12\"\n" +
+	"\rwind_gust_kph\x18\f \x01(\x01R\vwindGustKph\x12#\n" +
+	"\rvisibility_km\x18\r \x01(\x01R\fvisibilityKm\x12\x15\n" +
+	"\x06temp_f\x18\x0e \x01(\x01R\x05tempF\x12\x1e\n" +
+	"\vtemp_feel_f\x18\x0f \x01(\x01R\ttempFeelF\x12$\n" +
+	"\x0ewind_speed_mph\x18\x10 \x01(\x01R\fwindSpeedMph\x12\"\n" +
+	"\rwind_gust_mph\x18\x11 \x01(\x01R\vwindGustMph\x12)\n" +
+	"\x10visibility_miles\x18\x12 \x01(\x01R\x0fvisibilityMiles\x12\x1d\n" +
+	"\n" +
+	"dewpoint_f\x18\x13 \x01(\x01R\tdewpointF\"\xc8\x01\n" +
+	"\x16QueryRawWeatherRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"T\n" +
+	"\x17QueryRawWeatherResponse\x129\n" +
+	"\x06points\x18\x01 \x03(\v2!.weather_provider.v1.WeatherPointR\x06points2\xd7\x04\n" +
 	"\x14PressureStatsService\x12x\n" +
 	"\x13GetAllPressureStats\x12/.weather_provider.v1.GetAllPressureStatsRequest\x1a0.weather_provider.v1.GetAllPressureStatsResponse\x12o\n" +
 	"\x10GetPressureStats\x12,.weather_provider.v1.GetPressureStatsRequest\x1a-.weather_provider.v1.GetPressureStatsResponse\x12r\n" +
 	"\x11GetAllLastWeather\x12-.weather_provider.v1.GetAllLastWeatherRequest\x1a..weather_provider.v1.GetAllLastWeatherResponse\x12i\n" +
-	"\x0eGetLastWeather\x12*.weather_provider.v1.GetLastWeatherRequest\x1a+.weather_provider.v1.GetLastWeatherResponseB\xfe\x01\n" +
+	"\x0eGetLastWeather\x12*.weather_provider.v1.GetLastWeatherRequest\x1a+.weather_provider.v1.GetLastWeatherResponse\x12u\n" +
+	"\x12GetPressureHistory\x12..weather_provider.v1.GetPressureHistoryRequest\x1a/.weather_provider.v1.GetPressureHistoryResponse2\x83\x01\n" +
+	"\x11RawWeatherService\x12n\n" +
+	"\x0fQueryRawWeather\x12+.weather_provider.v1.QueryRawWeatherRequest\x1a,.weather_provider.v1.QueryRawWeatherResponse0\x01B\xfe\x01\n" +
 	"\x17com.weather_provider.v1B\x14WeatherProviderProtoP\x01Zdgithub.com/nickfang/personal-dashboard/services/weather-provider/internal/gen/go/weather-provider/v1\xa2\x02\x03WXX\xaa\x02\x12WeatherProvider.V1\xca\x02\x12WeatherProvider\\V1\xe2\x02\x1eWeatherProvider\\V1\\GPBMetadata\xea\x02\x13WeatherProvider::V1b\x06proto3"
 
 var (
@@ -628,7 +1202,7 @@ func file_weather_provider_v1_weather_provider_proto_rawDescGZIP() []byte {
 	return file_weather_provider_v1_weather_provider_proto_rawDescData
 }
 
-var file_weather_provider_v1_weather_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_weather_provider_v1_weather_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_weather_provider_v1_weather_provider_proto_goTypes = []any{
 	(*PressureStat)(nil),                // 0: weather_provider.v1.PressureStat
 	(*Weather)(nil),                     // 1: weather_provider.v1.Weather
@@ -640,28 +1214,44 @@ var file_weather_provider_v1_weather_provider_proto_goTypes = []any{
 	(*GetAllLastWeatherResponse)(nil),   // 7: weather_provider.v1.GetAllLastWeatherResponse
 	(*GetLastWeatherRequest)(nil),       // 8: weather_provider.v1.GetLastWeatherRequest
 	(*GetLastWeatherResponse)(nil),      // 9: weather_provider.v1.GetLastWeatherResponse
-	(*timestamppb.Timestamp)(nil),       // 10: google.protobuf.Timestamp
+	(*PressurePoint)(nil),               // 10: weather_provider.v1.PressurePoint
+	(*GetPressureHistoryRequest)(nil),   // 11: weather_provider.v1.GetPressureHistoryRequest
+	(*GetPressureHistoryResponse)(nil),  // 12: weather_provider.v1.GetPressureHistoryResponse
+	(*WeatherPoint)(nil),                // 13: weather_provider.v1.WeatherPoint
+	(*QueryRawWeatherRequest)(nil),      // 14: weather_provider.v1.QueryRawWeatherRequest
+	(*QueryRawWeatherResponse)(nil),     // 15: weather_provider.v1.QueryRawWeatherResponse
+	(*timestamppb.Timestamp)(nil),       // 16: google.protobuf.Timestamp
 }
 var file_weather_provider_v1_weather_provider_proto_depIdxs = []int32{
-	10, // 0: weather_provider.v1.PressureStat.last_updated:type_name -> google.protobuf.Timestamp
-	10, // 1: weather_provider.v1.Weather.last_updated:type_name -> google.protobuf.Timestamp
+	16, // 0: weather_provider.v1.PressureStat.last_updated:type_name -> google.protobuf.Timestamp
+	16, // 1: weather_provider.v1.Weather.last_updated:type_name -> google.protobuf.Timestamp
 	0,  // 2: weather_provider.v1.GetAllPressureStatsResponse.stats:type_name -> weather_provider.v1.PressureStat
 	0,  // 3: weather_provider.v1.GetPressureStatsResponse.stat:type_name -> weather_provider.v1.PressureStat
 	1,  // 4: weather_provider.v1.GetAllLastWeatherResponse.weather:type_name -> weather_provider.v1.Weather
 	1,  // 5: weather_provider.v1.GetLastWeatherResponse.weather:type_name -> weather_provider.v1.Weather
-	2,  // 6: weather_provider.v1.PressureStatsService.GetAllPressureStats:input_type -> weather_provider.v1.GetAllPressureStatsRequest
-	4,  // 7: weather_provider.v1.PressureStatsService.GetPressureStats:input_type -> weather_provider.v1.GetPressureStatsRequest
-	6,  // 8: weather_provider.v1.PressureStatsService.GetAllLastWeather:input_type -> weather_provider.v1.GetAllLastWeatherRequest
-	8,  // 9: weather_provider.v1.PressureStatsService.GetLastWeather:input_type -> weather_provider.v1.GetLastWeatherRequest
-	3,  // 10: weather_provider.v1.PressureStatsService.GetAllPressureStats:output_type -> weather_provider.v1.GetAllPressureStatsResponse
-	5,  // 11: weather_provider.v1.PressureStatsService.GetPressureStats:output_type -> weather_provider.v1.GetPressureStatsResponse
-	7,  // 12: weather_provider.v1.PressureStatsService.GetAllLastWeather:output_type -> weather_provider.v1.GetAllLastWeatherResponse
-	9,  // 13: weather_provider.v1.PressureStatsService.GetLastWeather:output_type -> weather_provider.v1.GetLastWeatherResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	16, // 6: weather_provider.v1.PressurePoint.timestamp:type_name -> google.protobuf.Timestamp
+	10, // 7: weather_provider.v1.GetPressureHistoryResponse.points:type_name -> weather_provider.v1.PressurePoint
+	16, // 8: weather_provider.v1.WeatherPoint.timestamp:type_name -> google.protobuf.Timestamp
+	16, // 9: weather_provider.v1.QueryRawWeatherRequest.start_time:type_name -> google.protobuf.Timestamp
+	16, // 10: weather_provider.v1.QueryRawWeatherRequest.end_time:type_name -> google.protobuf.Timestamp
+	13, // 11: weather_provider.v1.QueryRawWeatherResponse.points:type_name -> weather_provider.v1.WeatherPoint
+	2,  // 12: weather_provider.v1.PressureStatsService.GetAllPressureStats:input_type -> weather_provider.v1.GetAllPressureStatsRequest
+	4,  // 13: weather_provider.v1.PressureStatsService.GetPressureStats:input_type -> weather_provider.v1.GetPressureStatsRequest
+	6,  // 14: weather_provider.v1.PressureStatsService.GetAllLastWeather:input_type -> weather_provider.v1.GetAllLastWeatherRequest
+	8,  // 15: weather_provider.v1.PressureStatsService.GetLastWeather:input_type -> weather_provider.v1.GetLastWeatherRequest
+	11, // 16: weather_provider.v1.PressureStatsService.GetPressureHistory:input_type -> weather_provider.v1.GetPressureHistoryRequest
+	14, // 17: weather_provider.v1.RawWeatherService.QueryRawWeather:input_type -> weather_provider.v1.QueryRawWeatherRequest
+	3,  // 18: weather_provider.v1.PressureStatsService.GetAllPressureStats:output_type -> weather_provider.v1.GetAllPressureStatsResponse
+	5,  // 19: weather_provider.v1.PressureStatsService.GetPressureStats:output_type -> weather_provider.v1.GetPressureStatsResponse
+	7,  // 20: weather_provider.v1.PressureStatsService.GetAllLastWeather:output_type -> weather_provider.v1.GetAllLastWeatherResponse
+	9,  // 21: weather_provider.v1.PressureStatsService.GetLastWeather:output_type -> weather_provider.v1.GetLastWeatherResponse
+	12, // 22: weather_provider.v1.PressureStatsService.GetPressureHistory:output_type -> weather_provider.v1.GetPressureHistoryResponse
+	15, // 23: weather_provider.v1.RawWeatherService.QueryRawWeather:output_type -> weather_provider.v1.QueryRawWeatherResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_weather_provider_v1_weather_provider_proto_init() }
@@ -675,9 +1265,9 @@ func file_weather_provider_v1_weather_provider_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_weather_provider_v1_weather_provider_proto_rawDesc), len(file_weather_provider_v1_weather_provider_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   16,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_weather_provider_v1_weather_provider_proto_goTypes,
 		DependencyIndexes: file_weather_provider_v1_weather_provider_proto_depIdxs,
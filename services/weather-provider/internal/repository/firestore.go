@@ -2,87 +2,55 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sort"
 	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
 	"google.golang.org/api/iterator"
 )
 
-// Internal Firestore Models (Match weather-collector)
-type WeatherPoint struct {
-	LocationID           string    `firestore:"location"`
-	Timestamp            time.Time `firestore:"timestamp"`
-	HumidityPercent      int       `firestore:"humidity_pct"`
-	PrecipitationPercent int       `firestore:"precipitation_pct"`
-	UVIndex              int       `firestore:"uv_index"`
-	PressureMb           float64   `firestore:"pressure_mb"`
-	WindDirDeg           int       `firestore:"wind_dir_deg"`
-	TempC                float64   `firestore:"temp_c"`
-	TempFeelC            float64   `firestore:"temp_feel_c"`
-	DewpointC            float64   `firestore:"dewpoint_c"`
-	WindSpeedKph         float64   `firestore:"wind_speed_kph"`
-	WindGustKph          float64   `firestore:"wind_gust_kph"`
-	VisibilityKm         float64   `firestore:"visibility_km"`
-	TempF                float64   `firestore:"temp_f"`
-	TempFeelF            float64   `firestore:"temp_feel_f"`
-	WindSpeedMph         float64   `firestore:"wind_speed_mph"`
-	WindGustMph          float64   `firestore:"wind_gust_mph"`
-	VisibilityM          float64   `firestore:"visibility_miles"`
-	DewpointF            float64   `firestore:"dewpoint_f"`
-}
-
-type PressureStats struct {
-	Timestamp time.Time `firestore:"timestamp"`
-	Delta1h   *float64  `firestore:"delta_01h"`
-	Delta3h   *float64  `firestore:"delta_03h"`
-	Delta6h   *float64  `firestore:"delta_06h"`
-	Delta12h  *float64  `firestore:"delta_12h"`
-	Delta24h  *float64  `firestore:"delta_24h"`
-	Trend     string    `firestore:"trend"`
-}
-
-type PressureCacheDoc struct {
-	LocationID  string        `firestore:"-"` // Not in doc, but we use doc.ID
-	LastUpdated time.Time     `firestore:"last_updated"`
-	Analysis    PressureStats `firestore:"analysis"`
-}
-
-type WeatherCacheDoc struct {
-	LocationID   string       `firestore:"-"` // not in doc, but we use doc.ID
-	LastUpdated  time.Time    `firestore:"last_updated"`
-	CurrentValue WeatherPoint `firestore:"current"`
-}
-
-// ForecastPoint matches forecast-collector's storage model.
-type ForecastPoint struct {
-	ValidTime            time.Time `firestore:"valid_time"`
-	HumidityPercent      int       `firestore:"humidity_pct"`
-	PrecipitationPercent int       `firestore:"precipitation_pct"`
-	UVIndex              int       `firestore:"uv_index"`
-	PressureMb           float64   `firestore:"pressure_mb"`
-	WindDirDeg           int       `firestore:"wind_dir_deg"`
-	TempC                float64   `firestore:"temp_c"`
-	TempFeelC            float64   `firestore:"temp_feel_c"`
-	DewpointC            float64   `firestore:"dewpoint_c"`
-	WindSpeedKph         float64   `firestore:"wind_speed_kph"`
-	WindGustKph          float64   `firestore:"wind_gust_kph"`
-	TempF                float64   `firestore:"temp_f"`
-	TempFeelF            float64   `firestore:"temp_feel_f"`
-	DewpointF            float64   `firestore:"dewpoint_f"`
-}
-
-type ForecastCacheDoc struct {
-	LocationID string          `firestore:"-"` // not in doc, but we use doc.ID
-	IssuedAt   time.Time       `firestore:"issued_at"`
-	Points     []ForecastPoint `firestore:"points"`
-	Alerts     []shared.Alert  `firestore:"alerts"`
+// The stored documents are defined once, in shared/schema, for the
+// collectors that write them and every service that reads them.
+type (
+	WeatherPoint     = schema.WeatherPoint
+	PressureStats    = schema.PressureStats
+	PressurePoint    = schema.PressurePoint
+	WeatherCacheDoc  = schema.WeatherCacheDoc
+	ForecastPoint    = schema.ForecastPoint
+	ForecastRun      = schema.ForecastRun
+	ForecastCacheDoc = schema.ForecastCacheDoc
+	AlertRecord      = schema.AlertRecord
+	AlertEventRecord = schema.AlertEventRecord
+)
+
+// RawQuery selects observations from weather_raw. Zero values leave that
+// bound open.
+type RawQuery struct {
+	LocationID string    // empty matches every location
+	Start      time.Time // inclusive
+	End        time.Time // exclusive
+	PageSize   int       // documents per Firestore read; must be positive
+}
+
+// AlertHistoryQuery selects archived alerts from alert_history. Empty fields
+// and zero times leave that filter open.
+type AlertHistoryQuery struct {
+	LocationID string
+	RuleID     string
+	Start      time.Time // inclusive, on window_start
+	End        time.Time // exclusive, on window_start
+	Limit      int       // must be positive
 }
 
 type FirestoreRepository struct {
 	client *firestore.Client
+	// pollen is read only for the alert_history and alert_events the Pollen
+	// Collector writes alongside its own collections.
+	pollen *firestore.Client
 }
 
 func NewFirestoreRepository(ctx context.Context, projectID string) (*FirestoreRepository, error) {
@@ -90,29 +58,52 @@ func NewFirestoreRepository(ctx context.Context, projectID string) (*FirestoreRe
 	if err != nil {
 		return nil, err
 	}
-	return &FirestoreRepository{client: client}, nil
+	pollen, err := firestore.NewClientWithDatabase(ctx, projectID, shared.PollenDatabaseID)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("open pollen database: %w", err)
+	}
+	return &FirestoreRepository{client: client, pollen: pollen}, nil
+}
+
+// Client exposes the weather database so the export source can read whole
+// cache collections, which GetAllForecasts caps.
+func (r *FirestoreRepository) Client() *firestore.Client {
+	return r.client
 }
 
 func (r *FirestoreRepository) Close() error {
-	return r.client.Close()
+	werr := r.client.Close()
+	if perr := r.pollen.Close(); perr != nil {
+		return perr
+	}
+	return werr
 }
 
-func (r *FirestoreRepository) GetByID(ctx context.Context, id string) (*PressureCacheDoc, error) {
+// alertDatabases are the databases holding alert_history and alert_events:
+// the weather database for the forecast and weather collectors' alerts, the
+// pollen database for the pollen collector's. A Firestore transaction cannot
+// span databases, so each collector archives into its own.
+func (r *FirestoreRepository) alertDatabases() []*firestore.Client {
+	return []*firestore.Client{r.client, r.pollen}
+}
+
+func (r *FirestoreRepository) GetByID(ctx context.Context, id string) (*WeatherCacheDoc, error) {
 	doc, err := r.client.Collection(shared.WeatherCacheCollection).Doc(id).Get(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var cache PressureCacheDoc
+	var cache WeatherCacheDoc
 	if err := doc.DataTo(&cache); err != nil {
 		return nil, err
 	}
-	cache.LocationID = doc.Ref.ID
+	schema.Read(doc.Ref.ID, &cache)
 	return &cache, nil
 }
 
-func (r *FirestoreRepository) GetAll(ctx context.Context) ([]PressureCacheDoc, error) {
-	var results []PressureCacheDoc
+func (r *FirestoreRepository) GetAll(ctx context.Context) ([]WeatherCacheDoc, error) {
+	var results []WeatherCacheDoc
 	// Safety: Limit query to 100 documents to prevent OOM.
 	// In production, this should use pagination (cursors).
 	iter := r.client.Collection(shared.WeatherCacheCollection).Limit(100).Documents(ctx)
@@ -127,12 +118,12 @@ func (r *FirestoreRepository) GetAll(ctx context.Context) ([]PressureCacheDoc, e
 			return nil, err
 		}
 
-		var cache PressureCacheDoc
+		var cache WeatherCacheDoc
 		if err := doc.DataTo(&cache); err != nil {
 			slog.Warn("Skipping invalid document in GetAll", "doc_id", doc.Ref.ID, "error", err)
 			continue
 		}
-		cache.LocationID = doc.Ref.ID
+		schema.Read(doc.Ref.ID, &cache)
 		results = append(results, cache)
 	}
 
@@ -149,14 +140,14 @@ func (r *FirestoreRepository) GetLastWeather(ctx context.Context, id string) (*W
 	if err := doc.DataTo(&cache); err != nil {
 		return nil, err
 	}
-	cache.LocationID = doc.Ref.ID
+	schema.Read(doc.Ref.ID, &cache)
 	return &cache, nil
 }
 
 func (r *FirestoreRepository) GetAllLastWeather(ctx context.Context) ([]WeatherCacheDoc, error) {
 	var results []WeatherCacheDoc
 	iter := r.client.Collection(shared.WeatherCacheCollection).
-		Select("current", "last_updated").Limit(100).
+		Select("schema_version", "location", "current", "last_updated").Limit(100).
 		Documents(ctx)
 	defer iter.Stop()
 
@@ -174,7 +165,7 @@ func (r *FirestoreRepository) GetAllLastWeather(ctx context.Context) ([]WeatherC
 			slog.Warn("Skipping invalid document in GetAllLastWeather", "doc_id", doc.Ref.ID, "error", err)
 			continue
 		}
-		cache.LocationID = doc.Ref.ID
+		schema.Read(doc.Ref.ID, &cache)
 		results = append(results, cache)
 	}
 	return results, nil
@@ -199,7 +190,7 @@ func (r *FirestoreRepository) GetAllForecasts(ctx context.Context) ([]ForecastCa
 			slog.Warn("Skipping invalid document in GetAllForecasts", "doc_id", doc.Ref.ID, "error", err)
 			continue
 		}
-		cache.LocationID = doc.Ref.ID
+		schema.Read(doc.Ref.ID, &cache)
 		results = append(results, cache)
 	}
 	return results, nil
@@ -215,29 +206,201 @@ func (r *FirestoreRepository) GetForecast(ctx context.Context, id string) (*Fore
 	if err := doc.DataTo(&cache); err != nil {
 		return nil, err
 	}
-	cache.LocationID = doc.Ref.ID
+	schema.Read(doc.Ref.ID, &cache)
 	return &cache, nil
 }
 
-func (r *FirestoreRepository) GetAllRaw(ctx context.Context) ([]WeatherPoint, error) {
-	var results []WeatherPoint
-	iter := r.client.Collection(shared.WeatherRawCollection).Documents(ctx)
-	defer iter.Stop()
+// ListAlertHistory returns the archived alerts matching q from both alert
+// databases, newest window_start first. Each is read up to q.Limit and the
+// two merged, so the result is the newest q.Limit across both. Filtering by
+// location or rule requires the alert_history composite indexes declared,
+// for both databases, in infra/modules/firestore.
+func (r *FirestoreRepository) ListAlertHistory(ctx context.Context, q AlertHistoryQuery) ([]AlertRecord, error) {
+	var records []AlertRecord
+	for _, client := range r.alertDatabases() {
+		got, err := listAlertHistory(ctx, client, q)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, got...)
+	}
+	return newestAlertRecords(records, q.Limit), nil
+}
 
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
+// newestAlertRecords orders records newest window_start first and keeps at
+// most limit of them.
+func newestAlertRecords(records []AlertRecord, limit int) []AlertRecord {
+	sort.SliceStable(records, func(i, j int) bool { return records[i].WindowStart.After(records[j].WindowStart) })
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	return records
+}
+
+func listAlertHistory(ctx context.Context, client *firestore.Client, q AlertHistoryQuery) ([]AlertRecord, error) {
+	query := client.Collection(shared.AlertHistoryCollection).Query
+	if q.LocationID != "" {
+		query = query.Where("location", "==", q.LocationID)
+	}
+	if q.RuleID != "" {
+		query = query.Where("rule_id", "==", q.RuleID)
+	}
+	if !q.Start.IsZero() {
+		query = query.Where("window_start", ">=", q.Start)
+	}
+	if !q.End.IsZero() {
+		query = query.Where("window_start", "<", q.End)
+	}
+	docs, err := query.OrderBy("window_start", firestore.Desc).Limit(q.Limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]AlertRecord, 0, len(docs))
+	for _, doc := range docs {
+		var record AlertRecord
+		if err := doc.DataTo(&record); err != nil {
+			slog.Warn("Skipping invalid document in ListAlertHistory", "doc_id", doc.Ref.ID, "error", err)
+			continue
 		}
+		schema.Read(doc.Ref.ID, &record)
+		records = append(records, record)
+	}
+	return records, nil
+}
 
-		var wp WeatherPoint
-		if err := doc.DataTo(&wp); err != nil {
+// ListAlertEvents returns one alert's lifecycle events from both alert
+// databases, oldest first. An alert's events all live in the database of the
+// collector that raised it, but its ID does not say which, so both are read.
+// It requires the (alert_id, at) alert_events index declared, for both
+// databases, in infra/modules/firestore.
+func (r *FirestoreRepository) ListAlertEvents(ctx context.Context, alertID string) ([]AlertEventRecord, error) {
+	var events []AlertEventRecord
+	for _, client := range r.alertDatabases() {
+		docs, err := client.Collection(shared.AlertEventsCollection).
+			Where("alert_id", "==", alertID).
+			OrderBy("at", firestore.Asc).
+			Documents(ctx).GetAll()
+		if err != nil {
 			return nil, err
 		}
-		results = append(results, wp)
+		for _, doc := range docs {
+			var event AlertEventRecord
+			if err := doc.DataTo(&event); err != nil {
+				slog.Warn("Skipping invalid document in ListAlertEvents", "doc_id", doc.Ref.ID, "error", err)
+				continue
+			}
+			schema.Read(doc.Ref.ID, &event)
+			events = append(events, event)
+		}
+	}
+	return oldestEventsFirst(events), nil
+}
+
+// oldestEventsFirst orders events merged from both databases by time.
+func oldestEventsFirst(events []AlertEventRecord) []AlertEventRecord {
+	if events == nil {
+		return []AlertEventRecord{}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+	return events
+}
+
+// QueryRaw pages through weather_raw in timestamp order, handing each page to
+// fn before the next one is read, so memory stays bounded by q.PageSize no
+// matter how wide the range is. A non-nil error from fn stops the scan and is
+// returned unchanged.
+//
+// Filtering by location and time requires the (location, timestamp) composite
+// index declared in infra/modules/firestore.
+func (r *FirestoreRepository) QueryRaw(ctx context.Context, q RawQuery, fn func([]WeatherPoint) error) error {
+	query := RangeQuery(r.client.Collection(shared.WeatherRawCollection).Query, "location", "timestamp", q)
+	return Pages(ctx, query, q.PageSize, fn)
+}
+
+// QueryAlertHistory pages through alert_history by issued_at, the same way
+// QueryRaw does for weather_raw: the weather database's archive first, then
+// the pollen database's. Filtering by location requires the (location,
+// issued_at) alert_history index declared, for both databases, in
+// infra/modules/firestore.
+func (r *FirestoreRepository) QueryAlertHistory(ctx context.Context, q RawQuery, fn func([]AlertRecord) error) error {
+	for _, client := range r.alertDatabases() {
+		query := RangeQuery(client.Collection(shared.AlertHistoryCollection).Query, "location", "issued_at", q)
+		if err := Pages(ctx, query, q.PageSize, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QueryForecastRuns pages through forecast_raw by issued_at, the same way
+// QueryRaw does for weather_raw.
+func (r *FirestoreRepository) QueryForecastRuns(ctx context.Context, q RawQuery, fn func([]ForecastRun) error) error {
+	query := RangeQuery(r.client.Collection(shared.ForecastRawCollection).Query, "location", "issued_at", q)
+	return Pages(ctx, query, q.PageSize, fn)
+}
+
+// RangeQuery narrows query to q's location and [Start, End) window and orders
+// it by timeField, which Pages needs for its cursors. Append-only collections
+// name these fields differently, so the caller supplies them.
+func RangeQuery(query firestore.Query, locationField, timeField string, q RawQuery) firestore.Query {
+	if q.LocationID != "" {
+		query = query.Where(locationField, "==", q.LocationID)
+	}
+	if !q.Start.IsZero() {
+		query = query.Where(timeField, ">=", q.Start)
+	}
+	if !q.End.IsZero() {
+		query = query.Where(timeField, "<", q.End)
+	}
+	return query.OrderBy(timeField, firestore.Asc)
+}
+
+// Pages reads an ordered query pageSize documents at a time, resuming after
+// the last document of the previous page, and hands each decoded page to fn
+// before reading the next. Documents that fail to decode are logged and
+// skipped. A non-nil error from fn stops the scan and is returned unchanged.
+func Pages[T any](ctx context.Context, query firestore.Query, pageSize int, fn func([]T) error) error {
+	if pageSize <= 0 {
+		return fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	query = query.Limit(pageSize)
+
+	var cursor *firestore.DocumentSnapshot
+	for {
+		page := query
+		if cursor != nil {
+			page = query.StartAfter(cursor)
+		}
+		docs, err := page.Documents(ctx).GetAll()
+		if err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+
+		items := make([]T, 0, len(docs))
+		for _, doc := range docs {
+			var item T
+			if err := doc.DataTo(&item); err != nil {
+				slog.Warn("Skipping invalid document in paged query", "doc_id", doc.Ref.Path, "error", err)
+				continue
+			}
+			if d, ok := any(&item).(schema.Document); ok {
+				schema.Read(doc.Ref.ID, d)
+			}
+			items = append(items, item)
+		}
+		if len(items) > 0 {
+			if err := fn(items); err != nil {
+				return err
+			}
+		}
+
+		if len(docs) < pageSize {
+			return nil
+		}
+		cursor = docs[len(docs)-1]
 	}
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Timestamp.Before(results[j].Timestamp)
-	})
-	return results, nil
 }
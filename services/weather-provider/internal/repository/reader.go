@@ -5,11 +5,19 @@ import "context"
 // WeatherReader defines the interface for fetching weather data.
 // This allows the Service layer to be tested using a mock repository.
 type WeatherReader interface {
-	GetByID(ctx context.Context, id string) (*PressureCacheDoc, error)
-	GetAll(ctx context.Context) ([]PressureCacheDoc, error)
+	GetByID(ctx context.Context, id string) (*WeatherCacheDoc, error)
+	GetAll(ctx context.Context) ([]WeatherCacheDoc, error)
 	GetLastWeather(ctx context.Context, id string) (*WeatherCacheDoc, error)
 	GetAllLastWeather(ctx context.Context) ([]WeatherCacheDoc, error)
-	GetAllRaw(ctx context.Context) ([]WeatherPoint, error)
+	QueryRaw(ctx context.Context, q RawQuery, fn func([]WeatherPoint) error) error
 	GetForecast(ctx context.Context, id string) (*ForecastCacheDoc, error)
 	GetAllForecasts(ctx context.Context) ([]ForecastCacheDoc, error)
+	ListAlertHistory(ctx context.Context, q AlertHistoryQuery) ([]AlertRecord, error)
+	ListAlertEvents(ctx context.Context, alertID string) ([]AlertEventRecord, error)
+}
+
+// ReadCloser is a WeatherReader that holds a connection.
+type ReadCloser interface {
+	WeatherReader
+	Close() error
 }
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"github.com/nickfang/personal-dashboard/services/shared/schema"
+)
+
+// SQLiteRepository serves the same reads as FirestoreRepository from the
+// docstore file, where the pollen alert history and events share the file
+// rather than needing a second database. Missing documents return
+// docstore.ErrNotFound, which carries codes.NotFound, so the transport maps
+// them exactly as it maps Firestore's.
+type SQLiteRepository struct {
+	db *docstore.DB
+}
+
+func NewSQLiteRepository(db *docstore.DB) *SQLiteRepository {
+	return &SQLiteRepository{db: db}
+}
+
+// OpenReader returns the SQLite repository when docstore selects it, and
+// otherwise connects to weather-log, and to pollen-log for the Pollen
+// Collector's alert history and events.
+func OpenReader(ctx context.Context, projectID string) (ReadCloser, error) {
+	db, err := docstore.OpenSelected()
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		return NewSQLiteRepository(db), nil
+	}
+	fr, err := NewFirestoreRepository(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return fr, nil
+}
+
+// DB exposes the underlying file so the export source can read the pollen
+// archive from it too.
+func (r *SQLiteRepository) DB() *docstore.DB {
+	return r.db
+}
+
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *SQLiteRepository) GetByID(ctx context.Context, id string) (*WeatherCacheDoc, error) {
+	var cache WeatherCacheDoc
+	if err := r.db.Get(ctx, shared.WeatherCacheCollection, id, &cache); err != nil {
+		return nil, err
+	}
+	schema.Read(id, &cache)
+	return &cache, nil
+}
+
+func (r *SQLiteRepository) GetAll(ctx context.Context) ([]WeatherCacheDoc, error) {
+	return getAll[WeatherCacheDoc](ctx, r.db, shared.WeatherCacheCollection)
+}
+
+func (r *SQLiteRepository) GetLastWeather(ctx context.Context, id string) (*WeatherCacheDoc, error) {
+	var cache WeatherCacheDoc
+	if err := r.db.Get(ctx, shared.WeatherCacheCollection, id, &cache); err != nil {
+		return nil, err
+	}
+	schema.Read(id, &cache)
+	return &cache, nil
+}
+
+func (r *SQLiteRepository) GetAllLastWeather(ctx context.Context) ([]WeatherCacheDoc, error) {
+	return getAll[WeatherCacheDoc](ctx, r.db, shared.WeatherCacheCollection)
+}
+
+func (r *SQLiteRepository) GetForecast(ctx context.Context, id string) (*ForecastCacheDoc, error) {
+	var cache ForecastCacheDoc
+	if err := r.db.Get(ctx, shared.ForecastCacheCollection, id, &cache); err != nil {
+		return nil, err
+	}
+	schema.Read(id, &cache)
+	return &cache, nil
+}
+
+func (r *SQLiteRepository) GetAllForecasts(ctx context.Context) ([]ForecastCacheDoc, error) {
+	return getAll[ForecastCacheDoc](ctx, r.db, shared.ForecastCacheCollection)
+}
+
+func (r *SQLiteRepository) QueryRaw(ctx context.Context, q RawQuery, fn func([]WeatherPoint) error) error {
+	return SQLitePages(ctx, r.db, SQLiteRangeQuery(shared.WeatherRawCollection, "location", "timestamp", q), q.PageSize, fn)
+}
+
+func (r *SQLiteRepository) QueryForecastRuns(ctx context.Context, q RawQuery, fn func([]ForecastRun) error) error {
+	return SQLitePages(ctx, r.db, SQLiteRangeQuery(shared.ForecastRawCollection, "location", "issued_at", q), q.PageSize, fn)
+}
+
+func (r *SQLiteRepository) ListAlertHistory(ctx context.Context, q AlertHistoryQuery) ([]AlertRecord, error) {
+	query := docstore.Query{Collection: shared.AlertHistoryCollection, OrderBy: "window_start", Desc: true, Limit: q.Limit}
+	if q.LocationID != "" {
+		query.Where = append(query.Where, docstore.Filter{Field: "location", Op: "==", Value: q.LocationID})
+	}
+	if q.RuleID != "" {
+		query.Where = append(query.Where, docstore.Filter{Field: "rule_id", Op: "==", Value: q.RuleID})
+	}
+	if !q.Start.IsZero() {
+		query.Where = append(query.Where, docstore.Filter{Field: "window_start", Op: ">=", Value: q.Start})
+	}
+	if !q.End.IsZero() {
+		query.Where = append(query.Where, docstore.Filter{Field: "window_start", Op: "<", Value: q.End})
+	}
+	docs, err := r.db.Documents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]AlertRecord, 0, len(docs))
+	for _, doc := range docs {
+		var record AlertRecord
+		if err := doc.DataTo(&record); err != nil {
+			slog.Warn("Skipping invalid document in ListAlertHistory", "doc_id", doc.ID, "error", err)
+			continue
+		}
+		schema.Read(doc.ID, &record)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// QueryAlertHistory pages through alert_history by issued_at. The docstore
+// file holds every collector's archive in the one collection.
+func (r *SQLiteRepository) QueryAlertHistory(ctx context.Context, q RawQuery, fn func([]AlertRecord) error) error {
+	return SQLitePages(ctx, r.db, SQLiteRangeQuery(shared.AlertHistoryCollection, "location", "issued_at", q), q.PageSize, fn)
+}
+
+func (r *SQLiteRepository) ListAlertEvents(ctx context.Context, alertID string) ([]AlertEventRecord, error) {
+	docs, err := r.db.Documents(ctx, docstore.Query{
+		Collection: shared.AlertEventsCollection,
+		Where:      []docstore.Filter{{Field: "alert_id", Op: "==", Value: alertID}},
+		OrderBy:    "at",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]AlertEventRecord, 0, len(docs))
+	for _, doc := range docs {
+		var event AlertEventRecord
+		if err := doc.DataTo(&event); err != nil {
+			slog.Warn("Skipping invalid document in ListAlertEvents", "doc_id", doc.ID, "error", err)
+			continue
+		}
+		schema.Read(doc.ID, &event)
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// getAll reads a cache collection, capped at 100 documents like the
+// Firestore reads, skipping documents that fail to decode.
+func getAll[T any, PT interface {
+	*T
+	schema.Document
+}](ctx context.Context, db *docstore.DB, collection string) ([]T, error) {
+	docs, err := db.Documents(ctx, docstore.Query{Collection: collection, Limit: 100})
+	if err != nil {
+		return nil, err
+	}
+	var results []T
+	for _, doc := range docs {
+		var item T
+		if err := doc.DataTo(&item); err != nil {
+			slog.Warn("Skipping invalid document", "collection", collection, "doc_id", doc.ID, "error", err)
+			continue
+		}
+		schema.Read(doc.ID, PT(&item))
+		results = append(results, item)
+	}
+	return results, nil
+}
+
+// SQLiteRangeQuery is RangeQuery for the docstore.
+func SQLiteRangeQuery(collection, locationField, timeField string, q RawQuery) docstore.Query {
+	query := docstore.Query{Collection: collection, OrderBy: timeField}
+	if q.LocationID != "" {
+		query.Where = append(query.Where, docstore.Filter{Field: locationField, Op: "==", Value: q.LocationID})
+	}
+	if !q.Start.IsZero() {
+		query.Where = append(query.Where, docstore.Filter{Field: timeField, Op: ">=", Value: q.Start})
+	}
+	if !q.End.IsZero() {
+		query.Where = append(query.Where, docstore.Filter{Field: timeField, Op: "<", Value: q.End})
+	}
+	return query
+}
+
+// SQLitePages is Pages for the docstore. It pages by offset: the archives
+// are append-only and ordered by time, so a page already read does not move.
+func SQLitePages[T any](ctx context.Context, db *docstore.DB, query docstore.Query, pageSize int, fn func([]T) error) error {
+	if pageSize <= 0 {
+		return fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	query.Limit = pageSize
+	for {
+		docs, err := db.Documents(ctx, query)
+		if err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+
+		items := make([]T, 0, len(docs))
+		for _, doc := range docs {
+			var item T
+			if err := doc.DataTo(&item); err != nil {
+				slog.Warn("Skipping invalid document in paged query", "doc_id", doc.ID, "error", err)
+				continue
+			}
+			if d, ok := any(&item).(schema.Document); ok {
+				schema.Read(doc.ID, d)
+			}
+			items = append(items, item)
+		}
+		if len(items) > 0 {
+			if err := fn(items); err != nil {
+				return err
+			}
+		}
+
+		if len(docs) < pageSize {
+			return nil
+		}
+		query.Offset += len(docs)
+	}
+}
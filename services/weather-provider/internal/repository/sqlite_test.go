@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	"github.com/nickfang/personal-dashboard/services/shared/docstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func openTestRepository(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	db, err := docstore.Open(filepath.Join(t.TempDir(), "weather.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	repo := NewSQLiteRepository(db)
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestSQLiteRepository_GetByID(t *testing.T) {
+	repo := openTestRepository(t)
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, "house-nick"); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetByID on an empty store: got %v, want codes.NotFound for the transport to map", err)
+	}
+
+	// Seeded as version 0, which left the location to the document ID.
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := repo.DB().Set(ctx, shared.WeatherCacheCollection, "house-nick", WeatherCacheDoc{
+		LastUpdated: updated,
+		Analysis:    PressureStats{Trend: "falling"},
+	})
+	if err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+	got, err := repo.GetByID(ctx, "house-nick")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Location != "house-nick" || got.Analysis.Trend != "falling" || !got.LastUpdated.Equal(updated) {
+		t.Errorf("GetByID = %+v", got)
+	}
+	all, err := repo.GetAll(ctx)
+	if err != nil || len(all) != 1 || all[0].Location != "house-nick" {
+		t.Errorf("GetAll = %+v, %v", all, err)
+	}
+}
+
+func TestSQLiteRepository_QueryRawPages(t *testing.T) {
+	repo := openTestRepository(t)
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		for _, loc := range []string{"house-nick", "cabin"} {
+			wp := WeatherPoint{Location: loc, Timestamp: base.Add(time.Duration(i) * time.Hour), PressureMb: float64(1000 + i)}
+			if _, err := repo.DB().Add(ctx, shared.WeatherRawCollection, wp); err != nil {
+				t.Fatalf("seeding: %v", err)
+			}
+		}
+	}
+
+	var pages [][]float64
+	q := RawQuery{LocationID: "house-nick", Start: base.Add(time.Hour), End: base.Add(5 * time.Hour), PageSize: 3}
+	err := repo.QueryRaw(ctx, q, func(points []WeatherPoint) error {
+		var page []float64
+		for _, p := range points {
+			page = append(page, p.PressureMb)
+		}
+		pages = append(pages, page)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("QueryRaw: %v", err)
+	}
+	if len(pages) != 2 || len(pages[0]) != 3 || len(pages[1]) != 1 || pages[0][0] != 1001 || pages[1][0] != 1004 {
+		t.Errorf("pages = %v, want [[1001 1002 1003] [1004]]", pages)
+	}
+}
+
+func TestSQLiteRepository_ListAlertHistory(t *testing.T) {
+	repo := openTestRepository(t)
+	ctx := context.Background()
+
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	seed := []AlertRecord{
+		{ID: "a1", Location: "house-nick", RuleID: "pressure-drop-3h", WindowStart: base},
+		{ID: "a2", Location: "house-nick", RuleID: "wind-gust", WindowStart: base.Add(24 * time.Hour)},
+		{ID: "a3", Location: "house-nita", RuleID: "pressure-drop-3h", WindowStart: base.Add(48 * time.Hour)},
+		{ID: "a4", Location: "house-nick", RuleID: "pressure-drop-3h", WindowStart: base.Add(72 * time.Hour)},
+	}
+	for _, r := range seed {
+		if err := repo.DB().Set(ctx, shared.AlertHistoryCollection, r.ID, r); err != nil {
+			t.Fatalf("seeding %s: %v", r.ID, err)
+		}
+	}
+
+	ids := func(q AlertHistoryQuery) []string {
+		t.Helper()
+		q.Limit = 10
+		records, err := repo.ListAlertHistory(ctx, q)
+		if err != nil {
+			t.Fatalf("ListAlertHistory(%+v): %v", q, err)
+		}
+		var got []string
+		for _, r := range records {
+			got = append(got, r.ID)
+		}
+		return got
+	}
+
+	if got := ids(AlertHistoryQuery{}); !slices.Equal(got, []string{"a4", "a3", "a2", "a1"}) {
+		t.Errorf("unfiltered = %v, want newest window first", got)
+	}
+	if got := ids(AlertHistoryQuery{LocationID: "house-nick", RuleID: "pressure-drop-3h"}); !slices.Equal(got, []string{"a4", "a1"}) {
+		t.Errorf("by location and rule = %v", got)
+	}
+	if got := ids(AlertHistoryQuery{Start: base.Add(24 * time.Hour), End: base.Add(72 * time.Hour)}); !slices.Equal(got, []string{"a3", "a2"}) {
+		t.Errorf("by window_start range = %v, want start inclusive, end exclusive", got)
+	}
+}
+
+func TestNewestAlertRecords(t *testing.T) {
+	// Two databases' results, each already newest first, merged.
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	records := []AlertRecord{
+		{ID: "weather-new", WindowStart: base.Add(72 * time.Hour)},
+		{ID: "weather-old", WindowStart: base},
+		{ID: "pollen-new", WindowStart: base.Add(48 * time.Hour)},
+		{ID: "pollen-old", WindowStart: base.Add(24 * time.Hour)},
+	}
+	var got []string
+	for _, r := range newestAlertRecords(records, 3) {
+		got = append(got, r.ID)
+	}
+	if !slices.Equal(got, []string{"weather-new", "pollen-new", "pollen-old"}) {
+		t.Errorf("merged = %v, want the newest 3 across both", got)
+	}
+}
+
+func TestSQLiteRepository_ListAlertEvents(t *testing.T) {
+	repo := openTestRepository(t)
+	ctx := context.Background()
+
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	seed := []AlertEventRecord{
+		{AlertID: "a1", Type: shared.AlertEventDelivered, At: base.Add(time.Hour)},
+		{AlertID: "a1", Type: shared.AlertEventCreated, At: base},
+		{AlertID: "a2", Type: shared.AlertEventCreated, At: base},
+	}
+	for _, e := range seed {
+		if err := repo.DB().Set(ctx, shared.AlertEventsCollection, e.DocID(), e); err != nil {
+			t.Fatalf("seeding %s: %v", e.DocID(), err)
+		}
+	}
+
+	events, err := repo.ListAlertEvents(ctx, "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Type != shared.AlertEventCreated || events[1].Type != shared.AlertEventDelivered {
+		t.Errorf("events = %+v, want a1's two events oldest first", events)
+	}
+}
+
+func TestOldestEventsFirst(t *testing.T) {
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	events := oldestEventsFirst([]AlertEventRecord{
+		{Type: shared.AlertEventDelivered, At: base.Add(time.Hour)},
+		{Type: shared.AlertEventCreated, At: base},
+	})
+	if events[0].Type != shared.AlertEventCreated || events[1].Type != shared.AlertEventDelivered {
+		t.Errorf("events = %+v, want oldest first", events)
+	}
+	if got := oldestEventsFirst(nil); got == nil || len(got) != 0 {
+		t.Errorf("oldestEventsFirst(nil) = %#v, want an empty list", got)
+	}
+}
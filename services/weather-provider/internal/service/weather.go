@@ -6,6 +6,19 @@ import (
 	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
 )
 
+// Page sizes for QueryRaw. A Firestore page becomes one streamed response, so
+// the cap also bounds the size of a single gRPC message.
+const (
+	DefaultRawPageSize = 500
+	MaxRawPageSize     = 2000
+)
+
+// Limits for ListAlertHistory, which returns one unpaged response.
+const (
+	DefaultAlertHistoryLimit = 100
+	MaxAlertHistoryLimit     = 1000
+)
+
 type WeatherService struct {
 	repo repository.WeatherReader
 }
@@ -14,11 +27,17 @@ func NewWeatherService(repo repository.WeatherReader) *WeatherService {
 	return &WeatherService{repo: repo}
 }
 
-func (s *WeatherService) GetStatsByID(ctx context.Context, id string) (*repository.PressureCacheDoc, error) {
+func (s *WeatherService) GetStatsByID(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *WeatherService) GetAllStats(ctx context.Context) ([]repository.PressureCacheDoc, error) {
+// GetPressureHistory returns the location's pressure cache document, whose
+// History holds the observed series the stats were computed from.
+func (s *WeatherService) GetPressureHistory(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *WeatherService) GetAllStats(ctx context.Context) ([]repository.WeatherCacheDoc, error) {
 	return s.repo.GetAll(ctx)
 }
 
@@ -30,8 +49,17 @@ func (s *WeatherService) GetAllLastWeather(ctx context.Context) ([]repository.We
 	return s.repo.GetAllLastWeather(ctx)
 }
 
-func (s *WeatherService) GetAllRaw(ctx context.Context) ([]repository.WeatherPoint, error) {
-	return s.repo.GetAllRaw(ctx)
+// QueryRaw streams raw observations matching q to fn one page at a time,
+// oldest first. A non-positive page size uses DefaultRawPageSize and anything
+// above MaxRawPageSize is capped.
+func (s *WeatherService) QueryRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultRawPageSize
+	}
+	if q.PageSize > MaxRawPageSize {
+		q.PageSize = MaxRawPageSize
+	}
+	return s.repo.QueryRaw(ctx, q, fn)
 }
 
 func (s *WeatherService) GetForecast(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
@@ -41,3 +69,21 @@ func (s *WeatherService) GetForecast(ctx context.Context, id string) (*repositor
 func (s *WeatherService) GetAllForecasts(ctx context.Context) ([]repository.ForecastCacheDoc, error) {
 	return s.repo.GetAllForecasts(ctx)
 }
+
+// ListAlertHistory returns archived alerts matching q, newest first. A
+// non-positive limit uses DefaultAlertHistoryLimit and anything above
+// MaxAlertHistoryLimit is capped.
+func (s *WeatherService) ListAlertHistory(ctx context.Context, q repository.AlertHistoryQuery) ([]repository.AlertRecord, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultAlertHistoryLimit
+	}
+	if q.Limit > MaxAlertHistoryLimit {
+		q.Limit = MaxAlertHistoryLimit
+	}
+	return s.repo.ListAlertHistory(ctx, q)
+}
+
+// ListAlertEvents returns one alert's lifecycle events, oldest first.
+func (s *WeatherService) ListAlertEvents(ctx context.Context, alertID string) ([]repository.AlertEventRecord, error) {
+	return s.repo.ListAlertEvents(ctx, alertID)
+}
@@ -13,9 +13,9 @@ import (
 func TestGetStatsByID(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := &testutil.MockReader{
-			GetByIDFunc: func(ctx context.Context, id string) (*repository.PressureCacheDoc, error) {
-				return &repository.PressureCacheDoc{
-					LocationID:  id,
+			GetByIDFunc: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+				return &repository.WeatherCacheDoc{
+					Location:    id,
 					LastUpdated: time.Now(),
 				}, nil
 			},
@@ -26,14 +26,14 @@ func TestGetStatsByID(t *testing.T) {
 		if err != nil {
 			t.Errorf("expected no error, got %v", err)
 		}
-		if res.LocationID != "test-loc" {
-			t.Errorf("expected location test-loc, got %s", res.LocationID)
+		if res.Location != "test-loc" {
+			t.Errorf("expected location test-loc, got %s", res.Location)
 		}
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		mockRepo := &testutil.MockReader{
-			GetByIDFunc: func(ctx context.Context, id string) (*repository.PressureCacheDoc, error) {
+			GetByIDFunc: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
 				return nil, errors.New("db error")
 			},
 		}
@@ -51,11 +51,11 @@ func TestGetAllStats(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		now := time.Now()
 		mockRepo := &testutil.MockReader{
-			GetAllFunc: func(ctx context.Context) ([]repository.PressureCacheDoc, error) {
-				return []repository.PressureCacheDoc{
-					{LocationID: "house-nick", LastUpdated: now},
-					{LocationID: "house-nita", LastUpdated: now},
-					{LocationID: "distribution-hall", LastUpdated: now},
+			GetAllFunc: func(ctx context.Context) ([]repository.WeatherCacheDoc, error) {
+				return []repository.WeatherCacheDoc{
+					{Location: "house-nick", LastUpdated: now},
+					{Location: "house-nita", LastUpdated: now},
+					{Location: "distribution-hall", LastUpdated: now},
 				}, nil
 			},
 		}
@@ -68,14 +68,14 @@ func TestGetAllStats(t *testing.T) {
 		if len(results) != 3 {
 			t.Errorf("expected 3 results, got %d", len(results))
 		}
-		if results[0].LocationID != "house-nick" {
-			t.Errorf("expected first location house-nick, got %s", results[0].LocationID)
+		if results[0].Location != "house-nick" {
+			t.Errorf("expected first location house-nick, got %s", results[0].Location)
 		}
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		mockRepo := &testutil.MockReader{
-			GetAllFunc: func(ctx context.Context) ([]repository.PressureCacheDoc, error) {
+			GetAllFunc: func(ctx context.Context) ([]repository.WeatherCacheDoc, error) {
 				return nil, errors.New("db error")
 			},
 		}
@@ -88,3 +88,68 @@ func TestGetAllStats(t *testing.T) {
 		}
 	})
 }
+
+func TestQueryRaw_PageSize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+		want int
+	}{
+		{"default when unset", 0, DefaultRawPageSize},
+		{"default when negative", -5, DefaultRawPageSize},
+		{"passes through", 50, 50},
+		{"capped", MaxRawPageSize + 1, MaxRawPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got int
+			mockRepo := &testutil.MockReader{
+				QueryRawFunc: func(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+					got = q.PageSize
+					return nil
+				},
+			}
+			svc := NewWeatherService(mockRepo)
+
+			if err := svc.QueryRaw(context.Background(), repository.RawQuery{PageSize: tt.in}, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PageSize = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListAlertHistory_Limit(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+		want int
+	}{
+		{"default when unset", 0, DefaultAlertHistoryLimit},
+		{"passes through", 20, 20},
+		{"capped", MaxAlertHistoryLimit + 1, MaxAlertHistoryLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got int
+			mockRepo := &testutil.MockReader{
+				ListAlertHistoryFunc: func(ctx context.Context, q repository.AlertHistoryQuery) ([]repository.AlertRecord, error) {
+					got = q.Limit
+					return nil, nil
+				},
+			}
+			svc := NewWeatherService(mockRepo)
+
+			if _, err := svc.ListAlertHistory(context.Background(), repository.AlertHistoryQuery{Limit: tt.in}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Limit = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
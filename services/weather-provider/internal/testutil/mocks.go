@@ -10,23 +10,25 @@ import (
 // MockReader implements repository.WeatherReader for testing.
 // Unset function fields return a descriptive error instead of panicking.
 type MockReader struct {
-	GetAllFunc            func(ctx context.Context) ([]repository.PressureCacheDoc, error)
-	GetByIDFunc           func(ctx context.Context, id string) (*repository.PressureCacheDoc, error)
+	GetAllFunc            func(ctx context.Context) ([]repository.WeatherCacheDoc, error)
+	GetByIDFunc           func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error)
 	GetLastWeatherFunc    func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error)
 	GetAllLastWeatherFunc func(ctx context.Context) ([]repository.WeatherCacheDoc, error)
-	GetAllRawFunc         func(ctx context.Context) ([]repository.WeatherPoint, error)
+	QueryRawFunc          func(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error
 	GetForecastFunc       func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error)
 	GetAllForecastsFunc   func(ctx context.Context) ([]repository.ForecastCacheDoc, error)
+	ListAlertHistoryFunc  func(ctx context.Context, q repository.AlertHistoryQuery) ([]repository.AlertRecord, error)
+	ListAlertEventsFunc   func(ctx context.Context, alertID string) ([]repository.AlertEventRecord, error)
 }
 
-func (m *MockReader) GetAll(ctx context.Context) ([]repository.PressureCacheDoc, error) {
+func (m *MockReader) GetAll(ctx context.Context) ([]repository.WeatherCacheDoc, error) {
 	if m.GetAllFunc == nil {
 		return nil, fmt.Errorf("GetAll not mocked")
 	}
 	return m.GetAllFunc(ctx)
 }
 
-func (m *MockReader) GetByID(ctx context.Context, id string) (*repository.PressureCacheDoc, error) {
+func (m *MockReader) GetByID(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
 	if m.GetByIDFunc == nil {
 		return nil, fmt.Errorf("GetByID not mocked")
 	}
@@ -47,11 +49,11 @@ func (m *MockReader) GetAllLastWeather(ctx context.Context) ([]repository.Weathe
 	return m.GetAllLastWeatherFunc(ctx)
 }
 
-func (m *MockReader) GetAllRaw(ctx context.Context) ([]repository.WeatherPoint, error) {
-	if m.GetAllRawFunc == nil {
-		return nil, fmt.Errorf("GetAllRaw not mocked")
+func (m *MockReader) QueryRaw(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+	if m.QueryRawFunc == nil {
+		return fmt.Errorf("QueryRaw not mocked")
 	}
-	return m.GetAllRawFunc(ctx)
+	return m.QueryRawFunc(ctx, q, fn)
 }
 
 func (m *MockReader) GetForecast(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
@@ -67,3 +69,17 @@ func (m *MockReader) GetAllForecasts(ctx context.Context) ([]repository.Forecast
 	}
 	return m.GetAllForecastsFunc(ctx)
 }
+
+func (m *MockReader) ListAlertHistory(ctx context.Context, q repository.AlertHistoryQuery) ([]repository.AlertRecord, error) {
+	if m.ListAlertHistoryFunc == nil {
+		return nil, fmt.Errorf("ListAlertHistory not mocked")
+	}
+	return m.ListAlertHistoryFunc(ctx, q)
+}
+
+func (m *MockReader) ListAlertEvents(ctx context.Context, alertID string) ([]repository.AlertEventRecord, error) {
+	if m.ListAlertEventsFunc == nil {
+		return nil, fmt.Errorf("ListAlertEvents not mocked")
+	}
+	return m.ListAlertEventsFunc(ctx, alertID)
+}
@@ -0,0 +1,113 @@
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nickfang/personal-dashboard/services/shared"
+	pb "github.com/nickfang/personal-dashboard/services/weather-provider/internal/gen/go/weather-provider/v1"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/service"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListAlertHistory_MapsFiltersAndRecords(t *testing.T) {
+	start := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	windowStart := start.Add(30 * time.Hour)
+
+	var got repository.AlertHistoryQuery
+	mockRepo := &testutil.MockReader{
+		ListAlertHistoryFunc: func(ctx context.Context, q repository.AlertHistoryQuery) ([]repository.AlertRecord, error) {
+			got = q
+			return []repository.AlertRecord{
+				{ID: "alert-1", Location: "house-nick", RuleID: "pressure-drop-3h", Status: shared.AlertStatusExpired,
+					Severity: shared.AlertSeverityWarning, Value: -5.5, PeakSeverity: shared.AlertSeveritySevere, PeakValue: -8.1,
+					WindowStart: windowStart, WindowEnd: windowStart.Add(3 * time.Hour), NotifiedAt: windowStart.Add(-6 * time.Hour), ArchivedAt: windowStart.Add(6 * time.Hour)},
+				{ID: "alert-2", Location: "house-nick", RuleID: "pressure-drop-3h", Status: shared.AlertStatusResolved},
+			}, nil
+		},
+	}
+	handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+
+	resp, err := handler.ListAlertHistory(context.Background(), &pb.ListAlertHistoryRequest{
+		LocationId: "house-nick",
+		RuleId:     "pressure-drop-3h",
+		StartTime:  timestamppb.New(start),
+		EndTime:    timestamppb.New(end),
+	})
+	if err != nil {
+		t.Fatalf("ListAlertHistory: %v", err)
+	}
+	if got.LocationID != "house-nick" || got.RuleID != "pressure-drop-3h" || !got.Start.Equal(start) || !got.End.Equal(end) || got.Limit != service.DefaultAlertHistoryLimit {
+		t.Errorf("query = %+v", got)
+	}
+	if len(resp.Alerts) != 2 {
+		t.Fatalf("len(Alerts) = %d, want 2", len(resp.Alerts))
+	}
+	first := resp.Alerts[0]
+	if first.Alert.Id != "alert-1" || first.Alert.Status != shared.AlertStatusExpired || first.Alert.Value != -5.5 {
+		t.Errorf("alert = %+v", first.Alert)
+	}
+	if first.PeakValue != -8.1 || first.PeakSeverity != shared.AlertSeveritySevere || !first.Alert.WindowStart.AsTime().Equal(windowStart) {
+		t.Errorf("archived = %+v, want the episode's peak", first)
+	}
+	if first.NotifiedAt == nil || resp.Alerts[1].NotifiedAt != nil {
+		t.Errorf("notified_at = %v / %v, want set only for the delivered alert", first.NotifiedAt, resp.Alerts[1].NotifiedAt)
+	}
+}
+
+func TestListAlertHistory_InvalidArguments(t *testing.T) {
+	handler := NewGrpcHandler(service.NewWeatherService(&testutil.MockReader{}))
+	at := timestamppb.New(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	for name, req := range map[string]*pb.ListAlertHistoryRequest{
+		"negative limit": {Limit: -1},
+		"empty range":    {StartTime: at, EndTime: at},
+	} {
+		if _, err := handler.ListAlertHistory(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: error code = %v, want InvalidArgument", name, status.Code(err))
+		}
+	}
+}
+
+func TestListAlertEvents_MapsEvents(t *testing.T) {
+	at := time.Date(2026, 6, 1, 6, 0, 0, 0, time.UTC)
+	var gotID string
+	mockRepo := &testutil.MockReader{
+		ListAlertEventsFunc: func(ctx context.Context, alertID string) ([]repository.AlertEventRecord, error) {
+			gotID = alertID
+			return []repository.AlertEventRecord{
+				{AlertID: alertID, Type: shared.AlertEventCreated, At: at, Status: shared.AlertStatusActive, Value: -6},
+				{AlertID: alertID, Type: shared.AlertEventDelivered, At: at.Add(time.Hour), NotifiedAt: at.Add(time.Hour), PrevValue: -6},
+			}, nil
+		},
+	}
+	handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+
+	resp, err := handler.ListAlertEvents(context.Background(), &pb.ListAlertEventsRequest{AlertId: "alert-1"})
+	if err != nil {
+		t.Fatalf("ListAlertEvents: %v", err)
+	}
+	if gotID != "alert-1" || len(resp.Events) != 2 {
+		t.Fatalf("queried %q, got %d events", gotID, len(resp.Events))
+	}
+	created, delivered := resp.Events[0], resp.Events[1]
+	if created.Type != shared.AlertEventCreated || created.Value != -6 || created.NotifiedAt != nil || !created.At.AsTime().Equal(at) {
+		t.Errorf("created = %+v", created)
+	}
+	if delivered.Type != shared.AlertEventDelivered || delivered.NotifiedAt == nil || delivered.PrevNotifiedAt != nil {
+		t.Errorf("delivered = %+v, want notified_at set and prev_notified_at unset", delivered)
+	}
+}
+
+func TestListAlertEvents_RequiresAlertID(t *testing.T) {
+	handler := NewGrpcHandler(service.NewWeatherService(&testutil.MockReader{}))
+	if _, err := handler.ListAlertEvents(context.Background(), &pb.ListAlertEventsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error code = %v, want InvalidArgument", status.Code(err))
+	}
+}
@@ -21,8 +21,8 @@ func TestGetForecast_Mapping(t *testing.T) {
 	mockRepo := &testutil.MockReader{
 		GetForecastFunc: func(ctx context.Context, id string) (*repository.ForecastCacheDoc, error) {
 			return &repository.ForecastCacheDoc{
-				LocationID: id,
-				IssuedAt:   now,
+				Location: id,
+				IssuedAt: now,
 				Points: []repository.ForecastPoint{
 					{ValidTime: now, PressureMb: 1012.65, TempF: 81.5, HumidityPercent: 82},
 					{ValidTime: now.Add(time.Hour), PressureMb: 1013.13},
@@ -93,8 +93,8 @@ func TestGetAllForecasts(t *testing.T) {
 	mockRepo := &testutil.MockReader{
 		GetAllForecastsFunc: func(ctx context.Context) ([]repository.ForecastCacheDoc, error) {
 			return []repository.ForecastCacheDoc{
-				{LocationID: "house-nick", IssuedAt: now, Points: []repository.ForecastPoint{{ValidTime: now, PressureMb: 1012.65}}},
-				{LocationID: "house-nita", IssuedAt: now},
+				{Location: "house-nick", IssuedAt: now, Points: []repository.ForecastPoint{{ValidTime: now, PressureMb: 1012.65}}},
+				{Location: "house-nita", IssuedAt: now},
 			}, nil
 		},
 	}
@@ -134,7 +134,7 @@ func TestGetForecast_NotFound(t *testing.T) {
 	}
 }
 
-func TestGetForecast_EmptyLocationID(t *testing.T) {
+func TestGetForecast_EmptyLocation(t *testing.T) {
 	svc := service.NewWeatherService(&testutil.MockReader{})
 	handler := NewGrpcHandler(svc)
 
@@ -16,6 +16,7 @@ import (
 type GrpcHandler struct {
 	pb.UnimplementedPressureStatsServiceServer
 	pb.UnimplementedForecastServiceServer
+	pb.UnimplementedRawWeatherServiceServer
 	svc *service.WeatherService
 }
 
@@ -70,6 +71,25 @@ func (h *GrpcHandler) GetAllLastWeather(ctx context.Context, req *pb.GetAllLastW
 	return &pb.GetAllLastWeatherResponse{Weather: weathers}, nil
 }
 
+func (h *GrpcHandler) GetPressureHistory(ctx context.Context, req *pb.GetPressureHistoryRequest) (*pb.GetPressureHistoryResponse, error) {
+	if req.LocationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "location_id is required")
+	}
+	doc, err := h.svc.GetPressureHistory(ctx, req.LocationId)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "pressure history not found for location: %s", req.LocationId)
+		}
+		slog.Error("Failed to retrieve pressure history.", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to retrieve pressure history: %v", err)
+	}
+	resp := &pb.GetPressureHistoryResponse{LocationId: doc.Location}
+	for _, p := range doc.History {
+		resp.Points = append(resp.Points, mapToProtoPressurePoint(p))
+	}
+	return resp, nil
+}
+
 func (h *GrpcHandler) GetAllForecasts(ctx context.Context, req *pb.GetAllForecastsRequest) (*pb.GetAllForecastsResponse, error) {
 	docs, err := h.svc.GetAllForecasts(ctx)
 	if err != nil {
@@ -98,10 +118,87 @@ func (h *GrpcHandler) GetForecast(ctx context.Context, req *pb.GetForecastReques
 	return &pb.GetForecastResponse{Forecast: mapToProtoForecast(doc)}, nil
 }
 
+func (h *GrpcHandler) ListAlertHistory(ctx context.Context, req *pb.ListAlertHistoryRequest) (*pb.ListAlertHistoryResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative")
+	}
+	q := repository.AlertHistoryQuery{LocationID: req.LocationId, RuleID: req.RuleId, Limit: int(req.Limit)}
+	if req.StartTime != nil {
+		q.Start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		q.End = req.EndTime.AsTime()
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && !q.Start.Before(q.End) {
+		return nil, status.Errorf(codes.InvalidArgument, "start_time must be before end_time")
+	}
+
+	records, err := h.svc.ListAlertHistory(ctx, q)
+	if err != nil {
+		slog.Error("Failed to retrieve alert history.", "location", req.LocationId, "rule", req.RuleId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to retrieve alert history: %v", err)
+	}
+	resp := &pb.ListAlertHistoryResponse{Alerts: make([]*pb.ArchivedAlert, 0, len(records))}
+	for i := range records {
+		resp.Alerts = append(resp.Alerts, mapToProtoArchivedAlert(&records[i]))
+	}
+	return resp, nil
+}
+
+func (h *GrpcHandler) ListAlertEvents(ctx context.Context, req *pb.ListAlertEventsRequest) (*pb.ListAlertEventsResponse, error) {
+	if req.AlertId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "alert_id is required")
+	}
+	events, err := h.svc.ListAlertEvents(ctx, req.AlertId)
+	if err != nil {
+		slog.Error("Failed to retrieve alert events.", "alert", req.AlertId, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to retrieve alert events: %v", err)
+	}
+	resp := &pb.ListAlertEventsResponse{Events: make([]*pb.AlertEvent, 0, len(events))}
+	for i := range events {
+		resp.Events = append(resp.Events, mapToProtoAlertEvent(&events[i]))
+	}
+	return resp, nil
+}
+
+func (h *GrpcHandler) QueryRawWeather(req *pb.QueryRawWeatherRequest, stream pb.RawWeatherService_QueryRawWeatherServer) error {
+	if req.PageSize < 0 {
+		return status.Errorf(codes.InvalidArgument, "page_size must not be negative")
+	}
+	q := repository.RawQuery{LocationID: req.LocationId, PageSize: int(req.PageSize)}
+	if req.StartTime != nil {
+		q.Start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		q.End = req.EndTime.AsTime()
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && !q.Start.Before(q.End) {
+		return status.Errorf(codes.InvalidArgument, "start_time must be before end_time")
+	}
+
+	ctx := stream.Context()
+	err := h.svc.QueryRaw(ctx, q, func(points []repository.WeatherPoint) error {
+		resp := &pb.QueryRawWeatherResponse{Points: make([]*pb.WeatherPoint, 0, len(points))}
+		for i := range points {
+			resp.Points = append(resp.Points, mapToProtoWeatherPoint(&points[i]))
+		}
+		return stream.Send(resp)
+	})
+	if err != nil {
+		// The client hung up or timed out; there is nobody left to report to.
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		slog.Error("Failed to query raw weather data.", "location", req.LocationId, "error", err)
+		return status.Errorf(codes.Internal, "failed to query raw weather data: %v", err)
+	}
+	return nil
+}
+
 // mapToProto converts the internal repository model to the gRPC message
-func mapToProtoPressureStat(doc *repository.PressureCacheDoc) *pb.PressureStat {
+func mapToProtoPressureStat(doc *repository.WeatherCacheDoc) *pb.PressureStat {
 	stat := &pb.PressureStat{
-		LocationId:  doc.LocationID,
+		LocationId:  doc.Location,
 		LastUpdated: timestamppb.New(doc.LastUpdated),
 		Trend:       doc.Analysis.Trend,
 	}
@@ -126,9 +223,23 @@ func mapToProtoPressureStat(doc *repository.PressureCacheDoc) *pb.PressureStat {
 	return stat
 }
 
+func mapToProtoPressurePoint(p repository.PressurePoint) *pb.PressurePoint {
+	return &pb.PressurePoint{
+		Timestamp:       timestamppb.New(p.Timestamp),
+		PressureMb:      p.PressureMb,
+		HumidityPercent: int32(p.HumidityPercent),
+		TempC:           p.TempC,
+		TempF:           p.TempF,
+		TempFeelC:       p.TempFeelC,
+		TempFeelF:       p.TempFeelF,
+		DewpointC:       p.DewpointC,
+		DewpointF:       p.DewpointF,
+	}
+}
+
 func mapToProtoWeather(doc *repository.WeatherCacheDoc) *pb.Weather {
 	return &pb.Weather{
-		LocationId:           doc.LocationID,
+		LocationId:           doc.Location,
 		LastUpdated:          timestamppb.New(doc.CurrentValue.Timestamp),
 		TempC:                doc.CurrentValue.TempC,
 		TempF:                doc.CurrentValue.TempF,
@@ -140,9 +251,33 @@ func mapToProtoWeather(doc *repository.WeatherCacheDoc) *pb.Weather {
 	}
 }
 
+func mapToProtoWeatherPoint(p *repository.WeatherPoint) *pb.WeatherPoint {
+	return &pb.WeatherPoint{
+		LocationId:           p.Location,
+		Timestamp:            timestamppb.New(p.Timestamp),
+		HumidityPercent:      int32(p.HumidityPercent),
+		PrecipitationPercent: int32(p.PrecipitationPercent),
+		UvIndex:              int32(p.UVIndex),
+		PressureMb:           p.PressureMb,
+		WindDirDeg:           int32(p.WindDirDeg),
+		TempC:                p.TempC,
+		TempFeelC:            p.TempFeelC,
+		DewpointC:            p.DewpointC,
+		WindSpeedKph:         p.WindSpeedKph,
+		WindGustKph:          p.WindGustKph,
+		VisibilityKm:         p.VisibilityKm,
+		TempF:                p.TempF,
+		TempFeelF:            p.TempFeelF,
+		WindSpeedMph:         p.WindSpeedMph,
+		WindGustMph:          p.WindGustMph,
+		VisibilityMiles:      p.VisibilityM,
+		DewpointF:            p.DewpointF,
+	}
+}
+
 func mapToProtoForecast(doc *repository.ForecastCacheDoc) *pb.Forecast {
 	forecast := &pb.Forecast{
-		LocationId: doc.LocationID,
+		LocationId: doc.Location,
 		IssuedAt:   timestamppb.New(doc.IssuedAt),
 	}
 	for i := range doc.Points {
@@ -188,3 +323,54 @@ func mapToProtoAlert(a *shared.Alert) *pb.Alert {
 		IssuedAt:    timestamppb.New(a.IssuedAt),
 	}
 }
+
+func mapToProtoArchivedAlert(r *repository.AlertRecord) *pb.ArchivedAlert {
+	archived := &pb.ArchivedAlert{
+		Alert: &pb.Alert{
+			Id:          r.ID,
+			LocationId:  r.Location,
+			RuleId:      r.RuleID,
+			Severity:    r.Severity,
+			Value:       r.Value,
+			Threshold:   r.Threshold,
+			WindowStart: timestamppb.New(r.WindowStart),
+			WindowEnd:   timestamppb.New(r.WindowEnd),
+			Message:     r.Message,
+			Status:      r.Status,
+			IssuedAt:    timestamppb.New(r.IssuedAt),
+		},
+		PeakValue:    r.PeakValue,
+		PeakSeverity: r.PeakSeverity,
+		ArchivedAt:   timestamppb.New(r.ArchivedAt),
+	}
+	if !r.NotifiedAt.IsZero() {
+		archived.NotifiedAt = timestamppb.New(r.NotifiedAt)
+	}
+	return archived
+}
+
+func mapToProtoAlertEvent(r *repository.AlertEventRecord) *pb.AlertEvent {
+	event := &pb.AlertEvent{
+		AlertId:      r.AlertID,
+		Type:         r.Type,
+		At:           timestamppb.New(r.At),
+		LocationId:   r.Location,
+		RuleId:       r.RuleID,
+		Status:       r.Status,
+		Severity:     r.Severity,
+		Value:        r.Value,
+		Threshold:    r.Threshold,
+		WindowStart:  timestamppb.New(r.WindowStart),
+		WindowEnd:    timestamppb.New(r.WindowEnd),
+		PrevStatus:   r.PrevStatus,
+		PrevSeverity: r.PrevSeverity,
+		PrevValue:    r.PrevValue,
+	}
+	if !r.NotifiedAt.IsZero() {
+		event.NotifiedAt = timestamppb.New(r.NotifiedAt)
+	}
+	if !r.PrevNotifiedAt.IsZero() {
+		event.PrevNotifiedAt = timestamppb.New(r.PrevNotifiedAt)
+	}
+	return event
+}
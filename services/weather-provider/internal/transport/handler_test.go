@@ -16,9 +16,9 @@ func TestGetPressureStats_Mapping(t *testing.T) {
 	now := time.Now()
 
 	mockRepo := &testutil.MockReader{
-		GetByIDFunc: func(ctx context.Context, id string) (*repository.PressureCacheDoc, error) {
-			return &repository.PressureCacheDoc{
-				LocationID:  id,
+		GetByIDFunc: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+			return &repository.WeatherCacheDoc{
+				Location:    id,
 				LastUpdated: now,
 				Analysis: repository.PressureStats{
 					Delta3h: &deltaValue,
@@ -56,10 +56,10 @@ func TestGetPressureStats_Mapping(t *testing.T) {
 func TestGetAllPressureStats(t *testing.T) {
 	now := time.Now()
 	mockRepo := &testutil.MockReader{
-		GetAllFunc: func(ctx context.Context) ([]repository.PressureCacheDoc, error) {
-			return []repository.PressureCacheDoc{
-				{LocationID: "loc-1", LastUpdated: now},
-				{LocationID: "loc-2", LastUpdated: now},
+		GetAllFunc: func(ctx context.Context) ([]repository.WeatherCacheDoc, error) {
+			return []repository.WeatherCacheDoc{
+				{Location: "loc-1", LastUpdated: now},
+				{Location: "loc-2", LastUpdated: now},
 			}, nil
 		},
 	}
@@ -86,9 +86,9 @@ func TestGetLastWeather_Mapping(t *testing.T) {
 	mockRepo := &testutil.MockReader{
 		GetLastWeatherFunc: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
 			return &repository.WeatherCacheDoc{
-				LocationID: id,
+				Location: id,
 				CurrentValue: repository.WeatherPoint{
-					LocationID:           id,
+					Location:             id,
 					Timestamp:            now,
 					TempC:                22.5,
 					TempF:                72.5,
@@ -146,23 +146,23 @@ func TestGetAllLastWeather(t *testing.T) {
 		GetAllLastWeatherFunc: func(ctx context.Context) ([]repository.WeatherCacheDoc, error) {
 			return []repository.WeatherCacheDoc{
 				{
-					LocationID:  "loc-1",
+					Location:    "loc-1",
 					LastUpdated: now,
 					CurrentValue: repository.WeatherPoint{
-						LocationID: "loc-1",
-						Timestamp:  now,
-						TempC:      20.0,
-						TempF:      68.0,
+						Location:  "loc-1",
+						Timestamp: now,
+						TempC:     20.0,
+						TempF:     68.0,
 					},
 				},
 				{
-					LocationID:  "loc-2",
+					Location:    "loc-2",
 					LastUpdated: now,
 					CurrentValue: repository.WeatherPoint{
-						LocationID: "loc-2",
-						Timestamp:  now,
-						TempC:      25.0,
-						TempF:      77.0,
+						Location:  "loc-2",
+						Timestamp: now,
+						TempC:     25.0,
+						TempF:     77.0,
 					},
 				},
 			}, nil
@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/nickfang/personal-dashboard/services/weather-provider/internal/gen/go/weather-provider/v1"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/service"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetPressureHistory_Mapping(t *testing.T) {
+	start := time.Date(2026, 6, 12, 6, 0, 0, 0, time.UTC)
+
+	mockRepo := &testutil.MockReader{
+		GetByIDFunc: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+			return &repository.WeatherCacheDoc{
+				Location: id,
+				History: []repository.PressurePoint{
+					{Timestamp: start, PressureMb: 1013.2, HumidityPercent: 70, TempC: 24, TempF: 75.2, DewpointC: 18, DewpointF: 64.4},
+					{Timestamp: start.Add(time.Hour), PressureMb: 1012.4, HumidityPercent: 74, TempC: 23.5, TempF: 74.3, TempFeelC: 24, TempFeelF: 75.2},
+				},
+			}, nil
+		},
+	}
+
+	handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+	resp, err := handler.GetPressureHistory(context.Background(), &pb.GetPressureHistoryRequest{LocationId: "test-loc"})
+	if err != nil {
+		t.Fatalf("failed to call handler: %v", err)
+	}
+
+	if resp.LocationId != "test-loc" {
+		t.Errorf("LocationId = %q, want test-loc", resp.LocationId)
+	}
+	if len(resp.Points) != 2 {
+		t.Fatalf("len(Points) = %d, want 2", len(resp.Points))
+	}
+	first := resp.Points[0]
+	if !first.Timestamp.AsTime().Equal(start) {
+		t.Errorf("Points[0].Timestamp = %v, want %v", first.Timestamp.AsTime(), start)
+	}
+	if first.PressureMb != 1013.2 || first.HumidityPercent != 70 {
+		t.Errorf("Points[0] pressure/humidity = %v/%d, want 1013.2/70", first.PressureMb, first.HumidityPercent)
+	}
+	if first.DewpointC != 18 || first.DewpointF != 64.4 {
+		t.Errorf("Points[0] dewpoint = %v/%v, want 18/64.4", first.DewpointC, first.DewpointF)
+	}
+	if resp.Points[1].TempFeelF != 75.2 {
+		t.Errorf("Points[1].TempFeelF = %v, want 75.2", resp.Points[1].TempFeelF)
+	}
+}
+
+func TestGetPressureHistory_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		err      error
+		wantCode codes.Code
+	}{
+		{"missing location", "", nil, codes.InvalidArgument},
+		{"not found", "test-loc", status.Errorf(codes.NotFound, "no doc"), codes.NotFound},
+		{"read failure", "test-loc", status.Errorf(codes.Unavailable, "firestore down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &testutil.MockReader{
+				GetByIDFunc: func(ctx context.Context, id string) (*repository.WeatherCacheDoc, error) {
+					return nil, tt.err
+				},
+			}
+			handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+
+			_, err := handler.GetPressureHistory(context.Background(), &pb.GetPressureHistoryRequest{LocationId: tt.id})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("error code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
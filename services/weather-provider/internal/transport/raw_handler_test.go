@@ -0,0 +1,195 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/nickfang/personal-dashboard/services/weather-provider/internal/gen/go/weather-provider/v1"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/repository"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/service"
+	"github.com/nickfang/personal-dashboard/services/weather-provider/internal/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeRawStream records what the handler sends. The embedded ServerStream is
+// nil; only Context and Send are called.
+type fakeRawStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    []*pb.QueryRawWeatherResponse
+	sendErr error
+}
+
+func (s *fakeRawStream) Context() context.Context { return s.ctx }
+
+func (s *fakeRawStream) Send(resp *pb.QueryRawWeatherResponse) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func TestQueryRawWeather_StreamsPages(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+
+	var gotQuery repository.RawQuery
+	mockRepo := &testutil.MockReader{
+		QueryRawFunc: func(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+			gotQuery = q
+			pages := [][]repository.WeatherPoint{
+				{
+					{Location: "house-nick", Timestamp: start, PressureMb: 1013.2, HumidityPercent: 70, VisibilityM: 6.2},
+					{Location: "house-nick", Timestamp: start.Add(time.Hour), PressureMb: 1012.8},
+				},
+				{
+					{Location: "house-nick", Timestamp: start.Add(2 * time.Hour), PressureMb: 1012.1},
+				},
+			}
+			for _, p := range pages {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+	stream := &fakeRawStream{ctx: context.Background()}
+
+	req := &pb.QueryRawWeatherRequest{
+		LocationId: "house-nick",
+		StartTime:  timestamppb.New(start),
+		EndTime:    timestamppb.New(end),
+		PageSize:   2,
+	}
+	if err := handler.QueryRawWeather(req, stream); err != nil {
+		t.Fatalf("QueryRawWeather: %v", err)
+	}
+
+	if gotQuery.LocationID != "house-nick" || !gotQuery.Start.Equal(start) || !gotQuery.End.Equal(end) || gotQuery.PageSize != 2 {
+		t.Errorf("query = %+v, want house-nick %v..%v page 2", gotQuery, start, end)
+	}
+	if len(stream.sent) != 2 {
+		t.Fatalf("sent %d responses, want one per page (2)", len(stream.sent))
+	}
+	if len(stream.sent[0].Points) != 2 || len(stream.sent[1].Points) != 1 {
+		t.Fatalf("page sizes = %d/%d, want 2/1", len(stream.sent[0].Points), len(stream.sent[1].Points))
+	}
+	first := stream.sent[0].Points[0]
+	if first.LocationId != "house-nick" || !first.Timestamp.AsTime().Equal(start) {
+		t.Errorf("Points[0] = %s@%v, want house-nick@%v", first.LocationId, first.Timestamp.AsTime(), start)
+	}
+	if first.PressureMb != 1013.2 || first.HumidityPercent != 70 || first.VisibilityMiles != 6.2 {
+		t.Errorf("Points[0] pressure/humidity/visibility = %v/%d/%v, want 1013.2/70/6.2", first.PressureMb, first.HumidityPercent, first.VisibilityMiles)
+	}
+}
+
+func TestQueryRawWeather_OpenRange(t *testing.T) {
+	var gotQuery repository.RawQuery
+	mockRepo := &testutil.MockReader{
+		QueryRawFunc: func(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+			gotQuery = q
+			return nil
+		},
+	}
+	handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+	stream := &fakeRawStream{ctx: context.Background()}
+
+	if err := handler.QueryRawWeather(&pb.QueryRawWeatherRequest{}, stream); err != nil {
+		t.Fatalf("QueryRawWeather: %v", err)
+	}
+	if !gotQuery.Start.IsZero() || !gotQuery.End.IsZero() {
+		t.Errorf("unset times became %v..%v, want open bounds", gotQuery.Start, gotQuery.End)
+	}
+	if gotQuery.PageSize != service.DefaultRawPageSize {
+		t.Errorf("PageSize = %d, want default %d", gotQuery.PageSize, service.DefaultRawPageSize)
+	}
+	if len(stream.sent) != 0 {
+		t.Errorf("sent %d responses for an empty result, want 0", len(stream.sent))
+	}
+}
+
+func TestQueryRawWeather_Errors(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		req      *pb.QueryRawWeatherRequest
+		repoErr  error
+		wantCode codes.Code
+	}{
+		{
+			name:     "inverted range",
+			req:      &pb.QueryRawWeatherRequest{StartTime: timestamppb.New(t0), EndTime: timestamppb.New(t0.Add(-time.Hour))},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "empty range",
+			req:      &pb.QueryRawWeatherRequest{StartTime: timestamppb.New(t0), EndTime: timestamppb.New(t0)},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "negative page size",
+			req:      &pb.QueryRawWeatherRequest{PageSize: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "read failure",
+			req:      &pb.QueryRawWeatherRequest{LocationId: "house-nick"},
+			repoErr:  errors.New("firestore down"),
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &testutil.MockReader{
+				QueryRawFunc: func(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+					return tt.repoErr
+				},
+			}
+			handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+
+			err := handler.QueryRawWeather(tt.req, &fakeRawStream{ctx: context.Background()})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("code = %v, want %v (err: %v)", status.Code(err), tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestQueryRawWeather_StopsWhenClientGone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pagesRead := 0
+	mockRepo := &testutil.MockReader{
+		QueryRawFunc: func(ctx context.Context, q repository.RawQuery, fn func([]repository.WeatherPoint) error) error {
+			for i := 0; i < 3; i++ {
+				pagesRead++
+				if err := fn([]repository.WeatherPoint{{Location: "house-nick"}}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	handler := NewGrpcHandler(service.NewWeatherService(mockRepo))
+
+	cancel()
+	stream := &fakeRawStream{ctx: ctx, sendErr: context.Canceled}
+	err := handler.QueryRawWeather(&pb.QueryRawWeatherRequest{}, stream)
+
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("code = %v, want Canceled", status.Code(err))
+	}
+	if pagesRead != 1 {
+		t.Errorf("read %d pages, want the scan to stop after the failed send", pagesRead)
+	}
+}